@@ -42,8 +42,10 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	glooflags.AddConfigStorageOptionFlags(rootCmd, &opts.Options)
+	glooflags.AddSecretStorageOptionFlags(rootCmd, &opts.Options)
 	glooflags.AddFileFlags(rootCmd, &opts.Options)
 	glooflags.AddKubernetesFlags(rootCmd, &opts.Options)
 	glooflags.AddConsulFlags(rootCmd, &opts.Options)
+	glooflags.AddVaultFlags(rootCmd, &opts.Options)
 	flags.AddSqoopFlags(rootCmd, &opts)
 }
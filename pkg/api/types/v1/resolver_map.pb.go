@@ -33,6 +33,9 @@ type ResolverMap struct {
 	Status *gloo_api_v1.Status `protobuf:"bytes,4,opt,name=status" json:"status,omitempty" testdiff:"ignore"`
 	// Metadata contains the resource metadata for the role
 	Metadata *gloo_api_v11.Metadata `protobuf:"bytes,5,opt,name=metadata" json:"metadata,omitempty"`
+	// defaults inherited by every HttpResolver in the map. resolvers may override them
+	// GlooResolvers do not inherit them, they call functions through the proxy, whose routes are configured in gloo
+	HttpDefaults *HttpDefaults `protobuf:"bytes,6,opt,name=http_defaults,json=httpDefaults" json:"http_defaults,omitempty"`
 }
 
 func (m *ResolverMap) Reset()                    { *m = ResolverMap{} }
@@ -68,6 +71,58 @@ func (m *ResolverMap) GetMetadata() *gloo_api_v11.Metadata {
 	return nil
 }
 
+func (m *ResolverMap) GetHttpDefaults() *HttpDefaults {
+	if m != nil {
+		return m.HttpDefaults
+	}
+	return nil
+}
+
+// HttpDefaults declare the destination shared by the http resolvers of a resolver map
+type HttpDefaults struct {
+	// base URL for resolvers whose url template renders a relative URL, e.g. "https://api.example.com/v1"
+	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// hosts allowed in addition to those of each resolver
+	AllowedHosts []string `protobuf:"bytes,2,rep,name=allowed_hosts,json=allowedHosts" json:"allowed_hosts,omitempty"`
+	// headers sent by every resolver, unless the resolver sets a header of the same name
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// timeout for requests made by resolvers which don't set their own, in milliseconds
+	TimeoutMs uint32 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+}
+
+func (m *HttpDefaults) Reset()                    { *m = HttpDefaults{} }
+func (m *HttpDefaults) String() string            { return proto.CompactTextString(m) }
+func (*HttpDefaults) ProtoMessage()               {}
+func (*HttpDefaults) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{1} }
+
+func (m *HttpDefaults) GetBaseUrl() string {
+	if m != nil {
+		return m.BaseUrl
+	}
+	return ""
+}
+
+func (m *HttpDefaults) GetAllowedHosts() []string {
+	if m != nil {
+		return m.AllowedHosts
+	}
+	return nil
+}
+
+func (m *HttpDefaults) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *HttpDefaults) GetTimeoutMs() uint32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
 // TypeResolver contains the individual resolvers for each field for a specific type
 type TypeResolver struct {
 	// This is a map of Field Names to the resolver that Sqoop should invoke when a query arrives for that field
@@ -77,7 +132,7 @@ type TypeResolver struct {
 func (m *TypeResolver) Reset()                    { *m = TypeResolver{} }
 func (m *TypeResolver) String() string            { return proto.CompactTextString(m) }
 func (*TypeResolver) ProtoMessage()               {}
-func (*TypeResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{1} }
+func (*TypeResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{2} }
 
 func (m *TypeResolver) GetFields() map[string]*Resolver {
 	if m != nil {
@@ -94,13 +149,62 @@ type Resolver struct {
 	//	*Resolver_GlooResolver
 	//	*Resolver_TemplateResolver
 	//	*Resolver_NodejsResolver
+	//	*Resolver_ConditionalResolver
+	//	*Resolver_MockResolver
+	//	*Resolver_SignedUrlResolver
+	//	*Resolver_VariablesResolver
+	//	*Resolver_HttpResolver
+	//	*Resolver_MergeResolver
 	Resolver isResolver_Resolver `protobuf_oneof:"resolver"`
+	// optional caching of the results of the resolver
+	Cache *ResolverCache `protobuf:"bytes,6,opt,name=cache" json:"cache,omitempty"`
+	// for list fields, treat a null or empty result from the resolver as an empty list.
+	// by default a null result is returned as null, which is an error if the list is non-null (e.g. [T!]!).
+	// only the list itself is affected: null items of a list of non-null items are still errors
+	NullAsEmptyList bool `protobuf:"varint,10,opt,name=null_as_empty_list,json=nullAsEmptyList,proto3" json:"null_as_empty_list,omitempty"`
+	// for fields which are lists of scalars, split a string returned by the resolver into the items of the list.
+	// for upstreams which return lists as delimited strings, e.g. "red,green,blue"
+	SplitString *SplitString `protobuf:"bytes,11,opt,name=split_string,json=splitString" json:"split_string,omitempty"`
+	// codes to send to the upstream in place of the members of enum arguments, by argument name.
+	// members without a code are sent as they are. for conditional resolvers, set on each variant
+	EnumArguments map[string]*EnumCodes `protobuf:"bytes,12,rep,name=enum_arguments,json=enumArguments" json:"enum_arguments,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// only call the resolver while a feature flag is enabled
+	FeatureGate *FeatureGate `protobuf:"bytes,13,opt,name=feature_gate,json=featureGate" json:"feature_gate,omitempty"`
+	// only call the resolver if the condition matches, otherwise resolve the field to null.
+	// condition templates can refer to the parent object (.Parent), e.g. to skip the field unless a sibling
+	// returned with the parent has a particular value: {{ eq .Parent.type "premium" }}, and to the fields
+	// of the parent resolved before this one in the query (.Siblings): {{ eq .Siblings.plan "premium" }}
+	Precondition *Condition `protobuf:"bytes,14,opt,name=precondition" json:"precondition,omitempty"`
+	// resolve the items of a list returned by the resolver concurrently, with at most this many items in flight.
+	// useful when the fields of each item call an upstream. items keep their order in the list.
+	// zero or one resolves the items one after another. nested lists share the maximum number of items an operation
+	// resolves concurrently, set with --sqoop.max-concurrent-list-items, rather than multiplying their concurrency
+	ListConcurrency uint32 `protobuf:"varint,15,opt,name=list_concurrency,json=listConcurrency,proto3" json:"list_concurrency,omitempty"`
+	// for list fields whose upstream paginates its results, request every page and return the items of all pages
+	FollowPages *FollowPages `protobuf:"bytes,16,opt,name=follow_pages,json=followPages" json:"follow_pages,omitempty"`
+	// for list fields, bound the number of items returned, e.g. to protect clients from an upstream which
+	// suddenly returns an unbounded list. applied to the whole list returned by the resolver, after following pages
+	ListLimit *ListLimit `protobuf:"bytes,18,opt,name=list_limit,json=listLimit" json:"list_limit,omitempty"`
+	// for Relay connection fields, the page size applied when a query sets neither first nor last, and the
+	// largest page a query may request. overrides the page sizes Sqoop was started with
+	PageSize *PageSize `protobuf:"bytes,19,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	// for upstreams which report errors in the body of successful responses, fail the field with the error
+	// found in the response instead of resolving it from the response
+	ErrorEnvelope *ErrorEnvelope `protobuf:"bytes,20,opt,name=error_envelope,json=errorEnvelope" json:"error_envelope,omitempty"`
+	// bound the size of the bodies the resolver sends to and receives from its upstream.
+	// overrides the limits Sqoop was started with
+	BodyLimits *BodyLimits `protobuf:"bytes,21,opt,name=body_limits,json=bodyLimits" json:"body_limits,omitempty"`
+	// the codes reported in extensions.code of the errors of fields whose upstream responded with an error status,
+	// by status or range of statuses, e.g. {"404": "NOT_FOUND", "5xx": "UPSTREAM_DOWN", "520-529": "CDN_ERROR"}.
+	// the narrowest range containing a status applies. statuses which are not mapped here are mapped by the
+	// error codes Sqoop was started with
+	ErrorCodes map[string]string `protobuf:"bytes,22,rep,name=error_codes,json=errorCodes" json:"error_codes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *Resolver) Reset()                    { *m = Resolver{} }
 func (m *Resolver) String() string            { return proto.CompactTextString(m) }
 func (*Resolver) ProtoMessage()               {}
-func (*Resolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{2} }
+func (*Resolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{3} }
 
 type isResolver_Resolver interface {
 	isResolver_Resolver()
@@ -116,10 +220,34 @@ type Resolver_TemplateResolver struct {
 type Resolver_NodejsResolver struct {
 	NodejsResolver *NodeJSResolver `protobuf:"bytes,3,opt,name=nodejs_resolver,json=nodejsResolver,oneof"`
 }
+type Resolver_ConditionalResolver struct {
+	ConditionalResolver *ConditionalResolver `protobuf:"bytes,4,opt,name=conditional_resolver,json=conditionalResolver,oneof"`
+}
+type Resolver_MockResolver struct {
+	MockResolver *MockResolver `protobuf:"bytes,5,opt,name=mock_resolver,json=mockResolver,oneof"`
+}
+type Resolver_SignedUrlResolver struct {
+	SignedUrlResolver *SignedUrlResolver `protobuf:"bytes,7,opt,name=signed_url_resolver,json=signedUrlResolver,oneof"`
+}
+type Resolver_VariablesResolver struct {
+	VariablesResolver *VariablesResolver `protobuf:"bytes,8,opt,name=variables_resolver,json=variablesResolver,oneof"`
+}
+type Resolver_HttpResolver struct {
+	HttpResolver *HttpResolver `protobuf:"bytes,9,opt,name=http_resolver,json=httpResolver,oneof"`
+}
+type Resolver_MergeResolver struct {
+	MergeResolver *MergeResolver `protobuf:"bytes,17,opt,name=merge_resolver,json=mergeResolver,oneof"`
+}
 
-func (*Resolver_GlooResolver) isResolver_Resolver()     {}
-func (*Resolver_TemplateResolver) isResolver_Resolver() {}
-func (*Resolver_NodejsResolver) isResolver_Resolver()   {}
+func (*Resolver_GlooResolver) isResolver_Resolver()        {}
+func (*Resolver_TemplateResolver) isResolver_Resolver()    {}
+func (*Resolver_NodejsResolver) isResolver_Resolver()      {}
+func (*Resolver_ConditionalResolver) isResolver_Resolver() {}
+func (*Resolver_MockResolver) isResolver_Resolver()        {}
+func (*Resolver_SignedUrlResolver) isResolver_Resolver()   {}
+func (*Resolver_VariablesResolver) isResolver_Resolver()   {}
+func (*Resolver_HttpResolver) isResolver_Resolver()        {}
+func (*Resolver_MergeResolver) isResolver_Resolver()       {}
 
 func (m *Resolver) GetResolver() isResolver_Resolver {
 	if m != nil {
@@ -149,12 +277,151 @@ func (m *Resolver) GetNodejsResolver() *NodeJSResolver {
 	return nil
 }
 
+func (m *Resolver) GetConditionalResolver() *ConditionalResolver {
+	if x, ok := m.GetResolver().(*Resolver_ConditionalResolver); ok {
+		return x.ConditionalResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetMockResolver() *MockResolver {
+	if x, ok := m.GetResolver().(*Resolver_MockResolver); ok {
+		return x.MockResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetSignedUrlResolver() *SignedUrlResolver {
+	if x, ok := m.GetResolver().(*Resolver_SignedUrlResolver); ok {
+		return x.SignedUrlResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetVariablesResolver() *VariablesResolver {
+	if x, ok := m.GetResolver().(*Resolver_VariablesResolver); ok {
+		return x.VariablesResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetHttpResolver() *HttpResolver {
+	if x, ok := m.GetResolver().(*Resolver_HttpResolver); ok {
+		return x.HttpResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetMergeResolver() *MergeResolver {
+	if x, ok := m.GetResolver().(*Resolver_MergeResolver); ok {
+		return x.MergeResolver
+	}
+	return nil
+}
+
+func (m *Resolver) GetCache() *ResolverCache {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+func (m *Resolver) GetNullAsEmptyList() bool {
+	if m != nil {
+		return m.NullAsEmptyList
+	}
+	return false
+}
+
+func (m *Resolver) GetSplitString() *SplitString {
+	if m != nil {
+		return m.SplitString
+	}
+	return nil
+}
+
+func (m *Resolver) GetEnumArguments() map[string]*EnumCodes {
+	if m != nil {
+		return m.EnumArguments
+	}
+	return nil
+}
+
+func (m *Resolver) GetFeatureGate() *FeatureGate {
+	if m != nil {
+		return m.FeatureGate
+	}
+	return nil
+}
+
+func (m *Resolver) GetPrecondition() *Condition {
+	if m != nil {
+		return m.Precondition
+	}
+	return nil
+}
+
+func (m *Resolver) GetListConcurrency() uint32 {
+	if m != nil {
+		return m.ListConcurrency
+	}
+	return 0
+}
+
+func (m *Resolver) GetFollowPages() *FollowPages {
+	if m != nil {
+		return m.FollowPages
+	}
+	return nil
+}
+
+func (m *Resolver) GetListLimit() *ListLimit {
+	if m != nil {
+		return m.ListLimit
+	}
+	return nil
+}
+
+func (m *Resolver) GetPageSize() *PageSize {
+	if m != nil {
+		return m.PageSize
+	}
+	return nil
+}
+
+func (m *Resolver) GetErrorEnvelope() *ErrorEnvelope {
+	if m != nil {
+		return m.ErrorEnvelope
+	}
+	return nil
+}
+
+func (m *Resolver) GetBodyLimits() *BodyLimits {
+	if m != nil {
+		return m.BodyLimits
+	}
+	return nil
+}
+
+func (m *Resolver) GetErrorCodes() map[string]string {
+	if m != nil {
+		return m.ErrorCodes
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Resolver) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Resolver_OneofMarshaler, _Resolver_OneofUnmarshaler, _Resolver_OneofSizer, []interface{}{
 		(*Resolver_GlooResolver)(nil),
 		(*Resolver_TemplateResolver)(nil),
 		(*Resolver_NodejsResolver)(nil),
+		(*Resolver_ConditionalResolver)(nil),
+		(*Resolver_MockResolver)(nil),
+		(*Resolver_SignedUrlResolver)(nil),
+		(*Resolver_VariablesResolver)(nil),
+		(*Resolver_HttpResolver)(nil),
+		(*Resolver_MergeResolver)(nil),
 	}
 }
 
@@ -177,6 +444,36 @@ func _Resolver_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.NodejsResolver); err != nil {
 			return err
 		}
+	case *Resolver_ConditionalResolver:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConditionalResolver); err != nil {
+			return err
+		}
+	case *Resolver_MockResolver:
+		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MockResolver); err != nil {
+			return err
+		}
+	case *Resolver_SignedUrlResolver:
+		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SignedUrlResolver); err != nil {
+			return err
+		}
+	case *Resolver_VariablesResolver:
+		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VariablesResolver); err != nil {
+			return err
+		}
+	case *Resolver_HttpResolver:
+		_ = b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.HttpResolver); err != nil {
+			return err
+		}
+	case *Resolver_MergeResolver:
+		_ = b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MergeResolver); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Resolver.Resolver has unexpected type %T", x)
@@ -211,6 +508,54 @@ func _Resolver_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.Resolver = &Resolver_NodejsResolver{msg}
 		return true, err
+	case 4: // resolver.conditional_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConditionalResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_ConditionalResolver{msg}
+		return true, err
+	case 5: // resolver.mock_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MockResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_MockResolver{msg}
+		return true, err
+	case 7: // resolver.signed_url_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignedUrlResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_SignedUrlResolver{msg}
+		return true, err
+	case 8: // resolver.variables_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(VariablesResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_VariablesResolver{msg}
+		return true, err
+	case 9: // resolver.http_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(HttpResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_HttpResolver{msg}
+		return true, err
+	case 17: // resolver.merge_resolver
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MergeResolver)
+		err := b.DecodeMessage(msg)
+		m.Resolver = &Resolver_MergeResolver{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -235,6 +580,36 @@ func _Resolver_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Resolver_ConditionalResolver:
+		s := proto.Size(x.ConditionalResolver)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Resolver_MockResolver:
+		s := proto.Size(x.MockResolver)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Resolver_SignedUrlResolver:
+		s := proto.Size(x.SignedUrlResolver)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Resolver_VariablesResolver:
+		s := proto.Size(x.VariablesResolver)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Resolver_HttpResolver:
+		s := proto.Size(x.HttpResolver)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Resolver_MergeResolver:
+		s := proto.Size(x.MergeResolver)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -242,290 +617,1878 @@ func _Resolver_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-// GlooResolvers are the "meat" of Sqoop. GlooResolvers tell Sqoop how to invoke a "Gloo Function"
-type GlooResolver struct {
-	// the Request Template, if specified, will become the body of the HTTP request used to invoke a function through Gloo
-	// input parameters, if needed, should be specified in the request template. See Sqoop's [Resolver documentation](TODO)
-	// for more information on Request Templates.
-	RequestTemplate string `protobuf:"bytes,1,opt,name=request_template,json=requestTemplate,proto3" json:"request_template,omitempty"`
-	// The response template, if specified, will transform the body of HTTP responses returned by Gloo functions.
-	// This field should be used if the object returned by the Gloo Function does not match the type specified in the GraphQL schema.
-	// It can also be used to modify or transform responses from their original state. See Sqoop's [Resolver documentation](TODO)
-	// for more information on Response Templates.
-	ResponseTemplate string `protobuf:"bytes,2,opt,name=response_template,json=responseTemplate,proto3" json:"response_template,omitempty"`
-	// Optional. Use to set the outbound HTTP Request header `Content-Type`. Defaults to `application/json`
-	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	// Specify the function the resolver should invoke. The function must be one registered to Gloo.
-	//
-	// Types that are valid to be assigned to Function:
-	//	*GlooResolver_SingleFunction
-	//	*GlooResolver_MultiFunction
-	Function isGlooResolver_Function `protobuf_oneof:"function"`
+// BodyLimits bound the size of the bodies of upstream requests and responses, so a misbehaving upstream
+// can't exhaust the memory of Sqoop. Fields whose bodies exceed a limit fail without reading the rest of the body
+type BodyLimits struct {
+	// the largest request body sent to the upstream. zero applies the limit Sqoop was started with
+	MaxRequestBytes uint32 `protobuf:"varint,1,opt,name=max_request_bytes,json=maxRequestBytes,proto3" json:"max_request_bytes,omitempty"`
+	// the largest response body read from the upstream. zero applies the limit Sqoop was started with
+	MaxResponseBytes uint32 `protobuf:"varint,2,opt,name=max_response_bytes,json=maxResponseBytes,proto3" json:"max_response_bytes,omitempty"`
 }
 
-func (m *GlooResolver) Reset()                    { *m = GlooResolver{} }
-func (m *GlooResolver) String() string            { return proto.CompactTextString(m) }
-func (*GlooResolver) ProtoMessage()               {}
-func (*GlooResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{3} }
+func (m *BodyLimits) Reset()                    { *m = BodyLimits{} }
+func (m *BodyLimits) String() string            { return proto.CompactTextString(m) }
+func (*BodyLimits) ProtoMessage()               {}
+func (*BodyLimits) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{4} }
 
-type isGlooResolver_Function interface {
-	isGlooResolver_Function()
-	Equal(interface{}) bool
+func (m *BodyLimits) GetMaxRequestBytes() uint32 {
+	if m != nil {
+		return m.MaxRequestBytes
+	}
+	return 0
 }
 
-type GlooResolver_SingleFunction struct {
-	SingleFunction *Function `protobuf:"bytes,4,opt,name=single_function,json=singleFunction,oneof"`
+func (m *BodyLimits) GetMaxResponseBytes() uint32 {
+	if m != nil {
+		return m.MaxResponseBytes
+	}
+	return 0
 }
-type GlooResolver_MultiFunction struct {
-	MultiFunction *MultiFunction `protobuf:"bytes,5,opt,name=multi_function,json=multiFunction,oneof"`
+
+// ErrorEnvelope detects errors reported in the body of a response, e.g. {"status": "error", "error": {"message":
+// "not found", "code": "E404"}}, and fails the field with the message and code of the error. Only responses
+// which are JSON objects are checked. Fields are given as paths of field names separated by dots, where numbers
+// index into lists, e.g. "errors.0.message"
+type ErrorEnvelope struct {
+	// the field which tells errors apart from data, e.g. "status" or "error"
+	ErrorField string `protobuf:"bytes,1,opt,name=error_field,json=errorField,proto3" json:"error_field,omitempty"`
+	// if set, the response is an error when the error field has this value, e.g. "error". otherwise it is an
+	// error when the error field is set to anything but null, false, an empty string, list or object
+	ErrorValue string `protobuf:"bytes,2,opt,name=error_value,json=errorValue,proto3" json:"error_value,omitempty"`
+	// the field containing the message of the error, e.g. "error.message". defaults to the error field,
+	// if it is a string
+	MessageField string `protobuf:"bytes,3,opt,name=message_field,json=messageField,proto3" json:"message_field,omitempty"`
+	// the field containing a code for the error, reported in the extensions of the GraphQL error, e.g. "error.code"
+	CodeField string `protobuf:"bytes,4,opt,name=code_field,json=codeField,proto3" json:"code_field,omitempty"`
 }
 
-func (*GlooResolver_SingleFunction) isGlooResolver_Function() {}
-func (*GlooResolver_MultiFunction) isGlooResolver_Function()  {}
+func (m *ErrorEnvelope) Reset()                    { *m = ErrorEnvelope{} }
+func (m *ErrorEnvelope) String() string            { return proto.CompactTextString(m) }
+func (*ErrorEnvelope) ProtoMessage()               {}
+func (*ErrorEnvelope) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{5} }
 
-func (m *GlooResolver) GetFunction() isGlooResolver_Function {
+func (m *ErrorEnvelope) GetErrorField() string {
 	if m != nil {
-		return m.Function
+		return m.ErrorField
 	}
-	return nil
+	return ""
 }
 
-func (m *GlooResolver) GetRequestTemplate() string {
+func (m *ErrorEnvelope) GetErrorValue() string {
 	if m != nil {
-		return m.RequestTemplate
+		return m.ErrorValue
 	}
 	return ""
 }
 
-func (m *GlooResolver) GetResponseTemplate() string {
+func (m *ErrorEnvelope) GetMessageField() string {
 	if m != nil {
-		return m.ResponseTemplate
+		return m.MessageField
 	}
 	return ""
 }
 
-func (m *GlooResolver) GetContentType() string {
+func (m *ErrorEnvelope) GetCodeField() string {
 	if m != nil {
-		return m.ContentType
+		return m.CodeField
 	}
 	return ""
 }
 
-func (m *GlooResolver) GetSingleFunction() *Function {
-	if x, ok := m.GetFunction().(*GlooResolver_SingleFunction); ok {
-		return x.SingleFunction
+// PageSize bounds the pages requested through the first and last arguments of a connection field.
+// Sizes are enforced before the resolver is called, so templates passing first or last on to the upstream
+// always see a bounded page size
+type PageSize struct {
+	// set first to this size when a query sets neither first nor last. zero leaves both unset
+	DefaultSize uint32 `protobuf:"varint,1,opt,name=default_size,json=defaultSize,proto3" json:"default_size,omitempty"`
+	// fail queries which set first or last to a larger size. zero means no maximum
+	MaxSize uint32 `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+}
+
+func (m *PageSize) Reset()                    { *m = PageSize{} }
+func (m *PageSize) String() string            { return proto.CompactTextString(m) }
+func (*PageSize) ProtoMessage()               {}
+func (*PageSize) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{6} }
+
+func (m *PageSize) GetDefaultSize() uint32 {
+	if m != nil {
+		return m.DefaultSize
 	}
-	return nil
+	return 0
 }
 
-func (m *GlooResolver) GetMultiFunction() *MultiFunction {
-	if x, ok := m.GetFunction().(*GlooResolver_MultiFunction); ok {
-		return x.MultiFunction
+func (m *PageSize) GetMaxSize() uint32 {
+	if m != nil {
+		return m.MaxSize
 	}
-	return nil
+	return 0
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*GlooResolver) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _GlooResolver_OneofMarshaler, _GlooResolver_OneofUnmarshaler, _GlooResolver_OneofSizer, []interface{}{
-		(*GlooResolver_SingleFunction)(nil),
-		(*GlooResolver_MultiFunction)(nil),
+// ListLimit bounds the number of items of a list returned by a resolver. Lists with more items are truncated,
+// or fail the field
+type ListLimit struct {
+	// the maximum number of items. zero means no limit
+	MaxItems uint32 `protobuf:"varint,1,opt,name=max_items,json=maxItems,proto3" json:"max_items,omitempty"`
+	// fail the field with an error instead of truncating the list
+	Fail bool `protobuf:"varint,2,opt,name=fail,proto3" json:"fail,omitempty"`
+	// report truncated lists in the warnings extension of the response
+	Warn bool `protobuf:"varint,3,opt,name=warn,proto3" json:"warn,omitempty"`
+}
+
+func (m *ListLimit) Reset()                    { *m = ListLimit{} }
+func (m *ListLimit) String() string            { return proto.CompactTextString(m) }
+func (*ListLimit) ProtoMessage()               {}
+func (*ListLimit) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{7} }
+
+func (m *ListLimit) GetMaxItems() uint32 {
+	if m != nil {
+		return m.MaxItems
 	}
+	return 0
 }
 
-func _GlooResolver_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*GlooResolver)
-	// function
-	switch x := m.Function.(type) {
-	case *GlooResolver_SingleFunction:
-		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.SingleFunction); err != nil {
-			return err
-		}
-	case *GlooResolver_MultiFunction:
-		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.MultiFunction); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("GlooResolver.Function has unexpected type %T", x)
+func (m *ListLimit) GetFail() bool {
+	if m != nil {
+		return m.Fail
 	}
-	return nil
+	return false
 }
 
-func _GlooResolver_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*GlooResolver)
-	switch tag {
-	case 4: // function.single_function
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(Function)
-		err := b.DecodeMessage(msg)
-		m.Function = &GlooResolver_SingleFunction{msg}
-		return true, err
-	case 5: // function.multi_function
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(MultiFunction)
-		err := b.DecodeMessage(msg)
-		m.Function = &GlooResolver_MultiFunction{msg}
-		return true, err
-	default:
-		return false, nil
+func (m *ListLimit) GetWarn() bool {
+	if m != nil {
+		return m.Warn
 	}
+	return false
 }
 
-func _GlooResolver_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*GlooResolver)
-	// function
-	switch x := m.Function.(type) {
-	case *GlooResolver_SingleFunction:
-		s := proto.Size(x.SingleFunction)
-		n += proto.SizeVarint(4<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *GlooResolver_MultiFunction:
-		s := proto.Size(x.MultiFunction)
-		n += proto.SizeVarint(5<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+// FollowPages requests the pages of a paginated upstream one after another, passing the token or link to the next
+// page found in each page as an argument to the resolver, until a page has no next page or a limit is reached.
+// Pages are requested within the deadline of the operation
+type FollowPages struct {
+	// the field of each page containing its items, e.g. "data.items". nested fields are separated by dots
+	ItemsField string `protobuf:"bytes,1,opt,name=items_field,json=itemsField,proto3" json:"items_field,omitempty"`
+	// the field of each page containing the token or link to the next page, e.g. "meta.next".
+	// a missing, null or empty value ends pagination
+	NextField string `protobuf:"bytes,2,opt,name=next_field,json=nextField,proto3" json:"next_field,omitempty"`
+	// the argument set to the next token or link when requesting the following page, e.g. "pageToken".
+	// templates of the resolver can read it as an argument, e.g. "{{ or .Args.next \"https://example.com/items\" }}"
+	NextArgument string `protobuf:"bytes,3,opt,name=next_argument,json=nextArgument,proto3" json:"next_argument,omitempty"`
+	// the maximum number of pages requested. defaults to 10
+	MaxPages uint32 `protobuf:"varint,4,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`
+	// the maximum number of items returned. further items are dropped. zero means no limit
+	MaxItems uint32 `protobuf:"varint,5,opt,name=max_items,json=maxItems,proto3" json:"max_items,omitempty"`
+}
+
+func (m *FollowPages) Reset()                    { *m = FollowPages{} }
+func (m *FollowPages) String() string            { return proto.CompactTextString(m) }
+func (*FollowPages) ProtoMessage()               {}
+func (*FollowPages) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{8} }
+
+func (m *FollowPages) GetItemsField() string {
+	if m != nil {
+		return m.ItemsField
 	}
-	return n
+	return ""
 }
 
-// A reference to a function known to Gloo
-type Function struct {
-	// Name of the Gloo Upstream that provides this function
-	Upstream string `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
-	// Name of the function itself. See Gloo documentation for more details on functions in Gloo
-	Function string `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+func (m *FollowPages) GetNextField() string {
+	if m != nil {
+		return m.NextField
+	}
+	return ""
 }
 
-func (m *Function) Reset()                    { *m = Function{} }
-func (m *Function) String() string            { return proto.CompactTextString(m) }
-func (*Function) ProtoMessage()               {}
-func (*Function) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{4} }
+func (m *FollowPages) GetNextArgument() string {
+	if m != nil {
+		return m.NextArgument
+	}
+	return ""
+}
 
-func (m *Function) GetUpstream() string {
+func (m *FollowPages) GetMaxPages() uint32 {
 	if m != nil {
-		return m.Upstream
+		return m.MaxPages
+	}
+	return 0
+}
+
+func (m *FollowPages) GetMaxItems() uint32 {
+	if m != nil {
+		return m.MaxItems
+	}
+	return 0
+}
+
+// FeatureGate disables a field until a feature flag is enabled, so the field can be deployed before it is released.
+// Flags are evaluated on every request by the feature flag provider Sqoop was started with
+type FeatureGate struct {
+	// the name of the feature flag
+	Flag string `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	// while the flag is disabled, fail the field with an error instead of resolving it to null
+	ErrorWhenDisabled bool `protobuf:"varint,2,opt,name=error_when_disabled,json=errorWhenDisabled,proto3" json:"error_when_disabled,omitempty"`
+}
+
+func (m *FeatureGate) Reset()                    { *m = FeatureGate{} }
+func (m *FeatureGate) String() string            { return proto.CompactTextString(m) }
+func (*FeatureGate) ProtoMessage()               {}
+func (*FeatureGate) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{9} }
+
+func (m *FeatureGate) GetFlag() string {
+	if m != nil {
+		return m.Flag
 	}
 	return ""
 }
 
-func (m *Function) GetFunction() string {
+func (m *FeatureGate) GetErrorWhenDisabled() bool {
 	if m != nil {
-		return m.Function
+		return m.ErrorWhenDisabled
+	}
+	return false
+}
+
+// EnumCodes maps the members of a GraphQL enum to the codes used by an upstream
+type EnumCodes struct {
+	// the upstream code for each enum member, by member name
+	Codes map[string]string `protobuf:"bytes,1,rep,name=codes" json:"codes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *EnumCodes) Reset()                    { *m = EnumCodes{} }
+func (m *EnumCodes) String() string            { return proto.CompactTextString(m) }
+func (*EnumCodes) ProtoMessage()               {}
+func (*EnumCodes) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{10} }
+
+func (m *EnumCodes) GetCodes() map[string]string {
+	if m != nil {
+		return m.Codes
+	}
+	return nil
+}
+
+// SplitString splits a string into a list of strings
+type SplitString struct {
+	// the separator between items. defaults to ","
+	Delimiter string `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	// remove leading and trailing whitespace from each item
+	TrimSpace bool `protobuf:"varint,2,opt,name=trim_space,json=trimSpace,proto3" json:"trim_space,omitempty"`
+}
+
+func (m *SplitString) Reset()                    { *m = SplitString{} }
+func (m *SplitString) String() string            { return proto.CompactTextString(m) }
+func (*SplitString) ProtoMessage()               {}
+func (*SplitString) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{11} }
+
+func (m *SplitString) GetDelimiter() string {
+	if m != nil {
+		return m.Delimiter
 	}
 	return ""
 }
 
-// A reference to a list of functions known to Gloo
-type MultiFunction struct {
-	// A list of functions with weights. Must have size >= 1
-	WeightedFunctions []*WeightedFunction `protobuf:"bytes,1,rep,name=weighted_functions,json=weightedFunctions" json:"weighted_functions,omitempty"`
+func (m *SplitString) GetTrimSpace() bool {
+	if m != nil {
+		return m.TrimSpace
+	}
+	return false
 }
 
-func (m *MultiFunction) Reset()                    { *m = MultiFunction{} }
-func (m *MultiFunction) String() string            { return proto.CompactTextString(m) }
-func (*MultiFunction) ProtoMessage()               {}
-func (*MultiFunction) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{5} }
+// ResolverCache caches the result of a resolver for repeated queries.
+// Entries are keyed by the rendered key template, so fields of list items with the same parent key
+// share a single upstream call
+type ResolverCache struct {
+	// a Go template rendered with the arguments (.Args) and parent object (.Parent) of the field
+	// to produce the cache key, e.g. "{{ .Parent.id }}". defaults to the JSON encoding of the arguments, parent,
+	// cookies (.Cookies) and claims (.claims). key templates of resolvers whose results depend on cookies or
+	// claims must include them, or results are shared between users
+	KeyTemplate string `protobuf:"bytes,1,opt,name=key_template,json=keyTemplate,proto3" json:"key_template,omitempty"`
+	// how long an entry stays valid, in seconds. defaults to 60
+	TtlSeconds uint32 `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// the maximum number of entries cached for the field. defaults to 1000
+	MaxEntries uint32 `protobuf:"varint,3,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// keep expired entries whose upstream response carried an ETag, and revalidate them by sending the ETag
+	// in If-None-Match. if the upstream answers 304 Not Modified, the entry is served and stays valid for
+	// another ttl. only gloo and http resolvers making a single request per field can be revalidated
+	Revalidate bool `protobuf:"varint,4,opt,name=revalidate,proto3" json:"revalidate,omitempty"`
+}
 
-func (m *MultiFunction) GetWeightedFunctions() []*WeightedFunction {
+func (m *ResolverCache) Reset()                    { *m = ResolverCache{} }
+func (m *ResolverCache) String() string            { return proto.CompactTextString(m) }
+func (*ResolverCache) ProtoMessage()               {}
+func (*ResolverCache) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{12} }
+
+func (m *ResolverCache) GetKeyTemplate() string {
 	if m != nil {
-		return m.WeightedFunctions
+		return m.KeyTemplate
+	}
+	return ""
+}
+
+func (m *ResolverCache) GetTtlSeconds() uint32 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
+func (m *ResolverCache) GetMaxEntries() uint32 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *ResolverCache) GetRevalidate() bool {
+	if m != nil {
+		return m.Revalidate
+	}
+	return false
+}
+
+// ConditionalResolvers choose a resolver for each query by evaluating the conditions of their variants in order.
+// The first variant whose condition matches is used to resolve the field.
+type ConditionalResolver struct {
+	// the variants to select from, in order of precedence
+	Variants []*ResolverVariant `protobuf:"bytes,1,rep,name=variants" json:"variants,omitempty"`
+	// the resolver to use if no variant matches. if unset, queries which match no variant will return an error
+	DefaultResolver *Resolver `protobuf:"bytes,2,opt,name=default_resolver,json=defaultResolver" json:"default_resolver,omitempty"`
+}
+
+func (m *ConditionalResolver) Reset()                    { *m = ConditionalResolver{} }
+func (m *ConditionalResolver) String() string            { return proto.CompactTextString(m) }
+func (*ConditionalResolver) ProtoMessage()               {}
+func (*ConditionalResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{13} }
+
+func (m *ConditionalResolver) GetVariants() []*ResolverVariant {
+	if m != nil {
+		return m.Variants
 	}
 	return nil
 }
 
-type WeightedFunction struct {
-	// the function to call
-	Function *Function `protobuf:"bytes,1,opt,name=function" json:"function,omitempty"`
-	// Invoking each functoion will be balanced by the ratio of the function's weight to the total weight on a resolver
-	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+func (m *ConditionalResolver) GetDefaultResolver() *Resolver {
+	if m != nil {
+		return m.DefaultResolver
+	}
+	return nil
 }
 
-func (m *WeightedFunction) Reset()                    { *m = WeightedFunction{} }
-func (m *WeightedFunction) String() string            { return proto.CompactTextString(m) }
-func (*WeightedFunction) ProtoMessage()               {}
-func (*WeightedFunction) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{6} }
+// MergeResolvers resolve object fields whose data is spread across several upstreams, e.g. a profile
+// from one service and preferences from another. Every source is called concurrently, and the JSON objects
+// they return are deep-merged into the value of the field. enumArguments must be set on each source
+type MergeResolver struct {
+	// the sources to merge. unless sources own their keys, later sources win over earlier ones
+	Sources []*MergeSource `protobuf:"bytes,1,rep,name=sources" json:"sources,omitempty"`
+}
 
-func (m *WeightedFunction) GetFunction() *Function {
+func (m *MergeResolver) Reset()                    { *m = MergeResolver{} }
+func (m *MergeResolver) String() string            { return proto.CompactTextString(m) }
+func (*MergeResolver) ProtoMessage()               {}
+func (*MergeResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{14} }
+
+func (m *MergeResolver) GetSources() []*MergeSource {
 	if m != nil {
-		return m.Function
+		return m.Sources
 	}
 	return nil
 }
 
-func (m *WeightedFunction) GetWeight() uint32 {
+// A resolver whose result is merged into the value of a field
+type MergeSource struct {
+	// the resolver to call. it must return a JSON object or null. conditional and merge resolvers cannot be nested
+	Resolver *Resolver `protobuf:"bytes,1,opt,name=resolver" json:"resolver,omitempty"`
+	// the top-level keys of the field's value owned by this source. if set, only these keys are taken from the
+	// result of the source, and no other source may own them
+	Keys []string `protobuf:"bytes,2,rep,name=keys" json:"keys,omitempty"`
+	// by default a failing source fails the field. the results of optional sources which fail are left out,
+	// and their error is reported with the path of the field
+	Optional bool `protobuf:"varint,3,opt,name=optional,proto3" json:"optional,omitempty"`
+}
+
+func (m *MergeSource) Reset()                    { *m = MergeSource{} }
+func (m *MergeSource) String() string            { return proto.CompactTextString(m) }
+func (*MergeSource) ProtoMessage()               {}
+func (*MergeSource) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{15} }
+
+func (m *MergeSource) GetResolver() *Resolver {
 	if m != nil {
-		return m.Weight
+		return m.Resolver
 	}
-	return 0
+	return nil
 }
 
-// A Go-template which will return data for a Resolver without making a function call. Template Resolvers can make use
-// of Sqoop's builtin template functions as well as the data provided by the Params object to the resolver.
-// Read more about Templates and Resolvers in Sqoop's [Resolver documentation](TODO).
-type TemplateResolver struct {
-	// the Go template as an inline string
-	InlineTemplate string `protobuf:"bytes,1,opt,name=inline_template,json=inlineTemplate,proto3" json:"inline_template,omitempty"`
+func (m *MergeSource) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
 
-func (m *TemplateResolver) Reset()                    { *m = TemplateResolver{} }
-func (m *TemplateResolver) String() string            { return proto.CompactTextString(m) }
-func (*TemplateResolver) ProtoMessage()               {}
-func (*TemplateResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{7} }
+func (m *MergeSource) GetOptional() bool {
+	if m != nil {
+		return m.Optional
+	}
+	return false
+}
 
-func (m *TemplateResolver) GetInlineTemplate() string {
+// A resolver along with the condition under which it should be used
+type ResolverVariant struct {
+	// the condition which must match for this variant to be selected
+	When *Condition `protobuf:"bytes,1,opt,name=when" json:"when,omitempty"`
+	// the resolver to use when this variant is selected. conditional resolvers cannot be nested
+	Resolver *Resolver `protobuf:"bytes,2,opt,name=resolver" json:"resolver,omitempty"`
+}
+
+func (m *ResolverVariant) Reset()                    { *m = ResolverVariant{} }
+func (m *ResolverVariant) String() string            { return proto.CompactTextString(m) }
+func (*ResolverVariant) ProtoMessage()               {}
+func (*ResolverVariant) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{16} }
+
+func (m *ResolverVariant) GetWhen() *Condition {
 	if m != nil {
-		return m.InlineTemplate
+		return m.When
 	}
-	return ""
+	return nil
 }
 
-// NOTE: currently unsupported
-type NodeJSResolver struct {
-	InlineCode string `protobuf:"bytes,1,opt,name=inline_code,json=inlineCode,proto3" json:"inline_code,omitempty"`
+func (m *ResolverVariant) GetResolver() *Resolver {
+	if m != nil {
+		return m.Resolver
+	}
+	return nil
 }
 
-func (m *NodeJSResolver) Reset()                    { *m = NodeJSResolver{} }
-func (m *NodeJSResolver) String() string            { return proto.CompactTextString(m) }
-func (*NodeJSResolver) ProtoMessage()               {}
-func (*NodeJSResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{8} }
+// A predicate over the Params passed to a resolver. All specified criteria must be met for the condition to match
+type Condition struct {
+	// names of arguments which must be provided (and not null)
+	ArgsPresent []string `protobuf:"bytes,1,rep,name=args_present,json=argsPresent" json:"args_present,omitempty"`
+	// names of arguments which must be omitted (or null)
+	ArgsAbsent []string `protobuf:"bytes,2,rep,name=args_absent,json=argsAbsent" json:"args_absent,omitempty"`
+	// optional Go template which is evaluated against the resolver's Params. the condition matches if the
+	// template renders "true"
+	Template string `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`
+}
 
-func (m *NodeJSResolver) GetInlineCode() string {
+func (m *Condition) Reset()                    { *m = Condition{} }
+func (m *Condition) String() string            { return proto.CompactTextString(m) }
+func (*Condition) ProtoMessage()               {}
+func (*Condition) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{17} }
+
+func (m *Condition) GetArgsPresent() []string {
 	if m != nil {
-		return m.InlineCode
+		return m.ArgsPresent
+	}
+	return nil
+}
+
+func (m *Condition) GetArgsAbsent() []string {
+	if m != nil {
+		return m.ArgsAbsent
+	}
+	return nil
+}
+
+func (m *Condition) GetTemplate() string {
+	if m != nil {
+		return m.Template
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*ResolverMap)(nil), "sqoop.api.v1.ResolverMap")
-	proto.RegisterType((*TypeResolver)(nil), "sqoop.api.v1.TypeResolver")
-	proto.RegisterType((*Resolver)(nil), "sqoop.api.v1.Resolver")
-	proto.RegisterType((*GlooResolver)(nil), "sqoop.api.v1.GlooResolver")
-	proto.RegisterType((*Function)(nil), "sqoop.api.v1.Function")
-	proto.RegisterType((*MultiFunction)(nil), "sqoop.api.v1.MultiFunction")
-	proto.RegisterType((*WeightedFunction)(nil), "sqoop.api.v1.WeightedFunction")
-	proto.RegisterType((*TemplateResolver)(nil), "sqoop.api.v1.TemplateResolver")
-	proto.RegisterType((*NodeJSResolver)(nil), "sqoop.api.v1.NodeJSResolver")
+// GlooResolvers are the "meat" of Sqoop. GlooResolvers tell Sqoop how to invoke a "Gloo Function"
+type GlooResolver struct {
+	// the Request Template, if specified, will become the body of the HTTP request used to invoke a function through Gloo
+	// input parameters, if needed, should be specified in the request template. See Sqoop's [Resolver documentation](TODO)
+	// for more information on Request Templates.
+	RequestTemplate string `protobuf:"bytes,1,opt,name=request_template,json=requestTemplate,proto3" json:"request_template,omitempty"`
+	// The response template, if specified, will transform the body of HTTP responses returned by Gloo functions.
+	// This field should be used if the object returned by the Gloo Function does not match the type specified in the GraphQL schema.
+	// It can also be used to modify or transform responses from their original state. See Sqoop's [Resolver documentation](TODO)
+	// for more information on Response Templates.
+	ResponseTemplate string `protobuf:"bytes,2,opt,name=response_template,json=responseTemplate,proto3" json:"response_template,omitempty"`
+	// Optional. Use to set the outbound HTTP Request header `Content-Type`. Defaults to `application/json`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Specify the function the resolver should invoke. The function must be one registered to Gloo.
+	//
+	// Types that are valid to be assigned to Function:
+	//	*GlooResolver_SingleFunction
+	//	*GlooResolver_MultiFunction
+	//	*GlooResolver_Upstream
+	Function isGlooResolver_Function `protobuf_oneof:"function"`
+	// Optional. Headers whose values are read from secrets, e.g. API keys for the function.
+	// Secret values are never written to resolver maps or logs
+	SecretHeaders []*SecretHeader `protobuf:"bytes,6,rep,name=secret_headers,json=secretHeaders" json:"secret_headers,omitempty"`
+	// Optional. Request cookies to forward to the function, if the request sent them.
+	// Only cookies allowed with --sqoop.allowed-cookies are available to forward
+	ForwardCookies []string `protobuf:"bytes,8,rep,name=forward_cookies,json=forwardCookies" json:"forward_cookies,omitempty"`
+	// Optional. Timeout for requests to the function, in milliseconds, including retries. Programmed as the timeout
+	// of the resolver's route on the proxy, and applied by Sqoop with a small margin so the proxy's timeout
+	// response is returned rather than a cancelled request. Zero uses the proxy's default timeout
+	TimeoutMs uint32 `protobuf:"varint,9,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Optional. The number of times the proxy retries failed requests to the function
+	MaxRetries uint32 `protobuf:"varint,10,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	// Optional. Headers set to claims of the credential the request was authenticated with, by header name,
+	// e.g. {"X-User-Id": "sub"}. See HttpResolver.claim_headers
+	ClaimHeaders map[string]string `protobuf:"bytes,11,rep,name=claim_headers,json=claimHeaders" json:"claim_headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *GlooResolver) Reset()                    { *m = GlooResolver{} }
+func (m *GlooResolver) String() string            { return proto.CompactTextString(m) }
+func (*GlooResolver) ProtoMessage()               {}
+func (*GlooResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{18} }
+
+type isGlooResolver_Function interface {
+	isGlooResolver_Function()
+	Equal(interface{}) bool
+}
+
+type GlooResolver_SingleFunction struct {
+	SingleFunction *Function `protobuf:"bytes,4,opt,name=single_function,json=singleFunction,oneof"`
+}
+type GlooResolver_MultiFunction struct {
+	MultiFunction *MultiFunction `protobuf:"bytes,5,opt,name=multi_function,json=multiFunction,oneof"`
+}
+type GlooResolver_Upstream struct {
+	Upstream string `protobuf:"bytes,7,opt,name=upstream,proto3,oneof"`
+}
+
+func (*GlooResolver_SingleFunction) isGlooResolver_Function() {}
+func (*GlooResolver_MultiFunction) isGlooResolver_Function()  {}
+func (*GlooResolver_Upstream) isGlooResolver_Function()       {}
+
+func (m *GlooResolver) GetFunction() isGlooResolver_Function {
+	if m != nil {
+		return m.Function
+	}
+	return nil
+}
+
+func (m *GlooResolver) GetRequestTemplate() string {
+	if m != nil {
+		return m.RequestTemplate
+	}
+	return ""
+}
+
+func (m *GlooResolver) GetResponseTemplate() string {
+	if m != nil {
+		return m.ResponseTemplate
+	}
+	return ""
+}
+
+func (m *GlooResolver) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *GlooResolver) GetSingleFunction() *Function {
+	if x, ok := m.GetFunction().(*GlooResolver_SingleFunction); ok {
+		return x.SingleFunction
+	}
+	return nil
+}
+
+func (m *GlooResolver) GetMultiFunction() *MultiFunction {
+	if x, ok := m.GetFunction().(*GlooResolver_MultiFunction); ok {
+		return x.MultiFunction
+	}
+	return nil
+}
+
+func (m *GlooResolver) GetUpstream() string {
+	if x, ok := m.GetFunction().(*GlooResolver_Upstream); ok {
+		return x.Upstream
+	}
+	return ""
+}
+
+func (m *GlooResolver) GetSecretHeaders() []*SecretHeader {
+	if m != nil {
+		return m.SecretHeaders
+	}
+	return nil
+}
+
+func (m *GlooResolver) GetForwardCookies() []string {
+	if m != nil {
+		return m.ForwardCookies
+	}
+	return nil
+}
+
+func (m *GlooResolver) GetTimeoutMs() uint32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+func (m *GlooResolver) GetMaxRetries() uint32 {
+	if m != nil {
+		return m.MaxRetries
+	}
+	return 0
+}
+
+func (m *GlooResolver) GetClaimHeaders() map[string]string {
+	if m != nil {
+		return m.ClaimHeaders
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*GlooResolver) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _GlooResolver_OneofMarshaler, _GlooResolver_OneofUnmarshaler, _GlooResolver_OneofSizer, []interface{}{
+		(*GlooResolver_SingleFunction)(nil),
+		(*GlooResolver_MultiFunction)(nil),
+		(*GlooResolver_Upstream)(nil),
+	}
+}
+
+func _GlooResolver_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*GlooResolver)
+	// function
+	switch x := m.Function.(type) {
+	case *GlooResolver_SingleFunction:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SingleFunction); err != nil {
+			return err
+		}
+	case *GlooResolver_MultiFunction:
+		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MultiFunction); err != nil {
+			return err
+		}
+	case *GlooResolver_Upstream:
+		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
+		_ = b.EncodeStringBytes(x.Upstream)
+	case nil:
+	default:
+		return fmt.Errorf("GlooResolver.Function has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _GlooResolver_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*GlooResolver)
+	switch tag {
+	case 4: // function.single_function
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Function)
+		err := b.DecodeMessage(msg)
+		m.Function = &GlooResolver_SingleFunction{msg}
+		return true, err
+	case 5: // function.multi_function
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MultiFunction)
+		err := b.DecodeMessage(msg)
+		m.Function = &GlooResolver_MultiFunction{msg}
+		return true, err
+	case 7: // function.upstream
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Function = &GlooResolver_Upstream{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _GlooResolver_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*GlooResolver)
+	// function
+	switch x := m.Function.(type) {
+	case *GlooResolver_SingleFunction:
+		s := proto.Size(x.SingleFunction)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GlooResolver_MultiFunction:
+		s := proto.Size(x.MultiFunction)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *GlooResolver_Upstream:
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Upstream)))
+		n += len(x.Upstream)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// SecretHeader sets an outbound HTTP request header to a value stored in a secret
+type SecretHeader struct {
+	// the name of the header, e.g. `Authorization`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the secret containing the value of the header
+	SecretRef *SecretRef `protobuf:"bytes,2,opt,name=secret_ref,json=secretRef" json:"secret_ref,omitempty"`
+	// Optional. prepended to the secret value, e.g. `Bearer `
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (m *SecretHeader) Reset()                    { *m = SecretHeader{} }
+func (m *SecretHeader) String() string            { return proto.CompactTextString(m) }
+func (*SecretHeader) ProtoMessage()               {}
+func (*SecretHeader) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{19} }
+
+func (m *SecretHeader) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SecretHeader) GetSecretRef() *SecretRef {
+	if m != nil {
+		return m.SecretRef
+	}
+	return nil
+}
+
+func (m *SecretHeader) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// SecretRef references a single value in a secret
+type SecretRef struct {
+	// the name of the secret
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the key of the value within the secret
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *SecretRef) Reset()                    { *m = SecretRef{} }
+func (m *SecretRef) String() string            { return proto.CompactTextString(m) }
+func (*SecretRef) ProtoMessage()               {}
+func (*SecretRef) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{20} }
+
+func (m *SecretRef) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SecretRef) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// A reference to a function known to Gloo
+type Function struct {
+	// Name of the Gloo Upstream that provides this function
+	Upstream string `protobuf:"bytes,1,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	// Name of the function itself. See Gloo documentation for more details on functions in Gloo
+	Function string `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+}
+
+func (m *Function) Reset()                    { *m = Function{} }
+func (m *Function) String() string            { return proto.CompactTextString(m) }
+func (*Function) ProtoMessage()               {}
+func (*Function) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{21} }
+
+func (m *Function) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *Function) GetFunction() string {
+	if m != nil {
+		return m.Function
+	}
+	return ""
+}
+
+// A reference to a list of functions known to Gloo
+type MultiFunction struct {
+	// A list of functions with weights. Must have size >= 1
+	WeightedFunctions []*WeightedFunction `protobuf:"bytes,1,rep,name=weighted_functions,json=weightedFunctions" json:"weighted_functions,omitempty"`
+}
+
+func (m *MultiFunction) Reset()                    { *m = MultiFunction{} }
+func (m *MultiFunction) String() string            { return proto.CompactTextString(m) }
+func (*MultiFunction) ProtoMessage()               {}
+func (*MultiFunction) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{22} }
+
+func (m *MultiFunction) GetWeightedFunctions() []*WeightedFunction {
+	if m != nil {
+		return m.WeightedFunctions
+	}
+	return nil
+}
+
+type WeightedFunction struct {
+	// the function to call
+	Function *Function `protobuf:"bytes,1,opt,name=function" json:"function,omitempty"`
+	// Invoking each functoion will be balanced by the ratio of the function's weight to the total weight on a resolver
+	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (m *WeightedFunction) Reset()                    { *m = WeightedFunction{} }
+func (m *WeightedFunction) String() string            { return proto.CompactTextString(m) }
+func (*WeightedFunction) ProtoMessage()               {}
+func (*WeightedFunction) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{23} }
+
+func (m *WeightedFunction) GetFunction() *Function {
+	if m != nil {
+		return m.Function
+	}
+	return nil
+}
+
+func (m *WeightedFunction) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// A Go-template which will return data for a Resolver without making a function call. Template Resolvers can make use
+// of Sqoop's builtin template functions as well as the data provided by the Params object to the resolver.
+// Read more about Templates and Resolvers in Sqoop's [Resolver documentation](TODO).
+type TemplateResolver struct {
+	// the Go template as an inline string
+	InlineTemplate string `protobuf:"bytes,1,opt,name=inline_template,json=inlineTemplate,proto3" json:"inline_template,omitempty"`
+}
+
+func (m *TemplateResolver) Reset()                    { *m = TemplateResolver{} }
+func (m *TemplateResolver) String() string            { return proto.CompactTextString(m) }
+func (*TemplateResolver) ProtoMessage()               {}
+func (*TemplateResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{24} }
+
+func (m *TemplateResolver) GetInlineTemplate() string {
+	if m != nil {
+		return m.InlineTemplate
+	}
+	return ""
+}
+
+// A MockResolver returns fake data matching the type of the field instead of calling a backend.
+// Useful for developing against a schema before its backends exist.
+type MockResolver struct {
+	// the number of items to generate for list types. defaults to 3
+	ListLength uint32 `protobuf:"varint,1,opt,name=list_length,json=listLength,proto3" json:"list_length,omitempty"`
+}
+
+func (m *MockResolver) Reset()                    { *m = MockResolver{} }
+func (m *MockResolver) String() string            { return proto.CompactTextString(m) }
+func (*MockResolver) ProtoMessage()               {}
+func (*MockResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{25} }
+
+func (m *MockResolver) GetListLength() uint32 {
+	if m != nil {
+		return m.ListLength
+	}
+	return 0
+}
+
+// SignedUrlResolvers resolve String fields to pre-signed URLs for objects in S3, or any storage with an
+// S3-compatible API such as GCS with HMAC keys. Clients download the object directly from storage
+type SignedUrlResolver struct {
+	// the bucket containing the object
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// a Go template rendered with the arguments (.Args) and parent object (.Parent) of the field
+	// to produce the key of the object, e.g. "avatars/{{ .Parent.id }}.png"
+	KeyTemplate string `protobuf:"bytes,2,opt,name=key_template,json=keyTemplate,proto3" json:"key_template,omitempty"`
+	// the region of the bucket. defaults to us-east-1
+	Region string `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	// the storage endpoint. defaults to the S3 endpoint for the region. use storage.googleapis.com for GCS
+	Endpoint string `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// address the bucket in the path of the URL rather than the host name
+	PathStyle bool `protobuf:"varint,5,opt,name=path_style,json=pathStyle,proto3" json:"path_style,omitempty"`
+	// how long the URL is valid for, in seconds. defaults to 900
+	ExpirySeconds uint32 `protobuf:"varint,6,opt,name=expiry_seconds,json=expirySeconds,proto3" json:"expiry_seconds,omitempty"`
+	// secrets containing the access key id and secret access key used to sign URLs
+	AccessKeyId     *SecretRef `protobuf:"bytes,7,opt,name=access_key_id,json=accessKeyId" json:"access_key_id,omitempty"`
+	SecretAccessKey *SecretRef `protobuf:"bytes,8,opt,name=secret_access_key,json=secretAccessKey" json:"secret_access_key,omitempty"`
+}
+
+func (m *SignedUrlResolver) Reset()                    { *m = SignedUrlResolver{} }
+func (m *SignedUrlResolver) String() string            { return proto.CompactTextString(m) }
+func (*SignedUrlResolver) ProtoMessage()               {}
+func (*SignedUrlResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{26} }
+
+func (m *SignedUrlResolver) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SignedUrlResolver) GetKeyTemplate() string {
+	if m != nil {
+		return m.KeyTemplate
+	}
+	return ""
+}
+
+func (m *SignedUrlResolver) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *SignedUrlResolver) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *SignedUrlResolver) GetPathStyle() bool {
+	if m != nil {
+		return m.PathStyle
+	}
+	return false
+}
+
+func (m *SignedUrlResolver) GetExpirySeconds() uint32 {
+	if m != nil {
+		return m.ExpirySeconds
+	}
+	return 0
+}
+
+func (m *SignedUrlResolver) GetAccessKeyId() *SecretRef {
+	if m != nil {
+		return m.AccessKeyId
+	}
+	return nil
+}
+
+func (m *SignedUrlResolver) GetSecretAccessKey() *SecretRef {
+	if m != nil {
+		return m.SecretAccessKey
+	}
+	return nil
+}
+
+// A VariablesResolver returns the value of a variable of the request, or a Go template rendered with the
+// variables of the request, without calling a backend. Useful for echo and health schemas.
+// Every variable referenced by the resolver must be defined by the operation.
+type VariablesResolver struct {
+	// the name of the variable to return, without the $
+	Variable string `protobuf:"bytes,1,opt,name=variable,proto3" json:"variable,omitempty"`
+	// alternatively, a Go template rendered with the variables of the request, e.g. `{{ .first }} {{ .last }}`.
+	// variables which are defined but not provided are set to their default value, if any
+	InlineTemplate string `protobuf:"bytes,2,opt,name=inline_template,json=inlineTemplate,proto3" json:"inline_template,omitempty"`
+}
+
+func (m *VariablesResolver) Reset()                    { *m = VariablesResolver{} }
+func (m *VariablesResolver) String() string            { return proto.CompactTextString(m) }
+func (*VariablesResolver) ProtoMessage()               {}
+func (*VariablesResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{27} }
+
+func (m *VariablesResolver) GetVariable() string {
+	if m != nil {
+		return m.Variable
+	}
+	return ""
+}
+
+func (m *VariablesResolver) GetInlineTemplate() string {
+	if m != nil {
+		return m.InlineTemplate
+	}
+	return ""
+}
+
+// An HttpResolver sends a GET request directly to a URL rendered from the arguments (.Args) and parent
+// object (.Parent) of the field, rather than through Gloo. Useful when another resolver returns the
+// location of related data, e.g. a product with a reviewsUrl field.
+// The response body is returned as the result of the field
+type HttpResolver struct {
+	// a Go template producing an absolute http or https URL, e.g. "{{ .Parent.reviewsUrl }}"
+	UrlTemplate string `protobuf:"bytes,1,opt,name=url_template,json=urlTemplate,proto3" json:"url_template,omitempty"`
+	// the hosts the rendered URL may point to. a leading "*." matches any subdomain, e.g. "*.example.com".
+	// at least one host is required, so data returned by upstreams cannot direct requests to arbitrary hosts
+	AllowedHosts []string `protobuf:"bytes,2,rep,name=allowed_hosts,json=allowedHosts" json:"allowed_hosts,omitempty"`
+	// headers to send with the request
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// if set, relative URLs rendered by the url template are resolved against this URL.
+	// the host of the base URL is always allowed
+	BaseUrl string `protobuf:"bytes,4,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// timeout for the request, in milliseconds. zero means no timeout
+	TimeoutMs uint32 `protobuf:"varint,5,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// request cookies to forward, if the request sent them.
+	// Only cookies allowed with --sqoop.allowed-cookies are available to forward
+	ForwardCookies []string `protobuf:"bytes,6,rep,name=forward_cookies,json=forwardCookies" json:"forward_cookies,omitempty"`
+	// headers set to claims of the credential the request was authenticated with by the external auth of the
+	// schema, by header name, e.g. {"X-User-Id": "sub"}. nested claims are separated by dots, and claims which
+	// are not strings are sent as JSON. headers whose claim is missing are not sent. the field fails if the
+	// request was not authenticated
+	ClaimHeaders map[string]string `protobuf:"bytes,7,rep,name=claim_headers,json=claimHeaders" json:"claim_headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *HttpResolver) Reset()                    { *m = HttpResolver{} }
+func (m *HttpResolver) String() string            { return proto.CompactTextString(m) }
+func (*HttpResolver) ProtoMessage()               {}
+func (*HttpResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{28} }
+
+func (m *HttpResolver) GetUrlTemplate() string {
+	if m != nil {
+		return m.UrlTemplate
+	}
+	return ""
+}
+
+func (m *HttpResolver) GetAllowedHosts() []string {
+	if m != nil {
+		return m.AllowedHosts
+	}
+	return nil
+}
+
+func (m *HttpResolver) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *HttpResolver) GetBaseUrl() string {
+	if m != nil {
+		return m.BaseUrl
+	}
+	return ""
+}
+
+func (m *HttpResolver) GetTimeoutMs() uint32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+func (m *HttpResolver) GetForwardCookies() []string {
+	if m != nil {
+		return m.ForwardCookies
+	}
+	return nil
+}
+
+func (m *HttpResolver) GetClaimHeaders() map[string]string {
+	if m != nil {
+		return m.ClaimHeaders
+	}
+	return nil
+}
+
+// NOTE: currently unsupported
+type NodeJSResolver struct {
+	InlineCode string `protobuf:"bytes,1,opt,name=inline_code,json=inlineCode,proto3" json:"inline_code,omitempty"`
+}
+
+func (m *NodeJSResolver) Reset()                    { *m = NodeJSResolver{} }
+func (m *NodeJSResolver) String() string            { return proto.CompactTextString(m) }
+func (*NodeJSResolver) ProtoMessage()               {}
+func (*NodeJSResolver) Descriptor() ([]byte, []int) { return fileDescriptorResolverMap, []int{29} }
+
+func (m *NodeJSResolver) GetInlineCode() string {
+	if m != nil {
+		return m.InlineCode
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ResolverMap)(nil), "sqoop.api.v1.ResolverMap")
+	proto.RegisterType((*HttpDefaults)(nil), "sqoop.api.v1.HttpDefaults")
+	proto.RegisterType((*TypeResolver)(nil), "sqoop.api.v1.TypeResolver")
+	proto.RegisterType((*Resolver)(nil), "sqoop.api.v1.Resolver")
+	proto.RegisterType((*BodyLimits)(nil), "sqoop.api.v1.BodyLimits")
+	proto.RegisterType((*ErrorEnvelope)(nil), "sqoop.api.v1.ErrorEnvelope")
+	proto.RegisterType((*PageSize)(nil), "sqoop.api.v1.PageSize")
+	proto.RegisterType((*ListLimit)(nil), "sqoop.api.v1.ListLimit")
+	proto.RegisterType((*FollowPages)(nil), "sqoop.api.v1.FollowPages")
+	proto.RegisterType((*FeatureGate)(nil), "sqoop.api.v1.FeatureGate")
+	proto.RegisterType((*EnumCodes)(nil), "sqoop.api.v1.EnumCodes")
+	proto.RegisterType((*SplitString)(nil), "sqoop.api.v1.SplitString")
+	proto.RegisterType((*ResolverCache)(nil), "sqoop.api.v1.ResolverCache")
+	proto.RegisterType((*ConditionalResolver)(nil), "sqoop.api.v1.ConditionalResolver")
+	proto.RegisterType((*MergeResolver)(nil), "sqoop.api.v1.MergeResolver")
+	proto.RegisterType((*MergeSource)(nil), "sqoop.api.v1.MergeSource")
+	proto.RegisterType((*ResolverVariant)(nil), "sqoop.api.v1.ResolverVariant")
+	proto.RegisterType((*Condition)(nil), "sqoop.api.v1.Condition")
+	proto.RegisterType((*GlooResolver)(nil), "sqoop.api.v1.GlooResolver")
+	proto.RegisterType((*SecretHeader)(nil), "sqoop.api.v1.SecretHeader")
+	proto.RegisterType((*SecretRef)(nil), "sqoop.api.v1.SecretRef")
+	proto.RegisterType((*Function)(nil), "sqoop.api.v1.Function")
+	proto.RegisterType((*MultiFunction)(nil), "sqoop.api.v1.MultiFunction")
+	proto.RegisterType((*WeightedFunction)(nil), "sqoop.api.v1.WeightedFunction")
+	proto.RegisterType((*TemplateResolver)(nil), "sqoop.api.v1.TemplateResolver")
+	proto.RegisterType((*MockResolver)(nil), "sqoop.api.v1.MockResolver")
+	proto.RegisterType((*SignedUrlResolver)(nil), "sqoop.api.v1.SignedUrlResolver")
+	proto.RegisterType((*VariablesResolver)(nil), "sqoop.api.v1.VariablesResolver")
+	proto.RegisterType((*HttpResolver)(nil), "sqoop.api.v1.HttpResolver")
+	proto.RegisterType((*NodeJSResolver)(nil), "sqoop.api.v1.NodeJSResolver")
+}
+func (this *ResolverMap) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResolverMap)
+	if !ok {
+		that2, ok := that.(ResolverMap)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if len(this.Types) != len(that1.Types) {
+		return false
+	}
+	for i := range this.Types {
+		if !this.Types[i].Equal(that1.Types[i]) {
+			return false
+		}
+	}
+	if !this.Status.Equal(that1.Status) {
+		return false
+	}
+	if !this.Metadata.Equal(that1.Metadata) {
+		return false
+	}
+	if !this.HttpDefaults.Equal(that1.HttpDefaults) {
+		return false
+	}
+	return true
+}
+func (this *HttpDefaults) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*HttpDefaults)
+	if !ok {
+		that2, ok := that.(HttpDefaults)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.BaseUrl != that1.BaseUrl {
+		return false
+	}
+	if len(this.AllowedHosts) != len(that1.AllowedHosts) {
+		return false
+	}
+	for i := range this.AllowedHosts {
+		if this.AllowedHosts[i] != that1.AllowedHosts[i] {
+			return false
+		}
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if this.Headers[i] != that1.Headers[i] {
+			return false
+		}
+	}
+	if this.TimeoutMs != that1.TimeoutMs {
+		return false
+	}
+	return true
+}
+func (this *TypeResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TypeResolver)
+	if !ok {
+		that2, ok := that.(TypeResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Fields) != len(that1.Fields) {
+		return false
+	}
+	for i := range this.Fields {
+		if !this.Fields[i].Equal(that1.Fields[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *Resolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver)
+	if !ok {
+		that2, ok := that.(Resolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Resolver == nil {
+		if this.Resolver != nil {
+			return false
+		}
+	} else if this.Resolver == nil {
+		return false
+	} else if !this.Resolver.Equal(that1.Resolver) {
+		return false
+	}
+	if !this.Cache.Equal(that1.Cache) {
+		return false
+	}
+	if this.NullAsEmptyList != that1.NullAsEmptyList {
+		return false
+	}
+	if !this.SplitString.Equal(that1.SplitString) {
+		return false
+	}
+	if len(this.EnumArguments) != len(that1.EnumArguments) {
+		return false
+	}
+	for i := range this.EnumArguments {
+		if !this.EnumArguments[i].Equal(that1.EnumArguments[i]) {
+			return false
+		}
+	}
+	if !this.FeatureGate.Equal(that1.FeatureGate) {
+		return false
+	}
+	if !this.Precondition.Equal(that1.Precondition) {
+		return false
+	}
+	if this.ListConcurrency != that1.ListConcurrency {
+		return false
+	}
+	if !this.FollowPages.Equal(that1.FollowPages) {
+		return false
+	}
+	if !this.ListLimit.Equal(that1.ListLimit) {
+		return false
+	}
+	if !this.PageSize.Equal(that1.PageSize) {
+		return false
+	}
+	if !this.ErrorEnvelope.Equal(that1.ErrorEnvelope) {
+		return false
+	}
+	if !this.BodyLimits.Equal(that1.BodyLimits) {
+		return false
+	}
+	if len(this.ErrorCodes) != len(that1.ErrorCodes) {
+		return false
+	}
+	for i := range this.ErrorCodes {
+		if this.ErrorCodes[i] != that1.ErrorCodes[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *Resolver_GlooResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_GlooResolver)
+	if !ok {
+		that2, ok := that.(Resolver_GlooResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.GlooResolver.Equal(that1.GlooResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_TemplateResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_TemplateResolver)
+	if !ok {
+		that2, ok := that.(Resolver_TemplateResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.TemplateResolver.Equal(that1.TemplateResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_NodejsResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_NodejsResolver)
+	if !ok {
+		that2, ok := that.(Resolver_NodejsResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.NodejsResolver.Equal(that1.NodejsResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_ConditionalResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_ConditionalResolver)
+	if !ok {
+		that2, ok := that.(Resolver_ConditionalResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ConditionalResolver.Equal(that1.ConditionalResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_MockResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_MockResolver)
+	if !ok {
+		that2, ok := that.(Resolver_MockResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.MockResolver.Equal(that1.MockResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_SignedUrlResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_SignedUrlResolver)
+	if !ok {
+		that2, ok := that.(Resolver_SignedUrlResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.SignedUrlResolver.Equal(that1.SignedUrlResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_VariablesResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_VariablesResolver)
+	if !ok {
+		that2, ok := that.(Resolver_VariablesResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.VariablesResolver.Equal(that1.VariablesResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_HttpResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_HttpResolver)
+	if !ok {
+		that2, ok := that.(Resolver_HttpResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.HttpResolver.Equal(that1.HttpResolver) {
+		return false
+	}
+	return true
+}
+func (this *Resolver_MergeResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Resolver_MergeResolver)
+	if !ok {
+		that2, ok := that.(Resolver_MergeResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.MergeResolver.Equal(that1.MergeResolver) {
+		return false
+	}
+	return true
+}
+func (this *BodyLimits) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BodyLimits)
+	if !ok {
+		that2, ok := that.(BodyLimits)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxRequestBytes != that1.MaxRequestBytes {
+		return false
+	}
+	if this.MaxResponseBytes != that1.MaxResponseBytes {
+		return false
+	}
+	return true
+}
+func (this *ErrorEnvelope) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ErrorEnvelope)
+	if !ok {
+		that2, ok := that.(ErrorEnvelope)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ErrorField != that1.ErrorField {
+		return false
+	}
+	if this.ErrorValue != that1.ErrorValue {
+		return false
+	}
+	if this.MessageField != that1.MessageField {
+		return false
+	}
+	if this.CodeField != that1.CodeField {
+		return false
+	}
+	return true
+}
+func (this *PageSize) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PageSize)
+	if !ok {
+		that2, ok := that.(PageSize)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.DefaultSize != that1.DefaultSize {
+		return false
+	}
+	if this.MaxSize != that1.MaxSize {
+		return false
+	}
+	return true
+}
+func (this *ListLimit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ListLimit)
+	if !ok {
+		that2, ok := that.(ListLimit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxItems != that1.MaxItems {
+		return false
+	}
+	if this.Fail != that1.Fail {
+		return false
+	}
+	if this.Warn != that1.Warn {
+		return false
+	}
+	return true
+}
+func (this *FollowPages) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FollowPages)
+	if !ok {
+		that2, ok := that.(FollowPages)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ItemsField != that1.ItemsField {
+		return false
+	}
+	if this.NextField != that1.NextField {
+		return false
+	}
+	if this.NextArgument != that1.NextArgument {
+		return false
+	}
+	if this.MaxPages != that1.MaxPages {
+		return false
+	}
+	if this.MaxItems != that1.MaxItems {
+		return false
+	}
+	return true
+}
+func (this *FeatureGate) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FeatureGate)
+	if !ok {
+		that2, ok := that.(FeatureGate)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Flag != that1.Flag {
+		return false
+	}
+	if this.ErrorWhenDisabled != that1.ErrorWhenDisabled {
+		return false
+	}
+	return true
+}
+func (this *EnumCodes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EnumCodes)
+	if !ok {
+		that2, ok := that.(EnumCodes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Codes) != len(that1.Codes) {
+		return false
+	}
+	for i := range this.Codes {
+		if this.Codes[i] != that1.Codes[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *SplitString) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SplitString)
+	if !ok {
+		that2, ok := that.(SplitString)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Delimiter != that1.Delimiter {
+		return false
+	}
+	if this.TrimSpace != that1.TrimSpace {
+		return false
+	}
+	return true
+}
+func (this *ResolverCache) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResolverCache)
+	if !ok {
+		that2, ok := that.(ResolverCache)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.KeyTemplate != that1.KeyTemplate {
+		return false
+	}
+	if this.TtlSeconds != that1.TtlSeconds {
+		return false
+	}
+	if this.MaxEntries != that1.MaxEntries {
+		return false
+	}
+	if this.Revalidate != that1.Revalidate {
+		return false
+	}
+	return true
+}
+func (this *ConditionalResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ConditionalResolver)
+	if !ok {
+		that2, ok := that.(ConditionalResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Variants) != len(that1.Variants) {
+		return false
+	}
+	for i := range this.Variants {
+		if !this.Variants[i].Equal(that1.Variants[i]) {
+			return false
+		}
+	}
+	if !this.DefaultResolver.Equal(that1.DefaultResolver) {
+		return false
+	}
+	return true
+}
+func (this *MergeResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MergeResolver)
+	if !ok {
+		that2, ok := that.(MergeResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Sources) != len(that1.Sources) {
+		return false
+	}
+	for i := range this.Sources {
+		if !this.Sources[i].Equal(that1.Sources[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *ResolverMap) Equal(that interface{}) bool {
+func (this *MergeSource) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ResolverMap)
+	that1, ok := that.(*MergeSource)
 	if !ok {
-		that2, ok := that.(ResolverMap)
+		that2, ok := that.(MergeSource)
 		if ok {
 			that1 = &that2
 		} else {
@@ -537,33 +2500,30 @@ func (this *ResolverMap) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Name != that1.Name {
+	if !this.Resolver.Equal(that1.Resolver) {
 		return false
 	}
-	if len(this.Types) != len(that1.Types) {
+	if len(this.Keys) != len(that1.Keys) {
 		return false
 	}
-	for i := range this.Types {
-		if !this.Types[i].Equal(that1.Types[i]) {
+	for i := range this.Keys {
+		if this.Keys[i] != that1.Keys[i] {
 			return false
 		}
 	}
-	if !this.Status.Equal(that1.Status) {
-		return false
-	}
-	if !this.Metadata.Equal(that1.Metadata) {
+	if this.Optional != that1.Optional {
 		return false
 	}
 	return true
 }
-func (this *TypeResolver) Equal(that interface{}) bool {
+func (this *ResolverVariant) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TypeResolver)
+	that1, ok := that.(*ResolverVariant)
 	if !ok {
-		that2, ok := that.(TypeResolver)
+		that2, ok := that.(ResolverVariant)
 		if ok {
 			that1 = &that2
 		} else {
@@ -575,24 +2535,22 @@ func (this *TypeResolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Fields) != len(that1.Fields) {
+	if !this.When.Equal(that1.When) {
 		return false
 	}
-	for i := range this.Fields {
-		if !this.Fields[i].Equal(that1.Fields[i]) {
-			return false
-		}
+	if !this.Resolver.Equal(that1.Resolver) {
+		return false
 	}
 	return true
 }
-func (this *Resolver) Equal(that interface{}) bool {
+func (this *Condition) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Resolver)
+	that1, ok := that.(*Condition)
 	if !ok {
-		that2, ok := that.(Resolver)
+		that2, ok := that.(Condition)
 		if ok {
 			that1 = &that2
 		} else {
@@ -604,25 +2562,35 @@ func (this *Resolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if that1.Resolver == nil {
-		if this.Resolver != nil {
+	if len(this.ArgsPresent) != len(that1.ArgsPresent) {
+		return false
+	}
+	for i := range this.ArgsPresent {
+		if this.ArgsPresent[i] != that1.ArgsPresent[i] {
 			return false
 		}
-	} else if this.Resolver == nil {
+	}
+	if len(this.ArgsAbsent) != len(that1.ArgsAbsent) {
 		return false
-	} else if !this.Resolver.Equal(that1.Resolver) {
+	}
+	for i := range this.ArgsAbsent {
+		if this.ArgsAbsent[i] != that1.ArgsAbsent[i] {
+			return false
+		}
+	}
+	if this.Template != that1.Template {
 		return false
 	}
 	return true
 }
-func (this *Resolver_GlooResolver) Equal(that interface{}) bool {
+func (this *GlooResolver) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Resolver_GlooResolver)
+	that1, ok := that.(*GlooResolver)
 	if !ok {
-		that2, ok := that.(Resolver_GlooResolver)
+		that2, ok := that.(GlooResolver)
 		if ok {
 			that1 = &that2
 		} else {
@@ -634,19 +2602,64 @@ func (this *Resolver_GlooResolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.GlooResolver.Equal(that1.GlooResolver) {
+	if this.RequestTemplate != that1.RequestTemplate {
+		return false
+	}
+	if this.ResponseTemplate != that1.ResponseTemplate {
+		return false
+	}
+	if this.ContentType != that1.ContentType {
+		return false
+	}
+	if that1.Function == nil {
+		if this.Function != nil {
+			return false
+		}
+	} else if this.Function == nil {
+		return false
+	} else if !this.Function.Equal(that1.Function) {
 		return false
 	}
+	if len(this.SecretHeaders) != len(that1.SecretHeaders) {
+		return false
+	}
+	for i := range this.SecretHeaders {
+		if !this.SecretHeaders[i].Equal(that1.SecretHeaders[i]) {
+			return false
+		}
+	}
+	if len(this.ForwardCookies) != len(that1.ForwardCookies) {
+		return false
+	}
+	for i := range this.ForwardCookies {
+		if this.ForwardCookies[i] != that1.ForwardCookies[i] {
+			return false
+		}
+	}
+	if this.TimeoutMs != that1.TimeoutMs {
+		return false
+	}
+	if this.MaxRetries != that1.MaxRetries {
+		return false
+	}
+	if len(this.ClaimHeaders) != len(that1.ClaimHeaders) {
+		return false
+	}
+	for i := range this.ClaimHeaders {
+		if this.ClaimHeaders[i] != that1.ClaimHeaders[i] {
+			return false
+		}
+	}
 	return true
 }
-func (this *Resolver_TemplateResolver) Equal(that interface{}) bool {
+func (this *GlooResolver_SingleFunction) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Resolver_TemplateResolver)
+	that1, ok := that.(*GlooResolver_SingleFunction)
 	if !ok {
-		that2, ok := that.(Resolver_TemplateResolver)
+		that2, ok := that.(GlooResolver_SingleFunction)
 		if ok {
 			that1 = &that2
 		} else {
@@ -658,19 +2671,19 @@ func (this *Resolver_TemplateResolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.TemplateResolver.Equal(that1.TemplateResolver) {
+	if !this.SingleFunction.Equal(that1.SingleFunction) {
 		return false
 	}
 	return true
 }
-func (this *Resolver_NodejsResolver) Equal(that interface{}) bool {
+func (this *GlooResolver_MultiFunction) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Resolver_NodejsResolver)
+	that1, ok := that.(*GlooResolver_MultiFunction)
 	if !ok {
-		that2, ok := that.(Resolver_NodejsResolver)
+		that2, ok := that.(GlooResolver_MultiFunction)
 		if ok {
 			that1 = &that2
 		} else {
@@ -682,19 +2695,19 @@ func (this *Resolver_NodejsResolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.NodejsResolver.Equal(that1.NodejsResolver) {
+	if !this.MultiFunction.Equal(that1.MultiFunction) {
 		return false
 	}
 	return true
 }
-func (this *GlooResolver) Equal(that interface{}) bool {
+func (this *GlooResolver_Upstream) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlooResolver)
+	that1, ok := that.(*GlooResolver_Upstream)
 	if !ok {
-		that2, ok := that.(GlooResolver)
+		that2, ok := that.(GlooResolver_Upstream)
 		if ok {
 			that1 = &that2
 		} else {
@@ -706,34 +2719,19 @@ func (this *GlooResolver) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.RequestTemplate != that1.RequestTemplate {
-		return false
-	}
-	if this.ResponseTemplate != that1.ResponseTemplate {
-		return false
-	}
-	if this.ContentType != that1.ContentType {
-		return false
-	}
-	if that1.Function == nil {
-		if this.Function != nil {
-			return false
-		}
-	} else if this.Function == nil {
-		return false
-	} else if !this.Function.Equal(that1.Function) {
+	if this.Upstream != that1.Upstream {
 		return false
 	}
 	return true
 }
-func (this *GlooResolver_SingleFunction) Equal(that interface{}) bool {
+func (this *SecretHeader) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlooResolver_SingleFunction)
+	that1, ok := that.(*SecretHeader)
 	if !ok {
-		that2, ok := that.(GlooResolver_SingleFunction)
+		that2, ok := that.(SecretHeader)
 		if ok {
 			that1 = &that2
 		} else {
@@ -745,19 +2743,25 @@ func (this *GlooResolver_SingleFunction) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.SingleFunction.Equal(that1.SingleFunction) {
+	if this.Name != that1.Name {
+		return false
+	}
+	if !this.SecretRef.Equal(that1.SecretRef) {
+		return false
+	}
+	if this.Prefix != that1.Prefix {
 		return false
 	}
 	return true
 }
-func (this *GlooResolver_MultiFunction) Equal(that interface{}) bool {
+func (this *SecretRef) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlooResolver_MultiFunction)
+	that1, ok := that.(*SecretRef)
 	if !ok {
-		that2, ok := that.(GlooResolver_MultiFunction)
+		that2, ok := that.(SecretRef)
 		if ok {
 			that1 = &that2
 		} else {
@@ -769,7 +2773,10 @@ func (this *GlooResolver_MultiFunction) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.MultiFunction.Equal(that1.MultiFunction) {
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Key != that1.Key {
 		return false
 	}
 	return true
@@ -881,6 +2888,164 @@ func (this *TemplateResolver) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *MockResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MockResolver)
+	if !ok {
+		that2, ok := that.(MockResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ListLength != that1.ListLength {
+		return false
+	}
+	return true
+}
+func (this *SignedUrlResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SignedUrlResolver)
+	if !ok {
+		that2, ok := that.(SignedUrlResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Bucket != that1.Bucket {
+		return false
+	}
+	if this.KeyTemplate != that1.KeyTemplate {
+		return false
+	}
+	if this.Region != that1.Region {
+		return false
+	}
+	if this.Endpoint != that1.Endpoint {
+		return false
+	}
+	if this.PathStyle != that1.PathStyle {
+		return false
+	}
+	if this.ExpirySeconds != that1.ExpirySeconds {
+		return false
+	}
+	if !this.AccessKeyId.Equal(that1.AccessKeyId) {
+		return false
+	}
+	if !this.SecretAccessKey.Equal(that1.SecretAccessKey) {
+		return false
+	}
+	return true
+}
+func (this *VariablesResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*VariablesResolver)
+	if !ok {
+		that2, ok := that.(VariablesResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Variable != that1.Variable {
+		return false
+	}
+	if this.InlineTemplate != that1.InlineTemplate {
+		return false
+	}
+	return true
+}
+func (this *HttpResolver) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*HttpResolver)
+	if !ok {
+		that2, ok := that.(HttpResolver)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.UrlTemplate != that1.UrlTemplate {
+		return false
+	}
+	if len(this.AllowedHosts) != len(that1.AllowedHosts) {
+		return false
+	}
+	for i := range this.AllowedHosts {
+		if this.AllowedHosts[i] != that1.AllowedHosts[i] {
+			return false
+		}
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if this.Headers[i] != that1.Headers[i] {
+			return false
+		}
+	}
+	if this.BaseUrl != that1.BaseUrl {
+		return false
+	}
+	if this.TimeoutMs != that1.TimeoutMs {
+		return false
+	}
+	if len(this.ForwardCookies) != len(that1.ForwardCookies) {
+		return false
+	}
+	for i := range this.ForwardCookies {
+		if this.ForwardCookies[i] != that1.ForwardCookies[i] {
+			return false
+		}
+	}
+	if len(this.ClaimHeaders) != len(that1.ClaimHeaders) {
+		return false
+	}
+	for i := range this.ClaimHeaders {
+		if this.ClaimHeaders[i] != that1.ClaimHeaders[i] {
+			return false
+		}
+	}
+	return true
+}
 func (this *NodeJSResolver) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -909,49 +3074,147 @@ func (this *NodeJSResolver) Equal(that interface{}) bool {
 func init() { proto.RegisterFile("resolver_map.proto", fileDescriptorResolverMap) }
 
 var fileDescriptorResolverMap = []byte{
-	// 691 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5d, 0x6b, 0xd4, 0x40,
-	0x14, 0x35, 0xbb, 0xed, 0xb2, 0xbd, 0xd9, 0xcf, 0xd1, 0xca, 0xba, 0xa2, 0xad, 0x11, 0x71, 0xa5,
-	0x34, 0x61, 0xeb, 0x8b, 0x56, 0x84, 0xb2, 0xc5, 0x2a, 0xd2, 0x8a, 0xa4, 0x82, 0x20, 0x94, 0x25,
-	0xdd, 0xcc, 0xa6, 0xb1, 0x49, 0x26, 0xcd, 0x4c, 0xb6, 0xf4, 0xbf, 0x08, 0xbe, 0xf6, 0x57, 0xf9,
-	0xe0, 0x0f, 0xf0, 0xc1, 0x5f, 0x20, 0x99, 0x8f, 0x7c, 0x2c, 0x55, 0xdf, 0x32, 0xe7, 0x9e, 0x73,
-	0x72, 0xee, 0xcd, 0xcd, 0x00, 0x4a, 0x30, 0x25, 0xc1, 0x02, 0x27, 0xd3, 0xd0, 0x89, 0xcd, 0x38,
-	0x21, 0x8c, 0x20, 0xfd, 0x22, 0x20, 0xc4, 0x74, 0x62, 0xdf, 0x5c, 0x8c, 0x87, 0x77, 0x3c, 0xe2,
-	0x11, 0x8e, 0x5b, 0xd9, 0x93, 0xa0, 0x0c, 0xb7, 0x3c, 0x9f, 0x9d, 0xa5, 0xa7, 0xe6, 0x8c, 0x84,
-	0x16, 0x25, 0x01, 0xd9, 0xf6, 0x89, 0xe5, 0x05, 0x84, 0x58, 0x4e, 0xec, 0x5b, 0x8b, 0xb1, 0x45,
-	0x99, 0xc3, 0x52, 0x2a, 0xc9, 0xdb, 0xff, 0x21, 0x87, 0x98, 0x39, 0xae, 0xc3, 0x1c, 0x41, 0x37,
-	0xae, 0x6b, 0xa0, 0xdb, 0x32, 0xd5, 0x91, 0x13, 0x23, 0x04, 0x2b, 0x91, 0x13, 0xe2, 0x81, 0xb6,
-	0xa9, 0x8d, 0xd6, 0x6c, 0xfe, 0x8c, 0x5e, 0xc2, 0x2a, 0xbb, 0x8a, 0x31, 0x1d, 0xd4, 0x37, 0xeb,
-	0x23, 0x7d, 0xe7, 0xb1, 0x59, 0x8a, 0x6c, 0x96, 0xc4, 0xe6, 0xa7, 0x8c, 0xf5, 0x26, 0x62, 0xc9,
-	0x95, 0x2d, 0x14, 0x68, 0x02, 0x0d, 0x91, 0x6e, 0xb0, 0xb2, 0xa9, 0x8d, 0xf4, 0x9d, 0xdb, 0xa6,
-	0x57, 0xd2, 0x1e, 0xf3, 0xd2, 0x64, 0xfd, 0xf7, 0x8f, 0x8d, 0x3e, 0xc3, 0x94, 0xb9, 0xfe, 0x7c,
-	0xbe, 0x6b, 0xf8, 0x5e, 0x44, 0x12, 0x6c, 0xd8, 0x52, 0x89, 0xc6, 0xd0, 0x54, 0xa1, 0x07, 0xab,
-	0xdc, 0x65, 0xbd, 0xe2, 0x72, 0x24, 0x8b, 0x76, 0x4e, 0x1b, 0x1e, 0x03, 0x14, 0x59, 0x50, 0x0f,
-	0xea, 0xe7, 0xf8, 0x4a, 0xb6, 0x94, 0x3d, 0x22, 0x0b, 0x56, 0x17, 0x4e, 0x90, 0xe2, 0x41, 0x8d,
-	0xfb, 0xdd, 0xab, 0x74, 0x94, 0x29, 0x55, 0x57, 0xb6, 0xe0, 0xed, 0xd6, 0x5e, 0x68, 0xc6, 0x77,
-	0x0d, 0x5a, 0xe5, 0x1a, 0x7a, 0x0d, 0x8d, 0xb9, 0x8f, 0x03, 0x97, 0x0e, 0x34, 0x3e, 0x98, 0x27,
-	0x7f, 0xb5, 0x31, 0x0f, 0x38, 0x4f, 0x8c, 0x46, 0x8a, 0x86, 0x1f, 0x41, 0x2f, 0xc1, 0x37, 0xa4,
-	0xdc, 0xaa, 0xa6, 0x5c, 0xbf, 0x71, 0xee, 0xe5, 0x84, 0xbf, 0x34, 0x68, 0xe6, 0xe9, 0xf6, 0xa0,
-	0x9d, 0x4d, 0x69, 0xaa, 0x76, 0x8e, 0x3b, 0x2f, 0xf7, 0xfa, 0x36, 0x20, 0x44, 0x29, 0xde, 0xdd,
-	0xb2, 0x5b, 0x5e, 0xe9, 0x8c, 0x0e, 0xa1, 0xcf, 0x70, 0x18, 0x07, 0x0e, 0xc3, 0x85, 0x8b, 0xc8,
-	0xf2, 0xa0, 0xda, 0xaa, 0x64, 0x95, 0x9c, 0x7a, 0x6c, 0x09, 0x43, 0x07, 0xd0, 0x8d, 0x88, 0x8b,
-	0xbf, 0xd2, 0xc2, 0xab, 0xce, 0xbd, 0xee, 0x57, 0xbc, 0x3e, 0x10, 0x17, 0xbf, 0x3f, 0x2e, 0x39,
-	0x75, 0x84, 0x4a, 0x21, 0x13, 0x80, 0xa6, 0x32, 0x30, 0xbe, 0xd5, 0xa0, 0x55, 0x6e, 0x01, 0x3d,
-	0x83, 0x5e, 0x82, 0x2f, 0x52, 0x4c, 0xd9, 0x54, 0x05, 0x90, 0x13, 0xed, 0x4a, 0x5c, 0x65, 0x45,
-	0x5b, 0xd0, 0x4f, 0x30, 0x8d, 0x49, 0x44, 0x71, 0xc1, 0xad, 0x71, 0x6e, 0x4f, 0x15, 0x72, 0xf2,
-	0x23, 0x68, 0xcd, 0x48, 0xc4, 0x70, 0xc4, 0xa6, 0xd9, 0x62, 0xf3, 0xe4, 0x6b, 0xb6, 0x2e, 0xb1,
-	0xec, 0x53, 0xa3, 0x3d, 0xe8, 0x52, 0x3f, 0xf2, 0x02, 0x3c, 0x9d, 0xa7, 0xd1, 0x8c, 0xf9, 0x24,
-	0x92, 0x3b, 0x5f, 0xfd, 0x6e, 0x07, 0xb2, 0x98, 0x75, 0x26, 0xf8, 0x0a, 0x41, 0xfb, 0xd0, 0x09,
-	0xd3, 0x80, 0xf9, 0x85, 0x81, 0x58, 0xf7, 0x61, 0xc5, 0xe0, 0x28, 0xa3, 0x94, 0x5c, 0xda, 0x61,
-	0x19, 0xc8, 0xc6, 0xa3, 0xe4, 0xc6, 0x04, 0x9a, 0xb9, 0xf9, 0x10, 0x9a, 0x69, 0x4c, 0x59, 0x82,
-	0x9d, 0x50, 0x4e, 0x24, 0x3f, 0x67, 0xb5, 0xfc, 0x95, 0x62, 0x02, 0x85, 0xc7, 0x09, 0xb4, 0x2b,
-	0x6f, 0x44, 0x87, 0x80, 0x2e, 0xb1, 0xef, 0x9d, 0x31, 0xec, 0xe6, 0x41, 0xd5, 0x1f, 0x50, 0x5d,
-	0x8b, 0xcf, 0x92, 0xa6, 0xa4, 0x76, 0xff, 0x72, 0x09, 0xa1, 0xc6, 0x09, 0xf4, 0x96, 0x69, 0xd9,
-	0x0f, 0x9f, 0xc7, 0xd1, 0xfe, 0x31, 0xc2, 0x22, 0x25, 0xba, 0x0b, 0x0d, 0xe1, 0xcd, 0xf3, 0xb7,
-	0x6d, 0x79, 0x32, 0x5e, 0x41, 0x6f, 0x79, 0x39, 0xd1, 0x53, 0xe8, 0xfa, 0x51, 0xe0, 0x47, 0x78,
-	0x79, 0x45, 0x3a, 0x02, 0x56, 0x02, 0x63, 0x0c, 0x9d, 0xea, 0x36, 0xa2, 0x0d, 0xd0, 0xa5, 0x74,
-	0x46, 0x5c, 0x25, 0x03, 0x01, 0xed, 0x13, 0x17, 0x4f, 0xcc, 0xeb, 0x9f, 0x0f, 0xb5, 0x2f, 0xa3,
-	0x1b, 0xee, 0xe0, 0xac, 0x01, 0x2b, 0x3e, 0xf7, 0xf8, 0x3d, 0xcc, 0x2f, 0x47, 0x6b, 0x31, 0x3e,
-	0x6d, 0xf0, 0x5b, 0xf8, 0xf9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xee, 0x0c, 0x12, 0x24, 0x1a,
-	0x06, 0x00, 0x00,
+	// 2265 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcd, 0x76, 0x1c, 0x47,
+	0xf5, 0xcf, 0xe8, 0x73, 0xe6, 0xce, 0x8c, 0x46, 0x53, 0x72, 0xfc, 0x6f, 0x4f, 0x62, 0x5b, 0xee,
+	0xfc, 0x21, 0x36, 0xb6, 0x47, 0xc8, 0x3e, 0x27, 0xc7, 0xb1, 0x81, 0x1c, 0x49, 0x96, 0xad, 0x04,
+	0x8b, 0x63, 0xb7, 0x12, 0x07, 0x58, 0xa4, 0x4f, 0xab, 0xbb, 0x66, 0xa6, 0x51, 0x7f, 0xb9, 0xab,
+	0x46, 0xd2, 0x64, 0xc3, 0x43, 0x00, 0x0f, 0xc0, 0x8e, 0x05, 0x4f, 0xc2, 0xe1, 0x15, 0x60, 0xc1,
+	0x16, 0x56, 0xac, 0x59, 0x70, 0x6e, 0x7d, 0x74, 0x57, 0x8f, 0x5a, 0x0e, 0x21, 0xec, 0xaa, 0x7e,
+	0xf5, 0xab, 0xdb, 0xf7, 0xde, 0xba, 0x75, 0xeb, 0x56, 0x35, 0x90, 0x9c, 0xb2, 0x34, 0x3a, 0xa5,
+	0xb9, 0x1b, 0x7b, 0xd9, 0x30, 0xcb, 0x53, 0x9e, 0x92, 0x0e, 0x7b, 0x93, 0xa6, 0xd9, 0xd0, 0xcb,
+	0xc2, 0xe1, 0xe9, 0xf6, 0xe0, 0xca, 0x38, 0x1d, 0xa7, 0x62, 0x60, 0x0b, 0x5b, 0x92, 0x33, 0xb8,
+	0x3b, 0x0e, 0xf9, 0x64, 0x7a, 0x3c, 0xf4, 0xd3, 0x78, 0x8b, 0xa5, 0x51, 0x7a, 0x3f, 0x4c, 0xb7,
+	0xc6, 0x51, 0x9a, 0x6e, 0x79, 0x59, 0xb8, 0x75, 0xba, 0xbd, 0xc5, 0xb8, 0xc7, 0xa7, 0x4c, 0x91,
+	0xef, 0x7f, 0x03, 0x39, 0xa6, 0xdc, 0x0b, 0x3c, 0xee, 0x49, 0xba, 0xfd, 0xf7, 0x05, 0x68, 0x3b,
+	0x4a, 0xad, 0x43, 0x2f, 0x23, 0x04, 0x96, 0x12, 0x2f, 0xa6, 0x56, 0x63, 0xb3, 0x71, 0xbb, 0xe5,
+	0x88, 0x36, 0x79, 0x0c, 0xcb, 0x7c, 0x96, 0x51, 0x66, 0x2d, 0x6e, 0x2e, 0xde, 0x6e, 0x3f, 0xf8,
+	0xff, 0xa1, 0xa9, 0xf3, 0xd0, 0x98, 0x3d, 0xfc, 0x1c, 0x69, 0xfb, 0x09, 0xcf, 0x67, 0x8e, 0x9c,
+	0x42, 0x76, 0x61, 0x45, 0xaa, 0x67, 0x2d, 0x6d, 0x36, 0x6e, 0xb7, 0x1f, 0x6c, 0x0c, 0x51, 0x19,
+	0x3d, 0xf7, 0x48, 0x0c, 0xed, 0xbe, 0xfb, 0xcf, 0xbf, 0xde, 0xec, 0x73, 0xca, 0x78, 0x10, 0x8e,
+	0x46, 0x8f, 0xed, 0x70, 0x9c, 0xa4, 0x39, 0xb5, 0x1d, 0x35, 0x93, 0x6c, 0x43, 0x53, 0x6b, 0x6d,
+	0x2d, 0x0b, 0x29, 0xef, 0x56, 0xa4, 0x1c, 0xaa, 0x41, 0xa7, 0xa0, 0x91, 0x4f, 0xa0, 0x3b, 0xe1,
+	0x3c, 0x73, 0x03, 0x3a, 0xf2, 0xa6, 0x11, 0x67, 0xd6, 0x8a, 0x98, 0x37, 0xa8, 0xaa, 0x7e, 0xc0,
+	0x79, 0xf6, 0x54, 0x31, 0x9c, 0xce, 0xc4, 0xe8, 0x0d, 0x3e, 0x07, 0x28, 0x8d, 0x21, 0xeb, 0xb0,
+	0x78, 0x42, 0x67, 0xca, 0x29, 0xd8, 0x24, 0x3f, 0x84, 0xe5, 0x53, 0x2f, 0x9a, 0x52, 0x6b, 0xa1,
+	0x4e, 0x30, 0x4e, 0xd5, 0x7e, 0x71, 0x24, 0xf1, 0xf1, 0xc2, 0xa3, 0x86, 0xfd, 0x8f, 0x06, 0x74,
+	0xcc, 0x8f, 0x92, 0x6b, 0xd0, 0x3c, 0xf6, 0x18, 0x75, 0xa7, 0x79, 0xa4, 0xa4, 0xaf, 0x62, 0xff,
+	0x8b, 0x3c, 0x22, 0x1f, 0x40, 0xd7, 0x8b, 0xa2, 0xf4, 0x8c, 0x06, 0xee, 0x24, 0x65, 0x9c, 0x59,
+	0x0b, 0x9b, 0x8b, 0xb7, 0x5b, 0x4e, 0x47, 0x81, 0x07, 0x88, 0x91, 0x1d, 0x58, 0x9d, 0x50, 0x2f,
+	0xa0, 0xb9, 0x5e, 0x9c, 0x0f, 0x2f, 0xb7, 0x70, 0x78, 0x20, 0x99, 0x72, 0x7d, 0xf4, 0x3c, 0x72,
+	0x1d, 0x80, 0x87, 0x31, 0x4d, 0xa7, 0xdc, 0x8d, 0xe5, 0x2a, 0x75, 0x9d, 0x96, 0x42, 0x0e, 0xd9,
+	0xe0, 0x31, 0x74, 0xcc, 0x79, 0x35, 0xae, 0xb8, 0x62, 0xba, 0xa2, 0x65, 0x9a, 0xfb, 0xfb, 0x06,
+	0x74, 0x4c, 0x57, 0x90, 0x9f, 0xc0, 0xca, 0x28, 0xa4, 0x51, 0xc0, 0xac, 0x86, 0xd0, 0xf6, 0xfb,
+	0x97, 0xbb, 0x6d, 0xf8, 0x4c, 0x10, 0xa5, 0xb2, 0x6a, 0xd6, 0xe0, 0x15, 0xb4, 0x0d, 0xb8, 0x46,
+	0x97, 0x7b, 0xd5, 0x65, 0xb9, 0x5a, 0x1f, 0xaa, 0xa6, 0x8e, 0x7f, 0xe9, 0x40, 0xb3, 0xd0, 0x6f,
+	0x07, 0xba, 0x18, 0x58, 0xae, 0xde, 0xa8, 0x56, 0xa3, 0x6e, 0x75, 0x9f, 0x47, 0x69, 0xaa, 0xa7,
+	0x1c, 0xbc, 0xe3, 0x74, 0xc6, 0x46, 0x9f, 0x1c, 0x42, 0x9f, 0xd3, 0x38, 0x8b, 0x3c, 0x4e, 0x4b,
+	0x31, 0x52, 0x9b, 0x1b, 0x73, 0xd6, 0x2a, 0x9a, 0x21, 0x6a, 0x9d, 0xcf, 0x61, 0xe4, 0x39, 0xf4,
+	0x92, 0x34, 0xa0, 0xbf, 0x62, 0xa5, 0xb0, 0x45, 0x21, 0xec, 0xfd, 0xaa, 0xb0, 0x9f, 0xa5, 0x01,
+	0xfd, 0xec, 0xc8, 0x10, 0xb5, 0x26, 0xa7, 0x15, 0x82, 0x5e, 0xc3, 0x15, 0x3f, 0x4d, 0x82, 0x90,
+	0x87, 0x69, 0xe2, 0x45, 0xa5, 0x34, 0xb9, 0x2d, 0x6f, 0x55, 0xa5, 0xed, 0x95, 0x4c, 0x43, 0xe4,
+	0x86, 0x7f, 0x11, 0x46, 0x97, 0xc5, 0xa9, 0x7f, 0x52, 0x0a, 0x5c, 0xae, 0x73, 0xd9, 0x61, 0xea,
+	0x9f, 0x98, 0x2e, 0x8b, 0x8d, 0x3e, 0x79, 0x05, 0x1b, 0x2c, 0x1c, 0x27, 0x34, 0xc0, 0x6d, 0x50,
+	0x0a, 0x5a, 0x15, 0x82, 0x6e, 0x56, 0x05, 0x1d, 0x09, 0xe2, 0x17, 0xb9, 0xa9, 0x57, 0x9f, 0xcd,
+	0x83, 0xe4, 0x25, 0x90, 0x53, 0x2f, 0x0f, 0xbd, 0xe3, 0x88, 0x1a, 0x9e, 0x6b, 0xd6, 0x49, 0x7c,
+	0xad, 0x79, 0xa6, 0xc4, 0xd3, 0x79, 0x10, 0xed, 0x14, 0x19, 0xa5, 0x10, 0xd6, 0xba, 0x2c, 0xa3,
+	0x98, 0x76, 0x4e, 0x8c, 0x3e, 0x79, 0x0a, 0x6b, 0x31, 0xcd, 0xc7, 0x46, 0x5c, 0xf4, 0x85, 0x8c,
+	0xf7, 0xe6, 0x7c, 0x85, 0x1c, 0x43, 0x48, 0x37, 0x36, 0x01, 0xb2, 0x0d, 0xcb, 0xbe, 0xe7, 0x4f,
+	0xa8, 0xb5, 0x52, 0x37, 0x59, 0xd3, 0xf6, 0x90, 0xe2, 0x48, 0x26, 0xb9, 0x0b, 0x24, 0x99, 0x46,
+	0x91, 0xeb, 0x31, 0x97, 0xc6, 0x19, 0x9f, 0xb9, 0x51, 0xc8, 0xb8, 0x05, 0x9b, 0x8d, 0xdb, 0x4d,
+	0xa7, 0x87, 0x23, 0x3b, 0x6c, 0x1f, 0xf1, 0x17, 0x21, 0xe3, 0xe4, 0x47, 0xd0, 0x61, 0x59, 0x14,
+	0x72, 0x97, 0xf1, 0x3c, 0x4c, 0xc6, 0x56, 0x5b, 0x7c, 0xe6, 0xda, 0xdc, 0x32, 0x20, 0xe3, 0x48,
+	0x10, 0x9c, 0x36, 0x2b, 0x3b, 0xe4, 0x25, 0xac, 0xd1, 0x64, 0x1a, 0xbb, 0x5e, 0x3e, 0x9e, 0xc6,
+	0x34, 0xe1, 0xcc, 0xea, 0x88, 0x9d, 0x7e, 0xa7, 0x5e, 0xcd, 0xe1, 0x7e, 0x32, 0x8d, 0x77, 0x34,
+	0x57, 0x6e, 0xf6, 0x2e, 0x35, 0x31, 0xd4, 0x67, 0x44, 0x3d, 0x3e, 0xcd, 0xa9, 0x3b, 0xf6, 0x38,
+	0xb5, 0xba, 0x75, 0xfa, 0x3c, 0x93, 0x8c, 0xe7, 0xb8, 0x75, 0xda, 0xa3, 0xb2, 0x43, 0x9e, 0x40,
+	0x27, 0xcb, 0x69, 0x11, 0xb8, 0xd6, 0x9a, 0x98, 0xfd, 0x7f, 0x97, 0x84, 0xbb, 0x53, 0x21, 0x93,
+	0x3b, 0xb0, 0x8e, 0x9e, 0x72, 0xfd, 0x34, 0xf1, 0xa7, 0x79, 0x4e, 0x13, 0x7f, 0x66, 0xf5, 0x44,
+	0x82, 0xec, 0x21, 0xbe, 0x57, 0xc2, 0x42, 0xcb, 0x14, 0x33, 0xb3, 0x9b, 0x79, 0x63, 0xca, 0xac,
+	0xf5, 0x5a, 0x2d, 0x05, 0xe3, 0x25, 0x12, 0x9c, 0xf6, 0xa8, 0xec, 0x90, 0x8f, 0x00, 0xc4, 0x87,
+	0xa2, 0x30, 0x0e, 0xb9, 0x45, 0xea, 0x74, 0xc4, 0xb5, 0x79, 0x81, 0xc3, 0x4e, 0x2b, 0xd2, 0x4d,
+	0xf2, 0x10, 0x5a, 0xf8, 0x39, 0x97, 0x85, 0x5f, 0x53, 0x6b, 0xa3, 0x2e, 0xe5, 0xa1, 0xfc, 0xa3,
+	0xf0, 0x6b, 0xea, 0x34, 0x33, 0xd5, 0x22, 0xbb, 0xb0, 0x46, 0xf3, 0x3c, 0xcd, 0x5d, 0x9a, 0x9c,
+	0xd2, 0x28, 0xcd, 0xa8, 0x75, 0xa5, 0x2e, 0x92, 0xf6, 0x91, 0xb3, 0xaf, 0x28, 0x4e, 0x97, 0x9a,
+	0x5d, 0xf2, 0x31, 0xb4, 0x8f, 0xd3, 0x60, 0x26, 0x15, 0x66, 0xd6, 0xbb, 0x42, 0x80, 0x55, 0x15,
+	0xb0, 0x9b, 0x06, 0x33, 0xa1, 0x26, 0x73, 0xe0, 0xb8, 0x68, 0x93, 0xe7, 0xd0, 0x96, 0x9f, 0xf7,
+	0xd3, 0x80, 0x32, 0xeb, 0x6a, 0xdd, 0x41, 0x50, 0x86, 0x07, 0x32, 0xf7, 0x90, 0x28, 0x63, 0x03,
+	0x68, 0x01, 0x0c, 0x7e, 0x01, 0xe4, 0x62, 0xf4, 0xd4, 0x9c, 0x09, 0xf7, 0xab, 0x67, 0xc2, 0x9c,
+	0x5f, 0x51, 0x84, 0x90, 0x67, 0x1c, 0x0a, 0x83, 0x1f, 0x43, 0x6f, 0xee, 0xcb, 0xdf, 0xe6, 0xdc,
+	0xdb, 0x05, 0x68, 0xea, 0x2d, 0x6e, 0x8f, 0x00, 0x4a, 0x47, 0x90, 0x1f, 0x40, 0x3f, 0xf6, 0xce,
+	0xdd, 0x9c, 0xbe, 0x99, 0x52, 0xc6, 0xdd, 0xe3, 0x19, 0xa7, 0x4c, 0xc8, 0xec, 0x3a, 0xbd, 0xd8,
+	0x3b, 0x77, 0x24, 0xbe, 0x8b, 0x30, 0xb9, 0x07, 0x44, 0x72, 0x59, 0x96, 0x26, 0x8c, 0x2a, 0xf2,
+	0x82, 0x20, 0xaf, 0x0b, 0xb2, 0x1c, 0x10, 0x6c, 0xfb, 0x77, 0x0d, 0xe8, 0x56, 0x96, 0x8c, 0xdc,
+	0xd4, 0x8e, 0x16, 0x87, 0xa7, 0xd2, 0x5c, 0x3a, 0x50, 0x1c, 0xa2, 0x25, 0xc1, 0x34, 0x43, 0x12,
+	0x5e, 0x23, 0x82, 0x25, 0x48, 0x4c, 0x19, 0xc3, 0x08, 0x93, 0x32, 0x16, 0x05, 0xa5, 0xa3, 0x40,
+	0x29, 0xe5, 0x3a, 0x00, 0xae, 0xa4, 0x62, 0x2c, 0x09, 0x46, 0x0b, 0x11, 0x31, 0x6c, 0x1f, 0x40,
+	0x53, 0xc7, 0x20, 0xb9, 0x05, 0x1d, 0x55, 0x90, 0xc9, 0x88, 0x95, 0x86, 0xb7, 0x15, 0x26, 0x28,
+	0xd7, 0xa0, 0x89, 0x46, 0x8b, 0x61, 0x69, 0xea, 0x6a, 0xec, 0x9d, 0xe3, 0x90, 0xfd, 0x12, 0x5a,
+	0xc5, 0x26, 0x20, 0xef, 0x41, 0x0b, 0x79, 0x21, 0xa7, 0xb1, 0x76, 0x20, 0x4e, 0xfc, 0x14, 0xfb,
+	0x58, 0xc4, 0x8e, 0xbc, 0x30, 0x12, 0x02, 0x9a, 0x8e, 0x68, 0x23, 0x76, 0xe6, 0xe5, 0x89, 0x30,
+	0xa1, 0xe9, 0x88, 0xb6, 0xfd, 0xc7, 0x06, 0xb4, 0x8d, 0x3d, 0x89, 0x0e, 0x11, 0x02, 0xab, 0x1e,
+	0x13, 0x50, 0x61, 0x6b, 0x42, 0xcf, 0xb9, 0x1a, 0x97, 0x0e, 0x6b, 0x21, 0x22, 0x87, 0x3f, 0x80,
+	0xae, 0x18, 0xd6, 0xc9, 0x4f, 0xfb, 0x0b, 0x41, 0x1d, 0xa6, 0x5a, 0x73, 0x99, 0x26, 0x96, 0x0a,
+	0xcd, 0xa5, 0x06, 0x15, 0xb3, 0x96, 0xab, 0x66, 0xd9, 0x58, 0xfd, 0x18, 0xa9, 0x0d, 0xad, 0x8c,
+	0xbc, 0xb1, 0x2e, 0xd5, 0xb1, 0x4d, 0x86, 0xb0, 0x21, 0x97, 0xf4, 0x6c, 0x42, 0x13, 0x37, 0x08,
+	0x19, 0x1e, 0x62, 0x81, 0x72, 0x44, 0x5f, 0x0c, 0x7d, 0x39, 0xa1, 0xc9, 0x53, 0x35, 0x60, 0xff,
+	0x1a, 0x5a, 0xc5, 0x06, 0x20, 0x8f, 0x60, 0x59, 0xee, 0x49, 0x59, 0x9c, 0xd9, 0x97, 0x6c, 0x94,
+	0xa1, 0xb1, 0x1f, 0xe5, 0x84, 0xc1, 0x23, 0x80, 0xff, 0x6e, 0xab, 0xd8, 0x9f, 0x41, 0xdb, 0x38,
+	0x4b, 0xc8, 0xfb, 0xd0, 0x0a, 0xa8, 0x48, 0x2a, 0xaa, 0xf8, 0x6a, 0x39, 0x25, 0x20, 0x4a, 0xd5,
+	0x3c, 0x8c, 0x5d, 0x96, 0x79, 0x3e, 0x55, 0x46, 0xb5, 0x10, 0x39, 0x42, 0xc0, 0xfe, 0x6d, 0x03,
+	0xba, 0x95, 0xf3, 0x0f, 0x03, 0xee, 0x84, 0xce, 0x5c, 0x5d, 0x55, 0x29, 0x89, 0xed, 0x13, 0x3a,
+	0xd3, 0xc5, 0x17, 0xae, 0x39, 0xe7, 0x91, 0xcb, 0x44, 0xda, 0xd7, 0xdb, 0x0b, 0x38, 0x8f, 0x8e,
+	0x24, 0x82, 0x04, 0x5c, 0x12, 0x9a, 0xf0, 0x3c, 0x14, 0x77, 0x20, 0x41, 0x88, 0xbd, 0xf3, 0x7d,
+	0x89, 0x90, 0x1b, 0x00, 0x39, 0x3d, 0xf5, 0xa2, 0x30, 0xc0, 0x4f, 0x2c, 0x09, 0xad, 0x0c, 0xc4,
+	0xfe, 0x4d, 0x03, 0x36, 0x6a, 0x0a, 0x2a, 0xf2, 0x31, 0x34, 0x45, 0x99, 0x91, 0x70, 0xed, 0xf1,
+	0xeb, 0xf5, 0x59, 0xf0, 0xb5, 0x64, 0x39, 0x05, 0x9d, 0xec, 0xc0, 0xba, 0xde, 0x48, 0x73, 0x35,
+	0xe6, 0x65, 0x15, 0x6f, 0x4f, 0xf1, 0x35, 0x60, 0x3f, 0x85, 0x6e, 0xa5, 0xd0, 0x20, 0x0f, 0x61,
+	0x95, 0xa5, 0xd3, 0xdc, 0x2f, 0xd6, 0xff, 0x5a, 0x4d, 0x59, 0x72, 0x24, 0x18, 0x8e, 0x66, 0xda,
+	0x6f, 0xa0, 0x6d, 0xe0, 0xe4, 0x41, 0x99, 0xf8, 0xac, 0xc6, 0x5b, 0xf5, 0x29, 0x78, 0x18, 0xc6,
+	0x27, 0x74, 0xa6, 0xaf, 0x37, 0xa2, 0x4d, 0x06, 0xd0, 0x4c, 0x33, 0xe9, 0x2e, 0xb5, 0x61, 0x8b,
+	0xbe, 0x9d, 0x43, 0x6f, 0xce, 0x31, 0xe4, 0x2e, 0x2c, 0x61, 0xbc, 0x5b, 0x8d, 0xba, 0x04, 0x5f,
+	0x1e, 0xee, 0x82, 0x54, 0xd1, 0x71, 0xe1, 0x3f, 0xd3, 0xd1, 0x3e, 0x81, 0x56, 0x21, 0x06, 0x83,
+	0xca, 0xcb, 0xc7, 0xcc, 0xcd, 0x72, 0xca, 0x70, 0x93, 0x37, 0x84, 0xe2, 0x6d, 0xc4, 0x5e, 0x4a,
+	0x08, 0x63, 0x46, 0x50, 0xbc, 0x63, 0xc1, 0x90, 0xa6, 0x01, 0x42, 0x3b, 0x02, 0x41, 0x03, 0x8b,
+	0xa0, 0x94, 0x49, 0xa2, 0xe8, 0xdb, 0xff, 0x5a, 0x82, 0x8e, 0x79, 0xc5, 0xc0, 0x32, 0x44, 0x1f,
+	0x18, 0x73, 0x91, 0xdc, 0x53, 0x78, 0x11, 0xcd, 0x77, 0xa1, 0x5f, 0x9c, 0x17, 0x05, 0x57, 0x6e,
+	0xba, 0x75, 0x3d, 0x50, 0x90, 0x6f, 0x41, 0xc7, 0x4f, 0x13, 0x4e, 0x13, 0xee, 0xe2, 0x65, 0x5d,
+	0x29, 0xd2, 0x56, 0x18, 0x5e, 0xc6, 0xc8, 0x0e, 0xf4, 0x58, 0x98, 0x8c, 0x23, 0xea, 0x8e, 0xa6,
+	0x89, 0x2f, 0x2a, 0xa8, 0xa5, 0x3a, 0x9f, 0x3d, 0x53, 0xa3, 0x78, 0xf1, 0x90, 0x13, 0x34, 0x22,
+	0xaa, 0xde, 0x69, 0xc4, 0xc3, 0x52, 0xc2, 0x72, 0x6d, 0xd5, 0x8b, 0x1c, 0x43, 0x4c, 0x37, 0x36,
+	0x01, 0xf2, 0x3e, 0x34, 0xa7, 0x19, 0xe3, 0x39, 0xf5, 0x62, 0x71, 0x31, 0x68, 0x1d, 0xbc, 0xe3,
+	0x14, 0x08, 0xd9, 0x81, 0x35, 0x46, 0xfd, 0x9c, 0x72, 0x57, 0xdf, 0x86, 0x57, 0x36, 0x17, 0x2f,
+	0x56, 0xe7, 0x47, 0x82, 0x23, 0xaf, 0xb3, 0x4e, 0x97, 0x19, 0x3d, 0x46, 0x3e, 0x84, 0xde, 0x28,
+	0xcd, 0xcf, 0xbc, 0x3c, 0x70, 0xfd, 0x34, 0x3d, 0xc1, 0xad, 0xde, 0x14, 0xcb, 0xb6, 0xa6, 0xe0,
+	0x3d, 0x89, 0xce, 0xdd, 0x97, 0x5b, 0x73, 0xf7, 0x65, 0x9d, 0x2e, 0x72, 0x2a, 0xd3, 0x05, 0x14,
+	0xe9, 0xc2, 0x91, 0x08, 0x79, 0x05, 0x5d, 0x3f, 0xf2, 0xc2, 0xb8, 0x50, 0xb5, 0x2d, 0x54, 0xbd,
+	0x77, 0xf9, 0x1d, 0x73, 0xb8, 0x87, 0xfc, 0xca, 0xed, 0xbd, 0xe3, 0x1b, 0xd0, 0xe0, 0x13, 0xe8,
+	0x5f, 0xa0, 0x7c, 0xdb, 0x82, 0x45, 0xaf, 0x8e, 0x9d, 0x43, 0xc7, 0xf4, 0x53, 0xed, 0x8b, 0xd0,
+	0x47, 0x00, 0xca, 0xdf, 0x39, 0x1d, 0xd5, 0xd7, 0x55, 0x52, 0x86, 0x43, 0x47, 0x4e, 0x8b, 0xe9,
+	0x26, 0xb9, 0x0a, 0x2b, 0x59, 0x4e, 0x47, 0xe1, 0xb9, 0x8a, 0x35, 0xd5, 0xb3, 0xb7, 0xa1, 0x55,
+	0xf0, 0x6b, 0x3f, 0xa8, 0x8c, 0x59, 0x28, 0x8c, 0xb1, 0x77, 0xa1, 0x59, 0x04, 0xc7, 0xc0, 0x08,
+	0x0e, 0x39, 0xab, 0x0c, 0x8d, 0x41, 0x69, 0x9a, 0x9a, 0x5e, 0x9a, 0xfa, 0x15, 0x74, 0x2b, 0x61,
+	0x47, 0x0e, 0x81, 0x9c, 0xd1, 0x70, 0x3c, 0xe1, 0x34, 0x28, 0xc2, 0x55, 0xa7, 0xc3, 0xb9, 0xdb,
+	0xfb, 0x97, 0x8a, 0xa7, 0xe7, 0x3a, 0xfd, 0xb3, 0x39, 0x84, 0xd9, 0x5f, 0xc1, 0xfa, 0x3c, 0x0d,
+	0xd3, 0x4f, 0xa1, 0x4f, 0xe3, 0x6d, 0x5b, 0xa9, 0xd4, 0x13, 0xdd, 0x26, 0x85, 0xab, 0xe3, 0x49,
+	0xf5, 0xec, 0x27, 0xb0, 0x3e, 0xff, 0x88, 0x80, 0x71, 0x1c, 0x26, 0x51, 0x98, 0xd0, 0xf9, 0x5c,
+	0xb1, 0x26, 0x61, 0x3d, 0xc1, 0xde, 0x82, 0x8e, 0x79, 0x2b, 0xc7, 0xc0, 0x95, 0x77, 0x10, 0x9a,
+	0x8c, 0xf9, 0x44, 0xd5, 0x54, 0xe2, 0x5a, 0xf2, 0x42, 0x20, 0xf6, 0x9f, 0x16, 0xa0, 0x7f, 0xe1,
+	0xfa, 0x8d, 0xba, 0x1d, 0x4f, 0xfd, 0x13, 0xca, 0xd5, 0x67, 0x54, 0xef, 0xc2, 0xd1, 0xbb, 0x70,
+	0xf1, 0xe8, 0xbd, 0x0a, 0x2b, 0x39, 0x1d, 0xa3, 0x23, 0x54, 0x34, 0xc8, 0x1e, 0x2e, 0x19, 0x4d,
+	0x82, 0x2c, 0x0d, 0x13, 0xae, 0xea, 0xc9, 0xa2, 0x8f, 0xbb, 0x2f, 0xf3, 0xf8, 0xc4, 0x65, 0x7c,
+	0x16, 0x51, 0x91, 0x49, 0x9a, 0x4e, 0x0b, 0x91, 0x23, 0x04, 0xc8, 0xf7, 0x60, 0x8d, 0x9e, 0x67,
+	0x61, 0x3e, 0x2b, 0x0e, 0xf4, 0x15, 0x61, 0x47, 0x57, 0xa2, 0xfa, 0x4c, 0x7f, 0x02, 0x5d, 0xcf,
+	0xf7, 0x29, 0x63, 0x2e, 0xea, 0x18, 0x06, 0xd6, 0xea, 0xdb, 0x43, 0xb8, 0x2d, 0xd9, 0x3f, 0xa5,
+	0xb3, 0x4f, 0x03, 0xb2, 0x07, 0x7d, 0x15, 0xfc, 0xa5, 0x0c, 0xab, 0xf9, 0x76, 0x01, 0x3d, 0x39,
+	0x63, 0x47, 0x8b, 0xb1, 0x7f, 0x0e, 0xfd, 0x0b, 0x0f, 0x0f, 0x68, 0xb8, 0x7e, 0x78, 0xd0, 0x71,
+	0xac, 0xfb, 0x75, 0xeb, 0xba, 0x50, 0xbb, 0xae, 0x7f, 0x5e, 0x94, 0x6f, 0x8c, 0x85, 0xd4, 0x5b,
+	0xd0, 0xc1, 0x77, 0x95, 0xf9, 0x22, 0x68, 0x9a, 0x47, 0xc5, 0x4a, 0xfc, 0xcf, 0xde, 0x1a, 0x8b,
+	0x94, 0x55, 0xff, 0xd6, 0x68, 0x3e, 0x77, 0x2e, 0x55, 0x9f, 0x3b, 0xab, 0x69, 0x75, 0x79, 0x3e,
+	0xad, 0xd6, 0xa4, 0xe7, 0x95, 0xda, 0xf4, 0x7c, 0x21, 0xbd, 0xae, 0xd6, 0xa5, 0xd7, 0x8a, 0xae,
+	0xdf, 0x94, 0x5e, 0xbf, 0xc3, 0x13, 0xe8, 0x77, 0x4e, 0xcd, 0xf6, 0x36, 0xac, 0x55, 0xdf, 0xf6,
+	0xc4, 0x2d, 0x45, 0x46, 0x02, 0x16, 0xdf, 0xc5, 0x2d, 0x45, 0x40, 0x58, 0x85, 0xef, 0x6e, 0xfd,
+	0xe1, 0x6f, 0x37, 0x1a, 0xbf, 0xbc, 0x53, 0xf3, 0x23, 0x40, 0xf8, 0x60, 0x2b, 0x3b, 0x19, 0x8b,
+	0xbf, 0x01, 0xe2, 0x85, 0x7e, 0xeb, 0x74, 0xfb, 0x78, 0x45, 0xfc, 0x0b, 0x78, 0xf8, 0xef, 0x01,
+	0x00, 0xf3, 0x77, 0x10, 0x17, 0xa1, 0x18, 0x00, 0x00,
 }
@@ -32,6 +32,33 @@ type Schema struct {
 	Status *gloo_api_v1.Status `protobuf:"bytes,6,opt,name=status" json:"status,omitempty" testdiff:"ignore"`
 	// Metadata contains the resource metadata for the role
 	Metadata *gloo_api_v11.Metadata `protobuf:"bytes,7,opt,name=metadata" json:"metadata,omitempty"`
+	// optional version label for the schema, e.g. "v1".
+	// versioned schemas are served at /<alias>/<version>, allowing multiple versions of a schema to be served side by side
+	Version string `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	// the path prefix under which versions of this schema are served.
+	// defaults to the schema name
+	Alias string `protobuf:"bytes,9,opt,name=alias,proto3" json:"alias,omitempty"`
+	// if set, responses for this schema will include a Sunset header with this value.
+	// should be an HTTP-date (e.g. "Sat, 31 Dec 2018 23:59:59 GMT") indicating when this version will be removed
+	Sunset string `protobuf:"bytes,10,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	// middleware applied to requests for this schema, in order
+	Middleware []*EndpointMiddleware `protobuf:"bytes,11,rep,name=middleware" json:"middleware,omitempty"`
+	// name of a response envelope registered with Sqoop, e.g. "ok-payload", which wraps the responses of this schema
+	// for clients which can't consume GraphQL responses directly. responses use the standard envelope by default
+	ResponseEnvelope string `protobuf:"bytes,12,opt,name=response_envelope,json=responseEnvelope,proto3" json:"response_envelope,omitempty"`
+	// serve the schema once for each binding, each at its own path and resolved by its own resolver map,
+	// e.g. to route each tenant of a shared schema to different upstreams.
+	// if any bindings are specified, resolver_map and alias are ignored
+	Bindings []*SchemaBinding `protobuf:"bytes,13,rep,name=bindings" json:"bindings,omitempty"`
+	// load the schema from the .graphql and .graphqls files of this directory instead of inline_schema.
+	// the files are concatenated in order of their names and parsed as one schema, so types may be extended
+	// in files other than the one defining them. the directory is watched for changes
+	SchemaDir string `protobuf:"bytes,14,opt,name=schema_dir,json=schemaDir,proto3" json:"schema_dir,omitempty"`
+	// ignore fields of input objects in variables which the schema doesn't define, instead of rejecting the
+	// operation as the GraphQL spec requires. meant for clients migrating between versions of a schema:
+	// a field misspelled by a client is silently left unset rather than reported.
+	// fields of input objects written inline in queries are always validated
+	IgnoreUnknownInputFields bool `protobuf:"varint,15,opt,name=ignore_unknown_input_fields,json=ignoreUnknownInputFields,proto3" json:"ignore_unknown_input_fields,omitempty"`
 }
 
 func (m *Schema) Reset()                    { *m = Schema{} }
@@ -74,8 +101,414 @@ func (m *Schema) GetMetadata() *gloo_api_v11.Metadata {
 	return nil
 }
 
+func (m *Schema) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Schema) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *Schema) GetSunset() string {
+	if m != nil {
+		return m.Sunset
+	}
+	return ""
+}
+
+func (m *Schema) GetMiddleware() []*EndpointMiddleware {
+	if m != nil {
+		return m.Middleware
+	}
+	return nil
+}
+
+func (m *Schema) GetResponseEnvelope() string {
+	if m != nil {
+		return m.ResponseEnvelope
+	}
+	return ""
+}
+
+func (m *Schema) GetBindings() []*SchemaBinding {
+	if m != nil {
+		return m.Bindings
+	}
+	return nil
+}
+
+func (m *Schema) GetSchemaDir() string {
+	if m != nil {
+		return m.SchemaDir
+	}
+	return ""
+}
+
+func (m *Schema) GetIgnoreUnknownInputFields() bool {
+	if m != nil {
+		return m.IgnoreUnknownInputFields
+	}
+	return false
+}
+
+// SchemaBinding serves a schema at a path using a resolver map
+type SchemaBinding struct {
+	// the path prefix under which the schema is served, e.g. "tenant-a". versions are served beneath it
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// name of the resolver map used to resolve the schema at this path. the resolver map must exist
+	ResolverMap string `protobuf:"bytes,2,opt,name=resolver_map,json=resolverMap,proto3" json:"resolver_map,omitempty"`
+}
+
+func (m *SchemaBinding) Reset()                    { *m = SchemaBinding{} }
+func (m *SchemaBinding) String() string            { return proto.CompactTextString(m) }
+func (*SchemaBinding) ProtoMessage()               {}
+func (*SchemaBinding) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{1} }
+
+func (m *SchemaBinding) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SchemaBinding) GetResolverMap() string {
+	if m != nil {
+		return m.ResolverMap
+	}
+	return ""
+}
+
+// EndpointMiddleware applies a policy to every request made to a schema's query endpoint
+type EndpointMiddleware struct {
+	// Types that are valid to be assigned to Middleware:
+	//	*EndpointMiddleware_Cors
+	//	*EndpointMiddleware_RateLimit
+	//	*EndpointMiddleware_ApiKeyAuth
+	//	*EndpointMiddleware_ExternalAuth
+	Middleware isEndpointMiddleware_Middleware `protobuf_oneof:"middleware"`
+}
+
+func (m *EndpointMiddleware) Reset()                    { *m = EndpointMiddleware{} }
+func (m *EndpointMiddleware) String() string            { return proto.CompactTextString(m) }
+func (*EndpointMiddleware) ProtoMessage()               {}
+func (*EndpointMiddleware) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{2} }
+
+type isEndpointMiddleware_Middleware interface {
+	isEndpointMiddleware_Middleware()
+	Equal(interface{}) bool
+}
+
+type EndpointMiddleware_Cors struct {
+	Cors *CorsPolicy `protobuf:"bytes,1,opt,name=cors,oneof"`
+}
+type EndpointMiddleware_RateLimit struct {
+	RateLimit *RateLimit `protobuf:"bytes,2,opt,name=rate_limit,json=rateLimit,oneof"`
+}
+type EndpointMiddleware_ApiKeyAuth struct {
+	ApiKeyAuth *ApiKeyAuth `protobuf:"bytes,3,opt,name=api_key_auth,json=apiKeyAuth,oneof"`
+}
+type EndpointMiddleware_ExternalAuth struct {
+	ExternalAuth *ExternalAuth `protobuf:"bytes,4,opt,name=external_auth,json=externalAuth,oneof"`
+}
+
+func (*EndpointMiddleware_Cors) isEndpointMiddleware_Middleware()         {}
+func (*EndpointMiddleware_RateLimit) isEndpointMiddleware_Middleware()    {}
+func (*EndpointMiddleware_ApiKeyAuth) isEndpointMiddleware_Middleware()   {}
+func (*EndpointMiddleware_ExternalAuth) isEndpointMiddleware_Middleware() {}
+
+func (m *EndpointMiddleware) GetMiddleware() isEndpointMiddleware_Middleware {
+	if m != nil {
+		return m.Middleware
+	}
+	return nil
+}
+
+func (m *EndpointMiddleware) GetCors() *CorsPolicy {
+	if x, ok := m.GetMiddleware().(*EndpointMiddleware_Cors); ok {
+		return x.Cors
+	}
+	return nil
+}
+
+func (m *EndpointMiddleware) GetRateLimit() *RateLimit {
+	if x, ok := m.GetMiddleware().(*EndpointMiddleware_RateLimit); ok {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (m *EndpointMiddleware) GetApiKeyAuth() *ApiKeyAuth {
+	if x, ok := m.GetMiddleware().(*EndpointMiddleware_ApiKeyAuth); ok {
+		return x.ApiKeyAuth
+	}
+	return nil
+}
+
+func (m *EndpointMiddleware) GetExternalAuth() *ExternalAuth {
+	if x, ok := m.GetMiddleware().(*EndpointMiddleware_ExternalAuth); ok {
+		return x.ExternalAuth
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*EndpointMiddleware) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _EndpointMiddleware_OneofMarshaler, _EndpointMiddleware_OneofUnmarshaler, _EndpointMiddleware_OneofSizer, []interface{}{
+		(*EndpointMiddleware_Cors)(nil),
+		(*EndpointMiddleware_RateLimit)(nil),
+		(*EndpointMiddleware_ApiKeyAuth)(nil),
+		(*EndpointMiddleware_ExternalAuth)(nil),
+	}
+}
+
+func _EndpointMiddleware_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*EndpointMiddleware)
+	// middleware
+	switch x := m.Middleware.(type) {
+	case *EndpointMiddleware_Cors:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Cors); err != nil {
+			return err
+		}
+	case *EndpointMiddleware_RateLimit:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RateLimit); err != nil {
+			return err
+		}
+	case *EndpointMiddleware_ApiKeyAuth:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApiKeyAuth); err != nil {
+			return err
+		}
+	case *EndpointMiddleware_ExternalAuth:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExternalAuth); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("EndpointMiddleware.Middleware has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _EndpointMiddleware_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*EndpointMiddleware)
+	switch tag {
+	case 1: // middleware.cors
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CorsPolicy)
+		err := b.DecodeMessage(msg)
+		m.Middleware = &EndpointMiddleware_Cors{msg}
+		return true, err
+	case 2: // middleware.rate_limit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RateLimit)
+		err := b.DecodeMessage(msg)
+		m.Middleware = &EndpointMiddleware_RateLimit{msg}
+		return true, err
+	case 3: // middleware.api_key_auth
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ApiKeyAuth)
+		err := b.DecodeMessage(msg)
+		m.Middleware = &EndpointMiddleware_ApiKeyAuth{msg}
+		return true, err
+	case 4: // middleware.external_auth
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExternalAuth)
+		err := b.DecodeMessage(msg)
+		m.Middleware = &EndpointMiddleware_ExternalAuth{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _EndpointMiddleware_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*EndpointMiddleware)
+	// middleware
+	switch x := m.Middleware.(type) {
+	case *EndpointMiddleware_Cors:
+		s := proto.Size(x.Cors)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *EndpointMiddleware_RateLimit:
+		s := proto.Size(x.RateLimit)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *EndpointMiddleware_ApiKeyAuth:
+		s := proto.Size(x.ApiKeyAuth)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *EndpointMiddleware_ExternalAuth:
+		s := proto.Size(x.ExternalAuth)
+		n += proto.SizeVarint(4<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type CorsPolicy struct {
+	// origins allowed to query the endpoint. "*" allows any origin
+	AllowOrigins []string `protobuf:"bytes,1,rep,name=allow_origins,json=allowOrigins" json:"allow_origins,omitempty"`
+	// headers clients may send in addition to the CORS-safelisted headers
+	AllowHeaders []string `protobuf:"bytes,2,rep,name=allow_headers,json=allowHeaders" json:"allow_headers,omitempty"`
+	// how long browsers may cache the result of a preflight request, in seconds
+	MaxAgeSeconds uint32 `protobuf:"varint,3,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+}
+
+func (m *CorsPolicy) Reset()                    { *m = CorsPolicy{} }
+func (m *CorsPolicy) String() string            { return proto.CompactTextString(m) }
+func (*CorsPolicy) ProtoMessage()               {}
+func (*CorsPolicy) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{3} }
+
+func (m *CorsPolicy) GetAllowOrigins() []string {
+	if m != nil {
+		return m.AllowOrigins
+	}
+	return nil
+}
+
+func (m *CorsPolicy) GetAllowHeaders() []string {
+	if m != nil {
+		return m.AllowHeaders
+	}
+	return nil
+}
+
+func (m *CorsPolicy) GetMaxAgeSeconds() uint32 {
+	if m != nil {
+		return m.MaxAgeSeconds
+	}
+	return 0
+}
+
+type RateLimit struct {
+	// the sustained number of requests per second allowed, across all clients
+	RequestsPerSecond uint32 `protobuf:"varint,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// the number of requests allowed in a burst. defaults to requests_per_second
+	Burst uint32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+}
+
+func (m *RateLimit) Reset()                    { *m = RateLimit{} }
+func (m *RateLimit) String() string            { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()               {}
+func (*RateLimit) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{4} }
+
+func (m *RateLimit) GetRequestsPerSecond() uint32 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+type ApiKeyAuth struct {
+	// the header containing the API key. defaults to `X-Api-Key`
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// the secret containing the accepted API key
+	SecretRef *SecretRef `protobuf:"bytes,2,opt,name=secret_ref,json=secretRef" json:"secret_ref,omitempty"`
+}
+
+func (m *ApiKeyAuth) Reset()                    { *m = ApiKeyAuth{} }
+func (m *ApiKeyAuth) String() string            { return proto.CompactTextString(m) }
+func (*ApiKeyAuth) ProtoMessage()               {}
+func (*ApiKeyAuth) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{5} }
+
+func (m *ApiKeyAuth) GetHeader() string {
+	if m != nil {
+		return m.Header
+	}
+	return ""
+}
+
+func (m *ApiKeyAuth) GetSecretRef() *SecretRef {
+	if m != nil {
+		return m.SecretRef
+	}
+	return nil
+}
+
+type ExternalAuth struct {
+	// name of the authenticator registered with Sqoop which validates credentials, e.g. "jwt"
+	Authenticator string `protobuf:"bytes,1,opt,name=authenticator,proto3" json:"authenticator,omitempty"`
+	// the header containing the credential. defaults to `Authorization`
+	Header string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// how long authentication decisions are cached by credential, in seconds. decisions are never cached
+	// beyond the expiry of the credential. zero disables caching
+	CacheTtlSeconds uint32 `protobuf:"varint,3,opt,name=cache_ttl_seconds,json=cacheTtlSeconds,proto3" json:"cache_ttl_seconds,omitempty"`
+	// the maximum number of credentials whose decisions are cached. defaults to 1000
+	CacheSize uint32 `protobuf:"varint,4,opt,name=cache_size,json=cacheSize,proto3" json:"cache_size,omitempty"`
+}
+
+func (m *ExternalAuth) Reset()                    { *m = ExternalAuth{} }
+func (m *ExternalAuth) String() string            { return proto.CompactTextString(m) }
+func (*ExternalAuth) ProtoMessage()               {}
+func (*ExternalAuth) Descriptor() ([]byte, []int) { return fileDescriptorSchema, []int{6} }
+
+func (m *ExternalAuth) GetAuthenticator() string {
+	if m != nil {
+		return m.Authenticator
+	}
+	return ""
+}
+
+func (m *ExternalAuth) GetHeader() string {
+	if m != nil {
+		return m.Header
+	}
+	return ""
+}
+
+func (m *ExternalAuth) GetCacheTtlSeconds() uint32 {
+	if m != nil {
+		return m.CacheTtlSeconds
+	}
+	return 0
+}
+
+func (m *ExternalAuth) GetCacheSize() uint32 {
+	if m != nil {
+		return m.CacheSize
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Schema)(nil), "sqoop.api.v1.Schema")
+	proto.RegisterType((*SchemaBinding)(nil), "sqoop.api.v1.SchemaBinding")
+	proto.RegisterType((*EndpointMiddleware)(nil), "sqoop.api.v1.EndpointMiddleware")
+	proto.RegisterType((*CorsPolicy)(nil), "sqoop.api.v1.CorsPolicy")
+	proto.RegisterType((*RateLimit)(nil), "sqoop.api.v1.RateLimit")
+	proto.RegisterType((*ApiKeyAuth)(nil), "sqoop.api.v1.ApiKeyAuth")
+	proto.RegisterType((*ExternalAuth)(nil), "sqoop.api.v1.ExternalAuth")
 }
 func (this *Schema) Equal(that interface{}) bool {
 	if that == nil {
@@ -111,29 +544,376 @@ func (this *Schema) Equal(that interface{}) bool {
 	if !this.Metadata.Equal(that1.Metadata) {
 		return false
 	}
+	if this.Version != that1.Version {
+		return false
+	}
+	if this.Alias != that1.Alias {
+		return false
+	}
+	if this.Sunset != that1.Sunset {
+		return false
+	}
+	if len(this.Middleware) != len(that1.Middleware) {
+		return false
+	}
+	for i := range this.Middleware {
+		if !this.Middleware[i].Equal(that1.Middleware[i]) {
+			return false
+		}
+	}
+	if this.ResponseEnvelope != that1.ResponseEnvelope {
+		return false
+	}
+	if len(this.Bindings) != len(that1.Bindings) {
+		return false
+	}
+	for i := range this.Bindings {
+		if !this.Bindings[i].Equal(that1.Bindings[i]) {
+			return false
+		}
+	}
+	if this.SchemaDir != that1.SchemaDir {
+		return false
+	}
+	if this.IgnoreUnknownInputFields != that1.IgnoreUnknownInputFields {
+		return false
+	}
+	return true
+}
+func (this *SchemaBinding) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SchemaBinding)
+	if !ok {
+		that2, ok := that.(SchemaBinding)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.ResolverMap != that1.ResolverMap {
+		return false
+	}
+	return true
+}
+func (this *EndpointMiddleware) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EndpointMiddleware)
+	if !ok {
+		that2, ok := that.(EndpointMiddleware)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Middleware == nil {
+		if this.Middleware != nil {
+			return false
+		}
+	} else if this.Middleware == nil {
+		return false
+	} else if !this.Middleware.Equal(that1.Middleware) {
+		return false
+	}
+	return true
+}
+func (this *EndpointMiddleware_Cors) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EndpointMiddleware_Cors)
+	if !ok {
+		that2, ok := that.(EndpointMiddleware_Cors)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Cors.Equal(that1.Cors) {
+		return false
+	}
+	return true
+}
+func (this *EndpointMiddleware_RateLimit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EndpointMiddleware_RateLimit)
+	if !ok {
+		that2, ok := that.(EndpointMiddleware_RateLimit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.RateLimit.Equal(that1.RateLimit) {
+		return false
+	}
+	return true
+}
+func (this *EndpointMiddleware_ApiKeyAuth) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EndpointMiddleware_ApiKeyAuth)
+	if !ok {
+		that2, ok := that.(EndpointMiddleware_ApiKeyAuth)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ApiKeyAuth.Equal(that1.ApiKeyAuth) {
+		return false
+	}
+	return true
+}
+func (this *EndpointMiddleware_ExternalAuth) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EndpointMiddleware_ExternalAuth)
+	if !ok {
+		that2, ok := that.(EndpointMiddleware_ExternalAuth)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ExternalAuth.Equal(that1.ExternalAuth) {
+		return false
+	}
+	return true
+}
+func (this *CorsPolicy) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CorsPolicy)
+	if !ok {
+		that2, ok := that.(CorsPolicy)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.AllowOrigins) != len(that1.AllowOrigins) {
+		return false
+	}
+	for i := range this.AllowOrigins {
+		if this.AllowOrigins[i] != that1.AllowOrigins[i] {
+			return false
+		}
+	}
+	if len(this.AllowHeaders) != len(that1.AllowHeaders) {
+		return false
+	}
+	for i := range this.AllowHeaders {
+		if this.AllowHeaders[i] != that1.AllowHeaders[i] {
+			return false
+		}
+	}
+	if this.MaxAgeSeconds != that1.MaxAgeSeconds {
+		return false
+	}
+	return true
+}
+func (this *RateLimit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RateLimit)
+	if !ok {
+		that2, ok := that.(RateLimit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.RequestsPerSecond != that1.RequestsPerSecond {
+		return false
+	}
+	if this.Burst != that1.Burst {
+		return false
+	}
+	return true
+}
+func (this *ApiKeyAuth) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ApiKeyAuth)
+	if !ok {
+		that2, ok := that.(ApiKeyAuth)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Header != that1.Header {
+		return false
+	}
+	if !this.SecretRef.Equal(that1.SecretRef) {
+		return false
+	}
+	return true
+}
+func (this *ExternalAuth) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExternalAuth)
+	if !ok {
+		that2, ok := that.(ExternalAuth)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Authenticator != that1.Authenticator {
+		return false
+	}
+	if this.Header != that1.Header {
+		return false
+	}
+	if this.CacheTtlSeconds != that1.CacheTtlSeconds {
+		return false
+	}
+	if this.CacheSize != that1.CacheSize {
+		return false
+	}
 	return true
 }
 
 func init() { proto.RegisterFile("schema.proto", fileDescriptorSchema) }
 
 var fileDescriptorSchema = []byte{
-	// 284 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xb1, 0x4e, 0xc3, 0x30,
-	0x14, 0x45, 0x15, 0x40, 0x01, 0x9c, 0x30, 0x60, 0xa8, 0x14, 0x75, 0x80, 0x12, 0x96, 0x48, 0xa8,
-	0xb6, 0x02, 0x1b, 0x63, 0xf6, 0x2e, 0xe9, 0xc6, 0x52, 0xb9, 0xad, 0xeb, 0x5a, 0xd8, 0x79, 0x26,
-	0x76, 0x23, 0xf1, 0x47, 0xfc, 0x13, 0x12, 0x03, 0x9f, 0xc0, 0x17, 0xa0, 0x3a, 0x2e, 0x08, 0x09,
-	0xa9, 0xdb, 0xd3, 0xbb, 0xc7, 0xd7, 0xf7, 0x5d, 0x94, 0xda, 0xc5, 0x9a, 0x6b, 0x46, 0x4c, 0x0b,
-	0x0e, 0x70, 0xf2, 0xa2, 0x00, 0x08, 0x33, 0x92, 0x74, 0xe5, 0xf0, 0x52, 0x80, 0x00, 0xbf, 0xa7,
-	0xdb, 0xa9, 0x47, 0x86, 0x77, 0x42, 0xba, 0xf5, 0x66, 0x4e, 0x16, 0xa0, 0xa9, 0x05, 0x05, 0x63,
-	0x09, 0x54, 0x28, 0x00, 0xca, 0x8c, 0xa4, 0x5d, 0x49, 0xad, 0x63, 0x6e, 0x63, 0x03, 0x3c, 0xde,
-	0x03, 0x6b, 0xee, 0xd8, 0x92, 0xb9, 0xf0, 0x7d, 0xfe, 0x1e, 0xa1, 0x78, 0xea, 0xf3, 0x60, 0x8c,
-	0x8e, 0x1a, 0xa6, 0x79, 0x16, 0x8d, 0xa2, 0xe2, 0xb4, 0xf6, 0x33, 0xbe, 0x41, 0x69, 0xcb, 0x2d,
-	0xa8, 0x8e, 0xb7, 0x33, 0xcd, 0x4c, 0x76, 0xe0, 0xb5, 0x64, 0xb7, 0x9b, 0x30, 0x83, 0x6f, 0xd1,
-	0x99, 0x6c, 0x94, 0x6c, 0xf8, 0xac, 0xbf, 0x2b, 0x3b, 0xf4, 0x4c, 0xda, 0x2f, 0x83, 0x77, 0x85,
-	0xe2, 0x3e, 0x65, 0x16, 0x8f, 0xa2, 0x22, 0xb9, 0xbf, 0x20, 0xe2, 0xf7, 0x6c, 0x32, 0xf5, 0x52,
-	0x35, 0xf8, 0xfa, 0xb8, 0x3e, 0x77, 0xdc, 0xba, 0xa5, 0x5c, 0xad, 0x1e, 0x73, 0x29, 0x1a, 0x68,
-	0x79, 0x5e, 0x87, 0x97, 0xb8, 0x44, 0x27, 0xbb, 0xf0, 0xd9, 0xb1, 0x77, 0x19, 0xfc, 0x71, 0x99,
-	0x04, 0xb1, 0xfe, 0xc1, 0x2a, 0xf2, 0xf6, 0x79, 0x15, 0x3d, 0x15, 0xff, 0x54, 0xb2, 0x6d, 0x9d,
-	0x9a, 0x67, 0xe1, 0x6b, 0x71, 0xaf, 0x86, 0x5b, 0xda, 0x95, 0xf3, 0xd8, 0x97, 0xf2, 0xf0, 0x1d,
-	0x00, 0x00, 0xff, 0xff, 0x71, 0xae, 0x89, 0xbe, 0xa3, 0x01, 0x00, 0x00,
+	// 810 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x6e, 0xe4, 0x34,
+	0x14, 0xee, 0xb4, 0x65, 0xb6, 0x73, 0x26, 0xa1, 0xd4, 0xbb, 0x0b, 0x56, 0x57, 0xc0, 0x10, 0x10,
+	0x2a, 0xac, 0x36, 0x51, 0x8b, 0x04, 0x08, 0x81, 0x44, 0x07, 0x76, 0x55, 0x04, 0x15, 0x4b, 0x06,
+	0x6e, 0x10, 0x52, 0xe4, 0x26, 0x67, 0x32, 0x56, 0x13, 0x3b, 0x6b, 0x3b, 0xd3, 0xce, 0x5e, 0xf0,
+	0x06, 0x5c, 0xf3, 0x0a, 0xf0, 0x52, 0x5c, 0xf0, 0x08, 0x3c, 0x01, 0x8a, 0x9d, 0x4c, 0x33, 0x4b,
+	0x25, 0xf6, 0xce, 0xe7, 0x3b, 0xdf, 0xf9, 0xf1, 0x77, 0x8e, 0x0d, 0x9e, 0x4e, 0x17, 0x58, 0xb2,
+	0xb0, 0x52, 0xd2, 0x48, 0xe2, 0xe9, 0x67, 0x52, 0x56, 0x21, 0xab, 0x78, 0xb8, 0x3c, 0x3e, 0xbc,
+	0x97, 0xcb, 0x5c, 0x5a, 0x47, 0xd4, 0x9c, 0x1c, 0xe7, 0x90, 0x28, 0xd4, 0xb2, 0x58, 0xa2, 0x4a,
+	0x4a, 0x56, 0xb5, 0xd8, 0xc3, 0x9c, 0x9b, 0x45, 0x7d, 0x11, 0xa6, 0xb2, 0x8c, 0xb4, 0x2c, 0xe4,
+	0x23, 0x2e, 0xa3, 0xbc, 0x90, 0x32, 0x62, 0x15, 0x8f, 0x96, 0xc7, 0x91, 0x36, 0xcc, 0xd4, 0xba,
+	0x25, 0x3f, 0xfa, 0x1f, 0x72, 0x89, 0x86, 0x65, 0xcc, 0xb4, 0x3d, 0x05, 0x7f, 0xee, 0xc2, 0x70,
+	0x66, 0x9b, 0x24, 0x04, 0x76, 0x05, 0x2b, 0x91, 0x0e, 0x26, 0x83, 0xa3, 0x51, 0x6c, 0xcf, 0xe4,
+	0x1d, 0xf0, 0xfa, 0x0d, 0xd1, 0x6d, 0xeb, 0x1b, 0x77, 0xd8, 0x39, 0xab, 0xc8, 0xbb, 0xe0, 0x73,
+	0x51, 0x70, 0x81, 0x89, 0xbb, 0x2c, 0xdd, 0xb1, 0x1c, 0xcf, 0x81, 0x6d, 0xee, 0x29, 0x0c, 0x5d,
+	0x97, 0x74, 0x38, 0x19, 0x1c, 0x8d, 0x4f, 0xee, 0x86, 0x4d, 0x4f, 0xad, 0x14, 0xe1, 0xcc, 0xba,
+	0xa6, 0xf7, 0xff, 0xf9, 0xeb, 0xed, 0x03, 0x83, 0xda, 0x64, 0x7c, 0x3e, 0xff, 0x2c, 0xe0, 0xb9,
+	0x90, 0x0a, 0x83, 0xb8, 0x8d, 0x24, 0xc7, 0xb0, 0xd7, 0x35, 0x4f, 0xef, 0xd8, 0x2c, 0xf7, 0x37,
+	0xb2, 0x9c, 0xb7, 0xce, 0x78, 0x4d, 0x23, 0x14, 0xee, 0x2c, 0x51, 0x69, 0x2e, 0x05, 0xdd, 0xb3,
+	0x5d, 0x75, 0x26, 0xb9, 0x07, 0xaf, 0xb0, 0x82, 0x33, 0x4d, 0x47, 0x16, 0x77, 0x06, 0x79, 0x1d,
+	0x86, 0xba, 0x16, 0x1a, 0x0d, 0x05, 0x0b, 0xb7, 0x16, 0xf9, 0x12, 0xa0, 0xe4, 0x59, 0x56, 0xe0,
+	0x15, 0x53, 0x48, 0xc7, 0x93, 0x9d, 0xa3, 0xf1, 0xc9, 0x24, 0xec, 0x8f, 0x33, 0x7c, 0x2c, 0xb2,
+	0x4a, 0x72, 0x61, 0xce, 0xd7, 0xbc, 0xb8, 0x17, 0x43, 0x1e, 0xc2, 0x81, 0x42, 0x5d, 0x49, 0xa1,
+	0x31, 0x41, 0xb1, 0xc4, 0x42, 0x56, 0x48, 0x3d, 0x5b, 0xe4, 0xb5, 0xce, 0xf1, 0xb8, 0xc5, 0xc9,
+	0x27, 0xb0, 0x77, 0xc1, 0x45, 0xc6, 0x45, 0xae, 0xa9, 0x6f, 0x8b, 0x3d, 0xd8, 0x2c, 0xe6, 0x54,
+	0x9d, 0x3a, 0x4e, 0xbc, 0x26, 0x93, 0x37, 0x01, 0xdc, 0x10, 0x92, 0x8c, 0x2b, 0xfa, 0xaa, 0x4d,
+	0x3f, 0x72, 0xc8, 0xd7, 0x5c, 0x91, 0x2f, 0xe0, 0x81, 0x13, 0x35, 0xa9, 0xc5, 0xa5, 0x90, 0x57,
+	0x22, 0xe1, 0xa2, 0xaa, 0x4d, 0x32, 0xe7, 0x58, 0x64, 0x9a, 0xee, 0x4f, 0x06, 0x47, 0x7b, 0x31,
+	0x75, 0x94, 0x9f, 0x1c, 0xe3, 0x9b, 0x86, 0xf0, 0xc4, 0xfa, 0x83, 0x27, 0xe0, 0x6f, 0x14, 0x6e,
+	0x36, 0xa6, 0x62, 0x66, 0xd1, 0x6d, 0x4c, 0x73, 0x7e, 0x89, 0x8d, 0x09, 0x7e, 0xdb, 0x06, 0xf2,
+	0x5f, 0xb9, 0x48, 0x08, 0xbb, 0xa9, 0x54, 0xda, 0x66, 0x1b, 0x9f, 0xd0, 0xcd, 0x1b, 0x7f, 0x25,
+	0x95, 0x7e, 0x2a, 0x0b, 0x9e, 0xae, 0xce, 0xb6, 0x62, 0xcb, 0x23, 0x9f, 0x02, 0x28, 0x66, 0x30,
+	0x29, 0x78, 0xc9, 0x8d, 0xad, 0x33, 0x3e, 0x79, 0x63, 0x33, 0x2a, 0x66, 0x06, 0xbf, 0x6b, 0xdc,
+	0x67, 0x5b, 0xf1, 0x48, 0x75, 0x06, 0xf9, 0x1c, 0x3c, 0x56, 0xf1, 0xe4, 0x12, 0x57, 0x09, 0xab,
+	0xcd, 0x82, 0xee, 0xdc, 0x56, 0xf1, 0xb4, 0xe2, 0xdf, 0xe2, 0xea, 0xb4, 0x36, 0x8b, 0xb3, 0xad,
+	0x18, 0xd8, 0xda, 0x22, 0xa7, 0xe0, 0xe3, 0xb5, 0x41, 0x25, 0x58, 0xe1, 0xc2, 0x77, 0x6d, 0xf8,
+	0xe1, 0x0b, 0xfb, 0xd0, 0x52, 0xda, 0x04, 0x1e, 0xf6, 0xec, 0xa9, 0xd7, 0xdf, 0xa7, 0xe0, 0x57,
+	0x80, 0x9b, 0xeb, 0x35, 0xef, 0x89, 0x15, 0x85, 0xbc, 0x4a, 0xa4, 0xe2, 0x39, 0x17, 0x8d, 0x1e,
+	0x3b, 0xcd, 0x7b, 0xb2, 0xe0, 0xf7, 0x0e, 0xbb, 0x21, 0x2d, 0x90, 0x65, 0xa8, 0x34, 0xdd, 0xee,
+	0x91, 0xce, 0x1c, 0x46, 0xde, 0x87, 0xfd, 0x92, 0x5d, 0x27, 0x2c, 0xc7, 0x44, 0x63, 0x2a, 0x45,
+	0xa6, 0xed, 0x4d, 0xfd, 0xd8, 0x2f, 0xd9, 0xf5, 0x69, 0x8e, 0x33, 0x07, 0x06, 0x3f, 0xc0, 0x68,
+	0x2d, 0x14, 0x09, 0xe1, 0xae, 0xc2, 0x67, 0x35, 0x6a, 0xa3, 0x93, 0x0a, 0x55, 0x1b, 0x69, 0x87,
+	0xe2, 0xc7, 0x07, 0x9d, 0xeb, 0x29, 0x2a, 0x17, 0xdd, 0x3c, 0xa4, 0x8b, 0x5a, 0x69, 0x37, 0x00,
+	0x3f, 0x76, 0x46, 0xf0, 0x0b, 0xc0, 0x8d, 0x7e, 0xcd, 0xb3, 0x72, 0x7d, 0xb6, 0x9b, 0xd2, 0x5a,
+	0xe4, 0x63, 0x00, 0x8d, 0xa9, 0x42, 0x93, 0x28, 0x9c, 0xdf, 0x3e, 0xc1, 0x99, 0xf5, 0xc7, 0x38,
+	0x8f, 0x47, 0xba, 0x3b, 0x06, 0xbf, 0x0f, 0xc0, 0xeb, 0xeb, 0x4b, 0xde, 0x03, 0xbf, 0x99, 0x04,
+	0x0a, 0xc3, 0x53, 0x66, 0x64, 0x57, 0x67, 0x13, 0xec, 0xb5, 0xb1, 0xbd, 0xd1, 0xc6, 0x87, 0x70,
+	0x90, 0xb2, 0x74, 0x81, 0x89, 0x31, 0xc5, 0x0b, 0x4a, 0xed, 0x5b, 0xc7, 0x8f, 0xa6, 0x68, 0xb5,
+	0x6a, 0x5e, 0x98, 0xe3, 0x6a, 0xfe, 0x1c, 0xed, 0xe4, 0xfd, 0x78, 0x64, 0x91, 0x19, 0x7f, 0x8e,
+	0xd3, 0xe8, 0x8f, 0xbf, 0xdf, 0x1a, 0xfc, 0xfc, 0xc1, 0x2d, 0x7f, 0xb0, 0xbd, 0x55, 0x54, 0x5d,
+	0xe6, 0xf6, 0x23, 0x36, 0xab, 0x0a, 0x75, 0xb4, 0x3c, 0xbe, 0x18, 0xda, 0x6f, 0xf8, 0xa3, 0x7f,
+	0x07, 0x00, 0x18, 0xc2, 0x96, 0x03, 0x2a, 0x06, 0x00, 0x00,
 }
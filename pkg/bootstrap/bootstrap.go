@@ -1,11 +1,18 @@
 package bootstrap
 
 import (
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/transport"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/reporter"
 	"github.com/solo-io/sqoop/pkg/storage"
 	"github.com/solo-io/sqoop/pkg/storage/consul"
 	"github.com/solo-io/sqoop/pkg/storage/crd"
+	"github.com/solo-io/sqoop/pkg/storage/etcd"
+	"github.com/solo-io/sqoop/pkg/storage/failover"
 	"github.com/solo-io/sqoop/pkg/storage/file"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -16,6 +23,304 @@ type Options struct {
 	RoleName           string
 	ProxyAddr          string
 	BindAddr           string
+	// the address to serve admin endpoints on. admin endpoints are disabled if empty
+	AdminBindAddr    string
+	StrictOutput     bool
+	AllOrNothing     bool
+	OperationTimeout time.Duration
+	// the longest timeout an operation may request with the @timeout directive
+	MaxOperationTimeout time.Duration
+	MockResolvers       bool
+	WarmUp              WarmUpOptions
+	TLS                 TLSOptions
+	Listener            ListenerOptions
+	Shutdown            ShutdownOptions
+	Registry            RegistryOptions
+	JSON                JSONOptions
+	Compression         CompressionOptions
+	QueryCacheSize      int
+	PlanCacheSize       int
+	MaxAliases          int
+	MaxOperationLabels  int
+	ResolverMaps        ResolverMapOptions
+	Egress              EgressOptions
+	Debug               DebugOptions
+	Tracing             TracingOptions
+	// record response sizes of resolvers and operations
+	RecordResponseSizes bool
+	// how long the config watcher may go without a config or heartbeat before it is reported as stalled.
+	// zero disables the check
+	ConfigWatcherStaleness time.Duration
+	GlooRetry              GlooRetryOptions
+	// the maximum number of fields a single operation may resolve, counting every item of a list. zero means no limit
+	MaxResolutions int
+	// the maximum number of list items a single operation resolves concurrently, across all of its lists.
+	// zero means no limit
+	MaxConcurrentItems int
+	// feature flags enabled for resolvers gated by a flag
+	FeatureFlags []string
+	// never write schemas or resolver maps to storage, e.g. when config is managed declaratively.
+	// skeleton resolver maps are not generated, schemas without a resolver map are reported instead.
+	// statuses are still reported
+	ReadOnlyStorage bool
+	// request cookies resolvers may read and forward to upstreams. other cookies are never seen by resolvers
+	AllowedCookies []string
+	// urls to post the outcome of each reload to when it changes, e.g. to be notified of rejected schemas
+	ReportWebhooks []string
+	// additional sinks for the reports of each reload. statuses are always written to storage
+	ReportSinks []reporter.Interface
+	// the secret source is selected with the SecretStorageOptions of the embedded gloo options
+	SecretRefreshInterval time.Duration
+	// the storage backend for Sqoop config. defaults to the config storage type of the embedded gloo options.
+	// etcd is only supported for Sqoop config, Gloo config must be stored elsewhere
+	StorageType string
+	Etcd        EtcdOptions
+	// the maximum number of queries and mutations, and of subscriptions, served at once. further requests
+	// are rejected with 503. zero means no limit
+	MaxInFlightRequests      int
+	MaxInFlightSubscriptions int
+	// add a _sqoop field to the query type of every schema, reporting the health of Sqoop itself
+	HealthField bool
+	// schemas, <Type>.<field>s or <schema>/<Type>.<field>s to log in detail, including upstream requests and
+	// responses. can be changed at runtime on the admin listener
+	DebugLogging []string
+	// "spec" responds 200 to every executed operation. "errors" responds 400 to requests which fail to
+	// validate and an error status to operations which produce no data. defaults to spec
+	StatusCodes string
+	// a secondary storage backend config is read from while the primary is unavailable
+	Failover FailoverStorageOptions
+	// page sizes of Relay connection fields whose resolvers don't set their own
+	PageSizes PageSizeOptions
+	// clients of admin endpoints which change the state of Sqoop, e.g. POST /reload, authenticate with the
+	// X-Sqoop-Admin-Token header. such endpoints are not served without a token
+	AdminToken string
+	// the maximum depth of fragment spreads nested within fragments. zero means no limit
+	MaxFragmentDepth int
+	// ignore fields of input objects in variables which schemas don't define, rather than rejecting the operation
+	IgnoreUnknownInputFields bool
+	// resolvers of a query making identical upstream calls share a single call
+	DedupUpstreamCalls bool
+	// bound the bodies of upstream requests and responses of resolvers which don't set their own limits
+	UpstreamBodyLimits BodyLimitOptions
+	// codes of the errors of failed upstream responses by status, given as <statuses>=<code>, e.g. "404=NOT_FOUND".
+	// replace the default codes if set
+	ErrorCodes []string
+	// introspection reports the @example values of fields and arguments in extensions.examples
+	IntrospectionExamples bool
+}
+
+// BodyLimitOptions bound the size of the bodies of upstream requests and responses. zero applies the defaults
+type BodyLimitOptions struct {
+	MaxRequestBytes  int64
+	MaxResponseBytes int64
+}
+
+// PageSizeOptions bound the pages requested through the first and last arguments of fields which return
+// a type named *Connection
+type PageSizeOptions struct {
+	// the page size of queries which set neither first nor last. zero leaves both unset
+	Default int
+	// the largest page a query may request. zero means no maximum
+	Max int
+}
+
+// FailoverStorageOptions configure the backend Sqoop falls back to when its storage backend fails.
+// the secondary is configured with the same options as the primary, except for the directory of file storage
+type FailoverStorageOptions struct {
+	// the storage type of the secondary backend. empty disables failover
+	Type string
+	// the directory of the secondary backend if it is file storage
+	ConfigDir string
+	// how often an unavailable primary is checked for recovery
+	CheckInterval time.Duration
+}
+
+// WatcherTypeEtcd stores Sqoop config in etcd
+const WatcherTypeEtcd = "etcd"
+
+// EtcdOptions configure the connection to etcd when Sqoop config is stored there
+type EtcdOptions struct {
+	Endpoints []string
+	// schemas and resolver maps are stored under RootPath/schemas and RootPath/resolverMaps
+	RootPath    string
+	DialTimeout time.Duration
+	Username    string
+	Password    string
+	// connect with TLS if any is set. the CA verifies the servers, the certificate authenticates Sqoop
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+func (opts EtcdOptions) ToEtcdConfig() (clientv3.Config, error) {
+	cfg := clientv3.Config{
+		Endpoints:   opts.Endpoints,
+		DialTimeout: opts.DialTimeout,
+		Username:    opts.Username,
+		Password:    opts.Password,
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" || opts.CAFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile:      opts.CertFile,
+			KeyFile:       opts.KeyFile,
+			TrustedCAFile: opts.CAFile,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return cfg, errors.Wrap(err, "loading etcd tls config")
+		}
+		cfg.TLS = tlsConfig
+	}
+	return cfg, nil
+}
+
+type ResolverMapOptions struct {
+	// by default a skeleton resolver map is generated for schemas which don't name one.
+	// if set, such schemas are not served and the missing map is reported on the schema
+	DisableGenerateForUnset bool
+	// generate a skeleton resolver map when a schema names one which doesn't exist.
+	// otherwise the schema is not served and the missing map is reported on the schema
+	GenerateForMissing bool
+	// template of the names of resolver maps generated for schemas which don't name one, e.g. "{{.SchemaName}}-rm".
+	// it must contain "{{.SchemaName}}". defaults to "{{.SchemaName}}-resolvers"
+	NamePattern string
+}
+
+// EgressOptions restrict the urls resolvers may call when the url is computed from data
+type EgressOptions struct {
+	// if not empty, only these hosts may be called. a leading "*." matches any subdomain
+	AllowedHosts []string
+	// defaults to http and https
+	AllowedSchemes []string
+	// allow calling loopback, link-local and private addresses
+	AllowPrivateNetworks bool
+}
+
+type DebugOptions struct {
+	// serve endpoints for replaying operations with tracing on the admin listener
+	Enabled bool
+	// required when debug endpoints are enabled. clients sending it in the X-Sqoop-Debug-Token header get
+	// the status and timing of upstream calls in responses
+	Token string
+}
+
+type TracingOptions struct {
+	// the fraction of operations to trace, from 0 to 1. zero disables tracing.
+	// sampling decisions in incoming trace headers are honored while tracing is enabled
+	SampleRate float64
+	// the maximum number of operations traced per second. zero means no limit on operations sampled at the
+	// sample rate, operations whose trace headers ask for sampling are still limited to 10 per second
+	MaxPerSecond int
+}
+
+type CompressionOptions struct {
+	// gzip responses of at least this many bytes for clients which accept it. zero disables compression
+	MinSize int
+	// gzip level from 1 (fastest) to 9 (smallest)
+	Level int
+}
+
+type JSONOptions struct {
+	// indent GraphQL responses with this string. responses are compact by default
+	Indent string
+	// write <, > and & in responses literally instead of as unicode escapes
+	DisableHTMLEscape bool
+}
+
+type RegistryOptions struct {
+	// if set, the SDL of every served schema is published to this url on each reload
+	URL    string
+	APIKey string
+	// the variant of the graph to publish, e.g. "current" or "staging"
+	Variant string
+}
+
+// ListenerOptions bound how long clients may hold connections to the GraphQL server. zero means no limit
+type ListenerOptions struct {
+	// reading the entire request, including the body
+	ReadTimeout time.Duration
+	// reading the request headers
+	ReadHeaderTimeout time.Duration
+	// from the end of reading the request headers to the end of writing the response.
+	// must be longer than the operation timeout, or slow operations are cut off without a response
+	WriteTimeout time.Duration
+	// keeping an idle keep-alive connection open
+	IdleTimeout time.Duration
+}
+
+// on SIGTERM, readiness fails for the drain delay so load balancers stop routing new requests, then the
+// listeners close and in-flight requests get up to the grace period to finish
+type ShutdownOptions struct {
+	DrainDelay  time.Duration
+	GracePeriod time.Duration
+}
+
+type TLSOptions struct {
+	// serve TLS using this certificate and key. reloaded when the files change
+	CertFile string
+	KeyFile  string
+	// if set, clients must present a certificate signed by this CA
+	ClientCAFile string
+}
+
+// GlooRetryOptions control how writing config to Gloo is retried after a failure.
+// GraphQL endpoints are updated and served regardless
+type GlooRetryOptions struct {
+	// the delay before the first retry, doubled after every failed retry. zero disables retries,
+	// leaving the config to be written on the next reload
+	InitialBackoff time.Duration
+	// the maximum delay between retries. zero means no maximum
+	MaxBackoff time.Duration
+}
+
+type WarmUpOptions struct {
+	// pre-establish connections to the proxy for every resolver route on config load
+	Enabled bool
+	// maximum number of connections to open at once
+	Concurrency int
+	// give up on warming connections after this long
+	Timeout time.Duration
+}
+
+// BootstrapStorage creates the client for Sqoop config, stored with the Sqoop storage type if one is set
+func BootstrapStorage(opts Options) (storage.Interface, error) {
+	storageType := opts.ConfigStorageOptions.Type
+	if opts.StorageType != "" {
+		storageType = opts.StorageType
+	}
+	primary, err := bootstrapStorageType(opts, storageType)
+	if err != nil || opts.Failover.Type == "" {
+		return primary, err
+	}
+	secondaryOpts := opts
+	if opts.Failover.ConfigDir != "" {
+		secondaryOpts.FileOptions.ConfigDir = opts.Failover.ConfigDir
+	}
+	secondary, err := bootstrapStorageType(secondaryOpts, opts.Failover.Type)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating failover storage")
+	}
+	return failover.NewStorage(primary, secondary, opts.Failover.CheckInterval), nil
+}
+
+func bootstrapStorageType(opts Options, storageType string) (storage.Interface, error) {
+	if storageType == WatcherTypeEtcd {
+		if len(opts.Etcd.Endpoints) == 0 {
+			return nil, errors.New("must provide endpoints for etcd config watcher")
+		}
+		cfg, err := opts.Etcd.ToEtcdConfig()
+		if err != nil {
+			return nil, err
+		}
+		cfgWatcher, err := etcd.NewStorage(cfg, opts.Etcd.RootPath, opts.ConfigStorageOptions.SyncFrequency)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to start etcd config watcher with endpoints %v", opts.Etcd.Endpoints)
+		}
+		return cfgWatcher, nil
+	}
+	glooOpts := opts.Options
+	glooOpts.ConfigStorageOptions.Type = storageType
+	return Bootstrap(glooOpts)
 }
 
 func Bootstrap(opts bootstrap.Options) (storage.Interface, error) {
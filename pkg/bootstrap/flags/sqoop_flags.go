@@ -1,7 +1,10 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/solo-io/sqoop/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/resolvers"
 	"github.com/spf13/cobra"
 )
 
@@ -15,4 +18,168 @@ func AddSqoopFlags(cmd *cobra.Command, opts *bootstrap.Options) {
 		"address (hostname:port) of the Sqoop proxy")
 	cmd.PersistentFlags().StringVar(&opts.BindAddr, "sqoop.bind-addr", ":9090", "the "+
 		"address for the Sqoop server to listen on")
+	cmd.PersistentFlags().StringVar(&opts.AdminBindAddr, "sqoop.admin-bind-addr", "", "the "+
+		"address to serve admin endpoints on, e.g. localhost:9091. must not be exposed to GraphQL clients. admin endpoints are disabled if empty")
+	cmd.PersistentFlags().BoolVar(&opts.StrictOutput, "sqoop.strict-output", false, "validate that "+
+		"resolver results match the types declared in the schema")
+	cmd.PersistentFlags().BoolVar(&opts.AllOrNothing, "sqoop.all-or-nothing", false, "return "+
+		"no data for a query if any of its fields fail to resolve, instead of partial data")
+	cmd.PersistentFlags().StringVar(&opts.TLS.CertFile, "sqoop.tls-cert", "", "path to a "+
+		"certificate file. if set, Sqoop will serve HTTPS")
+	cmd.PersistentFlags().StringVar(&opts.TLS.KeyFile, "sqoop.tls-key", "", "path to the "+
+		"private key for the certificate given with --sqoop.tls-cert")
+	cmd.PersistentFlags().StringVar(&opts.TLS.ClientCAFile, "sqoop.tls-client-ca", "", "path to a "+
+		"CA certificate file. if set, clients must present a certificate signed by this CA")
+	cmd.PersistentFlags().DurationVar(&opts.Listener.ReadTimeout, "sqoop.listener-read-timeout", 30*time.Second, "the "+
+		"maximum time to read an entire request, including the body. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&opts.Listener.ReadHeaderTimeout, "sqoop.listener-read-header-timeout", 10*time.Second, "the "+
+		"maximum time to read the headers of a request. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&opts.Listener.WriteTimeout, "sqoop.listener-write-timeout", 2*time.Minute, "the "+
+		"maximum time to write a response. must be longer than --sqoop.operation-timeout. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&opts.Listener.IdleTimeout, "sqoop.listener-idle-timeout", 2*time.Minute, "the "+
+		"maximum time to keep an idle keep-alive connection open. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&opts.Shutdown.DrainDelay, "sqoop.shutdown-drain-delay", 0, "how "+
+		"long to fail readiness on SIGTERM before closing the listeners, so load balancers stop routing new requests")
+	cmd.PersistentFlags().DurationVar(&opts.Shutdown.GracePeriod, "sqoop.shutdown-grace-period", 20*time.Second, "the "+
+		"maximum time to wait for in-flight requests to finish after closing the listeners on SIGTERM")
+	cmd.PersistentFlags().DurationVar(&opts.OperationTimeout, "sqoop.operation-timeout", 0, "the "+
+		"maximum time to spend executing a single GraphQL operation. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&opts.MaxOperationTimeout, "sqoop.max-operation-timeout", 0, "the "+
+		"longest timeout an operation may request with @timeout. 0 means operations may only shorten the operation timeout")
+	cmd.PersistentFlags().BoolVar(&opts.MockResolvers, "sqoop.mock-resolvers", false, "resolve "+
+		"every field with generated mock data instead of calling backends. useful for development")
+	cmd.PersistentFlags().BoolVar(&opts.WarmUp.Enabled, "sqoop.warm-up", false, "pre-establish "+
+		"connections to the proxy for resolver routes when config is loaded")
+	cmd.PersistentFlags().IntVar(&opts.WarmUp.Concurrency, "sqoop.warm-up-concurrency", 8, "the "+
+		"maximum number of connections to establish at once during warm-up")
+	cmd.PersistentFlags().DurationVar(&opts.WarmUp.Timeout, "sqoop.warm-up-timeout", 2*time.Second, "the "+
+		"maximum time to spend warming up connections on each config load")
+	cmd.PersistentFlags().StringVar(&opts.Registry.URL, "sqoop.registry-url", "", "url of a "+
+		"schema registry. if set, Sqoop will publish the schemas it serves after each reload")
+	cmd.PersistentFlags().StringVar(&opts.Registry.APIKey, "sqoop.registry-api-key", "", "the "+
+		"api key to authenticate with the schema registry")
+	cmd.PersistentFlags().StringVar(&opts.Registry.Variant, "sqoop.registry-variant", "current", "the "+
+		"graph variant to publish schemas to")
+	cmd.PersistentFlags().StringVar(&opts.JSON.Indent, "sqoop.json-indent", "", "indent "+
+		"GraphQL responses with this string, e.g. two spaces. responses are compact by default")
+	cmd.PersistentFlags().BoolVar(&opts.JSON.DisableHTMLEscape, "sqoop.json-disable-html-escape", false, "write "+
+		"<, > and & in GraphQL responses literally instead of escaping them")
+	cmd.PersistentFlags().IntVar(&opts.Compression.MinSize, "sqoop.compression-min-size", 0, "gzip "+
+		"GraphQL responses of at least this many bytes for clients which accept it. 0 disables compression")
+	cmd.PersistentFlags().IntVar(&opts.Compression.Level, "sqoop.compression-level", 6, "the "+
+		"gzip compression level, from 1 (fastest) to 9 (smallest)")
+	cmd.PersistentFlags().IntVar(&opts.QueryCacheSize, "sqoop.query-cache-size", 1000, "the "+
+		"number of parsed and validated query documents to cache per schema. 0 disables caching")
+	cmd.PersistentFlags().DurationVar(&opts.ConfigWatcherStaleness, "sqoop.config-watcher-staleness", time.Minute, "report "+
+		"the config watcher as stalled if it neither delivers config nor confirms it can read storage for this long. 0 disables the check")
+	cmd.PersistentFlags().DurationVar(&opts.GlooRetry.InitialBackoff, "sqoop.gloo-retry-initial-backoff", time.Second, "how "+
+		"long to wait before retrying to write config to Gloo after a failure, doubled after each failed retry. 0 disables retries")
+	cmd.PersistentFlags().DurationVar(&opts.GlooRetry.MaxBackoff, "sqoop.gloo-retry-max-backoff", time.Minute, "the "+
+		"maximum time to wait between retries to write config to Gloo. 0 means no maximum")
+	cmd.PersistentFlags().IntVar(&opts.MaxResolutions, "sqoop.max-resolutions", 0, "the "+
+		"maximum number of fields a single operation may resolve, counting every item of a list. "+
+		"fields beyond the limit resolve to null with an error. 0 means no limit")
+	cmd.PersistentFlags().IntVar(&opts.MaxConcurrentItems, "sqoop.max-concurrent-list-items", 64, "the "+
+		"maximum number of list items a single operation resolves concurrently, including the items of nested lists. "+
+		"further items are resolved one after another. 0 means no limit")
+	cmd.PersistentFlags().StringSliceVar(&opts.FeatureFlags, "sqoop.feature-flags", nil, "feature "+
+		"flags to enable. fields whose resolvers are gated by other flags resolve to null")
+	cmd.PersistentFlags().StringSliceVar(&opts.AllowedCookies, "sqoop.allowed-cookies", nil, "names "+
+		"of request cookies resolvers may read and forward to upstreams. other cookies are dropped")
+	cmd.PersistentFlags().StringSliceVar(&opts.ReportWebhooks, "sqoop.report-webhooks", nil, "urls "+
+		"to post the accepted and rejected schemas and resolver maps to whenever they change")
+	cmd.PersistentFlags().Float64Var(&opts.Tracing.SampleRate, "sqoop.tracing-sample-rate", 0, "the "+
+		"fraction of GraphQL operations to trace, from 0 to 1. 0 disables tracing")
+	cmd.PersistentFlags().IntVar(&opts.Tracing.MaxPerSecond, "sqoop.tracing-max-per-second", 0, "the "+
+		"maximum number of GraphQL operations to trace per second. 0 means no limit, except on operations whose "+
+		"trace headers ask for tracing, which are limited to 10 per second")
+	cmd.PersistentFlags().IntVar(&opts.PlanCacheSize, "sqoop.plan-cache-size", 1000, "the "+
+		"number of operations per schema whose execution plans are cached across requests. 0 disables caching")
+	cmd.PersistentFlags().IntVar(&opts.MaxAliases, "sqoop.max-aliases", 0, "the "+
+		"maximum number of aliases under which a field may be selected in a single selection set. 0 means no limit")
+	cmd.PersistentFlags().BoolVar(&opts.HealthField, "sqoop.health-field", false, "add "+
+		"a _sqoop { version uptime schemasLoaded upstreamsHealthy } field to the query type of every schema which doesn't declare one")
+	cmd.PersistentFlags().StringSliceVar(&opts.DebugLogging, "sqoop.debug-logging", nil, "schemas, "+
+		"<Type>.<field>s or <schema>/<Type>.<field>s whose resolution, including upstream requests and responses, is logged in detail")
+	cmd.PersistentFlags().StringVar(&opts.StatusCodes, "sqoop.status-codes", "spec", "the "+
+		"http status of failed operations. spec: 200 for every executed operation. errors: 400 for requests which fail "+
+		"to validate, and 400, 502 or 500 for operations which produce only errors")
+	cmd.PersistentFlags().IntVar(&opts.MaxInFlightRequests, "sqoop.max-in-flight-requests", 0, "the "+
+		"maximum number of GraphQL queries and mutations served at once. further requests are rejected with 503. 0 means no limit")
+	cmd.PersistentFlags().IntVar(&opts.MaxInFlightSubscriptions, "sqoop.max-in-flight-subscriptions", 0, "the "+
+		"maximum number of GraphQL subscriptions served at once. further subscriptions are rejected with 503. 0 means no limit")
+	cmd.PersistentFlags().IntVar(&opts.MaxOperationLabels, "sqoop.metrics-max-operations", 100, "the "+
+		"maximum number of operation names to track in metrics per schema. further operations are counted as \"other\", "+
+		"and operations which fail to validate as \"invalid\". names unused for an hour are freed. 0 means no limit")
+	cmd.PersistentFlags().BoolVar(&opts.RecordResponseSizes, "sqoop.metrics-response-sizes", false, "record "+
+		"histograms of the size of the data returned by each resolver and of each GraphQL response")
+	cmd.PersistentFlags().BoolVar(&opts.ResolverMaps.DisableGenerateForUnset, "sqoop.disable-resolver-map-generation", false, "do "+
+		"not generate a skeleton resolver map for schemas which do not specify one. such schemas are not served")
+	cmd.PersistentFlags().BoolVar(&opts.ResolverMaps.GenerateForMissing, "sqoop.generate-missing-resolver-maps", false, "generate "+
+		"a skeleton resolver map when a schema specifies one which does not exist, instead of reporting an error")
+	cmd.PersistentFlags().StringVar(&opts.ResolverMaps.NamePattern, "sqoop.resolver-map-name-pattern", "{{.SchemaName}}-resolvers", "the "+
+		"template of the names of resolver maps generated for schemas which do not specify one. it must contain {{.SchemaName}}")
+	cmd.PersistentFlags().BoolVar(&opts.ReadOnlyStorage, "sqoop.read-only-storage", false, "never "+
+		"write schemas or resolver maps to storage. implies --sqoop.disable-resolver-map-generation")
+	cmd.PersistentFlags().StringSliceVar(&opts.Egress.AllowedHosts, "sqoop.egress-allowed-hosts", nil, "if "+
+		"set, resolvers may only call urls computed from data on these hosts. a leading *. matches any subdomain")
+	cmd.PersistentFlags().StringSliceVar(&opts.Egress.AllowedSchemes, "sqoop.egress-allowed-schemes", []string{"http", "https"}, "the "+
+		"schemes resolvers may use when calling urls computed from data")
+	cmd.PersistentFlags().BoolVar(&opts.Egress.AllowPrivateNetworks, "sqoop.egress-allow-private-networks", false, "allow "+
+		"resolvers to call loopback, link-local and private addresses when calling urls computed from data")
+	cmd.PersistentFlags().BoolVar(&opts.Debug.Enabled, "sqoop.debug-endpoints", false, "serve "+
+		"/endpoints/<schema>/debug/replay, which replays recent queries with tracing, and /endpoints/debug/traces on the admin "+
+		"listener. requires --sqoop.debug-token, --sqoop.admin-bind-addr and --sqoop.admin-token")
+	cmd.PersistentFlags().StringVar(&opts.Debug.Token, "sqoop.debug-token", "", "the "+
+		"token clients must send in the X-Sqoop-Debug-Token header to get the status and timing of upstream calls in responses")
+	cmd.PersistentFlags().DurationVar(&opts.SecretRefreshInterval, "sqoop.secret-refresh-interval", time.Minute, "how "+
+		"often to re-read secrets referenced by resolvers, to pick up rotated values")
+	cmd.PersistentFlags().StringVar(&opts.StorageType, "sqoop.storage-type", "", "the "+
+		"storage backend for Sqoop config: file, kube, consul or etcd. defaults to --storage.type")
+	cmd.PersistentFlags().StringSliceVar(&opts.Etcd.Endpoints, "sqoop.etcd-endpoints", []string{"localhost:2379"}, "the "+
+		"etcd endpoints to connect to when Sqoop config is stored in etcd")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.RootPath, "sqoop.etcd-root-path", "sqoop", "the "+
+		"key prefix to store Sqoop config under in etcd")
+	cmd.PersistentFlags().DurationVar(&opts.Etcd.DialTimeout, "sqoop.etcd-dial-timeout", 5*time.Second, "how "+
+		"long to wait for a connection to etcd")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.Username, "sqoop.etcd-username", "", "the "+
+		"username to authenticate to etcd with")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.Password, "sqoop.etcd-password", "", "the "+
+		"password to authenticate to etcd with")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.CertFile, "sqoop.etcd-cert", "", "path to a "+
+		"client certificate file to authenticate to etcd with")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.KeyFile, "sqoop.etcd-key", "", "path to the "+
+		"private key for the certificate given with --sqoop.etcd-cert")
+	cmd.PersistentFlags().StringVar(&opts.Etcd.CAFile, "sqoop.etcd-ca", "", "path to a "+
+		"CA certificate file to verify etcd servers with")
+	cmd.PersistentFlags().StringVar(&opts.Failover.Type, "sqoop.failover-storage-type", "", "a "+
+		"secondary storage backend to read Sqoop config from while the primary is unavailable: file, kube, consul or etcd")
+	cmd.PersistentFlags().StringVar(&opts.Failover.ConfigDir, "sqoop.failover-config-dir", "", "the "+
+		"directory of the secondary storage backend when it is file storage. defaults to --file.config.dir")
+	cmd.PersistentFlags().DurationVar(&opts.Failover.CheckInterval, "sqoop.failover-check-interval", 10*time.Second, "how "+
+		"often an unavailable primary storage backend is checked for recovery")
+	cmd.PersistentFlags().IntVar(&opts.PageSizes.Default, "sqoop.default-page-size", 0, "the "+
+		"page size of connection fields queried without first or last. 0 leaves both unset")
+	cmd.PersistentFlags().IntVar(&opts.PageSizes.Max, "sqoop.max-page-size", 0, "the "+
+		"largest first or last a query may request of a connection field. 0 means no maximum")
+	cmd.PersistentFlags().StringVar(&opts.AdminToken, "sqoop.admin-token", "", "the "+
+		"token clients must send in the X-Sqoop-Admin-Token header to use admin endpoints which change "+
+		"the state of Sqoop, e.g. POST /reload. they are not served without a token")
+	cmd.PersistentFlags().IntVar(&opts.MaxFragmentDepth, "sqoop.max-fragment-depth", 0, "the "+
+		"maximum depth of fragment spreads nested within fragments. 0 means no limit")
+	cmd.PersistentFlags().BoolVar(&opts.IgnoreUnknownInputFields, "sqoop.ignore-unknown-input-fields", false, "ignore "+
+		"fields of input objects in variables which the schema doesn't define, instead of rejecting the operation. "+
+		"misspelled fields are silently left unset")
+	cmd.PersistentFlags().BoolVar(&opts.DedupUpstreamCalls, "sqoop.dedup-upstream-calls", false, "make "+
+		"identical upstream calls of a query, e.g. for a field selected under several aliases, once and share the response. "+
+		"calls of mutations are never deduplicated")
+	cmd.PersistentFlags().Int64Var(&opts.UpstreamBodyLimits.MaxRequestBytes, "sqoop.max-upstream-request-bytes", resolvers.DefaultMaxRequestBytes, "the "+
+		"largest request body resolvers send to their upstreams, unless a resolver sets its own limit")
+	cmd.PersistentFlags().Int64Var(&opts.UpstreamBodyLimits.MaxResponseBytes, "sqoop.max-upstream-response-bytes", resolvers.DefaultMaxResponseBytes, "the "+
+		"largest response body resolvers read from their upstreams, unless a resolver sets its own limit")
+	cmd.PersistentFlags().StringSliceVar(&opts.ErrorCodes, "sqoop.error-codes", nil, "codes "+
+		"reported in extensions.code of errors of failed upstream responses by status, e.g. 404=NOT_FOUND,5xx=INTERNAL. "+
+		"replace the default codes")
+	cmd.PersistentFlags().BoolVar(&opts.IntrospectionExamples, "sqoop.introspection-examples", false, "report "+
+		"the @example values of the fields and arguments of introspected types in extensions.examples")
 }
@@ -0,0 +1,343 @@
+package client
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/storage"
+	"github.com/solo-io/sqoop/pkg/util"
+)
+
+// one or more lowercase rfc1035/rfc1123 labels separated by '.'
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+const maxNameLength = 253
+
+// Client is a thin wrapper around Sqoop's storage which validates config objects before writing them.
+// It works with any storage backend
+type Client struct {
+	sqoop storage.Interface
+}
+
+func NewClient(sqoop storage.Interface) *Client {
+	return &Client{sqoop: sqoop}
+}
+
+// Storage returns the underlying storage client
+func (c *Client) Storage() storage.Interface {
+	return c.sqoop
+}
+
+// CreateSchema validates and writes a new schema. If resolverMap is empty, Sqoop will generate
+// an empty resolver map for the schema
+func (c *Client) CreateSchema(name, inlineSchema, resolverMap string) (*v1.Schema, error) {
+	schema := &v1.Schema{
+		Name:         name,
+		InlineSchema: inlineSchema,
+		ResolverMap:  resolverMap,
+	}
+	if err := c.ValidateSchema(schema); err != nil {
+		return nil, err
+	}
+	return c.sqoop.V1().Schemas().Create(schema)
+}
+
+// UpdateSchemaDefinition replaces the GraphQL definition of an existing schema
+func (c *Client) UpdateSchemaDefinition(name, inlineSchema string) (*v1.Schema, error) {
+	schema, err := c.sqoop.V1().Schemas().Get(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving schema %v", name)
+	}
+	schema.InlineSchema = inlineSchema
+	if err := c.ValidateSchema(schema); err != nil {
+		return nil, err
+	}
+	return c.sqoop.V1().Schemas().Update(schema)
+}
+
+// AttachResolverMap sets the resolver map used to resolve a schema
+func (c *Client) AttachResolverMap(schemaName, resolverMapName string) (*v1.Schema, error) {
+	schema, err := c.sqoop.V1().Schemas().Get(schemaName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving schema %v", schemaName)
+	}
+	resolverMap, err := c.sqoop.V1().ResolverMaps().Get(resolverMapName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving resolver map %v", resolverMapName)
+	}
+	if err := ValidateResolverMapForSchema(resolverMap, schema); err != nil {
+		return nil, err
+	}
+	schema.ResolverMap = resolverMapName
+	return c.sqoop.V1().Schemas().Update(schema)
+}
+
+// GenerateResolverMap writes an empty resolver map for the schema and attaches it. The schema is attached
+// first, so Sqoop doesn't generate a resolver map of its own for the schema in the meantime
+func (c *Client) GenerateResolverMap(schemaName, resolverMapName string) (*v1.ResolverMap, error) {
+	schema, err := c.sqoop.V1().Schemas().Get(schemaName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving schema %v", schemaName)
+	}
+	if err := ValidateName(resolverMapName); err != nil {
+		return nil, errors.Wrap(err, "invalid resolver map")
+	}
+	parsedSchema, err := exec.ParseSchema(schema.InlineSchema)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing schema %v", schemaName)
+	}
+	if _, err := c.sqoop.V1().ResolverMaps().Get(resolverMapName); err == nil {
+		return nil, errors.Errorf("resolver map %v already exists", resolverMapName)
+	}
+	schema.ResolverMap = resolverMapName
+	if _, err := c.sqoop.V1().Schemas().Update(schema); err != nil {
+		return nil, errors.Wrapf(err, "updating schema %v", schemaName)
+	}
+	resolverMap, err := c.sqoop.V1().ResolverMaps().Create(util.GenerateResolverMapSkeleton(resolverMapName, parsedSchema.Schema))
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing resolver map %v", resolverMapName)
+	}
+	return resolverMap, nil
+}
+
+// CreateResolverMap validates and writes a new resolver map
+func (c *Client) CreateResolverMap(resolverMap *v1.ResolverMap) (*v1.ResolverMap, error) {
+	if err := ValidateResolverMap(resolverMap); err != nil {
+		return nil, err
+	}
+	return c.sqoop.V1().ResolverMaps().Create(resolverMap)
+}
+
+// SetResolver sets the resolver for a single field in an existing resolver map
+func (c *Client) SetResolver(resolverMapName, typeName, fieldName string, resolver *v1.Resolver) (*v1.ResolverMap, error) {
+	resolverMap, err := c.sqoop.V1().ResolverMaps().Get(resolverMapName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving resolver map %v", resolverMapName)
+	}
+	typeResolver, ok := resolverMap.Types[typeName]
+	if !ok {
+		return nil, errors.Errorf("resolver map %v does not contain type %v", resolverMapName, typeName)
+	}
+	if _, ok := typeResolver.Fields[fieldName]; !ok {
+		return nil, errors.Errorf("resolver map %v does not contain field %v.%v", resolverMapName, typeName, fieldName)
+	}
+	typeResolver.Fields[fieldName] = resolver
+	if err := ValidateResolverMap(resolverMap); err != nil {
+		return nil, err
+	}
+	return c.sqoop.V1().ResolverMaps().Update(resolverMap)
+}
+
+// DeleteSchema deletes a schema. The schema's resolver map is left in place
+func (c *Client) DeleteSchema(name string) error {
+	return c.sqoop.V1().Schemas().Delete(name)
+}
+
+// DeleteResolverMap deletes a resolver map, refusing if it is still in use by a schema
+func (c *Client) DeleteResolverMap(name string) error {
+	schemas, err := c.sqoop.V1().Schemas().List()
+	if err != nil {
+		return errors.Wrap(err, "listing schemas")
+	}
+	for _, schema := range schemas {
+		if schema.ResolverMap == name {
+			return errors.Errorf("resolver map %v is in use by schema %v", name, schema.Name)
+		}
+	}
+	return c.sqoop.V1().ResolverMaps().Delete(name)
+}
+
+// ValidateSchema checks the schema name and GraphQL definition.
+// If the schema references a resolver map which already exists, it is checked against the schema
+func (c *Client) ValidateSchema(schema *v1.Schema) error {
+	if err := ValidateName(schema.Name); err != nil {
+		return errors.Wrap(err, "invalid schema")
+	}
+	if _, err := exec.ParseSchema(schema.InlineSchema); err != nil {
+		return errors.Wrapf(err, "invalid schema %v", schema.Name)
+	}
+	if schema.ResolverMap == "" {
+		return nil
+	}
+	resolverMap, err := c.sqoop.V1().ResolverMaps().Get(schema.ResolverMap)
+	if err != nil {
+		// the resolver map may be written after the schema
+		return nil
+	}
+	return ValidateResolverMapForSchema(resolverMap, schema)
+}
+
+// ValidateResolverMap checks the resolver map name and that every resolver is fully specified
+func ValidateResolverMap(resolverMap *v1.ResolverMap) error {
+	if err := ValidateName(resolverMap.Name); err != nil {
+		return errors.Wrap(err, "invalid resolver map")
+	}
+	if defaults := resolverMap.HttpDefaults; defaults != nil && defaults.BaseUrl != "" {
+		if u, err := url.Parse(defaults.BaseUrl); err != nil || !u.IsAbs() {
+			return errors.Errorf("http defaults: base url %v must be an absolute url", defaults.BaseUrl)
+		}
+	}
+	for typeName, typeResolver := range resolverMap.Types {
+		if typeResolver == nil {
+			continue
+		}
+		for fieldName, resolver := range typeResolver.Fields {
+			if err := validateResolver(resolver, resolverMap.HttpDefaults); err != nil {
+				return errors.Wrapf(err, "invalid resolver for %v.%v", typeName, fieldName)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateResolverMapForSchema checks that every type and field in the resolver map is defined by the schema
+func ValidateResolverMapForSchema(resolverMap *v1.ResolverMap, schema *v1.Schema) error {
+	if err := ValidateResolverMap(resolverMap); err != nil {
+		return err
+	}
+	parsedSchema, err := exec.ParseSchema(schema.InlineSchema)
+	if err != nil {
+		return errors.Wrapf(err, "parsing schema %v", schema.Name)
+	}
+	skeleton := util.GenerateResolverMapSkeleton(resolverMap.Name, parsedSchema.Schema)
+	for typeName, typeResolver := range resolverMap.Types {
+		schemaType, ok := skeleton.Types[typeName]
+		if !ok {
+			return errors.Errorf("type %v in resolver map %v is not defined in schema %v", typeName, resolverMap.Name, schema.Name)
+		}
+		if typeResolver == nil {
+			continue
+		}
+		for fieldName := range typeResolver.Fields {
+			if _, ok := schemaType.Fields[fieldName]; !ok {
+				return errors.Errorf("field %v.%v in resolver map %v is not defined in schema %v", typeName, fieldName, resolverMap.Name, schema.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// resolvers inherit the http defaults of their map
+func validateResolver(resolver *v1.Resolver, defaults *v1.HttpDefaults) error {
+	if resolver == nil {
+		return nil
+	}
+	if resolver.Cache != nil && resolver.Cache.KeyTemplate != "" {
+		if _, err := util.Template(resolver.Cache.KeyTemplate); err != nil {
+			return errors.Wrap(err, "invalid cache key template")
+		}
+	}
+	switch r := resolver.Resolver.(type) {
+	case *v1.Resolver_GlooResolver:
+		if r.GlooResolver == nil || r.GlooResolver.Function == nil {
+			return errors.Errorf("gloo resolver must specify a function")
+		}
+		switch fn := r.GlooResolver.Function.(type) {
+		case *v1.GlooResolver_SingleFunction:
+			if fn.SingleFunction == nil || fn.SingleFunction.Upstream == "" || fn.SingleFunction.Function == "" {
+				return errors.Errorf("function must specify an upstream and function name")
+			}
+		case *v1.GlooResolver_MultiFunction:
+			if fn.MultiFunction == nil || len(fn.MultiFunction.WeightedFunctions) == 0 {
+				return errors.Errorf("multi function must specify at least one weighted function")
+			}
+		case *v1.GlooResolver_Upstream:
+			if fn.Upstream == "" {
+				return errors.Errorf("gloo resolver must specify an upstream name")
+			}
+		}
+		for _, header := range r.GlooResolver.SecretHeaders {
+			if header.Name == "" || header.SecretRef == nil || header.SecretRef.Name == "" || header.SecretRef.Key == "" {
+				return errors.Errorf("secret headers must specify a header name and a secret name and key")
+			}
+		}
+	case *v1.Resolver_TemplateResolver:
+		if r.TemplateResolver == nil {
+			return errors.Errorf("template resolver must not be empty")
+		}
+	case *v1.Resolver_SignedUrlResolver:
+		if r.SignedUrlResolver == nil || r.SignedUrlResolver.Bucket == "" || r.SignedUrlResolver.KeyTemplate == "" {
+			return errors.Errorf("signed url resolver must specify a bucket and key template")
+		}
+		if r.SignedUrlResolver.AccessKeyId == nil || r.SignedUrlResolver.SecretAccessKey == nil {
+			return errors.Errorf("signed url resolver must reference secrets for its credentials")
+		}
+	case *v1.Resolver_VariablesResolver:
+		if r.VariablesResolver == nil || (r.VariablesResolver.Variable == "") == (r.VariablesResolver.InlineTemplate == "") {
+			return errors.Errorf("variables resolver must specify either a variable or an inline template")
+		}
+		if r.VariablesResolver.InlineTemplate != "" {
+			if _, err := util.Template(r.VariablesResolver.InlineTemplate); err != nil {
+				return errors.Wrap(err, "invalid variables resolver template")
+			}
+		}
+	case *v1.Resolver_HttpResolver:
+		if r.HttpResolver == nil || r.HttpResolver.UrlTemplate == "" {
+			return errors.Errorf("http resolver must specify a url template")
+		}
+		if len(r.HttpResolver.AllowedHosts) == 0 && r.HttpResolver.BaseUrl == "" &&
+			len(defaults.GetAllowedHosts()) == 0 && defaults.GetBaseUrl() == "" {
+			return errors.Errorf("http resolver must specify at least one allowed host or a base url")
+		}
+		if _, err := util.Template(r.HttpResolver.UrlTemplate); err != nil {
+			return errors.Wrap(err, "invalid http resolver url template")
+		}
+	case *v1.Resolver_ConditionalResolver:
+		if r.ConditionalResolver == nil {
+			return errors.Errorf("conditional resolver must not be empty")
+		}
+		for i, variant := range r.ConditionalResolver.Variants {
+			if variant.Resolver == nil {
+				return errors.Errorf("variant %v must specify a resolver", i)
+			}
+			if _, nested := variant.Resolver.Resolver.(*v1.Resolver_ConditionalResolver); nested {
+				return errors.Errorf("conditional resolvers cannot be nested")
+			}
+			if err := validateResolver(variant.Resolver, defaults); err != nil {
+				return errors.Wrapf(err, "invalid resolver for variant %v", i)
+			}
+		}
+		if err := validateResolver(r.ConditionalResolver.DefaultResolver, defaults); err != nil {
+			return errors.Wrap(err, "invalid default resolver")
+		}
+	case *v1.Resolver_MergeResolver:
+		if r.MergeResolver == nil || len(r.MergeResolver.Sources) == 0 {
+			return errors.Errorf("merge resolver must specify at least one source")
+		}
+		owners := make(map[string]int)
+		for i, source := range r.MergeResolver.Sources {
+			if source.Resolver == nil {
+				return errors.Errorf("source %v must specify a resolver", i)
+			}
+			switch source.Resolver.Resolver.(type) {
+			case *v1.Resolver_ConditionalResolver, *v1.Resolver_MergeResolver:
+				return errors.Errorf("source %v: conditional and merge resolvers cannot be nested", i)
+			}
+			for _, key := range source.Keys {
+				if owner, ok := owners[key]; ok {
+					return errors.Errorf("key %v is owned by sources %v and %v", key, owner, i)
+				}
+				owners[key] = i
+			}
+			if err := validateResolver(source.Resolver, defaults); err != nil {
+				return errors.Wrapf(err, "invalid resolver for source %v", i)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateName returns an error if name is not a valid name for a schema or resolver map
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.Errorf("name must be set")
+	}
+	if len(name) > maxNameLength || !nameRegex.MatchString(name) {
+		return errors.Errorf("name %q must consist of one or more lowercase rfc1035/rfc1123 labels "+
+			"separated by '.' with a maximum length of %v characters", name, maxNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	. "github.com/solo-io/sqoop/pkg/client"
+	"github.com/solo-io/sqoop/pkg/storage"
+	"github.com/solo-io/sqoop/pkg/storage/file"
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		dir    string
+		client *Client
+	)
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "clienttest")
+		Expect(err).NotTo(HaveOccurred())
+		sqoop, err := file.NewStorage(dir, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sqoop.V1().Register()).NotTo(HaveOccurred())
+		client = NewClient(sqoop)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	It("creates a schema and attaches a generated resolver map", func() {
+		schema, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.Name).To(Equal("starwars"))
+
+		resolverMap, err := client.GenerateResolverMap("starwars", "starwars-resolvers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolverMap.Types).To(HaveKey("Query"))
+
+		schema, err = client.Storage().V1().Schemas().Get("starwars")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.ResolverMap).To(Equal("starwars-resolvers"))
+	})
+	It("attaches the generated resolver map to the schema before writing it", func() {
+		_, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		failing := NewClient(failingResolverMapCreates{Interface: client.Storage()})
+		_, err = failing.GenerateResolverMap("starwars", "starwars-resolvers")
+		Expect(err).To(MatchError(ContainSubstring("writing resolver map starwars-resolvers")))
+
+		schema, err := client.Storage().V1().Schemas().Get("starwars")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.ResolverMap).To(Equal("starwars-resolvers"))
+	})
+	It("does not attach resolver maps which already exist", func() {
+		_, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.CreateResolverMap(&v1.ResolverMap{Name: "starwars-resolvers"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.GenerateResolverMap("starwars", "starwars-resolvers")
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+
+		schema, err := client.Storage().V1().Schemas().Get("starwars")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.ResolverMap).To(BeEmpty())
+	})
+	It("sets resolvers for individual fields", func() {
+		_, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.GenerateResolverMap("starwars", "starwars-resolvers")
+		Expect(err).NotTo(HaveOccurred())
+		resolver := &v1.Resolver{
+			Resolver: &v1.Resolver_GlooResolver{
+				GlooResolver: &v1.GlooResolver{
+					Function: &v1.GlooResolver_SingleFunction{
+						SingleFunction: &v1.Function{Upstream: "starwars-rest", Function: "GetHero"},
+					},
+				},
+			},
+		}
+		resolverMap, err := client.SetResolver("starwars-resolvers", "Query", "hero", resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolverMap.Types["Query"].Fields["hero"]).To(Equal(resolver))
+
+		_, err = client.SetResolver("starwars-resolvers", "Query", "villain", resolver)
+		Expect(err).To(HaveOccurred())
+	})
+	It("rejects invalid schemas before writing them", func() {
+		_, err := client.CreateSchema("Not_A_Valid_Name", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).To(HaveOccurred())
+		_, err = client.CreateSchema("broken", "type Query {", "")
+		Expect(err).To(HaveOccurred())
+		schemas, err := client.Storage().V1().Schemas().List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schemas).To(BeEmpty())
+	})
+	It("rejects resolver maps which do not match the schema", func() {
+		_, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.CreateResolverMap(&v1.ResolverMap{
+			Name: "other-resolvers",
+			Types: map[string]*v1.TypeResolver{
+				"Villain": {Fields: map[string]*v1.Resolver{"name": {}}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.AttachResolverMap("starwars", "other-resolvers")
+		Expect(err).To(HaveOccurred())
+	})
+	It("refuses to delete resolver maps which are in use", func() {
+		_, err := client.CreateSchema("starwars", test.StarWarsV1Schema().InlineSchema, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.GenerateResolverMap("starwars", "starwars-resolvers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.DeleteResolverMap("starwars-resolvers")).To(HaveOccurred())
+		Expect(client.DeleteSchema("starwars")).NotTo(HaveOccurred())
+		Expect(client.DeleteResolverMap("starwars-resolvers")).NotTo(HaveOccurred())
+	})
+})
+
+// storage which fails to create resolver maps
+type failingResolverMapCreates struct {
+	storage.Interface
+}
+
+func (s failingResolverMapCreates) V1() storage.V1 {
+	return failingResolverMapCreatesV1{V1: s.Interface.V1()}
+}
+
+type failingResolverMapCreatesV1 struct {
+	storage.V1
+}
+
+func (v failingResolverMapCreatesV1) ResolverMaps() storage.ResolverMaps {
+	return failingResolverMaps{ResolverMaps: v.V1.ResolverMaps()}
+}
+
+type failingResolverMaps struct {
+	storage.ResolverMaps
+}
+
+func (failingResolverMaps) Create(*v1.ResolverMap) (*v1.ResolverMap, error) {
+	return nil, errors.New("storage unavailable")
+}
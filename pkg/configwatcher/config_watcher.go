@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -11,16 +13,28 @@ import (
 	"github.com/mitchellh/hashstructure"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
 	"github.com/solo-io/sqoop/pkg/storage"
 )
 
 type configWatcher struct {
+	storage  storage.Interface
 	watchers []*storage.Watcher
 	configs  chan *v1.Config
 	errs     chan error
+
+	// re-reads the directories of schemas loaded from them, sending a new config if any files changed
+	pollSchemaDirs func()
+
+	heartbeatInterval time.Duration
+	heartbeats        chan time.Time
+	// number of watchers which have not returned
+	running int32
 }
 
-func NewConfigWatcher(storageClient storage.Interface) (*configWatcher, error) {
+// the watcher sends a heartbeat every heartbeatInterval while all of its watches are running and storage
+// can be read. zero disables heartbeats
+func NewConfigWatcher(storageClient storage.Interface, heartbeatInterval time.Duration) (*configWatcher, error) {
 	if err := storageClient.V1().Register(); err != nil && !storage.IsAlreadyExists(err) {
 		return nil, fmt.Errorf("failed to register to storage backend: %v", err)
 	}
@@ -32,10 +46,19 @@ func NewConfigWatcher(storageClient storage.Interface) (*configWatcher, error) {
 		ResolverMaps: nil,
 	}
 
+	// schemas are synced both by the storage watch and by polling their directories
+	var (
+		schemasMu     sync.Mutex
+		storedSchemas []*v1.Schema
+	)
 	syncSchemas := func(updatedList []*v1.Schema, _ *v1.Schema) {
+		schemasMu.Lock()
+		defer schemasMu.Unlock()
 		sort.SliceStable(updatedList, func(i, j int) bool {
 			return updatedList[i].GetName() < updatedList[j].GetName()
 		})
+		storedSchemas = updatedList
+		updatedList = readSchemaDirs(updatedList)
 
 		oldHash, newHash := hashSchemas(cache.Schemas), hashSchemas(updatedList)
 		if oldHash == newHash {
@@ -79,24 +102,86 @@ func NewConfigWatcher(storageClient storage.Interface) (*configWatcher, error) {
 	}
 
 	return &configWatcher{
-		watchers: []*storage.Watcher{resolverMapWatcher, schemaWatcher},
-		configs:  configs,
-		errs:     make(chan error),
+		storage:           storageClient,
+		watchers:          []*storage.Watcher{resolverMapWatcher, schemaWatcher},
+		configs:           configs,
+		errs:              make(chan error),
+		heartbeatInterval: heartbeatInterval,
+		heartbeats:        make(chan time.Time, 1),
+		pollSchemaDirs: func() {
+			schemasMu.Lock()
+			schemas := storedSchemas
+			schemasMu.Unlock()
+			for _, schema := range schemas {
+				if schema.SchemaDir != "" {
+					syncSchemas(schemas, nil)
+					return
+				}
+			}
+		},
 	}, nil
 }
 
 func (w *configWatcher) Run(stop <-chan struct{}) {
 	done := &sync.WaitGroup{}
+	atomic.StoreInt32(&w.running, int32(len(w.watchers)))
 	for _, watcher := range w.watchers {
 		done.Add(1)
 		go func(watcher *storage.Watcher, stop <-chan struct{}, errs chan error) {
 			watcher.Run(stop, errs)
+			atomic.AddInt32(&w.running, -1)
 			done.Done()
 		}(watcher, stop, w.errs)
 	}
+	if w.heartbeatInterval > 0 {
+		go w.heartbeat(stop)
+	}
+	go w.watchSchemaDirs(stop)
 	done.Wait()
 }
 
+func (w *configWatcher) watchSchemaDirs(stop <-chan struct{}) {
+	ticker := time.NewTicker(schemaDirPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.pollSchemaDirs()
+		}
+	}
+}
+
+// heartbeats stop as soon as any watch returns or storage can't be read
+func (w *configWatcher) heartbeat(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if int(atomic.LoadInt32(&w.running)) < len(w.watchers) {
+				continue
+			}
+			if _, err := w.storage.V1().Schemas().List(); err != nil {
+				log.Warnf("config watcher heartbeat: reading schemas: %v", err)
+				continue
+			}
+			// drop the heartbeat if the last one hasn't been received yet
+			select {
+			case w.heartbeats <- time.Now():
+			default:
+			}
+		}
+	}
+}
+
+func (w *configWatcher) Heartbeat() <-chan time.Time {
+	return w.heartbeats
+}
+
 func (w *configWatcher) Config() <-chan *v1.Config {
 	return w.configs
 }
@@ -105,6 +190,49 @@ func (w *configWatcher) Error() <-chan error {
 	return w.errs
 }
 
+func (w *configWatcher) Read() (*v1.Config, error) {
+	schemas, err := w.storage.V1().Schemas().List()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing schemas")
+	}
+	resolverMaps, err := w.storage.V1().ResolverMaps().List()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing resolver maps")
+	}
+	sort.SliceStable(schemas, func(i, j int) bool {
+		return schemas[i].GetName() < schemas[j].GetName()
+	})
+	schemas = readSchemaDirs(schemas)
+	sort.SliceStable(resolverMaps, func(i, j int) bool {
+		return resolverMaps[i].GetName() < resolverMaps[j].GetName()
+	})
+	return &v1.Config{Schemas: schemas, ResolverMaps: resolverMaps}, nil
+}
+
+// how often the directories of schemas loaded from files are checked for changes
+const schemaDirPollInterval = 5 * time.Second
+
+// readSchemaDirs returns copies of schemas loaded from directories with the files of their directories
+// inlined. schemas whose directories can't be read are left without an inline schema, to be rejected when
+// they are parsed
+func readSchemaDirs(schemas []*v1.Schema) []*v1.Schema {
+	loaded := make([]*v1.Schema, len(schemas))
+	for i, schema := range schemas {
+		if schema.SchemaDir == "" {
+			loaded[i] = schema
+			continue
+		}
+		schema = proto.Clone(schema).(*v1.Schema)
+		sdl, err := exec.ReadSchemaDir(schema.SchemaDir)
+		if err != nil {
+			log.Warnf("schema %v: %v", schema.Name, err)
+		}
+		schema.InlineSchema = sdl
+		loaded[i] = schema
+	}
+	return loaded
+}
+
 func hashSchemas(schemas []*v1.Schema) uint64 {
 	// shave off status and resource version
 	for _, item := range schemas {
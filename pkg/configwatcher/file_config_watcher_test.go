@@ -32,7 +32,7 @@ var _ = Describe("FileConfigWatcher", func() {
 		It("watches gloo files", func() {
 			storageClient, err := file.NewStorage(dir, time.Millisecond)
 			Must(err)
-			watcher, err := NewConfigWatcher(storageClient)
+			watcher, err := NewConfigWatcher(storageClient, 0)
 			Must(err)
 			go func() { watcher.Run(make(chan struct{})) }()
 
@@ -66,5 +66,28 @@ var _ = Describe("FileConfigWatcher", func() {
 				Expect(err).NotTo(HaveOccurred())
 			}
 		})
+		It("sends heartbeats while the watches are running", func() {
+			storageClient, err := file.NewStorage(dir, time.Millisecond)
+			Must(err)
+			watcher, err := NewConfigWatcher(storageClient, 10*time.Millisecond)
+			Must(err)
+			stop := make(chan struct{})
+			defer close(stop)
+			go func() { watcher.Run(stop) }()
+			Eventually(watcher.Heartbeat(), time.Second).Should(Receive())
+		})
+		It("reads the current config from storage", func() {
+			storageClient, err := file.NewStorage(dir, time.Millisecond)
+			Must(err)
+			watcher, err := NewConfigWatcher(storageClient, 0)
+			Must(err)
+			schema := test.StarWarsV1Schema()
+			_, err = storageClient.V1().Schemas().Create(schema)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := watcher.Read()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Schemas).To(HaveLen(1))
+			Expect(cfg.Schemas[0].InlineSchema).To(Equal(schema.InlineSchema))
+		})
 	})
 })
@@ -1,9 +1,17 @@
 package configwatcher
 
-import "github.com/solo-io/sqoop/pkg/api/types/v1"
+import (
+	"time"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+)
 
 type Interface interface {
 	Run(stop <-chan struct{})
 	Config() <-chan *v1.Config
 	Error() <-chan error
+	// Heartbeat receives the time whenever the watcher confirms it is still running and can read from storage
+	Heartbeat() <-chan time.Time
+	// Read lists the current config from storage, e.g. to reload config after a change the watches missed
+	Read() (*v1.Config, error)
 }
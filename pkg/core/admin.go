@@ -0,0 +1,383 @@
+package core
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/protoutil"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/debuglog"
+	"github.com/solo-io/sqoop/pkg/reporter"
+	"github.com/solo-io/sqoop/pkg/version"
+)
+
+// adminHandler serves endpoints for operators of Sqoop. They are only served on the admin listener,
+// which should not be exposed to GraphQL clients
+func (el *EventLoop) adminHandler() http.Handler {
+	m := mux.NewRouter()
+	m.HandleFunc("/resolvermaps", el.exportResolverMaps).Methods("GET")
+	m.HandleFunc("/resolvermaps/{name}", el.exportResolverMaps).Methods("GET")
+	m.HandleFunc("/logging", getDebugLogging).Methods("GET")
+	// debug logging logs the requests sent to upstreams, so only holders of the admin token may enable it
+	m.Handle("/logging", el.requireAdminToken(http.HandlerFunc(setDebugLogging))).Methods("PUT")
+	m.HandleFunc("/info", el.info).Methods("GET")
+	// metrics. only served here, and without the command line sqoop was started with, as it includes credentials
+	m.HandleFunc("/debug/vars", serveVars).Methods("GET")
+	if el.adminToken != "" {
+		m.Handle("/reload", el.requireAdminToken(http.HandlerFunc(el.reload))).Methods("POST")
+		// e.g. DELETE /endpoints/<schema>/cache, or the debug endpoints
+		m.PathPrefix("/endpoints/").Handler(el.requireAdminToken(http.StripPrefix("/endpoints", el.router.AdminHandler())))
+	}
+	return m
+}
+
+func (el *EventLoop) newAdminServer() *http.Server {
+	return &http.Server{
+		Addr:              el.adminBindAddr,
+		Handler:           el.adminHandler(),
+		ReadHeaderTimeout: el.listener.ReadHeaderTimeout,
+	}
+}
+
+// GET /resolvermaps[/<name>]?format=yaml|json exports resolver maps from storage, e.g. skeletons generated
+// for schemas without one, so they can be filled in and committed. status and resource versions are
+// left out, as they are managed by Sqoop and storage. lists are exported as a yaml stream or a json array
+func (el *EventLoop) exportResolverMaps(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+	if format != "yaml" && format != "json" {
+		http.Error(w, fmt.Sprintf("unknown format %v, must be yaml or json", format), http.StatusBadRequest)
+		return
+	}
+	resolverMaps, err := el.sqoop.V1().ResolverMaps().List()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("listing resolver maps: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(resolverMaps, func(i, j int) bool {
+		return resolverMaps[i].Name < resolverMaps[j].Name
+	})
+	name, single := mux.Vars(r)["name"]
+	if single {
+		var found *v1.ResolverMap
+		for _, resolverMap := range resolverMaps {
+			if resolverMap.Name == name {
+				found = resolverMap
+			}
+		}
+		if found == nil {
+			http.Error(w, fmt.Sprintf("resolver map %v not found", name), http.StatusNotFound)
+			return
+		}
+		resolverMaps = []*v1.ResolverMap{found}
+	}
+	var docs [][]byte
+	for _, resolverMap := range resolverMaps {
+		doc, err := marshalResolverMap(resolverMap, format)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		docs = append(docs, doc)
+	}
+	var out []byte
+	switch {
+	case single:
+		out = docs[0]
+	case format == "json":
+		out = append(append([]byte("["), bytes.Join(docs, []byte(","))...), ']')
+	default:
+		out = bytes.Join(docs, []byte("---\n"))
+	}
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "application/x-yaml")
+	}
+	w.Write(out)
+}
+
+// vars which are not served at /debug/vars
+var unservedVars = map[string]bool{
+	// the command line includes the admin token, registry api key etc.
+	"cmdline": true,
+}
+
+// serveVars serves the published vars like expvar.Handler, but without the unserved vars
+func serveVars(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprintf(w, "{\n")
+	first := true
+	expvar.Do(func(kv expvar.KeyValue) {
+		if unservedVars[kv.Key] {
+			return
+		}
+		if !first {
+			fmt.Fprintf(w, ",\n")
+		}
+		first = false
+		fmt.Fprintf(w, "%q: %s", kv.Key, kv.Value)
+	})
+	fmt.Fprintf(w, "\n}\n")
+}
+
+type debugLogging struct {
+	// schemas, <Type>.<field>s or <schema>/<Type>.<field>s logged in detail
+	Debug []string `json:"debug"`
+}
+
+// GET /logging returns the targets of debug logging
+func getDebugLogging(w http.ResponseWriter, r *http.Request) {
+	writeDebugLogging(w)
+}
+
+// PUT /logging {"debug": [...]} replaces the targets of debug logging, e.g. to inspect the upstream requests
+// of a single misbehaving field without restarting. an empty list disables debug logging. clients authenticate
+// with the X-Sqoop-Admin-Token header
+func setDebugLogging(w http.ResponseWriter, r *http.Request) {
+	var logging debugLogging
+	if err := json.NewDecoder(r.Body).Decode(&logging); err != nil {
+		http.Error(w, fmt.Sprintf("invalid logging config: %v", err), http.StatusBadRequest)
+		return
+	}
+	debuglog.SetTargets(logging.Debug)
+	log.Printf("debug logging enabled for %v", debuglog.Targets())
+	writeDebugLogging(w)
+}
+
+func writeDebugLogging(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(debugLogging{Debug: debuglog.Targets()})
+}
+
+const (
+	adminTokenHeader = "X-Sqoop-Admin-Token"
+	// forced reloads re-list all config from storage, so they are accepted at most this often
+	minForcedReloadInterval = 10 * time.Second
+)
+
+type reloadResult struct {
+	// nil if the config could not be read
+	summary *reporter.Summary
+	err     error
+}
+
+type reloadResponse struct {
+	Accepted             int            `json:"accepted"`
+	Rejected             int            `json:"rejected"`
+	RejectedSchemas      []string       `json:"rejectedSchemas,omitempty"`
+	RejectedResolverMaps []string       `json:"rejectedResolverMaps,omitempty"`
+	ErrorCategories      map[string]int `json:"errorCategories,omitempty"`
+	// errors rejecting config objects or writing config to gloo
+	Error string `json:"error,omitempty"`
+}
+
+// forceReload reads the config from storage and applies it as if the watcher had sent it.
+// it is only called by the event loop
+func (el *EventLoop) forceReload() reloadResult {
+	cfg, err := el.cfgWatcher.Read()
+	if err != nil {
+		return reloadResult{err: errors.Wrap(err, "reading config")}
+	}
+	log.Printf("forced reload of %v schemas and %v resolver maps", len(cfg.Schemas), len(cfg.ResolverMaps))
+	summary, err := el.update(cfg)
+	return reloadResult{summary: &summary, err: err}
+}
+
+// requireAdminToken rejects requests which don't present the admin token in the X-Sqoop-Admin-Token header
+func (el *EventLoop) requireAdminToken(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if el.adminToken == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(adminTokenHeader)), []byte(el.adminToken)) != 1 {
+			http.Error(w, "invalid admin token", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// POST /reload re-reads config from storage and reloads it, e.g. after an out-of-band change the watches
+// missed. responds with the summary of the reload once it is applied. clients authenticate with the
+// X-Sqoop-Admin-Token header, and reloads are rejected with 429 within 10s of the last one
+func (el *EventLoop) reload(w http.ResponseWriter, r *http.Request) {
+	el.reloadMu.Lock()
+	wait := minForcedReloadInterval - time.Since(el.lastReload)
+	if wait > 0 {
+		el.reloadMu.Unlock()
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", wait.Seconds()+0.5))
+		http.Error(w, "config was reloaded too recently", http.StatusTooManyRequests)
+		return
+	}
+	el.lastReload = time.Now()
+	el.reloadMu.Unlock()
+
+	results := make(chan reloadResult, 1)
+	select {
+	case el.reloads <- results:
+	case <-r.Context().Done():
+		return
+	}
+	var result reloadResult
+	select {
+	case result = <-results:
+	case <-r.Context().Done():
+		// the reload still completes
+		return
+	}
+	if result.summary == nil {
+		http.Error(w, fmt.Sprintf("reload failed: %v", result.err), http.StatusInternalServerError)
+		return
+	}
+	res := reloadResponse{
+		Accepted:             result.summary.Accepted,
+		Rejected:             result.summary.Rejected,
+		RejectedSchemas:      result.summary.RejectedSchemas,
+		RejectedResolverMaps: result.summary.RejectedResolverMaps,
+		ErrorCategories:      result.summary.ErrorCategories,
+	}
+	if result.err != nil {
+		res.Error = result.err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"goVersion"`
+}
+
+type runtimeInfo struct {
+	Build              buildInfo `json:"build"`
+	Storage            string    `json:"storage"`
+	Started            time.Time `json:"started"`
+	Uptime             string    `json:"uptime"`
+	SchemasLoaded      int       `json:"schemasLoaded"`
+	ResolverMapsLoaded int       `json:"resolverMapsLoaded"`
+	// the options Sqoop was started with, with credentials redacted
+	Options map[string]interface{} `json:"options"`
+}
+
+// options whose names contain any of these are redacted from GET /info, at any depth.
+// webhook urls are redacted since they commonly carry credentials
+var redactedOptions = []string{"token", "password", "secret", "apikey", "webhook"}
+
+// GET /info reports the build of Sqoop and the config it is running with, to confirm what is actually
+// deployed, e.g. during an incident
+func (el *EventLoop) info(w http.ResponseWriter, r *http.Request) {
+	opts, err := redactOptions(el.opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(runtimeInfo{
+		Build: buildInfo{
+			Version:   version.Version,
+			Commit:    version.Commit,
+			GoVersion: runtime.Version(),
+		},
+		Storage:            el.storageType,
+		Started:            el.started,
+		Uptime:             time.Since(el.started).Round(time.Second).String(),
+		SchemasLoaded:      int(atomic.LoadInt32(&el.schemasLoaded)),
+		ResolverMapsLoaded: int(atomic.LoadInt32(&el.resolverMapsLoaded)),
+		Options:            opts,
+	})
+}
+
+func redactOptions(opts bootstrap.Options) (map[string]interface{}, error) {
+	// sinks are implementations, not options
+	opts.ReportSinks = nil
+	jsn, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(jsn, &out); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling options")
+	}
+	redact(out)
+	return out, nil
+}
+
+// redact replaces the values of string options with secret names, leaving unset options empty.
+// credentials are stripped from the urls of other options, e.g. of etcd endpoints
+func redact(opts map[string]interface{}) {
+	for name, val := range opts {
+		switch val := val.(type) {
+		case map[string]interface{}:
+			redact(val)
+		case string:
+			if val != "" && isRedacted(name) {
+				opts[name] = "<redacted>"
+			} else {
+				opts[name] = stripUserinfo(val)
+			}
+		case []interface{}:
+			if len(val) > 0 && isRedacted(name) {
+				opts[name] = "<redacted>"
+				continue
+			}
+			for i, item := range val {
+				if item, ok := item.(string); ok {
+					val[i] = stripUserinfo(item)
+				}
+			}
+		}
+	}
+}
+
+// stripUserinfo removes the username and password from values which are urls
+func stripUserinfo(val string) string {
+	u, err := url.Parse(val)
+	if err != nil || u.User == nil {
+		return val
+	}
+	u.User = nil
+	return u.String()
+}
+
+func isRedacted(name string) bool {
+	name = strings.ToLower(name)
+	for _, redacted := range redactedOptions {
+		if strings.Contains(name, redacted) {
+			return true
+		}
+	}
+	return false
+}
+
+func marshalResolverMap(resolverMap *v1.ResolverMap, format string) ([]byte, error) {
+	exported := proto.Clone(resolverMap).(*v1.ResolverMap)
+	exported.Status = nil
+	if exported.Metadata != nil {
+		exported.Metadata.ResourceVersion = ""
+	}
+	jsn, err := protoutil.Marshal(exported)
+	if err != nil {
+		return nil, errors.Wrapf(err, "marshalling resolver map %v", resolverMap.Name)
+	}
+	if format == "json" {
+		return jsn, nil
+	}
+	return yaml.JSONToYAML(jsn)
+}
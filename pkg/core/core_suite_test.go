@@ -51,7 +51,9 @@ var (
 	glooInstance  *localhelpers.GlooInstance
 )
 
-var _ = BeforeEach(func() {
+// startInstances starts envoy, gloo and the starwars server for an end to end test. unit tests of the
+// package don't need them
+func startInstances() {
 	var err error
 	envoyInstance, err = envoyFactory.NewEnvoyInstance()
 	Expect(err).NotTo(HaveOccurred())
@@ -63,9 +65,9 @@ var _ = BeforeEach(func() {
 			log.Printf("starwars server error: %v", err.Error())
 		}
 	}()
-})
+}
 
-var _ = AfterEach(func() {
+func stopInstances() {
 	if envoyInstance != nil {
 		envoyInstance.Clean()
 	}
@@ -73,4 +75,4 @@ var _ = AfterEach(func() {
 		glooInstance.Clean()
 	}
 	starWarsRest.Close()
-})
+}
@@ -25,6 +25,8 @@ import (
 var sqoopPort int
 
 var _ = Describe("Core", func() {
+	BeforeEach(startInstances)
+	AfterEach(stopInstances)
 	It("does the happy path", func() {
 		rand.Seed(time.Now().Unix())
 		sqoopPort = 9090
@@ -1,47 +1,197 @@
 package core
 
 import (
-	"fmt"
+	"bytes"
+	"crypto/tls"
+	"expvar"
 	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	gloobootstrap "github.com/solo-io/gloo/pkg/bootstrap"
 	"github.com/solo-io/gloo/pkg/bootstrap/configstorage"
+	"github.com/solo-io/gloo/pkg/bootstrap/secretstorage"
 	"github.com/solo-io/gloo/pkg/log"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	"github.com/solo-io/sqoop/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/client"
 	"github.com/solo-io/sqoop/pkg/configwatcher"
+	"github.com/solo-io/sqoop/pkg/debuglog"
 	"github.com/solo-io/sqoop/pkg/exec"
 	"github.com/solo-io/sqoop/pkg/graphql"
 	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/registry"
 	"github.com/solo-io/sqoop/pkg/reporter"
 	"github.com/solo-io/sqoop/pkg/resolvers"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
+	glooresolvers "github.com/solo-io/sqoop/pkg/resolvers/gloo"
+	"github.com/solo-io/sqoop/pkg/secrets"
 	"github.com/solo-io/sqoop/pkg/storage"
 	"github.com/solo-io/sqoop/pkg/util"
-	"github.com/vektah/gqlgen/neelance/schema"
+	"github.com/solo-io/sqoop/pkg/version"
 )
 
+// number of schemas currently served from the last accepted version of their config
+var staleEndpoints = expvar.NewInt("sqoop_stale_endpoints")
+
 type EventLoop struct {
-	cfgWatcher configwatcher.Interface
-	operator   *operator.GlooOperator
-	router     *graphql.Router
-	sqoop       storage.Interface
-	reporter   reporter.Interface
-	proxyAddr  string
-	bindAddr   string
+	cfgWatcher   configwatcher.Interface
+	operator     *operator.GlooOperator
+	router       *graphql.Router
+	routerOpts   graphql.Options
+	sqoop        storage.Interface
+	reporter     reporter.Interface
+	proxyAddr    string
+	bindAddr     string
+	execOpts     exec.Options
+	warmUp       bootstrap.WarmUpOptions
+	tlsConfig    *tls.Config
+	listener     bootstrap.ListenerOptions
+	resolverOpts resolvers.Options
+	// when to generate skeleton resolver maps
+	resolverMapOpts bootstrap.ResolverMapOptions
+	// names the resolver maps generated for schemas which don't name one
+	resolverMapNamer *template.Template
+	publisher        *registry.Publisher
+	publishMu        sync.Mutex
+	// counts the reloads whose schemas were published, so publishes superseded by a newer reload are dropped
+	publishGen uint64
+	secrets    *secrets.Store
+	// how often to re-read secrets
+	secretRefresh time.Duration
+	// the last endpoint successfully built for each schema, by the path it is served at
+	endpoints map[string]*builtEndpoint
+	// response envelopes schemas may select by name
+	envelopes map[string]graphql.Envelope
+	// how long the config watcher may go without a config or heartbeat before it is considered stalled
+	watcherStaleness time.Duration
+	// retries writing config to gloo after a failure
+	glooRetry *glooRetry
+	// the reports of the last update, written again once a retry writes its config to gloo
+	reports []reporter.ConfigObjectReport
+	// admin endpoints are disabled if empty
+	adminBindAddr string
+	// authenticators external auth middleware may select by name
+	authenticators map[string]graphql.Authenticator
+	shutdown       bootstrap.ShutdownOptions
+	// set once a config is applied without errors, cleared when shutdown starts. accessed atomically
+	ready int32
+	// add the _sqoop health field to the query type of every schema
+	healthField bool
+	// ignore unknown fields of input objects in the variables of every schema
+	ignoreUnknownInputFields bool
+	started                  time.Time
+	// the number of schemas served from the last config. accessed atomically
+	schemasLoaded int32
+	// the number of resolver maps resolving the schemas served from the last config. accessed atomically
+	resolverMapsLoaded int32
+	// the options Sqoop was started with, and the storage backend they selected, reported on the admin listener
+	opts        bootstrap.Options
+	storageType string
+	// admin endpoints which change the state of Sqoop are not served without a token
+	adminToken string
+	// forced reloads requested on the admin listener, run by the event loop
+	reloads chan chan reloadResult
+	// when the last forced reload was accepted, to rate limit them
+	reloadMu   sync.Mutex
+	lastReload time.Time
+}
+
+// an endpoint and the config it was built from
+type builtEndpoint struct {
+	schema      *v1.Schema
+	resolverMap *v1.ResolverMap
+	endpoint    *graphql.Endpoint
+	routePrefix string
+}
+
+// SetupOption customizes the event loop with extensions that can't be configured with flags
+type SetupOption func(el *EventLoop)
+
+// WithDirectiveHandler registers a handler for the schema directive with the given name (without the @).
+// The handler is applied to the resolved value of every field annotated with the directive
+func WithDirectiveHandler(name string, handler exec.DirectiveHandler) SetupOption {
+	return func(el *EventLoop) {
+		if el.execOpts.Directives == nil {
+			el.execOpts.Directives = make(exec.DirectiveHandlers)
+		}
+		el.execOpts.Directives[name] = handler
+	}
+}
+
+// WithResponseEnvelope registers an envelope which schemas can select by name to wrap their responses
+func WithResponseEnvelope(name string, envelope graphql.Envelope) SetupOption {
+	return func(el *EventLoop) {
+		el.envelopes[name] = envelope
+	}
+}
+
+// WithAuthenticator registers an authenticator which the external auth middleware of schemas can select by name
+func WithAuthenticator(name string, authenticator graphql.Authenticator) SetupOption {
+	return func(el *EventLoop) {
+		if el.authenticators == nil {
+			el.authenticators = make(map[string]graphql.Authenticator)
+		}
+		el.authenticators[name] = authenticator
+	}
+}
+
+// WithFeatureFlags sets the provider of the feature flags which gate resolvers,
+// replacing the flags enabled with --sqoop.feature-flags
+func WithFeatureFlags(provider resolvers.FlagProvider) SetupOption {
+	return func(el *EventLoop) {
+		el.resolverOpts.Flags = provider
+	}
+}
+
+// WithTraceExporter sends the traces of sampled operations to exporter, instead of keeping the most recent
+// traces for the debug endpoints
+func WithTraceExporter(exporter graphql.TraceExporter) SetupOption {
+	return func(el *EventLoop) {
+		el.routerOpts.Tracing.Exporter = exporter
+	}
 }
 
-func Setup(opts bootstrap.Options) (*EventLoop, error) {
+// WithOperationNamer sets how operations are named in metrics
+func WithOperationNamer(namer graphql.OperationNamer) SetupOption {
+	return func(el *EventLoop) {
+		el.routerOpts.Metrics.OperationNamer = namer
+	}
+}
+
+// WithValidationRule registers a rule which every query must pass, in addition to the standard validation
+func WithValidationRule(name string, rule graphql.ValidationRule) SetupOption {
+	return func(el *EventLoop) {
+		if el.routerOpts.ValidationRules == nil {
+			el.routerOpts.ValidationRules = make(graphql.ValidationRules)
+		}
+		el.routerOpts.ValidationRules[name] = rule
+	}
+}
+
+func Setup(opts bootstrap.Options, setupOpts ...SetupOption) (*EventLoop, error) {
 	gloo, err := configstorage.Bootstrap(opts.Options)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating gloo client")
 	}
-	sqoop, err := bootstrap.Bootstrap(opts.Options)
+	sqoop, err := bootstrap.BootstrapStorage(opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating sqoop client")
 	}
-	switch opts.ConfigStorageOptions.Type {
+	storageType := opts.ConfigStorageOptions.Type
+	if opts.StorageType != "" {
+		storageType = opts.StorageType
+	}
+	switch storageType {
+	case bootstrap.WatcherTypeEtcd:
+		log.Printf("Sqoop storage options: endpoints=%v root=%v", opts.Etcd.Endpoints, opts.Etcd.RootPath)
 	case gloobootstrap.WatcherTypeFile:
 		log.Printf("Sqoop storage options: %v", opts.FileOptions)
 	case gloobootstrap.WatcherTypeConsul:
@@ -49,28 +199,189 @@ func Setup(opts bootstrap.Options) (*EventLoop, error) {
 	case gloobootstrap.WatcherTypeKube:
 		log.Printf("Sqoop storage options: %v", opts.KubeOptions)
 	}
+	if opts.Failover.Type != "" {
+		log.Printf("Sqoop failover storage: %v", opts.Failover.Type)
+	}
 	if err := gloo.V1().Register(); err != nil {
 		return nil, errors.Wrap(err, "registering gloo client")
 	}
 	if err := sqoop.V1().Register(); err != nil {
 		return nil, errors.Wrap(err, "registering sqoop storage client")
 	}
-	cfgWatcher, err := configwatcher.NewConfigWatcher(sqoop)
+	// heartbeat often enough that a single missed heartbeat doesn't look like a stall
+	cfgWatcher, err := configwatcher.NewConfigWatcher(sqoop, opts.ConfigWatcherStaleness/4)
 	if err != nil {
 		return nil, errors.Wrap(err, "starting watch for Sqoop config")
 	}
+	if opts.Debug.Enabled && opts.Debug.Token == "" {
+		return nil, errors.New("a debug token is required to enable debug endpoints")
+	}
+	if opts.Debug.Enabled && (opts.AdminBindAddr == "" || opts.AdminToken == "") {
+		return nil, errors.New("debug endpoints are served on the admin listener, they require an admin bind address and token")
+	}
+	resolverMapOpts, err := resolverMapOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Listener.WriteTimeout > 0 && opts.OperationTimeout >= opts.Listener.WriteTimeout {
+		return nil, errors.Errorf("the listener write timeout (%v) must be longer than the operation timeout (%v)",
+			opts.Listener.WriteTimeout, opts.OperationTimeout)
+	}
+	if opts.Listener.WriteTimeout > 0 && opts.MaxOperationTimeout >= opts.Listener.WriteTimeout {
+		return nil, errors.Errorf("the listener write timeout (%v) must be longer than the max operation timeout (%v)",
+			opts.Listener.WriteTimeout, opts.MaxOperationTimeout)
+	}
+	if err := graphql.StatusCodePolicy(opts.StatusCodes).Validate(); err != nil {
+		return nil, err
+	}
+	debuglog.SetTargets(opts.DebugLogging)
+	var tlsConfig *tls.Config
+	if opts.TLS.CertFile != "" || opts.TLS.KeyFile != "" {
+		tlsConfig, err = newTLSConfig(opts.TLS)
+		if err != nil {
+			return nil, errors.Wrap(err, "configuring TLS")
+		}
+	}
 	op := operator.NewGlooOperator(gloo, opts.VirtualServiceName, opts.RoleName)
-	router := graphql.NewRouter()
-	rep := reporter.NewReporter(sqoop)
-	return &EventLoop{
+	sinks := opts.ReportSinks
+	for _, url := range opts.ReportWebhooks {
+		sinks = append(sinks, reporter.NewWebhookSink(url))
+	}
+	rep := reporter.NewMultiReporter(reporter.NewReporter(sqoop), sinks...)
+	var secretStore *secrets.Store
+	if opts.SecretStorageOptions.Type != "" {
+		secretStorage, err := secretstorage.Bootstrap(opts.Options)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating secret storage client")
+		}
+		secretStore = secrets.NewStore(secrets.NewGlooSource(secretStorage))
+	}
+	resolverMapNamer, err := newResolverMapNamer(opts.ResolverMaps.NamePattern)
+	if err != nil {
+		return nil, err
+	}
+	var errorCodes map[string]string
+	if len(opts.ErrorCodes) > 0 {
+		if errorCodes, err = resolvers.ParseErrorCodes(opts.ErrorCodes); err != nil {
+			return nil, err
+		}
+	}
+	var publisher *registry.Publisher
+	if opts.Registry.URL != "" {
+		publisher = registry.NewPublisher(opts.Registry.URL, opts.Registry.APIKey, opts.Registry.Variant)
+	}
+	el := &EventLoop{
 		cfgWatcher: cfgWatcher,
 		operator:   op,
-		router:     router,
-		sqoop:       sqoop,
+		sqoop:      sqoop,
 		reporter:   rep,
 		proxyAddr:  opts.ProxyAddr,
 		bindAddr:   opts.BindAddr,
-	}, nil
+		execOpts: exec.Options{
+			StrictOutput:        opts.StrictOutput,
+			AllOrNothing:        opts.AllOrNothing,
+			OperationTimeout:    opts.OperationTimeout,
+			MaxOperationTimeout: opts.MaxOperationTimeout,
+			PlanCacheSize:       opts.PlanCacheSize,
+			MaxResolutions:      opts.MaxResolutions,
+			MaxConcurrentItems:  opts.MaxConcurrentItems,
+			DedupUpstreamCalls:  opts.DedupUpstreamCalls,
+		},
+		warmUp:    opts.WarmUp,
+		tlsConfig: tlsConfig,
+		listener:  opts.Listener,
+		resolverOpts: resolvers.Options{
+			MockAll: opts.MockResolvers,
+			Secrets: secretStore,
+			Egress: &egress.Policy{
+				AllowedHosts:         egress.NewHostList(opts.Egress.AllowedHosts),
+				AllowedSchemes:       opts.Egress.AllowedSchemes,
+				AllowPrivateNetworks: opts.Egress.AllowPrivateNetworks,
+			},
+			RecordSizes: opts.RecordResponseSizes,
+			Flags:       resolvers.NewStaticFlags(opts.FeatureFlags),
+			PageSizes: resolvers.PageSizes{
+				Default: opts.PageSizes.Default,
+				Max:     opts.PageSizes.Max,
+			},
+			BodyLimits: exec.BodyLimits{
+				MaxRequestBytes:  opts.UpstreamBodyLimits.MaxRequestBytes,
+				MaxResponseBytes: opts.UpstreamBodyLimits.MaxResponseBytes,
+			},
+			ErrorCodes: errorCodes,
+		},
+		resolverMapOpts:  resolverMapOpts,
+		resolverMapNamer: resolverMapNamer,
+		publisher:        publisher,
+		secrets:          secretStore,
+		secretRefresh:    opts.SecretRefreshInterval,
+		endpoints:        make(map[string]*builtEndpoint),
+		envelopes: map[string]graphql.Envelope{
+			"ok-payload": graphql.OKPayloadEnvelope,
+		},
+		watcherStaleness:         opts.ConfigWatcherStaleness,
+		glooRetry:                newGlooRetry(opts.GlooRetry),
+		adminBindAddr:            opts.AdminBindAddr,
+		shutdown:                 opts.Shutdown,
+		healthField:              opts.HealthField,
+		ignoreUnknownInputFields: opts.IgnoreUnknownInputFields,
+		started:                  time.Now(),
+		opts:                     opts,
+		storageType:              storageType,
+		adminToken:               opts.AdminToken,
+		reloads:                  make(chan chan reloadResult),
+		routerOpts: graphql.Options{
+			JSON: graphql.JSONOptions{
+				Indent:            opts.JSON.Indent,
+				DisableHTMLEscape: opts.JSON.DisableHTMLEscape,
+			},
+			Compression: graphql.CompressionOptions{
+				MinSize: opts.Compression.MinSize,
+				Level:   opts.Compression.Level,
+			},
+			QueryCacheSize:   opts.QueryCacheSize,
+			MaxAliases:       opts.MaxAliases,
+			MaxFragmentDepth: opts.MaxFragmentDepth,
+			Metrics: graphql.MetricsOptions{
+				MaxOperations: opts.MaxOperationLabels,
+				RecordSizes:   opts.RecordResponseSizes,
+			},
+			Debug: graphql.DebugOptions{
+				Enabled: opts.Debug.Enabled,
+				Token:   opts.Debug.Token,
+			},
+			Tracing: graphql.TracingOptions{
+				SampleRate:   opts.Tracing.SampleRate,
+				MaxPerSecond: opts.Tracing.MaxPerSecond,
+			},
+			AllowedCookies: opts.AllowedCookies,
+			Concurrency: graphql.ConcurrencyOptions{
+				MaxRequests:      opts.MaxInFlightRequests,
+				MaxSubscriptions: opts.MaxInFlightSubscriptions,
+			},
+			StatusCodes:           graphql.StatusCodePolicy(opts.StatusCodes),
+			IntrospectionExamples: opts.IntrospectionExamples,
+		},
+	}
+	for _, opt := range setupOpts {
+		opt(el)
+	}
+	if err := el.routerOpts.Compression.Validate(); err != nil {
+		return nil, err
+	}
+	el.execOpts.Health = el.health
+	el.router = graphql.NewRouter(el.routerOpts)
+	return el, nil
+}
+
+// health is the state reported by the _sqoop field
+func (el *EventLoop) health() exec.Health {
+	return exec.Health{
+		Version:          version.Version,
+		Uptime:           time.Since(el.started),
+		SchemasLoaded:    int(atomic.LoadInt32(&el.schemasLoaded)),
+		UpstreamsHealthy: glooConfigPending.Value() == 0,
+	}
 }
 
 func sendErr(errs chan error, err error) {
@@ -81,27 +392,79 @@ func sendErr(errs chan error, err error) {
 
 func (el *EventLoop) Run(stop <-chan struct{}) {
 	go el.cfgWatcher.Run(stop)
+	if el.secrets != nil && el.secretRefresh > 0 {
+		go el.secrets.Run(el.secretRefresh, stop)
+	}
+	servers := []*http.Server{el.newServer()}
 	go func() {
 		log.Printf("Sqoop server started and listening on %v", el.bindAddr)
-		log.Fatalf("failed to start server: %v", http.ListenAndServe(el.bindAddr, el.router))
+		if err := el.serve(servers[0]); err != http.ErrServerClosed {
+			log.Fatalf("failed to start server: %v", err)
+		}
 	}()
+	if el.adminBindAddr != "" {
+		admin := el.newAdminServer()
+		servers = append(servers, admin)
+		go func() {
+			log.Printf("Sqoop admin endpoints listening on %v", el.adminBindAddr)
+			if err := admin.ListenAndServe(); err != http.ErrServerClosed {
+				log.Fatalf("failed to start admin server: %v", err)
+			}
+		}()
+	}
 	errs := make(chan error)
+	watchdog := newWatchdog(el.watcherStaleness)
+	defer watchdog.stop()
+	defer el.glooRetry.stop()
 	for {
 		select {
 		case cfg := <-el.cfgWatcher.Config():
-			if err := el.update(cfg); err != nil {
+			watchdog.seen(time.Now())
+			if _, err := el.update(cfg); err != nil {
 				sendErr(errs, errors.Wrap(err, "update failed"))
 			}
+		case result := <-el.reloads:
+			result <- el.forceReload()
 		case err := <-el.cfgWatcher.Error():
 			sendErr(errs, errors.Wrap(err, "config watcher error"))
+		case t := <-el.cfgWatcher.Heartbeat():
+			watchdog.seen(t)
+		case t := <-watchdog.ticks():
+			watchdog.check(t)
+		case <-el.glooRetry.retries():
+			if err := el.retryGloo(); err != nil {
+				sendErr(errs, err)
+			}
 		case err := <-errs:
 			log.Warnf("error in event loop: %v", err)
 		case <-stop:
+			el.drain(servers)
 			return
 		}
 	}
 }
 
+func (el *EventLoop) newServer() *http.Server {
+	// subscriptions are not supported, so there are no long-lived responses exempt from the write timeout
+	return &http.Server{
+		Addr:              el.bindAddr,
+		Handler:           el.withReadiness(el.router),
+		TLSConfig:         el.tlsConfig,
+		ReadTimeout:       el.listener.ReadTimeout,
+		ReadHeaderTimeout: el.listener.ReadHeaderTimeout,
+		WriteTimeout:      el.listener.WriteTimeout,
+		IdleTimeout:       el.listener.IdleTimeout,
+	}
+}
+
+func (el *EventLoop) serve(server *http.Server) error {
+	if el.tlsConfig != nil {
+		// certificates are provided by the tls config
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
+
 func configErrs(reports []reporter.ConfigObjectReport) error {
 	var errs error
 	for _, report := range reports {
@@ -112,17 +475,122 @@ func configErrs(reports []reporter.ConfigObjectReport) error {
 	return errs
 }
 
-func (el *EventLoop) update(cfg *v1.Config) error {
+func (el *EventLoop) update(cfg *v1.Config) (reporter.Summary, error) {
+	// routes which could not be written for the previous config are superseded
+	el.operator.DiscardRoutes()
 	endpoints, reports := el.createGraphqlEndpoints(cfg)
 	el.router.UpdateEndpoints(endpoints...)
+	schemas := make(map[string]bool)
+	for _, endpoint := range endpoints {
+		schemas[endpoint.SchemaName] = true
+	}
+	atomic.StoreInt32(&el.schemasLoaded, int32(len(schemas)))
+	resolverMaps := make(map[string]bool)
+	for _, built := range el.endpoints {
+		resolverMaps[built.resolverMap.GetName()] = true
+	}
+	atomic.StoreInt32(&el.resolverMapsLoaded, int32(len(resolverMaps)))
+	if el.publisher != nil {
+		el.publishSchemas(cfg, endpoints)
+	}
 	errs := configErrs(reports)
+	summary := reporter.Summarize(reports)
+	summary.Publish()
+	log.Printf("config reloaded: %v", summary)
+	routePaths := el.operator.RoutePaths()
+	// endpoints are already serving, failing to configure gloo only delays changes to resolver routes
+	el.reports = reports
+	if err := el.configureGloo(); err != nil {
+		errs = multierror.Append(errs, err)
+		reports = withGlooErr(reports, err)
+	}
 	if err := el.reporter.WriteReports(reports); err != nil {
 		errs = multierror.Append(errs, err)
 	}
+	// failing to warm up connections should not fail the update, nor should it wait for them
+	if el.warmUp.Enabled {
+		go el.warmUpConnections(routePaths)
+	}
+	if errs == nil {
+		el.markReady()
+	}
+	return summary, errs
+}
+
+// withGlooErr adds a failure to write config to gloo to the reports of the schemas, which keep serving
+// with the resolver routes of the last config written to gloo
+func withGlooErr(reports []reporter.ConfigObjectReport, err error) []reporter.ConfigObjectReport {
+	withErr := make([]reporter.ConfigObjectReport, len(reports))
+	for i, report := range reports {
+		withErr[i] = report
+		if _, ok := report.CfgObject.(*v1.Schema); ok {
+			withErr[i].Err = multierror.Append(report.Err, errors.Wrap(err, "resolver routes are not up to date"))
+		}
+	}
+	return withErr
+}
+
+// configureGloo writes the pending resolver routes to gloo, scheduling a retry if it fails.
+// the routes are kept until they are written or superseded by the next config
+func (el *EventLoop) configureGloo() error {
 	if err := el.operator.ConfigureGloo(); err != nil {
-		errs = multierror.Append(errs, err)
+		el.glooRetry.failed(err)
+		return errors.Wrap(err, "configuring gloo")
+	}
+	el.glooRetry.succeeded()
+	return nil
+}
+
+// retryGloo retries writing the config to gloo, clearing the failure from the reports once it is written
+func (el *EventLoop) retryGloo() error {
+	if err := el.configureGloo(); err != nil {
+		return err
+	}
+	if err := el.reporter.WriteReports(el.reports); err != nil {
+		return err
+	}
+	if configErrs(el.reports) == nil {
+		el.markReady()
+	}
+	return nil
+}
+
+// publishSchemas publishes the served schemas to the registry in the background, so a slow registry doesn't
+// stall the event loop. publishing failures are only logged; the registry catches up on the next reload.
+// publishes run one at a time, and the schemas of a reload are dropped once a newer reload has been queued,
+// so an older reload never overwrites a newer one in the registry
+func (el *EventLoop) publishSchemas(cfg *v1.Config, endpoints []*graphql.Endpoint) {
+	served := make(map[string]bool)
+	for _, endpoint := range endpoints {
+		served[endpoint.SchemaName] = true
+	}
+	var schemas []*v1.Schema
+	for _, schema := range cfg.Schemas {
+		if served[schema.Name] {
+			schemas = append(schemas, schema)
+		}
+	}
+	gen := atomic.AddUint64(&el.publishGen, 1)
+	go func() {
+		// publish the schemas of one update at a time
+		el.publishMu.Lock()
+		defer el.publishMu.Unlock()
+		for _, schema := range schemas {
+			if atomic.LoadUint64(&el.publishGen) != gen {
+				log.Debugf("dropping superseded publish of schema %v", schema.Name)
+				return
+			}
+			if err := el.publisher.Publish(schema.Name, schema.InlineSchema); err != nil {
+				log.Warnf("publishing schema to registry: %v", err)
+			}
+		}
+	}()
+}
+
+func (el *EventLoop) warmUpConnections(routePaths []string) {
+	if err := glooresolvers.WarmUp(el.proxyAddr, routePaths, el.warmUp.Concurrency, el.warmUp.Timeout); err != nil {
+		log.Warnf("warming up connections to %v: %v", el.proxyAddr, err)
 	}
-	return errs
 }
 
 func (el *EventLoop) createGraphqlEndpoints(cfg *v1.Config) ([]*graphql.Endpoint, []reporter.ConfigObjectReport) {
@@ -132,29 +600,74 @@ func (el *EventLoop) createGraphqlEndpoints(cfg *v1.Config) ([]*graphql.Endpoint
 		resolverMapReports []reporter.ConfigObjectReport
 	)
 	resolverMapErrs := make(map[*v1.ResolverMap]error)
+	// detect schemas whose name, alias, and version collide
+	servedPaths := make(map[string]string)
+	// number of schemas served from the last accepted config
+	var stale int64
+	// paths of the schemas and bindings in the config
+	configured := make(map[string]bool)
 
 	for _, schema := range cfg.Schemas {
 		schemaReport := reporter.ConfigObjectReport{
 			CfgObject: schema,
 		}
-		// empty map means we should generate a skeleton and update the schema to point to it
-		ep, schemaErr, resolverMapErr := el.handleSchema(schema, cfg.ResolverMaps)
-		if schemaErr != nil {
-			resolverMapErr.err = multierror.Append(resolverMapErr.err, errors.Wrap(schemaErr, "schema was not accepted"))
+		bindings, err := schemaBindings(schema)
+		if err != nil {
+			schemaReport.Err = errors.Wrap(err, "invalid bindings")
+			schemaReports = append(schemaReports, schemaReport)
+			continue
 		}
-		if resolverMapErr.resolverMap != nil {
-			err := resolverMapErrs[resolverMapErr.resolverMap]
-			if resolverMapErr.err != nil {
-				err = multierror.Append(resolverMapErrs[resolverMapErr.resolverMap], resolverMapErr.err)
+		var schemaErrs error
+		for _, b := range bindings {
+			key := endpointPath(b.schema)
+			configured[key] = true
+			// empty map means we should generate a skeleton and update the schema to point to it
+			ep, schemaErr, resolverMapErr := el.handleSchema(key, b.schema, b.routePrefix, cfg.ResolverMaps)
+			if schemaErr != nil {
+				resolverMapErr.err = multierror.Append(resolverMapErr.err, errors.Wrap(schemaErr, "schema was not accepted"))
+			}
+			if built, ok := el.endpoints[key]; ok && ep == nil {
+				// keep serving the last version of the schema which was accepted
+				log.Warnf("schema %v could not be rebuilt for %v, serving the last accepted version", schema.Name, key)
+				schemaErr = multierror.Append(schemaErr, errors.New("serving the last accepted version of this schema"))
+				el.operator.ApplyResolversWithPrefix(built.routePrefix, built.resolverMap)
+				ep = built.endpoint
+				stale++
+			}
+			if resolverMapErr.resolverMap != nil {
+				err := resolverMapErrs[resolverMapErr.resolverMap]
+				if resolverMapErr.err != nil {
+					err = multierror.Append(resolverMapErrs[resolverMapErr.resolverMap], resolverMapErr.err)
+				}
+				resolverMapErrs[resolverMapErr.resolverMap] = err
+			}
+			if ep != nil {
+				if conflict, ok := servedPaths[ep.RootPath]; ok {
+					schemaErr = multierror.Append(schemaErr, errors.Errorf("path %v is already served by schema %v", ep.RootPath, conflict))
+					ep = nil
+				} else {
+					servedPaths[ep.RootPath] = schema.Name
+				}
+			}
+			if schemaErr != nil {
+				if len(schema.Bindings) > 0 {
+					schemaErr = errors.Wrapf(schemaErr, "binding %v", key)
+				}
+				schemaErrs = multierror.Append(schemaErrs, schemaErr)
+			}
+			if ep != nil {
+				endpoints = append(endpoints, ep)
 			}
-			resolverMapErrs[resolverMapErr.resolverMap] = err
 		}
-		schemaReport.Err = schemaErr
+		schemaReport.Err = schemaErrs
 		schemaReports = append(schemaReports, schemaReport)
-		if ep == nil {
-			continue
+	}
+	staleEndpoints.Set(stale)
+	// forget endpoints for schemas and bindings which were removed
+	for key := range el.endpoints {
+		if !configured[key] {
+			delete(el.endpoints, key)
 		}
-		endpoints = append(endpoints, ep)
 	}
 	for resolverMap, err := range resolverMapErrs {
 		resolverMapReports = append(resolverMapReports, reporter.ConfigObjectReport{
@@ -170,27 +683,64 @@ type resolverMapError struct {
 	err         error
 }
 
-func (el *EventLoop) handleSchema(schema *v1.Schema, resolvers []*v1.ResolverMap) (*graphql.Endpoint, error, resolverMapError) {
+// the endpoint built for the schema is remembered under key
+func (el *EventLoop) handleSchema(key string, schema *v1.Schema, routePrefix string, resolvers []*v1.ResolverMap) (*graphql.Endpoint, error, resolverMapError) {
 	if schema.ResolverMap == "" {
-		return nil, el.createEmptyResolverMap(schema), resolverMapError{}
+		if el.resolverMapOpts.DisableGenerateForUnset {
+			return nil, errors.Errorf("schema %v does not specify a resolver map, and generating resolver maps is disabled", schema.Name), resolverMapError{}
+		}
+		return nil, el.createEmptyResolverMap(schema, resolvers), resolverMapError{}
 	}
 	for _, resolverMap := range resolvers {
 		if resolverMap.Name == schema.ResolverMap {
-			ep, schemaErr, resolverErr := el.createGraphqlEndpoint(schema, resolverMap)
+			if built, ok := el.endpoints[key]; ok && specEqual(built.schema, schema) && specEqual(built.resolverMap, resolverMap) {
+				// nothing changed, keep serving the existing executable schema. upstreams may have been removed
+				// from gloo since it was built, which is reported without rebuilding it
+				el.operator.ApplyResolversWithPrefix(routePrefix, resolverMap)
+				return built.endpoint, nil, resolverMapError{resolverMap: resolverMap, err: el.validateResolvers(resolverMap)}
+			}
+			ep, schemaErr, resolverErr := el.createGraphqlEndpoint(schema, resolverMap, routePrefix)
+			if ep != nil && schemaErr == nil && resolverErr == nil {
+				el.endpoints[key] = &builtEndpoint{schema: schema, resolverMap: resolverMap, endpoint: ep, routePrefix: routePrefix}
+			}
 			return ep, schemaErr, resolverMapError{resolverMap: resolverMap, err: resolverErr}
 		}
 	}
+	if el.resolverMapOpts.GenerateForMissing {
+		// the schema will be served once the generated map is picked up on the next update
+		return nil, el.createResolverMap(schema, schema.ResolverMap), resolverMapError{}
+	}
 	return nil, errors.Errorf("resolver map %v for schema %v not found", schema.ResolverMap, schema.Name), resolverMapError{}
 }
 
+// resolverMapOptions returns when to generate resolver maps. read-only storage never generates them
+func resolverMapOptions(opts bootstrap.Options) (bootstrap.ResolverMapOptions, error) {
+	resolverMapOpts := opts.ResolverMaps
+	if opts.ReadOnlyStorage {
+		if resolverMapOpts.GenerateForMissing {
+			return resolverMapOpts, errors.New("generating missing resolver maps writes to storage, it can't be enabled with read-only storage")
+		}
+		resolverMapOpts.DisableGenerateForUnset = true
+	}
+	return resolverMapOpts, nil
+}
+
 // create an empty resolver map and
-func (el *EventLoop) createEmptyResolverMap(schema *v1.Schema) error {
-	resolverName := resolverMapName(schema)
-	parsedSchema, err := parseSchemaString(schema)
+func (el *EventLoop) createEmptyResolverMap(schema *v1.Schema, resolvers []*v1.ResolverMap) error {
+	resolverName, err := resolverMapName(el.resolverMapNamer, schema.Name)
 	if err != nil {
+		return err
+	}
+	// never take over a map which wasn't generated for the schema
+	for _, resolverMap := range resolvers {
+		if resolverMap.Name == resolverName {
+			return errors.Errorf("resolver map %v generated for schema %v already exists, "+
+				"set the resolver map of the schema or change the resolver map name pattern", resolverName, schema.Name)
+		}
+	}
+	if _, err := parseSchemaString(schema); err != nil {
 		return errors.Wrap(err, "failed to parse schema")
 	}
-	generatedResolvers := util.GenerateResolverMapSkeleton(resolverName, parsedSchema)
 
 	// update existing schema with the new schema name
 	// important to do this first or we may retry creating the resolver map in a race
@@ -203,37 +753,211 @@ func (el *EventLoop) createEmptyResolverMap(schema *v1.Schema) error {
 		return errors.Wrapf(err, "updating schema %v in storage", schema.Name)
 	}
 
+	return el.createResolverMap(schema, resolverName)
+}
+
+// write a skeleton resolver map for the schema to storage
+func (el *EventLoop) createResolverMap(schema *v1.Schema, resolverName string) error {
+	parsedSchema, err := parseSchemaString(schema)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse schema")
+	}
+	generatedResolvers := util.GenerateResolverMapSkeleton(resolverName, parsedSchema.Schema)
 	if _, err := el.sqoop.V1().ResolverMaps().Create(generatedResolvers); err != nil {
 		return errors.Wrapf(err, "writing resolver map %v to storage", resolverName)
 	}
 	return nil
 }
 
-func (el *EventLoop) createGraphqlEndpoint(schema *v1.Schema, resolverMap *v1.ResolverMap) (*graphql.Endpoint, error, error) {
-	resolverFactory := resolvers.NewResolverFactory(el.proxyAddr, resolverMap)
+// validateResolvers checks that the destinations of the resolvers exist in gloo. mocked resolvers are never
+// routed to gloo, so they aren't checked. if gloo can't be reached the resolver map is accepted with a warning,
+// rather than failing every endpoint while gloo is down
+func (el *EventLoop) validateResolvers(resolverMap *v1.ResolverMap) error {
+	if el.resolverOpts.MockAll {
+		return nil
+	}
+	err := el.operator.ValidateResolvers(resolverMap)
+	if operator.IsUpstreamsUnavailable(err) {
+		log.Warnf("resolver destinations of resolver map %v were not validated: %v", resolverMap.Name, err)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "invalid resolver destinations")
+	}
+	return nil
+}
+
+func (el *EventLoop) createGraphqlEndpoint(schema *v1.Schema, resolverMap *v1.ResolverMap, routePrefix string) (*graphql.Endpoint, error, error) {
 	parsedSchema, err := parseSchemaString(schema)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse schema"), nil
 	}
-	executableResolvers, err := exec.NewExecutableResolvers(parsedSchema, resolverFactory.CreateResolver)
+	if el.healthField {
+		parsedSchema.AddHealthField()
+	}
+	if el.ignoreUnknownInputFields || schema.IgnoreUnknownInputFields {
+		parsedSchema.IgnoreUnknownInputFields()
+	}
+	middleware, err := el.endpointMiddleware(schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid middleware"), nil
+	}
+	var envelope graphql.Envelope
+	if schema.ResponseEnvelope != "" {
+		envelope = el.envelopes[schema.ResponseEnvelope]
+		if envelope == nil {
+			return nil, errors.Errorf("unknown response envelope %v", schema.ResponseEnvelope), nil
+		}
+	}
+	if err := el.validateResolvers(resolverMap); err != nil {
+		return nil, nil, err
+	}
+	resolverOpts := el.resolverOpts
+	resolverOpts.RoutePrefix = routePrefix
+	// resolvers inherit the defaults declared on the map
+	resolverFactory := resolvers.NewResolverFactory(el.proxyAddr, parsedSchema.Schema, resolvers.WithDefaults(resolverMap), resolverOpts)
+	executableResolvers, err := exec.NewExecutableResolvers(parsedSchema.Schema, resolverFactory.CreateResolver)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to generate resolvers from map")
 	}
-	el.operator.ApplyResolvers(resolverMap)
-	executableSchema := exec.NewExecutableSchema(parsedSchema, executableResolvers)
+	el.operator.ApplyResolversWithPrefix(routePrefix, resolverMap)
+	execOpts := el.execOpts
+	execOpts.ListConcurrency = resolverFactory.ListConcurrency
+	execOpts.ListLimit = resolverFactory.ListLimit
+	executableSchema := exec.NewExecutableSchema(parsedSchema, executableResolvers, execOpts)
+	rootPath := endpointPath(schema)
 	return &graphql.Endpoint{
-		SchemaName: schema.Name,
-		RootPath:   "/" + schema.Name,
-		QueryPath:  "/" + schema.Name + "/query",
-		ExecSchema: executableSchema,
+		SchemaName:    schema.Name,
+		RootPath:      rootPath,
+		QueryPath:     rootPath + "/query",
+		ExecSchema:    executableSchema,
+		Version:       schema.Version,
+		Sunset:        schema.Sunset,
+		ResolverCache: resolverFactory.Cache(),
+		Middleware:    middleware,
+		Envelope:      envelope,
 	}, nil, nil
 }
 
-func parseSchemaString(sch *v1.Schema) (*schema.Schema, error) {
-	parsedSchema := schema.New()
-	return parsedSchema, parsedSchema.Parse(sch.InlineSchema)
+// versioned schemas are served at /<alias>/<version>
+func endpointPath(schema *v1.Schema) string {
+	prefix := schema.Name
+	if schema.Alias != "" {
+		prefix = schema.Alias
+	}
+	if schema.Version == "" {
+		return "/" + prefix
+	}
+	return "/" + prefix + "/" + schema.Version
+}
+
+// a schema as served at a single path
+type binding struct {
+	schema      *v1.Schema
+	routePrefix string
+}
+
+// schemaBindings returns a copy of the schema for each of its bindings, served at the binding's path
+// and resolved by its resolver map. schemas without bindings are served as they are
+func schemaBindings(schema *v1.Schema) ([]binding, error) {
+	if len(schema.Bindings) == 0 {
+		return []binding{{schema: schema}}, nil
+	}
+	var bindings []binding
+	for i, b := range schema.Bindings {
+		path := strings.Trim(b.Path, "/")
+		if path == "" || b.ResolverMap == "" {
+			return nil, errors.Errorf("binding %v must specify a path and a resolver map", i)
+		}
+		bound := proto.Clone(schema).(*v1.Schema)
+		bound.Bindings = nil
+		bound.Alias = path
+		bound.ResolverMap = b.ResolverMap
+		bindings = append(bindings, binding{schema: bound, routePrefix: endpointPath(bound)})
+	}
+	return bindings, nil
+}
+
+// parseSchemaString recovers from panics of the parser on malformed input, so a bad schema is reported as
+// rejected instead of taking down every endpoint
+func parseSchemaString(sch *v1.Schema) (parsed *exec.Schema, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Warnf("recovered from a panic parsing schema %v: %v\n%s", sch.Name, r, debug.Stack())
+			parsed, err = nil, errors.Errorf("schema parser panicked: %v", r)
+		}
+	}()
+	sdl := sch.InlineSchema
+	// the config watcher inlines the files of schema directories, unless they could not be read
+	if sch.SchemaDir != "" && sdl == "" {
+		if sdl, err = exec.ReadSchemaDir(sch.SchemaDir); err != nil {
+			return nil, err
+		}
+	}
+	return exec.ParseSchema(sdl)
+}
+
+// compare config objects ignoring their status and metadata, which change
+// whenever reports are written without affecting the served endpoint
+func specEqual(a, b proto.Message) bool {
+	a, b = proto.Clone(a), proto.Clone(b)
+	for _, msg := range []proto.Message{a, b} {
+		switch msg := msg.(type) {
+		case *v1.Schema:
+			msg.Status, msg.Metadata = nil, nil
+		case *v1.ResolverMap:
+			msg.Status, msg.Metadata = nil, nil
+		}
+	}
+	return proto.Equal(a, b)
+}
+
+// the default template of the names of generated resolver maps
+const defaultResolverMapNamePattern = "{{.SchemaName}}-resolvers"
+
+type resolverMapNameData struct {
+	SchemaName string
+}
+
+// the schema name must appear verbatim in the template, so schemas of different names can't be given the same name
+const resolverMapNameSchemaName = "{{.SchemaName}}"
+
+// newResolverMapNamer parses the template of the names of generated resolver maps. the template must contain
+// the schema name as is, so the maps of different schemas get different names, and must give valid names,
+// which is checked on a few sample schema names
+func newResolverMapNamer(pattern string) (*template.Template, error) {
+	if pattern == "" {
+		pattern = defaultResolverMapNamePattern
+	}
+	namer, err := template.New("resolver-map-name").Parse(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing resolver map name pattern")
+	}
+	if !strings.Contains(pattern, resolverMapNameSchemaName) {
+		return nil, errors.Errorf("resolver map name pattern %q must contain %v", pattern, resolverMapNameSchemaName)
+	}
+	named := make(map[string]string)
+	for _, schemaName := range []string{"a", "b", "starwars.v2"} {
+		name, err := resolverMapName(namer, schemaName)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := named[name]; ok {
+			return nil, errors.Errorf("resolver map name pattern %q names the resolver maps of schemas %v and %v alike", pattern, other, schemaName)
+		}
+		named[name] = schemaName
+	}
+	return namer, nil
 }
 
-func resolverMapName(schema *v1.Schema) string {
-	return fmt.Sprintf("%v-resolvers", schema.Name)
+func resolverMapName(namer *template.Template, schemaName string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := namer.Execute(buf, resolverMapNameData{SchemaName: schemaName}); err != nil {
+		return "", errors.Wrap(err, "executing resolver map name pattern")
+	}
+	name := buf.String()
+	if err := client.ValidateName(name); err != nil {
+		return "", errors.Wrapf(err, "resolver map name pattern gives schema %v an invalid name", schemaName)
+	}
+	return name, nil
 }
@@ -0,0 +1,513 @@
+package core
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	gloov1 "github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/file"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/graphql"
+	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/registry"
+	"github.com/solo-io/sqoop/pkg/reporter"
+	sqoopstorage "github.com/solo-io/sqoop/pkg/storage"
+	sqoopfile "github.com/solo-io/sqoop/pkg/storage/file"
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("Endpoints", func() {
+	var (
+		tmpDir string
+		gloo   storage.Interface
+		el     *EventLoop
+	)
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "sqoop-endpoints")
+		Expect(err).NotTo(HaveOccurred())
+		gloo, err = file.NewStorage(tmpDir, time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gloo.V1().Register()).To(Succeed())
+		_, err = gloo.V1().Upstreams().Create(&gloov1.Upstream{
+			Name:      "starwars-rest",
+			Type:      "static",
+			Functions: []*gloov1.Function{{Name: "GetHero"}, {Name: "GetCharacter"}, {Name: "GetCharacters"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		el = &EventLoop{
+			operator:  operator.NewGlooOperator(gloo, "sqoop-test", "sqoop-test"),
+			proxyAddr: "localhost:8080",
+			endpoints: make(map[string]*builtEndpoint),
+			envelopes: make(map[string]graphql.Envelope),
+		}
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+	config := func() *v1.Config {
+		return &v1.Config{
+			Schemas:      []*v1.Schema{test.StarWarsV1Schema()},
+			ResolverMaps: []*v1.ResolverMap{test.StarWarsResolverMap()},
+		}
+	}
+	// the error reported for each config object, by kind and name
+	reportErrs := func(reports []reporter.ConfigObjectReport) map[string]error {
+		errs := make(map[string]error)
+		for _, report := range reports {
+			switch obj := report.CfgObject.(type) {
+			case *v1.Schema:
+				errs["schema "+obj.Name] = report.Err
+			case *v1.ResolverMap:
+				errs["resolver map "+obj.Name] = report.Err
+			}
+		}
+		return errs
+	}
+
+	Context("on reload", func() {
+		It("reuses the endpoint of an unchanged schema and resolver map", func() {
+			endpoints, reports := el.createGraphqlEndpoints(config())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(reportErrs(reports)).To(Equal(map[string]error{
+				"schema starwars-schema":          nil,
+				"resolver map starwars-resolvers": nil,
+			}))
+
+			// reports written to storage change the status and metadata, but not the spec
+			cfg := config()
+			cfg.Schemas[0].Status = &gloov1.Status{State: gloov1.Status_Accepted}
+			cfg.ResolverMaps[0].Metadata = &gloov1.Metadata{ResourceVersion: "2"}
+			reused, _ := el.createGraphqlEndpoints(cfg)
+			Expect(reused).To(HaveLen(1))
+			Expect(reused[0]).To(BeIdenticalTo(endpoints[0]))
+		})
+		It("rebuilds the endpoint when the schema or resolver map changes", func() {
+			endpoints, _ := el.createGraphqlEndpoints(config())
+			Expect(endpoints).To(HaveLen(1))
+
+			cfg := config()
+			// new types would need resolvers, a comment changes the schema without them
+			cfg.Schemas[0].InlineSchema += "\n# reviewed\n"
+			rebuilt, reports := el.createGraphqlEndpoints(cfg)
+			Expect(rebuilt).To(HaveLen(1))
+			Expect(rebuilt[0]).NotTo(BeIdenticalTo(endpoints[0]))
+			Expect(reportErrs(reports)["schema starwars-schema"]).NotTo(HaveOccurred())
+
+			cfg = config()
+			resolverMap := cfg.ResolverMaps[0]
+			resolverMap.Types["Query"].Fields["hero"] = proto.Clone(resolverMap.Types["Query"].Fields["human"]).(*v1.Resolver)
+			changed, _ := el.createGraphqlEndpoints(cfg)
+			Expect(changed).To(HaveLen(1))
+			Expect(changed[0]).NotTo(BeIdenticalTo(rebuilt[0]))
+		})
+		It("reports upstreams removed from gloo since a reused endpoint was built", func() {
+			endpoints, _ := el.createGraphqlEndpoints(config())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(gloo.V1().Upstreams().Delete("starwars-rest")).To(Succeed())
+
+			reused, reports := el.createGraphqlEndpoints(config())
+			// the endpoint keeps serving, its resolvers fail until the upstream is restored
+			Expect(reused).To(HaveLen(1))
+			Expect(reused[0]).To(BeIdenticalTo(endpoints[0]))
+			err := reportErrs(reports)["resolver map starwars-resolvers"]
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("upstream starwars-rest not found"))
+		})
+	})
+	Context("with bindings", func() {
+		// the star wars schema served at /tenant-a and /tenant-b, resolved by copies of its resolver map
+		bindingsConfig := func() *v1.Config {
+			cfg := config()
+			cfg.Schemas[0].Bindings = []*v1.SchemaBinding{
+				{Path: "/tenant-a/", ResolverMap: "tenant-a-resolvers"},
+				{Path: "tenant-b", ResolverMap: "tenant-b-resolvers"},
+			}
+			for _, name := range []string{"tenant-a-resolvers", "tenant-b-resolvers"} {
+				resolverMap := test.StarWarsResolverMap()
+				resolverMap.Name = name
+				cfg.ResolverMaps = append(cfg.ResolverMaps, resolverMap)
+			}
+			return cfg
+		}
+		rootPaths := func(endpoints []*graphql.Endpoint) []string {
+			var paths []string
+			for _, ep := range endpoints {
+				paths = append(paths, ep.RootPath)
+			}
+			return paths
+		}
+		// routes the hero field of the resolver map to a function gloo doesn't have
+		breakResolverMap := func(cfg *v1.Config, name string) {
+			for _, resolverMap := range cfg.ResolverMaps {
+				if resolverMap.Name == name {
+					hero := resolverMap.Types["Query"].Fields["hero"].Resolver.(*v1.Resolver_GlooResolver)
+					hero.GlooResolver.Function.(*v1.GlooResolver_SingleFunction).SingleFunction.Function = "GetVillain"
+				}
+			}
+		}
+
+		It("requires a path and a resolver map for each binding", func() {
+			schema := test.StarWarsV1Schema()
+			schema.Bindings = []*v1.SchemaBinding{{Path: "tenant-a", ResolverMap: "tenant-a-resolvers"}, {Path: "/", ResolverMap: "tenant-b-resolvers"}}
+			_, err := schemaBindings(schema)
+			Expect(err).To(MatchError("binding 1 must specify a path and a resolver map"))
+			schema.Bindings[1] = &v1.SchemaBinding{Path: "tenant-b"}
+			_, err = schemaBindings(schema)
+			Expect(err).To(MatchError("binding 1 must specify a path and a resolver map"))
+
+			cfg := config()
+			cfg.Schemas[0].Bindings = schema.Bindings
+			endpoints, reports := el.createGraphqlEndpoints(cfg)
+			Expect(endpoints).To(BeEmpty())
+			err = reportErrs(reports)["schema starwars-schema"]
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid bindings"))
+		})
+		It("serves the schema at the path of each binding with its resolver map", func() {
+			bindings, err := schemaBindings(bindingsConfig().Schemas[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings).To(HaveLen(2))
+			Expect(bindings[0].schema.ResolverMap).To(Equal("tenant-a-resolvers"))
+			Expect(bindings[0].routePrefix).To(Equal("/tenant-a"))
+			Expect(bindings[1].schema.ResolverMap).To(Equal("tenant-b-resolvers"))
+			Expect(bindings[1].routePrefix).To(Equal("/tenant-b"))
+
+			endpoints, reports := el.createGraphqlEndpoints(bindingsConfig())
+			Expect(rootPaths(endpoints)).To(Equal([]string{"/tenant-a", "/tenant-b"}))
+			errs := reportErrs(reports)
+			Expect(errs["schema starwars-schema"]).NotTo(HaveOccurred())
+			Expect(errs["resolver map tenant-a-resolvers"]).NotTo(HaveOccurred())
+			Expect(errs["resolver map tenant-b-resolvers"]).NotTo(HaveOccurred())
+		})
+		It("requires the resolver map of each binding to exist", func() {
+			cfg := bindingsConfig()
+			cfg.Schemas[0].Bindings[1].ResolverMap = "missing-resolvers"
+			endpoints, reports := el.createGraphqlEndpoints(cfg)
+			Expect(rootPaths(endpoints)).To(Equal([]string{"/tenant-a"}))
+			err := reportErrs(reports)["schema starwars-schema"]
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("binding /tenant-b"))
+			Expect(err.Error()).To(ContainSubstring("resolver map missing-resolvers for schema starwars-schema not found"))
+		})
+		It("keeps serving the last accepted version of each binding on its own", func() {
+			endpoints, _ := el.createGraphqlEndpoints(bindingsConfig())
+			Expect(endpoints).To(HaveLen(2))
+
+			cfg := bindingsConfig()
+			breakResolverMap(cfg, "tenant-b-resolvers")
+			served, reports := el.createGraphqlEndpoints(cfg)
+			Expect(served).To(HaveLen(2))
+			Expect(served[0]).To(BeIdenticalTo(endpoints[0]))
+			Expect(served[1]).To(BeIdenticalTo(endpoints[1]))
+			Expect(staleEndpoints.Value()).To(Equal(int64(1)))
+			errs := reportErrs(reports)
+			Expect(errs["schema starwars-schema"]).To(HaveOccurred())
+			Expect(errs["schema starwars-schema"].Error()).To(ContainSubstring("binding /tenant-b"))
+			Expect(errs["schema starwars-schema"].Error()).To(ContainSubstring("serving the last accepted version"))
+			Expect(errs["schema starwars-schema"].Error()).NotTo(ContainSubstring("binding /tenant-a"))
+			Expect(errs["resolver map tenant-a-resolvers"]).NotTo(HaveOccurred())
+			Expect(errs["resolver map tenant-b-resolvers"]).To(HaveOccurred())
+		})
+		It("forgets the endpoints of removed bindings", func() {
+			endpoints, _ := el.createGraphqlEndpoints(bindingsConfig())
+			Expect(endpoints).To(HaveLen(2))
+
+			cfg := bindingsConfig()
+			cfg.Schemas[0].Bindings = cfg.Schemas[0].Bindings[:1]
+			served, _ := el.createGraphqlEndpoints(cfg)
+			Expect(rootPaths(served)).To(Equal([]string{"/tenant-a"}))
+			Expect(el.endpoints).NotTo(HaveKey("/tenant-b"))
+
+			// restored with a resolver map which can't be served, there is no last accepted version to fall back to
+			cfg = bindingsConfig()
+			breakResolverMap(cfg, "tenant-b-resolvers")
+			served, _ = el.createGraphqlEndpoints(cfg)
+			Expect(rootPaths(served)).To(Equal([]string{"/tenant-a"}))
+			Expect(staleEndpoints.Value()).To(BeZero())
+		})
+	})
+	Context("validating resolver destinations", func() {
+		It("doesn't validate mocked resolvers", func() {
+			Expect(gloo.V1().Upstreams().Delete("starwars-rest")).To(Succeed())
+			el.resolverOpts.MockAll = true
+			endpoints, reports := el.createGraphqlEndpoints(config())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(reportErrs(reports)["resolver map starwars-resolvers"]).NotTo(HaveOccurred())
+		})
+		It("accepts resolver maps when gloo's upstreams can't be listed", func() {
+			el.operator = operator.NewGlooOperator(unavailableUpstreams{gloo}, "sqoop-test", "sqoop-test")
+			endpoints, reports := el.createGraphqlEndpoints(config())
+			Expect(endpoints).To(HaveLen(1))
+			Expect(reportErrs(reports)["resolver map starwars-resolvers"]).NotTo(HaveOccurred())
+
+			reused, reports := el.createGraphqlEndpoints(config())
+			Expect(reused).To(HaveLen(1))
+			Expect(reused[0]).To(BeIdenticalTo(endpoints[0]))
+			Expect(reportErrs(reports)["resolver map starwars-resolvers"]).NotTo(HaveOccurred())
+		})
+	})
+})
+
+// gloo storage whose upstreams can't be listed, as while gloo's storage is unreachable
+type unavailableUpstreams struct {
+	storage.Interface
+}
+
+func (s unavailableUpstreams) V1() storage.V1 {
+	return unavailableUpstreamsV1{s.Interface.V1()}
+}
+
+type unavailableUpstreamsV1 struct {
+	storage.V1
+}
+
+func (v unavailableUpstreamsV1) Upstreams() storage.Upstreams {
+	return failingUpstreams{v.V1.Upstreams()}
+}
+
+type failingUpstreams struct {
+	storage.Upstreams
+}
+
+func (failingUpstreams) List() ([]*gloov1.Upstream, error) {
+	return nil, errors.New("storage unavailable")
+}
+
+var _ = Describe("publishing schemas", func() {
+	It("drops publishes superseded by a newer reload", func() {
+		var (
+			mu        sync.Mutex
+			published []string
+		)
+		first := make(chan struct{})
+		release := make(chan struct{})
+		registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct{ SDL string }
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			published = append(published, req.SDL)
+			count := len(published)
+			mu.Unlock()
+			if count == 1 {
+				close(first)
+				<-release
+			}
+		}))
+		defer registryServer.Close()
+		el := &EventLoop{publisher: registry.NewPublisher(registryServer.URL, "", "")}
+		reload := func(sdl string) {
+			el.publishSchemas(&v1.Config{Schemas: []*v1.Schema{{Name: "starwars", InlineSchema: sdl}}},
+				[]*graphql.Endpoint{{SchemaName: "starwars"}})
+		}
+		reload("v1")
+		Eventually(first).Should(BeClosed())
+		reload("v2")
+		reload("v3")
+		close(release)
+		Eventually(func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), published...)
+		}).Should(Equal([]string{"v1", "v3"}))
+		Consistently(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(published)
+		}, 100*time.Millisecond).Should(Equal(2))
+	})
+})
+
+var _ = Describe("generated resolver maps", func() {
+	Describe("newResolverMapNamer", func() {
+		It("names resolver maps after their schema", func() {
+			namer, err := newResolverMapNamer("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMapName(namer, "starwars")).To(Equal("starwars-resolvers"))
+
+			namer, err = newResolverMapNamer("rm-{{.SchemaName}}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMapName(namer, "starwars")).To(Equal("rm-starwars"))
+		})
+		It("rejects patterns which don't contain the schema name as is", func() {
+			for _, pattern := range []string{
+				"resolvers",
+				"{{len .SchemaName}}-resolvers",
+				`{{if eq .SchemaName "a"}}a{{else}}{{printf "%.1s" .SchemaName}}{{end}}-resolvers`,
+			} {
+				_, err := newResolverMapNamer(pattern)
+				Expect(err).To(MatchError(ContainSubstring("must contain {{.SchemaName}}")), pattern)
+			}
+		})
+		It("rejects patterns which don't parse or give invalid names", func() {
+			_, err := newResolverMapNamer("{{.SchemaName")
+			Expect(err).To(MatchError(ContainSubstring("parsing resolver map name pattern")))
+			_, err = newResolverMapNamer("{{.SchemaName}}/resolvers")
+			Expect(err).To(MatchError(ContainSubstring("invalid name")))
+		})
+	})
+	Describe("createEmptyResolverMap", func() {
+		var (
+			tmpDir string
+			sqoop  sqoopstorage.Interface
+			el     *EventLoop
+		)
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "sqoop-resolver-maps")
+			Expect(err).NotTo(HaveOccurred())
+			sqoop, err = sqoopfile.NewStorage(tmpDir, time.Millisecond)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sqoop.V1().Register()).To(Succeed())
+			namer, err := newResolverMapNamer("")
+			Expect(err).NotTo(HaveOccurred())
+			el = &EventLoop{sqoop: sqoop, resolverMapNamer: namer}
+		})
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("attaches a skeleton resolver map to the schema", func() {
+			schema, err := sqoop.V1().Schemas().Create(test.StarWarsV1Schema())
+			Expect(err).NotTo(HaveOccurred())
+			schema.ResolverMap = ""
+			Expect(el.createEmptyResolverMap(schema, nil)).To(Succeed())
+
+			updated, err := sqoop.V1().Schemas().Get(schema.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.ResolverMap).To(Equal(schema.Name + "-resolvers"))
+			_, err = sqoop.V1().ResolverMaps().Get(schema.Name + "-resolvers")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("does not take over a resolver map of the generated name which already exists", func() {
+			schema := test.StarWarsV1Schema()
+			schema.ResolverMap = ""
+			_, err := sqoop.V1().Schemas().Create(schema)
+			Expect(err).NotTo(HaveOccurred())
+			existing := &v1.ResolverMap{Name: schema.Name + "-resolvers"}
+
+			err = el.createEmptyResolverMap(schema, []*v1.ResolverMap{existing})
+			Expect(err).To(MatchError(ContainSubstring("already exists")))
+			stored, err := sqoop.V1().Schemas().Get(schema.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.ResolverMap).To(BeEmpty())
+			_, err = sqoop.V1().ResolverMaps().Get(existing.Name)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Describe("handleSchema", func() {
+		var (
+			tmpDir string
+			sqoop  sqoopstorage.Interface
+			el     *EventLoop
+		)
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "sqoop-resolver-maps")
+			Expect(err).NotTo(HaveOccurred())
+			sqoop, err = sqoopfile.NewStorage(tmpDir, time.Millisecond)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sqoop.V1().Register()).To(Succeed())
+			namer, err := newResolverMapNamer("")
+			Expect(err).NotTo(HaveOccurred())
+			el = &EventLoop{sqoop: sqoop, resolverMapNamer: namer, endpoints: make(map[string]*builtEndpoint)}
+		})
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+		createSchema := func(resolverMap string) *v1.Schema {
+			schema := test.StarWarsV1Schema()
+			schema.ResolverMap = resolverMap
+			schema, err := sqoop.V1().Schemas().Create(schema)
+			Expect(err).NotTo(HaveOccurred())
+			return schema
+		}
+
+		It("does not generate a resolver map for schemas without one when generation is disabled", func() {
+			el.resolverMapOpts.DisableGenerateForUnset = true
+			schema := createSchema("")
+
+			ep, err, _ := el.handleSchema(schema.Name, schema, "", nil)
+			Expect(ep).To(BeNil())
+			Expect(err).To(MatchError(ContainSubstring("does not specify a resolver map")))
+			resolverMaps, err := sqoop.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMaps).To(BeEmpty())
+			stored, err := sqoop.V1().Schemas().Get(schema.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.ResolverMap).To(BeEmpty())
+		})
+		It("reports resolver maps which don't exist by default", func() {
+			schema := createSchema("missing-resolvers")
+
+			_, err, _ := el.handleSchema(schema.Name, schema, "", nil)
+			Expect(err).To(MatchError(ContainSubstring("resolver map missing-resolvers for schema")))
+			_, err = sqoop.V1().ResolverMaps().Get("missing-resolvers")
+			Expect(err).To(HaveOccurred())
+		})
+		It("generates resolver maps which don't exist under the name of the schema's resolver map", func() {
+			el.resolverMapOpts.GenerateForMissing = true
+			schema := createSchema("missing-resolvers")
+
+			ep, err, _ := el.handleSchema(schema.Name, schema, "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			// served once the generated map is picked up
+			Expect(ep).To(BeNil())
+			generated, err := sqoop.V1().ResolverMaps().Get("missing-resolvers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generated.Types).To(HaveKey("Query"))
+		})
+		It("never writes to read-only storage", func() {
+			var opts bootstrap.Options
+			opts.ReadOnlyStorage = true
+			resolverMapOpts, err := resolverMapOptions(opts)
+			Expect(err).NotTo(HaveOccurred())
+			el.resolverMapOpts = resolverMapOpts
+			schema := createSchema("")
+
+			_, err, _ = el.handleSchema(schema.Name, schema, "", nil)
+			Expect(err).To(MatchError(ContainSubstring("generating resolver maps is disabled")))
+			resolverMaps, err := sqoop.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMaps).To(BeEmpty())
+			stored, err := sqoop.V1().Schemas().Get(schema.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.ResolverMap).To(BeEmpty())
+		})
+	})
+	Describe("resolverMapOptions", func() {
+		It("disables generating resolver maps with read-only storage", func() {
+			var opts bootstrap.Options
+			opts.ReadOnlyStorage = true
+			resolverMapOpts, err := resolverMapOptions(opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMapOpts.DisableGenerateForUnset).To(BeTrue())
+			Expect(resolverMapOpts.GenerateForMissing).To(BeFalse())
+		})
+		It("rejects generating missing resolver maps with read-only storage", func() {
+			var opts bootstrap.Options
+			opts.ReadOnlyStorage = true
+			opts.ResolverMaps.GenerateForMissing = true
+			_, err := resolverMapOptions(opts)
+			Expect(err).To(MatchError(ContainSubstring("read-only storage")))
+		})
+		It("keeps the resolver map options with writable storage", func() {
+			var opts bootstrap.Options
+			opts.ResolverMaps.GenerateForMissing = true
+			resolverMapOpts, err := resolverMapOptions(opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMapOpts).To(Equal(opts.ResolverMaps))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+)
+
+var (
+	// 1 while the routes of the current config have not been written to Gloo
+	glooConfigPending = expvar.NewInt("sqoop_gloo_config_pending")
+	// number of failed attempts to write config to Gloo
+	glooConfigFailures = expvar.NewInt("sqoop_gloo_config_failures")
+)
+
+// glooRetry schedules attempts to write config to Gloo after a failure, backing off exponentially.
+// endpoints keep serving while Gloo is unreachable; only changes to resolver routes are delayed
+type glooRetry struct {
+	opts    bootstrap.GlooRetryOptions
+	backoff time.Duration
+	timer   *time.Timer
+	pending bool
+}
+
+func newGlooRetry(opts bootstrap.GlooRetryOptions) *glooRetry {
+	return &glooRetry{opts: opts}
+}
+
+// retries fire when the next attempt is due. they never fire if retries are disabled
+func (r *glooRetry) retries() <-chan time.Time {
+	if r.timer == nil {
+		return nil
+	}
+	return r.timer.C
+}
+
+func (r *glooRetry) failed(err error) {
+	r.pending = true
+	glooConfigPending.Set(1)
+	glooConfigFailures.Add(1)
+	if r.opts.InitialBackoff <= 0 {
+		log.Warnf("configuring gloo failed, will retry on the next config change: %v", err)
+		return
+	}
+	switch {
+	case r.backoff == 0:
+		r.backoff = r.opts.InitialBackoff
+	case r.opts.MaxBackoff > 0 && r.backoff*2 > r.opts.MaxBackoff:
+		r.backoff = r.opts.MaxBackoff
+	default:
+		r.backoff *= 2
+	}
+	log.Warnf("configuring gloo failed, retrying in %v: %v", r.backoff, err)
+	r.stop()
+	r.timer = time.NewTimer(r.backoff)
+}
+
+func (r *glooRetry) succeeded() {
+	if r.pending {
+		log.Printf("configured gloo after %v failed attempts", glooConfigFailures.Value())
+	}
+	r.pending = false
+	r.backoff = 0
+	glooConfigPending.Set(0)
+	r.stop()
+	r.timer = nil
+}
+
+func (r *glooRetry) stop() {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	gloov1 "github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/gloo/pkg/storage"
+	"github.com/solo-io/gloo/pkg/storage/file"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+	"github.com/solo-io/sqoop/pkg/graphql"
+	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/reporter"
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("glooRetry", func() {
+	var r *glooRetry
+	AfterEach(func() {
+		r.stop()
+	})
+
+	It("doubles the backoff after every failure, up to the maximum", func() {
+		r = newGlooRetry(bootstrap.GlooRetryOptions{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second})
+		var backoffs []time.Duration
+		for i := 0; i < 5; i++ {
+			r.failed(errors.New("gloo is down"))
+			backoffs = append(backoffs, r.backoff)
+		}
+		Expect(backoffs).To(Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}))
+		Expect(r.retries()).NotTo(BeNil())
+		Expect(glooConfigPending.Value()).To(Equal(int64(1)))
+	})
+	It("resets the backoff once gloo is configured", func() {
+		r = newGlooRetry(bootstrap.GlooRetryOptions{InitialBackoff: time.Second})
+		r.failed(errors.New("gloo is down"))
+		r.failed(errors.New("gloo is down"))
+		Expect(r.backoff).To(Equal(2 * time.Second))
+
+		r.succeeded()
+		Expect(r.pending).To(BeFalse())
+		Expect(r.retries()).To(BeNil())
+		Expect(glooConfigPending.Value()).To(BeZero())
+
+		r.failed(errors.New("gloo is down"))
+		Expect(r.backoff).To(Equal(time.Second))
+	})
+	It("leaves the config to the next reload when retries are disabled", func() {
+		r = newGlooRetry(bootstrap.GlooRetryOptions{})
+		failures := glooConfigFailures.Value()
+		r.failed(errors.New("gloo is down"))
+		Expect(r.pending).To(BeTrue())
+		Expect(r.retries()).To(BeNil())
+		Expect(glooConfigFailures.Value()).To(Equal(failures + 1))
+	})
+})
+
+var _ = Describe("updating while gloo is unreachable", func() {
+	var (
+		tmpDir string
+		down   bool
+		rptr   *recordingReporter
+		el     *EventLoop
+	)
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "sqoop-gloo-retry")
+		Expect(err).NotTo(HaveOccurred())
+		gloo, err := file.NewStorage(tmpDir, time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gloo.V1().Register()).To(Succeed())
+		_, err = gloo.V1().Upstreams().Create(&gloov1.Upstream{
+			Name:      "starwars-rest",
+			Type:      "static",
+			Functions: []*gloov1.Function{{Name: "GetHero"}, {Name: "GetCharacter"}, {Name: "GetCharacters"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		down = true
+		rptr = &recordingReporter{}
+		el = &EventLoop{
+			operator:  operator.NewGlooOperator(unwritableVirtualServices{Interface: gloo, down: &down}, "sqoop-test", "sqoop-test"),
+			proxyAddr: "localhost:8080",
+			router:    graphql.NewRouter(graphql.Options{}),
+			reporter:  rptr,
+			glooRetry: newGlooRetry(bootstrap.GlooRetryOptions{}),
+			endpoints: make(map[string]*builtEndpoint),
+			envelopes: make(map[string]graphql.Envelope),
+		}
+	})
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+	config := func() *v1.Config {
+		return &v1.Config{
+			Schemas:      []*v1.Schema{test.StarWarsV1Schema()},
+			ResolverMaps: []*v1.ResolverMap{test.StarWarsResolverMap()},
+		}
+	}
+
+	It("reports the failure on the schemas until a retry configures gloo", func() {
+		summary, err := el.update(config())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("configuring gloo"))
+		// the schema is served regardless
+		Expect(summary.Rejected).To(BeZero())
+		Expect(rptr.errs()).To(HaveKey("schema starwars-schema"))
+		Expect(rptr.errs()["schema starwars-schema"].Error()).To(ContainSubstring("resolver routes are not up to date"))
+		Expect(rptr.errs()).NotTo(HaveKey("resolver map starwars-resolvers"))
+		// not ready until the config is applied without errors
+		Expect(el.ready).To(BeZero())
+
+		down = false
+		Expect(el.retryGloo()).To(Succeed())
+		Expect(rptr.errs()).To(BeEmpty())
+		Expect(el.ready).To(Equal(int32(1)))
+	})
+	It("discards the routes which could not be written once they are superseded", func() {
+		el.update(config())
+		routes := el.operator.RoutePaths()
+		Expect(routes).NotTo(BeEmpty())
+
+		el.update(config())
+		Expect(el.operator.RoutePaths()).To(Equal(routes))
+	})
+})
+
+// keeps the reports of the last update
+type recordingReporter struct {
+	reports []reporter.ConfigObjectReport
+}
+
+func (r *recordingReporter) WriteReports(reports []reporter.ConfigObjectReport) error {
+	r.reports = reports
+	return nil
+}
+
+// the errors of the reports which have one, by kind and name
+func (r *recordingReporter) errs() map[string]error {
+	errs := make(map[string]error)
+	for _, report := range r.reports {
+		if report.Err == nil {
+			continue
+		}
+		switch obj := report.CfgObject.(type) {
+		case *v1.Schema:
+			errs["schema "+obj.Name] = report.Err
+		case *v1.ResolverMap:
+			errs["resolver map "+obj.Name] = report.Err
+		}
+	}
+	return errs
+}
+
+// gloo storage whose virtual services can't be read or written while down, as while gloo's storage is unreachable
+type unwritableVirtualServices struct {
+	storage.Interface
+	down *bool
+}
+
+func (s unwritableVirtualServices) V1() storage.V1 {
+	return unwritableVirtualServicesV1{V1: s.Interface.V1(), down: s.down}
+}
+
+type unwritableVirtualServicesV1 struct {
+	storage.V1
+	down *bool
+}
+
+func (v unwritableVirtualServicesV1) VirtualServices() storage.VirtualServices {
+	return failingVirtualServices{VirtualServices: v.V1.VirtualServices(), down: v.down}
+}
+
+type failingVirtualServices struct {
+	storage.VirtualServices
+	down *bool
+}
+
+func (vs failingVirtualServices) Get(name string) (*gloov1.VirtualService, error) {
+	if *vs.down {
+		return nil, errors.New("storage unavailable")
+	}
+	return vs.VirtualServices.Get(name)
+}
+
+func (vs failingVirtualServices) Create(virtualService *gloov1.VirtualService) (*gloov1.VirtualService, error) {
+	if *vs.down {
+		return nil, errors.New("storage unavailable")
+	}
+	return vs.VirtualServices.Create(virtualService)
+}
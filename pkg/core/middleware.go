@@ -0,0 +1,67 @@
+package core
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/graphql"
+)
+
+const (
+	defaultAPIKeyHeader  = "X-Api-Key"
+	defaultAuthHeader    = "Authorization"
+	defaultAuthCacheSize = 1000
+)
+
+func (el *EventLoop) endpointMiddleware(schema *v1.Schema) ([]graphql.Middleware, error) {
+	var middleware []graphql.Middleware
+	for i, mw := range schema.Middleware {
+		switch mw := mw.Middleware.(type) {
+		case *v1.EndpointMiddleware_Cors:
+			cors := mw.Cors
+			middleware = append(middleware, graphql.CORS(cors.AllowOrigins, cors.AllowHeaders,
+				time.Duration(cors.MaxAgeSeconds)*time.Second))
+		case *v1.EndpointMiddleware_RateLimit:
+			if mw.RateLimit.RequestsPerSecond == 0 {
+				return nil, errors.Errorf("middleware %v: rate limit must allow at least one request per second", i)
+			}
+			middleware = append(middleware, graphql.RateLimit(int(mw.RateLimit.RequestsPerSecond), int(mw.RateLimit.Burst)))
+		case *v1.EndpointMiddleware_ApiKeyAuth:
+			if el.secrets == nil {
+				return nil, errors.Errorf("middleware %v: api key auth requires secret storage to be configured", i)
+			}
+			ref := mw.ApiKeyAuth.SecretRef
+			if _, err := el.secrets.Value(ref); err != nil {
+				return nil, errors.Wrapf(err, "middleware %v: reading api key", i)
+			}
+			header := mw.ApiKeyAuth.Header
+			if header == "" {
+				header = defaultAPIKeyHeader
+			}
+			middleware = append(middleware, graphql.APIKeyAuth(header, func() (string, error) {
+				return el.secrets.Value(ref)
+			}))
+		case *v1.EndpointMiddleware_ExternalAuth:
+			authenticator := el.authenticators[mw.ExternalAuth.Authenticator]
+			if authenticator == nil {
+				return nil, errors.Errorf("middleware %v: unknown authenticator %v", i, mw.ExternalAuth.Authenticator)
+			}
+			header := mw.ExternalAuth.Header
+			if header == "" {
+				header = defaultAuthHeader
+			}
+			cacheSize := int(mw.ExternalAuth.CacheSize)
+			if cacheSize == 0 {
+				cacheSize = defaultAuthCacheSize
+			}
+			middleware = append(middleware, graphql.ExternalAuth(header, authenticator, graphql.AuthCacheOptions{
+				TTL:  time.Duration(mw.ExternalAuth.CacheTtlSeconds) * time.Second,
+				Size: cacheSize,
+			}))
+		default:
+			return nil, errors.Errorf("middleware %v: no middleware specified", i)
+		}
+	}
+	return middleware, nil
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+// served on the main listener, where kubernetes probes reach it
+const readinessPath = "/readyz"
+
+// withReadiness serves the readiness probe, which fails until a config is applied without errors and once
+// shutdown starts. a config with rejected objects, or which could not be written to gloo, keeps new instances
+// out of rotation, while instances which are already ready keep serving
+func (el *EventLoop) withReadiness(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != readinessPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if atomic.LoadInt32(&el.ready) == 0 {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+}
+
+// markReady lets the readiness probe pass. updates and shutdown both run on the event loop, so an instance
+// never becomes ready again once it is draining
+func (el *EventLoop) markReady() {
+	atomic.StoreInt32(&el.ready, 1)
+}
+
+// drain shuts the servers down gracefully. readiness fails for the drain delay first, so load balancers
+// stop routing new requests before the listeners close. in-flight requests then get up to the grace
+// period to finish before their connections are closed
+func (el *EventLoop) drain(servers []*http.Server) {
+	atomic.StoreInt32(&el.ready, 0)
+	if el.shutdown.DrainDelay > 0 {
+		log.Printf("failing readiness for %v before shutting down", el.shutdown.DrainDelay)
+		time.Sleep(el.shutdown.DrainDelay)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), el.shutdown.GracePeriod)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server *http.Server) {
+			defer wg.Done()
+			if err := server.Shutdown(ctx); err != nil {
+				log.Warnf("requests to %v still in flight after %v, closing their connections", server.Addr, el.shutdown.GracePeriod)
+				server.Close()
+			}
+		}(server)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+)
+
+var _ = Describe("readiness", func() {
+	var (
+		el *EventLoop
+		h  http.Handler
+	)
+	BeforeEach(func() {
+		el = &EventLoop{}
+		h = el.withReadiness(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	})
+	probe := func(path string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec.Code
+	}
+
+	It("fails until a config is applied and once draining starts", func() {
+		Expect(probe("/readyz")).To(Equal(http.StatusServiceUnavailable))
+		el.markReady()
+		Expect(probe("/readyz")).To(Equal(http.StatusOK))
+
+		el.drain(nil)
+		Expect(probe("/readyz")).To(Equal(http.StatusServiceUnavailable))
+	})
+	It("passes other requests on", func() {
+		Expect(probe("/graphql")).To(Equal(http.StatusTeapot))
+	})
+})
+
+var _ = Describe("draining", func() {
+	var (
+		el       *EventLoop
+		server   *http.Server
+		addr     string
+		started  chan struct{}
+		finish   chan struct{}
+		finished chan error
+	)
+	BeforeEach(func() {
+		el = &EventLoop{shutdown: bootstrap.ShutdownOptions{DrainDelay: 100 * time.Millisecond, GracePeriod: time.Second}}
+		el.markReady()
+		started = make(chan struct{})
+		finish = make(chan struct{})
+		server = &http.Server{Handler: el.withReadiness(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-finish
+			w.Write([]byte("done"))
+		}))}
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr = ln.Addr().String()
+		go server.Serve(ln)
+
+		finished = make(chan error, 1)
+		go func() {
+			res, err := http.Get("http://" + addr + "/slow")
+			if err == nil {
+				_, err = ioutil.ReadAll(res.Body)
+				res.Body.Close()
+			}
+			finished <- err
+		}()
+		Eventually(started).Should(BeClosed())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("fails readiness for the drain delay, then lets in-flight requests finish", func() {
+		drained := make(chan struct{})
+		go func() {
+			el.drain([]*http.Server{server})
+			close(drained)
+		}()
+		Eventually(func() int32 { return atomic.LoadInt32(&el.ready) }).Should(BeZero())
+		// the listener stays open during the drain delay
+		res, err := http.Get("http://" + addr + "/readyz")
+		Expect(err).NotTo(HaveOccurred())
+		res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusServiceUnavailable))
+
+		time.Sleep(200 * time.Millisecond)
+		Consistently(drained, 100*time.Millisecond).ShouldNot(BeClosed())
+		close(finish)
+		Eventually(finished).Should(Receive(BeNil()))
+		Eventually(drained).Should(BeClosed())
+
+		_, err = http.Get("http://" + addr + "/readyz")
+		Expect(err).To(HaveOccurred())
+	})
+	It("closes the connections of requests still in flight after the grace period", func() {
+		defer close(finish)
+		el.shutdown.GracePeriod = 100 * time.Millisecond
+		el.drain([]*http.Server{server})
+		Eventually(finished).Should(Receive(HaveOccurred()))
+	})
+})
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+)
+
+func newTLSConfig(opts bootstrap.TLSOptions) (*tls.Config, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.Errorf("both a certificate and a key file must be provided to serve TLS")
+	}
+	reloader := &certReloader{certFile: opts.CertFile, keyFile: opts.KeyFile, retryInterval: certRetryInterval}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		GetCertificate: reloader.getCertificate,
+	}
+	if opts.ClientCAFile != "" {
+		caPem, err := ioutil.ReadFile(opts.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading client CA file %v", opts.ClientCAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, errors.Errorf("no certificates found in client CA file %v", opts.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// how long the certificate keeps being served after failing to reload it before reloading is tried again
+const certRetryInterval = 30 * time.Second
+
+// certReloader serves the certificate from disk, reloading it when the files change
+// so certificates can be rotated without restarting Sqoop
+type certReloader struct {
+	certFile, keyFile string
+	// how long to wait after a failed reload before trying again, rather than trying on every handshake
+	retryInterval time.Duration
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+	// when reloading last failed. zero if it hasn't
+	failedAt time.Time
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	retry := time.Since(r.failedAt) >= r.retryInterval
+	r.mu.Unlock()
+	if retry {
+		if err := r.reloadIfChanged(); err != nil {
+			// keep serving the previous certificate
+			log.Warnf("reloading TLS certificate: %v", err)
+			r.mu.Lock()
+			r.failedAt = time.Now()
+			r.mu.Unlock()
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) reloadIfChanged() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return errors.Wrapf(err, "checking for updated TLS certificate")
+	}
+	r.mu.Lock()
+	changed := modTime.After(r.modTime)
+	r.mu.Unlock()
+	if !changed {
+		return nil
+	}
+	if err := r.reload(); err != nil {
+		return err
+	}
+	log.Printf("reloaded TLS certificate from %v", r.certFile)
+	return nil
+}
+
+func (r *certReloader) reload() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrapf(err, "loading key pair %v, %v", r.certFile, r.keyFile)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "reading %v", file)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/sqoop/pkg/bootstrap"
+)
+
+var _ = Describe("certReloader", func() {
+	var (
+		dir               string
+		certFile, keyFile string
+		modTime           time.Time
+	)
+	// dates the files later than they were last written, so the change is seen regardless of the
+	// resolution of the timestamps of the filesystem
+	touch := func() {
+		modTime = modTime.Add(time.Minute)
+		Expect(os.Chtimes(certFile, modTime, modTime)).To(Succeed())
+		Expect(os.Chtimes(keyFile, modTime, modTime)).To(Succeed())
+	}
+	writeKeyPair := func(commonName string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: commonName},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		keyDer, err := x509.MarshalECPrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)).To(Succeed())
+		touch()
+	}
+	servedName := func(cfg *tls.Config) string {
+		cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		return parsed.Subject.CommonName
+	}
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "sqoop-tls")
+		Expect(err).NotTo(HaveOccurred())
+		certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		modTime = time.Now().Add(-time.Hour)
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("reloads the certificate when its files change", func() {
+		writeKeyPair("first")
+		cfg, err := newTLSConfig(bootstrap.TLSOptions{CertFile: certFile, KeyFile: keyFile})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(servedName(cfg)).To(Equal("first"))
+		writeKeyPair("second")
+		Expect(servedName(cfg)).To(Equal("second"))
+	})
+	It("keeps serving the previous certificate when reloading fails, and retries after the retry interval", func() {
+		writeKeyPair("first")
+		reloader := &certReloader{certFile: certFile, keyFile: keyFile, retryInterval: time.Hour}
+		Expect(reloader.reload()).To(Succeed())
+		cfg := &tls.Config{GetCertificate: reloader.getCertificate}
+
+		Expect(ioutil.WriteFile(certFile, []byte("not a certificate"), 0600)).To(Succeed())
+		touch()
+		Expect(servedName(cfg)).To(Equal("first"))
+		Expect(reloader.failedAt).NotTo(BeZero())
+
+		// fixed, but not retried until the retry interval has passed
+		writeKeyPair("second")
+		Expect(servedName(cfg)).To(Equal("first"))
+		reloader.mu.Lock()
+		reloader.failedAt = time.Now().Add(-time.Hour)
+		reloader.mu.Unlock()
+		Expect(servedName(cfg)).To(Equal("second"))
+	})
+	It("keeps serving the previous certificate when its files are removed", func() {
+		writeKeyPair("first")
+		reloader := &certReloader{certFile: certFile, keyFile: keyFile, retryInterval: time.Hour}
+		Expect(reloader.reload()).To(Succeed())
+		Expect(os.Remove(keyFile)).To(Succeed())
+		Expect(servedName(&tls.Config{GetCertificate: reloader.getCertificate})).To(Equal("first"))
+		Expect(reloader.failedAt).NotTo(BeZero())
+	})
+})
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+var (
+	// 1 while the config watcher appears stalled
+	configWatcherStalled = expvar.NewInt("sqoop_config_watcher_stalled")
+	// unix time of the last config or heartbeat received from the config watcher
+	configWatcherLastSeen = expvar.NewInt("sqoop_config_watcher_last_seen")
+)
+
+// watchdog flags the config watcher as stalled when neither a config nor a heartbeat
+// has been received within the threshold
+type watchdog struct {
+	threshold time.Duration
+	ticker    *time.Ticker
+	last      time.Time
+	stalled   bool
+}
+
+// a zero threshold disables the watchdog
+func newWatchdog(threshold time.Duration) *watchdog {
+	w := &watchdog{threshold: threshold}
+	if threshold > 0 {
+		w.ticker = time.NewTicker(threshold / 4)
+	}
+	w.seen(time.Now())
+	return w
+}
+
+// ticks never fire if the watchdog is disabled
+func (w *watchdog) ticks() <-chan time.Time {
+	if w.ticker == nil {
+		return nil
+	}
+	return w.ticker.C
+}
+
+func (w *watchdog) seen(now time.Time) {
+	w.last = now
+	configWatcherLastSeen.Set(now.Unix())
+	if w.stalled {
+		log.Printf("config watcher recovered")
+		w.stalled = false
+		configWatcherStalled.Set(0)
+	}
+}
+
+func (w *watchdog) check(now time.Time) {
+	if w.threshold <= 0 || w.stalled || now.Sub(w.last) <= w.threshold {
+		return
+	}
+	log.Warnf("config watcher has not been heard from since %v, config may be stale", w.last.Format(time.RFC3339))
+	w.stalled = true
+	configWatcherStalled.Set(1)
+}
+
+func (w *watchdog) stop() {
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("watchdog", func() {
+	var (
+		w     *watchdog
+		start time.Time
+	)
+	BeforeEach(func() {
+		configWatcherStalled.Set(0)
+		w = newWatchdog(time.Minute)
+		start = time.Now()
+		w.seen(start)
+	})
+	AfterEach(func() {
+		w.stop()
+	})
+
+	It("flags the config watcher as stalled once it hasn't been seen for longer than the threshold", func() {
+		w.check(start.Add(time.Minute))
+		Expect(w.stalled).To(BeFalse())
+		Expect(configWatcherStalled.Value()).To(BeZero())
+
+		w.check(start.Add(time.Minute + time.Second))
+		Expect(w.stalled).To(BeTrue())
+		Expect(configWatcherStalled.Value()).To(Equal(int64(1)))
+	})
+	It("recovers when the config watcher is seen again", func() {
+		w.check(start.Add(2 * time.Minute))
+		Expect(w.stalled).To(BeTrue())
+
+		w.seen(start.Add(3 * time.Minute))
+		Expect(w.stalled).To(BeFalse())
+		Expect(configWatcherStalled.Value()).To(BeZero())
+		Expect(configWatcherLastSeen.Value()).To(Equal(start.Add(3 * time.Minute).Unix()))
+
+		// the threshold counts from when it was last seen
+		w.check(start.Add(4 * time.Minute))
+		Expect(w.stalled).To(BeFalse())
+		w.check(start.Add(5 * time.Minute))
+		Expect(w.stalled).To(BeTrue())
+	})
+	It("is disabled by a zero threshold", func() {
+		w.stop()
+		w = newWatchdog(0)
+		Expect(w.ticks()).To(BeNil())
+		w.check(time.Now().Add(time.Hour))
+		Expect(w.stalled).To(BeFalse())
+	})
+})
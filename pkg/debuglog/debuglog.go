@@ -0,0 +1,177 @@
+// Package debuglog logs the resolution of selected schemas and fields in detail, including the requests
+// resolvers send to upstreams and the responses they receive, without logging every field of every schema
+package debuglog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// the maximum number of bytes of a body to log
+const maxBodySize = 4096
+
+const redacted = "[REDACTED]"
+
+// headers which carry credentials. their values are never logged
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// values of json properties whose names suggest credentials are never logged
+var sensitiveProperty = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token|api_?key|credential)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// the targets debug logging is enabled for
+var targets = &registry{}
+
+type registry struct {
+	mu      sync.RWMutex
+	targets map[string]bool
+}
+
+type schemaKey struct{}
+
+// WithSchema returns a context for resolving fields of the named schema
+func WithSchema(ctx context.Context, schemaName string) context.Context {
+	return context.WithValue(ctx, schemaKey{}, schemaName)
+}
+
+// SetTargets replaces the targets debug logging is enabled for. a target is the name of a schema, enabling
+// every field of the schema, a field as <Type>.<field>, enabling the field in every schema, or a field of a
+// single schema as <schema>/<Type>.<field>
+func SetTargets(names []string) {
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			enabled[name] = true
+		}
+	}
+	targets.mu.Lock()
+	targets.targets = enabled
+	targets.mu.Unlock()
+}
+
+// Targets returns the targets debug logging is enabled for, sorted
+func Targets() []string {
+	targets.mu.RLock()
+	defer targets.mu.RUnlock()
+	names := make([]string, 0, len(targets.targets))
+	for name := range targets.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled returns true if debug logging is enabled for the field being resolved with ctx
+func Enabled(ctx context.Context) bool {
+	schemaName, field := target(ctx)
+	targets.mu.RLock()
+	defer targets.mu.RUnlock()
+	if len(targets.targets) == 0 {
+		return false
+	}
+	return targets.targets[schemaName] || targets.targets[field] || targets.targets[schemaName+"/"+field]
+}
+
+// Printf logs a message about the field being resolved with ctx, if debug logging is enabled for it
+func Printf(ctx context.Context, format string, args ...interface{}) {
+	if !Enabled(ctx) {
+		return
+	}
+	schemaName, field := target(ctx)
+	log.Printf("debug %v %v: %v", schemaName, field, fmt.Sprintf(format, args...))
+}
+
+// DumpRequest logs a request sent to an upstream and its body, if debug logging is enabled for the field being
+// resolved with ctx. the values of credential headers, of the given headers and of query parameters are redacted
+func DumpRequest(ctx context.Context, req *http.Request, body []byte, redactHeaders ...string) {
+	if !Enabled(ctx) {
+		return
+	}
+	Printf(ctx, "request %v %v %v %s", req.Method, RedactURL(req.URL), formatHeaders(req.Header, redactHeaders), Redact(body))
+}
+
+// DumpResponse logs a response received from an upstream and its body, if debug logging is enabled for the field
+// being resolved with ctx
+func DumpResponse(ctx context.Context, res *http.Response, body []byte) {
+	if !Enabled(ctx) {
+		return
+	}
+	Printf(ctx, "response %v %v %s", res.Status, formatHeaders(res.Header, nil), Redact(body))
+}
+
+func target(ctx context.Context) (string, string) {
+	schemaName, _ := ctx.Value(schemaKey{}).(string)
+	var field string
+	if rctx := graphql.GetResolverContext(ctx); rctx != nil && rctx.Field.Name != "" {
+		field = rctx.Object + "." + rctx.Field.Name
+	}
+	return schemaName, field
+}
+
+func formatHeaders(header http.Header, redactHeaders []string) string {
+	redact := make(map[string]bool)
+	for _, name := range append(sensitiveHeaders, redactHeaders...) {
+		redact[http.CanonicalHeaderKey(name)] = true
+	}
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var formatted []string
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if redact[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		formatted = append(formatted, name+": "+value)
+	}
+	return "[" + strings.Join(formatted, "; ") + "]"
+}
+
+// RedactURL returns a url for logging with the values of its query parameters redacted, as they commonly carry
+// credentials such as api keys. the password of the url is left out
+func RedactURL(u *url.URL) string {
+	redactedURL := *u
+	if u.User != nil {
+		redactedURL.User = url.User(u.User.Username())
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		names := make([]string, 0, len(query))
+		for name := range query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var params []string
+		for _, name := range names {
+			for range query[name] {
+				params = append(params, url.QueryEscape(name)+"="+redacted)
+			}
+		}
+		redactedURL.RawQuery = strings.Join(params, "&")
+	}
+	return redactedURL.String()
+}
+
+// Redact returns a json body for logging, truncated, with the values of properties whose names suggest
+// credentials redacted
+func Redact(body []byte) []byte {
+	truncated := len(body) > maxBodySize
+	if truncated {
+		body = body[:maxBodySize]
+	}
+	body = sensitiveProperty.ReplaceAll(body, []byte(`$1"`+redacted+`"`))
+	if truncated {
+		body = append(body, "...(truncated)"...)
+	}
+	return body
+}
@@ -0,0 +1,13 @@
+package debuglog_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDebuglog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Debuglog Suite")
+}
@@ -117,24 +117,29 @@ func (t *InternalOnly) Type() common.Type {
 }
 
 func (t *Object) Marshaller() graphql.Marshaler {
-	// remove
-	for _, item := range t.Data.Items() {
-		if _, isInternal := item.Value.(*InternalOnly); isInternal {
-			t.Data.Delete(item.Key)
+	// skip internal values
+	n := 0
+	for _, val := range t.Data.Values {
+		if _, isInternal := val.(*InternalOnly); !isInternal {
+			n++
 		}
 	}
-	items := t.Data.Items()
-	fieldMap := graphql.NewOrderedMap(len(items))
-	for i, item := range items {
-		fieldMap.Keys[i] = item.Key
-		fieldMap.Values[i] = item.Value.Marshaller()
+	fieldMap := graphql.NewOrderedMap(n)
+	i := 0
+	for j, val := range t.Data.Values {
+		if _, isInternal := val.(*InternalOnly); isInternal {
+			continue
+		}
+		fieldMap.Keys[i] = t.Data.Keys[j]
+		fieldMap.Values[i] = val.Marshaller()
+		i++
 	}
 	return fieldMap
 }
 func (t *Array) Marshaller() graphql.Marshaler {
-	var array graphql.Array
-	for _, val := range t.Data {
-		array = append(array, val.Marshaller())
+	array := make(graphql.Array, len(t.Data))
+	for i, val := range t.Data {
+		array[i] = val.Marshaller()
 	}
 	return array
 }
@@ -0,0 +1,66 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// BodyLimits bound the size of the bodies of the requests resolvers send to their upstreams, and of the
+// responses they read. zero means no limit
+type BodyLimits struct {
+	MaxRequestBytes  int64
+	MaxResponseBytes int64
+}
+
+type bodyLimitsKey struct{}
+
+// WithBodyLimits returns a context whose upstream requests and responses are bounded by limits
+func WithBodyLimits(ctx context.Context, limits BodyLimits) context.Context {
+	return context.WithValue(ctx, bodyLimitsKey{}, limits)
+}
+
+func bodyLimits(ctx context.Context) BodyLimits {
+	limits, _ := ctx.Value(bodyLimitsKey{}).(BodyLimits)
+	return limits
+}
+
+// CheckRequestBody returns an error if a request body of size bytes is larger than the limit of ctx
+func CheckRequestBody(ctx context.Context, size int) error {
+	limit := bodyLimits(ctx).MaxRequestBytes
+	if limit > 0 && int64(size) > limit {
+		return errors.Errorf("request body of %v bytes is larger than the limit of %v bytes", size, limit)
+	}
+	return nil
+}
+
+// ReadResponseBody reads the body of an upstream response, failing as soon as it is larger than the limit of ctx
+func ReadResponseBody(ctx context.Context, body io.Reader) ([]byte, error) {
+	return readBody(body, bodyLimits(ctx).MaxResponseBytes)
+}
+
+// responseTooLarge is returned when a response body is larger than the limit it was read with
+type responseTooLarge struct {
+	limit int64
+}
+
+func (e *responseTooLarge) Error() string {
+	return fmt.Sprintf("response body is larger than the limit of %v bytes", e.limit)
+}
+
+func readBody(body io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return ioutil.ReadAll(body)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, &responseTooLarge{limit: limit}
+	}
+	return data, nil
+}
@@ -0,0 +1,124 @@
+package exec
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// CachePolicy accumulates the cache policy of a single operation from the
+// @cacheControl(maxAge: Int) directives of the fields it resolves.
+// The policy is the minimum maxAge of all resolved fields which declare one, and root fields which don't
+// declare one have a maxAge of 0. Responses to authenticated requests, and to operations whose resolvers could
+// read the credentials of the request, i.e. cookies, claims or the tenant or subject in .ctx, may only be
+// cached privately.
+// Mutations and operations with errors are never cached
+type CachePolicy struct {
+	mu      sync.Mutex
+	maxAge  int
+	hinted  bool
+	noStore bool
+	private bool
+}
+
+type cachePolicyKey struct{}
+
+// WithCachePolicy returns a context which collects the cache policy of the operation executed with it
+func WithCachePolicy(ctx context.Context) (context.Context, *CachePolicy) {
+	policy := &CachePolicy{}
+	return context.WithValue(ctx, cachePolicyKey{}, policy), policy
+}
+
+func cachePolicy(ctx context.Context) *CachePolicy {
+	policy, _ := ctx.Value(cachePolicyKey{}).(*CachePolicy)
+	return policy
+}
+
+func (p *CachePolicy) restrict(maxAge int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.hinted || maxAge < p.maxAge {
+		p.maxAge = maxAge
+	}
+	p.hinted = true
+}
+
+func (p *CachePolicy) forbid() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.noStore = true
+	p.mu.Unlock()
+}
+
+// markPrivate keeps the response out of shared caches, since it may differ between users
+func (p *CachePolicy) markPrivate() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.private = true
+	p.mu.Unlock()
+}
+
+// hasCredentials returns true if the request of ctx was authenticated, or resolvers of its operation can read
+// credentials of the request
+func hasCredentials(ctx context.Context) bool {
+	if Authenticated(ctx) {
+		return true
+	}
+	values := TemplateContext(ctx)
+	return len(Cookies(ctx)) > 0 || Claims(ctx) != nil || values[ContextTenant] != "" || values[ContextSubject] != ""
+}
+
+// Header returns the value of the Cache-Control header for the operation,
+// or "" if no resolved field declared a cache policy
+func (p *CachePolicy) Header() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.noStore:
+		return "no-store"
+	case !p.hinted:
+		return ""
+	case p.maxAge <= 0:
+		return "no-cache"
+	case p.private:
+		return "private, max-age=" + strconv.Itoa(p.maxAge)
+	}
+	return "max-age=" + strconv.Itoa(p.maxAge)
+}
+
+// fieldMaxAge reads the maxAge argument of a @cacheControl directive on the field
+func fieldMaxAge(field *schema.Field) (int, bool) {
+	if field == nil {
+		return 0, false
+	}
+	for _, directive := range field.Directives {
+		if directive.Name.Name != "cacheControl" {
+			continue
+		}
+		for _, arg := range directive.Args {
+			if arg.Name.Name != "maxAge" {
+				continue
+			}
+			switch maxAge := arg.Value.Value(nil).(type) {
+			case int32:
+				return int(maxAge), true
+			case int:
+				return maxAge, true
+			case int64:
+				return int(maxAge), true
+			case float64:
+				return int(maxAge), true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,80 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type claimsKey struct{}
+
+type authenticatedKey struct{}
+
+// WithAuthenticated marks the request as authenticated by an auth middleware of its endpoint, whether or not
+// the credential carried claims. responses to authenticated requests may differ between clients
+func WithAuthenticated(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authenticatedKey{}, true)
+}
+
+// Authenticated returns true if the request being served was authenticated
+func Authenticated(ctx context.Context) bool {
+	authenticated, _ := ctx.Value(authenticatedKey{}).(bool)
+	return authenticated
+}
+
+// WithClaims makes the claims of the credential a request was authenticated with available to resolvers.
+// it must only be called once the credential was validated, as resolvers trust the claims
+func WithClaims(ctx context.Context, claims map[string]interface{}) context.Context {
+	if claims == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// Claims returns the validated claims of the request being served. nil if the request was not authenticated
+func Claims(ctx context.Context) map[string]interface{} {
+	claims, _ := ctx.Value(claimsKey{}).(map[string]interface{})
+	return claims
+}
+
+// SetClaimHeaders sets headers of an upstream request to claims of the params, by header name, e.g.
+// {"X-User-Id": "sub"}. nested claims are separated by dots. claims which are not strings are sent as json,
+// and headers whose claim is missing are not set. fails if the request was not authenticated, so backends
+// which trust the headers never receive a request without them from a misconfigured endpoint
+func SetClaimHeaders(req *http.Request, params Params, claimHeaders map[string]string) error {
+	if len(claimHeaders) == 0 {
+		return nil
+	}
+	if params.Claims == nil {
+		return ValidationError(errors.New("the request was not authenticated, claims are not available"))
+	}
+	for header, claim := range claimHeaders {
+		var val interface{} = params.Claims
+		for _, name := range strings.Split(claim, ".") {
+			obj, ok := val.(map[string]interface{})
+			if !ok {
+				val = nil
+				break
+			}
+			val = obj[name]
+		}
+		switch val := val.(type) {
+		case nil:
+		case string:
+			req.Header.Set(header, val)
+		case json.Number, float64, bool:
+			req.Header.Set(header, fmt.Sprint(val))
+		default:
+			b, err := json.Marshal(val)
+			if err != nil {
+				return errors.Wrapf(err, "encoding claim %v", claim)
+			}
+			req.Header.Set(header, string(b))
+		}
+	}
+	return nil
+}
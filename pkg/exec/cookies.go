@@ -0,0 +1,29 @@
+package exec
+
+import (
+	"context"
+	"net/http"
+)
+
+type cookiesKey struct{}
+
+// WithCookies makes the cookies of the request being served available to resolvers.
+// Only cookies which resolvers are allowed to see should be included
+func WithCookies(ctx context.Context, cookies map[string]string) context.Context {
+	return context.WithValue(ctx, cookiesKey{}, cookies)
+}
+
+// Cookies returns the cookies available to resolvers, by name. nil if there are none
+func Cookies(ctx context.Context) map[string]string {
+	cookies, _ := ctx.Value(cookiesKey{}).(map[string]string)
+	return cookies
+}
+
+// ForwardCookies adds the named cookies to an upstream request, if they are among the cookies of the params
+func ForwardCookies(req *http.Request, params Params, names []string) {
+	for _, name := range names {
+		if value, ok := params.Cookies[name]; ok {
+			req.AddCookie(&http.Cookie{Name: name, Value: value})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/dynamic"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// DirectiveHandler transforms the resolved value of a field annotated with a schema directive,
+// e.g. `name: String @uppercase`. value and the returned value are plain Go values
+// (maps, slices and scalars, or nil for null). args holds the arguments given to the directive
+type DirectiveHandler func(ctx context.Context, value interface{}, args map[string]interface{}) (interface{}, error)
+
+// DirectiveHandlers maps directive names (without the @) to their handlers
+type DirectiveHandlers map[string]DirectiveHandler
+
+// apply the handlers for each directive on the field in the order the directives are declared.
+// directives without a handler are ignored
+func (h DirectiveHandlers) apply(ctx context.Context, schemaField *schema.Field, val dynamic.Value) (dynamic.Value, error) {
+	if len(h) == 0 || schemaField == nil {
+		return val, nil
+	}
+	for _, directive := range schemaField.Directives {
+		handler, ok := h[directive.Name.Name]
+		if !ok {
+			continue
+		}
+		args := make(map[string]interface{})
+		for _, arg := range directive.Args {
+			args[arg.Name.Name] = arg.Value.Value(nil)
+		}
+		transformed, err := handler(ctx, val.GoValue(), args)
+		if err != nil {
+			return nil, errors.Wrapf(err, "applying directive @%v", directive.Name.Name)
+		}
+		val, err = convertValue(schemaField.Type, transformed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting result of directive @%v", directive.Name.Name)
+		}
+	}
+	return val, nil
+}
@@ -0,0 +1,141 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// ErrorCategory tells clients and monitoring whether an error was caused by the request,
+// by an upstream serving a resolver, or by sqoop itself.
+// It is reported in the extensions of each error of a response
+type ErrorCategory string
+
+const (
+	// the request was invalid, e.g. a bad argument or variable
+	ErrorCategoryValidation ErrorCategory = "validation"
+	// an upstream failed or returned a response which could not be used
+	ErrorCategoryUpstream ErrorCategory = "upstream"
+	// anything else
+	ErrorCategoryInternal ErrorCategory = "internal"
+)
+
+type categorizedError struct {
+	error
+	category ErrorCategory
+}
+
+// Cause lets errors.Cause see through the category
+func (e *categorizedError) Cause() error {
+	return e.error
+}
+
+// WithErrorCategory tags err with a category. Errors which are not tagged are internal
+func WithErrorCategory(err error, category ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{error: err, category: category}
+}
+
+// ValidationError tags err as caused by the request
+func ValidationError(err error) error {
+	return WithErrorCategory(err, ErrorCategoryValidation)
+}
+
+// UpstreamError tags err as caused by an upstream
+func UpstreamError(err error) error {
+	return WithErrorCategory(err, ErrorCategoryUpstream)
+}
+
+// CategoryOf returns the outermost category err or any of its causes was tagged with
+func CategoryOf(err error) ErrorCategory {
+	for err != nil {
+		if categorized, ok := err.(*categorizedError); ok {
+			return categorized.category
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = causer.Cause()
+	}
+	return ErrorCategoryInternal
+}
+
+type codedError struct {
+	error
+	code string
+}
+
+func (e *codedError) Cause() error {
+	return e.error
+}
+
+// WithErrorCode tags err with a code for clients to tell errors apart by, e.g. a code extracted from the error
+// response of an upstream. It is reported in the extensions of the error
+func WithErrorCode(err error, code string) error {
+	if err == nil || code == "" {
+		return err
+	}
+	return &codedError{error: err, code: code}
+}
+
+// CodeOf returns the outermost code err or any of its causes was tagged with, or "" if there is none
+func CodeOf(err error) string {
+	for err != nil {
+		if coded, ok := err.(*codedError); ok {
+			return coded.code
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = causer.Cause()
+	}
+	return ""
+}
+
+type statusError struct {
+	error
+	status int
+}
+
+func (e *statusError) Cause() error {
+	return e.error
+}
+
+// UpstreamStatusError tags err as caused by an upstream which responded with an error status
+func UpstreamStatusError(err error, status int) error {
+	if err == nil {
+		return nil
+	}
+	return UpstreamError(&statusError{error: err, status: status})
+}
+
+// StatusOf returns the status of the upstream response err or any of its causes was tagged with, or 0 if there is none
+func StatusOf(err error) int {
+	for err != nil {
+		if withStatus, ok := err.(*statusError); ok {
+			return withStatus.status
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = causer.Cause()
+	}
+	return 0
+}
+
+// ReportError reports an error with the path of the field being resolved without failing the field,
+// e.g. when a resolver returns partial data
+func ReportError(ctx context.Context, err error) {
+	reqCtx := graphql.GetRequestContext(ctx)
+	if reqCtx == nil {
+		return
+	}
+	// don't cache partial results
+	cachePolicy(ctx).forbid()
+	reqCtx.Error(ctx, err)
+}
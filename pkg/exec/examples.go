@@ -0,0 +1,168 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"text/scanner"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/neelance/common"
+)
+
+// the neelance parser does not accept directives on arguments, so @example(value: ...) is stripped from
+// fields and arguments before parsing. a declaration of the directive is left in place. examples are keyed by
+// their schema coordinate, e.g. Query.hero for a field and Query.hero(episode:) for an argument
+func extractExamples(sdl string) (string, map[string]interface{}, error) {
+	if !strings.Contains(sdl, "@example") {
+		return sdl, nil, nil
+	}
+	tokens := lexSDL(sdl)
+	var (
+		examples                   = make(map[string]interface{})
+		edits                      []sdlEdit
+		kind, typeName, field, arg string
+	)
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.depth == 0 && definitionKeywords[tok.text] && (i == 0 || tokens[i-1].text != "@"):
+			kind, typeName, field, arg = tok.text, "", "", ""
+			if i+1 < len(tokens) && tokens[i+1].isName() {
+				typeName = tokens[i+1].text
+			}
+		case tok.text == "@" && i+1 < len(tokens) && tokens[i+1].text == "example" && (i == 0 || tokens[i-1].text != "directive"):
+			coordinate, err := exampleCoordinate(kind, typeName, field, arg, tok.depth)
+			if err != nil {
+				return "", nil, err
+			}
+			if _, ok := examples[coordinate]; ok {
+				return "", nil, errors.Errorf("%v has more than one @example", coordinate)
+			}
+			end, value, err := parseExample(sdl, tokens, i+2)
+			if err != nil {
+				return "", nil, errors.Wrapf(err, "@example of %v", coordinate)
+			}
+			examples[coordinate] = value
+			// keep the lines of the directive, so errors parsing the schema point at the lines they were found on
+			removed := sdl[tok.start:tokens[end].end]
+			edits = append(edits, sdlEdit{start: tok.start, end: tokens[end].end, text: strings.Repeat("\n", strings.Count(removed, "\n"))})
+			i = end
+		case tok.text == "@" && i+2 < len(tokens) && tokens[i+2].text == "(":
+			// skip the arguments of other directives, so they aren't taken for arguments of the field
+			i = closingToken(tokens, i+2)
+		case tok.isName() && i+1 < len(tokens) && (i == 0 || tokens[i-1].text != "@"):
+			next := tokens[i+1].text
+			if tok.depth == 1 && (next == ":" || next == "(") {
+				field, arg = tok.text, ""
+			} else if tok.depth == 2 && next == ":" && field != "" {
+				arg = tok.text
+			}
+		}
+	}
+
+	var (
+		out  bytes.Buffer
+		last int
+	)
+	for _, edit := range edits {
+		out.WriteString(sdl[last:edit.start])
+		out.WriteString(edit.text)
+		last = edit.end
+	}
+	out.WriteString(sdl[last:])
+	return out.String(), examples, nil
+}
+
+// exampleCoordinate returns the coordinate of the field or argument annotated with an @example found at depth
+func exampleCoordinate(kind, typeName, field, arg string, depth int) (string, error) {
+	switch {
+	case (kind == "type" || kind == "interface" || kind == "input") && depth == 1 && field != "":
+		return typeName + "." + field, nil
+	case (kind == "type" || kind == "interface") && depth == 2 && arg != "":
+		return typeName + "." + field + "(" + arg + ":)", nil
+	}
+	return "", errors.Errorf("@example is only supported on fields and arguments")
+}
+
+// parseExample parses the arguments of an @example whose opening parenthesis is the ith token, returning
+// the index of the closing parenthesis and the value of the example
+func parseExample(sdl string, tokens []sdlToken, i int) (int, interface{}, error) {
+	if i >= len(tokens) || tokens[i].text != "(" {
+		return 0, nil, errors.Errorf("missing the value argument")
+	}
+	end := closingToken(tokens, i)
+	if end >= len(tokens) || i+3 > end || tokens[i+1].text != "value" || tokens[i+2].text != ":" {
+		return 0, nil, errors.Errorf("must have a single argument, value")
+	}
+	literal := sdl[tokens[i+3].start:tokens[end].start]
+	sc := &scanner.Scanner{
+		Mode: scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanStrings,
+	}
+	sc.Init(strings.NewReader(literal))
+	var value common.Literal
+	lexer := common.New(sc)
+	if err := lexer.CatchSyntaxError(func() {
+		value = common.ParseLiteral(lexer, true)
+		lexer.ConsumeToken(scanner.EOF)
+	}); err != nil {
+		return 0, nil, errors.Errorf("invalid value %v: %v", strings.TrimSpace(literal), err.Message)
+	}
+	return end, value.Value(nil), nil
+}
+
+// closingToken returns the index of the token closing the brace, parenthesis or bracket which is the ith token
+func closingToken(tokens []sdlToken, i int) int {
+	for j := i + 1; j < len(tokens); j++ {
+		if tokens[j].depth == tokens[i].depth && (tokens[j].text == ")" || tokens[j].text == "}" || tokens[j].text == "]") {
+			return j
+		}
+	}
+	return len(tokens) - 1
+}
+
+// Examples collects the examples of the fields and arguments of the schema which an operation introspected,
+// reported in extensions.examples of its response. introspecting the schema reports all of its examples,
+// introspecting a type those of its fields and their arguments
+type Examples struct {
+	mu       sync.Mutex
+	examples map[string]interface{}
+}
+
+type examplesKey struct{}
+
+// WithExamples returns a context which collects the examples introspected by the operation executed with it
+func WithExamples(ctx context.Context) (context.Context, *Examples) {
+	examples := &Examples{}
+	return context.WithValue(ctx, examplesKey{}, examples), examples
+}
+
+// reportExamples reports the examples of the type named typeName, or of the whole schema if it is empty
+func reportExamples(ctx context.Context, s *Schema, typeName string) {
+	examples, _ := ctx.Value(examplesKey{}).(*Examples)
+	if examples == nil || len(s.examples) == 0 {
+		return
+	}
+	examples.mu.Lock()
+	defer examples.mu.Unlock()
+	for coordinate, value := range s.examples {
+		if typeName != "" && !strings.HasPrefix(coordinate, typeName+".") {
+			continue
+		}
+		if examples.examples == nil {
+			examples.examples = make(map[string]interface{})
+		}
+		examples.examples[coordinate] = value
+	}
+}
+
+// Examples returns the collected examples by coordinate. nil if the operation introspected none
+func (e *Examples) Examples() map[string]interface{} {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.examples
+}
@@ -1,11 +1,13 @@
 package exec
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/debuglog"
 	"github.com/solo-io/sqoop/pkg/dynamic"
 	"github.com/vektah/gqlgen/neelance/common"
 	"github.com/vektah/gqlgen/neelance/schema"
@@ -30,11 +32,21 @@ type fieldResolver struct {
 	resolverFunc RawResolver
 }
 
-type RawResolver func(params Params) ([]byte, error)
+type RawResolver func(ctx context.Context, params Params) ([]byte, error)
 
 type Params struct {
 	Parent *dynamic.Object
-	Args   map[string]interface{}
+	// the fields of the parent object which were resolved before this field, in the order of the selection.
+	// nil for root fields
+	Siblings *dynamic.OrderedMap
+	Args     map[string]interface{}
+	// the cookies of the request which resolvers are allowed to see
+	Cookies map[string]string
+	// well known values of the request, e.g. its id and the authenticated subject, read by templates from .ctx
+	Context map[string]string
+	// the validated claims of the credential the request was authenticated with, read by templates from .claims.
+	// nil if the request was not authenticated
+	Claims map[string]interface{}
 }
 
 func (p Params) Arg(name string) interface{} {
@@ -54,6 +66,9 @@ func NewExecutableResolvers(sch *schema.Schema, generateResolver func(string, st
 		switch typ := namedType.(type) {
 		case *schema.Object:
 			for _, field := range typ.Fields {
+				if isHealthField(field) {
+					continue
+				}
 				rawResolver, err := generateResolver(typ.Name, field.Name)
 				if err != nil {
 					return nil, errors.Wrapf(err, "generating resolver for %v.%v", typ.Name, field.Name)
@@ -233,7 +248,7 @@ func determineType(iface *schema.Interface, rawValue interface{}) (*schema.Objec
 	return nil, errors.Errorf("%v does not implement %v", objTypeName, iface.Name)
 }
 
-func (rm *ExecutableResolverMap) Resolve(typ schema.NamedType, field string, params Params) (dynamic.Value, error) {
+func (rm *ExecutableResolverMap) Resolve(ctx context.Context, typ schema.NamedType, field string, params Params) (dynamic.Value, error) {
 	fieldResolver, err := rm.getFieldResolver(typ, field)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolver lookup")
@@ -247,10 +262,20 @@ func (rm *ExecutableResolverMap) Resolve(typ schema.NamedType, field string, par
 		}
 		return nil, errors.Errorf("resolver for %v.%v has not been registered", typ.String(), field)
 	}
-	data, err := fieldResolver.resolverFunc(params)
+	if debuglog.Enabled(ctx) {
+		args, _ := json.Marshal(params.Args)
+		debuglog.Printf(ctx, "resolving with args %s", debuglog.Redact(args))
+	}
+	data, err := fieldResolver.resolverFunc(ctx, params)
 	if err != nil {
+		debuglog.Printf(ctx, "failed: %v", err)
 		return nil, errors.Wrapf(err, "failed executing resolver for %v.%v", typ.String(), field)
 	}
+	debuglog.Printf(ctx, "resolved %s", debuglog.Redact(data))
+	// resolvers return no data for null, e.g. when their precondition does not match
+	if data == nil {
+		return &dynamic.Null{}, nil
+	}
 	return toValue(data, fieldResolver.typ)
 }
 
@@ -281,6 +306,7 @@ var metaTypes = []string{
 	"__InputValue",
 	"__DirectiveLocation",
 	"__Field",
+	healthTypeName,
 }
 
 func MetaType(typeName string) bool {
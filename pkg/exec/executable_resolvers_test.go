@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -35,16 +36,16 @@ var _ = Describe("ExecutableResolverMap", func() {
 		server = httptest.NewServer(m)
 		proxyAddr = strings.TrimPrefix(server.URL, "http://")
 
-		resolverFactory := resolvers.NewResolverFactory(proxyAddr, test.StarWarsResolverMap())
+		resolverFactory := resolvers.NewResolverFactory(proxyAddr, test.StarWarsSchema.Schema, test.StarWarsResolverMap(), resolvers.Options{})
 		createResolver = resolverFactory.CreateResolver
 	})
 	AfterEach(func() {
 		server.Close()
 	})
 	It("does the happy path", func() {
-		execResolve, err := NewExecutableResolvers(test.StarWarsSchema, createResolver)
+		execResolve, err := NewExecutableResolvers(test.StarWarsSchema.Schema, createResolver)
 		Expect(err).NotTo(HaveOccurred())
-		res, err := execResolve.Resolve(test.StarWarsSchema.Types["Query"], "hero", Params{})
+		res, err := execResolve.Resolve(context.Background(), test.StarWarsSchema.Types["Query"], "hero", Params{})
 		Expect(err).NotTo(HaveOccurred())
 		data, ok := res.GoValue().(map[string]interface{})
 		Expect(ok).To(BeTrue())
@@ -5,33 +5,104 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/solo-io/sqoop/pkg/dynamic"
 	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/common"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
 	"github.com/vektah/gqlgen/neelance/introspection"
 	"github.com/vektah/gqlgen/neelance/query"
 	"github.com/vektah/gqlgen/neelance/schema"
 )
 
-func NewExecutableSchema(parsedSchema *schema.Schema, resolvers *ExecutableResolverMap) graphql.ExecutableSchema {
-	return &executableSchema{schema: parsedSchema, resolvers: resolvers}
+// Options configure the execution of queries against an executable schema
+type Options struct {
+	// validate resolver results against the types declared in the schema
+	StrictOutput bool
+	// maximum time to spend executing a single operation. zero means no limit
+	OperationTimeout time.Duration
+	// the longest timeout an operation may request with @timeout. zero only lets operations request
+	// timeouts shorter than the operation timeout
+	MaxOperationTimeout time.Duration
+	// the state reported by the health field of schemas it was added to. the field resolves to null if nil
+	Health func() Health
+	// return no data at all if any field fails to resolve, rather than partial data
+	AllOrNothing bool
+	// handlers for custom directives on field definitions, applied to resolved values
+	Directives DirectiveHandlers
+	// the number of operations whose execution plans are cached across requests. zero disables caching.
+	// only the plans of selection sets which don't depend on variables are shared
+	PlanCacheSize int
+	// the maximum number of items of a list returned by a field which are resolved concurrently.
+	// nil, or less than two for a field, resolves the items one after another
+	ListConcurrency func(typeName, fieldName string) int
+	// the maximum number of list items resolved concurrently while executing a single operation, across all of
+	// its lists. lists nested in items resolved concurrently share it, rather than multiplying their concurrency.
+	// items beyond it are resolved on the goroutine of their list. zero means no limit
+	MaxConcurrentItems int
+	// bounds the number of items of a list returned by a field. nil doesn't limit lists
+	ListLimit func(typeName, fieldName string) ListLimit
+	// the maximum number of fields resolved while executing a single operation, counting every item of a list.
+	// fields resolved after the limit is crossed resolve to null. zero means no limit
+	MaxResolutions int
+	// resolvers of a query making identical upstream calls share a single call. see DoUpstream
+	DedupUpstreamCalls bool
+}
+
+func NewExecutableSchema(parsedSchema *Schema, resolvers *ExecutableResolverMap, opts Options) graphql.ExecutableSchema {
+	return &executableSchema{schema: parsedSchema, resolvers: resolvers, opts: opts, plans: newOperationPlans(opts.PlanCacheSize)}
 }
 
 type executableSchema struct {
-	schema    *schema.Schema
+	schema    *Schema
 	resolvers *ExecutableResolverMap
+	opts      Options
+	plans     *operationPlans
 }
 
 func (e *executableSchema) Schema() *schema.Schema {
-	return e.schema
+	return e.schema.Schema
 }
 
 func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *graphql.Response {
+	ctx = WithOperation(ctx, op)
 	ec := executionContext{
 		RequestContext: graphql.GetRequestContext(ctx),
-		Schema:         e.Schema(),
+		Schema:         e.schema,
 		resolvers:      e.resolvers,
+		opts:           e.opts,
+		shared:         e.plans.get(op),
+	}
+	if e.opts.MaxConcurrentItems > 0 {
+		ec.concurrentItems = make(chan struct{}, e.opts.MaxConcurrentItems)
+	}
+	vars, errs := e.schema.CoerceVariables(op, ec.Variables)
+	if len(errs) > 0 {
+		return variablesErrorResponse(errs)
+	}
+	ec.Variables = vars
+	if hasCredentials(ctx) {
+		cachePolicy(ctx).markPrivate()
+	}
+
+	timeout, err := e.operationTimeout(op, vars)
+	if err != nil {
+		return graphql.ErrorResponse(ctx, "%v", err)
+	}
+	if timeout > 0 {
+		ec.timeout = timeout
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if e.opts.DedupUpstreamCalls {
+		var cancel context.CancelFunc
+		ctx, cancel = withUpstreamCalls(ctx)
+		defer cancel()
 	}
 
 	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
@@ -41,17 +112,36 @@ func (e *executableSchema) Query(ctx context.Context, op *query.Operation) *grap
 		return buf.Bytes()
 	})
 
-	return &graphql.Response{
-		Data:   buf,
-		Errors: ec.Errors,
-	}
+	return ec.response(buf)
 }
 
 func (e *executableSchema) Mutation(ctx context.Context, op *query.Operation) *graphql.Response {
+	ctx = WithOperation(ctx, op)
 	ec := executionContext{
 		RequestContext: graphql.GetRequestContext(ctx),
-		Schema:         e.Schema(),
+		Schema:         e.schema,
 		resolvers:      e.resolvers,
+		opts:           e.opts,
+		shared:         e.plans.get(op),
+	}
+	if e.opts.MaxConcurrentItems > 0 {
+		ec.concurrentItems = make(chan struct{}, e.opts.MaxConcurrentItems)
+	}
+	vars, errs := e.schema.CoerceVariables(op, ec.Variables)
+	if len(errs) > 0 {
+		return variablesErrorResponse(errs)
+	}
+	ec.Variables = vars
+
+	timeout, err := e.operationTimeout(op, vars)
+	if err != nil {
+		return graphql.ErrorResponse(ctx, "%v", err)
+	}
+	if timeout > 0 {
+		ec.timeout = timeout
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
 	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
@@ -61,21 +151,52 @@ func (e *executableSchema) Mutation(ctx context.Context, op *query.Operation) *g
 		return buf.Bytes()
 	})
 
+	return ec.response(buf)
+}
+
+func (ec *executionContext) response(data []byte) *graphql.Response {
+	if ec.opts.AllOrNothing && len(ec.Errors) > 0 {
+		// discard partial data
+		data = nil
+	}
 	return &graphql.Response{
-		Data:   buf,
+		Data:   data,
 		Errors: ec.Errors,
 	}
 }
 
+// variablesErrorResponse reports every invalid variable of an operation which was not executed
+func variablesErrorResponse(errs []*gqlerrors.QueryError) *graphql.Response {
+	res := &graphql.Response{Errors: make([]error, len(errs))}
+	for i, err := range errs {
+		res.Errors[i] = err
+	}
+	return res
+}
+
 func (e *executableSchema) Subscription(ctx context.Context, op *query.Operation) func() *graphql.Response {
 	return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
 }
 
 type executionContext struct {
 	*graphql.RequestContext
-	*schema.Schema
+	*Schema
 
 	resolvers *ExecutableResolverMap
+	opts      Options
+
+	// report the operation timing out or exceeding the resolution limit only once
+	timeoutReported sync.Once
+	// fields resolved so far, accessed atomically
+	resolutions int64
+	// the deadline of the operation, if it has one
+	timeout time.Duration
+	// field plans for the selection sets of the operation
+	plans planCache
+	// plans shared by every execution of the operation, if plan caching is enabled
+	shared *planCache
+	// list items of the operation resolved concurrently, if they are bounded. see Options.MaxConcurrentItems
+	concurrentItems chan struct{}
 }
 
 var queryImplementors = []string{"Query"}
@@ -100,9 +221,14 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 		case "__type":
 			out.Values[i] = ec._Query___type(ctx, field)
 		default:
-			val, err := ec.resolveField(ctx, ec.EntryPoints["query"].(*schema.Object), field, nil)
+			if field.Name == HealthFieldName && ec.healthField {
+				out.Values[i] = ec._Query__sqoop(ctx, field)
+				continue
+			}
+			// errors are reported by resolveField
+			queryType := ec.EntryPoints["query"].(*schema.Object)
+			val, err := ec.resolveField(ctx, queryType, ec.newFieldPlan(queryType, field), nil, nil)
 			if err != nil {
-				ec.Error(ctx, err)
 				out.Values[i] = graphql.Null
 				continue
 			}
@@ -113,62 +239,232 @@ func (ec *executionContext) _Query(ctx context.Context, sel []query.Selection) g
 	return out
 }
 
-func (ec *executionContext) resolveField(ctx context.Context, objectType *schema.Object, field graphql.CollectedField, parentObject *dynamic.Object) (dynamic.Value, error) {
-	val, err := ec.resolvers.Resolve(objectType, field.Name, Params{Parent: parentObject, Args: field.Args})
+// errNullPropagated is returned when a non-null field could not be resolved.
+// the error has already been reported, the parent of the field must be resolved to null
+var errNullPropagated = errors.New("null propagated from non-null field")
+
+// resolveField resolves a single field of an object. errors are reported along with the path to the field.
+// if the field is nullable, it resolves to null on error.
+// siblings are the fields of the object resolved so far
+func (ec *executionContext) resolveField(ctx context.Context, objectType *schema.Object, plan *fieldPlan, parentObject *dynamic.Object, siblings *dynamic.OrderedMap) (dynamic.Value, error) {
+	ctx = withPathElement(ctx, objectType.Name, plan.field, plan.field.Alias)
+	ctx, traced := startTrace(ctx, objectType.Name+"."+plan.field.Name)
+	val, err := ec.resolveFieldValue(ctx, objectType, plan, parentObject, siblings)
+	traced.finish(err)
+	if err != nil {
+		// don't cache partial results
+		cachePolicy(ctx).forbid()
+		if opErr := ec.operationErr(ctx); opErr != nil {
+			// every remaining field fails once the operation has timed out, report it once
+			ec.timeoutReported.Do(func() {
+				ec.Error(ctx, opErr)
+			})
+		} else if err != errNullPropagated {
+			ec.Error(ctx, err)
+		}
+		if plan.schemaField != nil && isNonNull(plan.schemaField.Type) {
+			return nil, errNullPropagated
+		}
+		return &dynamic.Null{}, nil
+	}
+	switch {
+	case plan.hasMaxAge:
+		cachePolicy(ctx).restrict(plan.maxAge)
+	case objectType == ec.Schema.EntryPoints["query"]:
+		// root fields without a hint are not cached, other fields inherit the policy of their parent
+		cachePolicy(ctx).restrict(0)
+	}
+	return val, nil
+}
+
+func (ec *executionContext) resolveFieldValue(ctx context.Context, objectType *schema.Object, plan *fieldPlan, parentObject *dynamic.Object, siblings *dynamic.OrderedMap) (dynamic.Value, error) {
+	field, schemaField := plan.field, plan.schemaField
+	if plan.argsErr != nil {
+		return nil, errors.Wrapf(ValidationError(plan.argsErr), "coercing arguments for field "+strconv.Quote(field.Name))
+	}
+	atomic.AddInt64(&ec.resolutions, 1)
+	if err := ec.operationErr(ctx); err != nil {
+		return nil, err
+	}
+	val, err := ec.resolvers.Resolve(ctx, objectType, field.Name, Params{Parent: parentObject, Siblings: siblings, Args: plan.argsCopy(), Cookies: Cookies(ctx), Context: TemplateContext(ctx), Claims: Claims(ctx)})
 	if err != nil {
 		return nil, errors.Wrapf(err, "executing resolver for field "+strconv.Quote(field.Name))
 	}
-	// lists and objects need to be recursed into
+	val, err = ec.opts.Directives.apply(ctx, schemaField, val)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving field "+strconv.Quote(field.Name))
+	}
+	if ec.opts.StrictOutput && schemaField != nil {
+		if err := validateValue(schemaField.Type, val, objectType.Name+"."+field.Name); err != nil {
+			return nil, errors.Wrapf(UpstreamError(err), "invalid result for field "+strconv.Quote(field.Name))
+		}
+	}
+	val, err = plan.listLimit.apply(ctx, field.Name, val)
+	if err != nil {
+		return nil, err
+	}
+	return ec.resolveValue(ctx, field, val, plan.listConcurrency)
+}
+
+// lists and objects need to be recursed into
+func (ec *executionContext) resolveValue(ctx context.Context, field graphql.CollectedField, val dynamic.Value, concurrency int) (dynamic.Value, error) {
 	switch result := val.(type) {
 	case *dynamic.Object:
-		val, err := ec.resolveObject(ctx, result.Object, field.Selections, result)
-		if err != nil {
-			return nil, errors.Wrapf(err, "resolving object "+strconv.Quote(result.Name))
-		}
-		return val, nil
+		return ec.resolveObject(ctx, result.Object, field.Selections, result)
 	case *dynamic.Array:
+		if concurrency > 1 {
+			return ec.resolveItemsConcurrently(ctx, field, result, concurrency)
+		}
 		for i, item := range result.Data {
-			obj, ok := item.(*dynamic.Object)
-			if !ok {
-				// not an object array, nothing to recurse
-				return val, nil
+			if !needsResolving(item) {
+				continue
 			}
-			result.Data[i], err = ec.resolveObject(ctx, obj.Object, field.Selections, obj)
+			itemCtx := withPathElement(ctx, graphql.GetResolverContext(ctx).Object, field, i)
+			resolved, err := ec.resolveValue(itemCtx, field, item, concurrency)
 			if err != nil {
-				return nil, errors.Wrapf(err, "resolving list item object "+strconv.Quote(obj.Name))
+				if isNonNull(result.List.OfType) {
+					return nil, err
+				}
+				resolved = &dynamic.Null{}
 			}
+			result.Data[i] = resolved
 		}
 		return result, nil
 	}
 	return val, nil
 }
 
-func (ec *executionContext) resolveObject(ctx context.Context, objectType *schema.Object, sel []query.Selection, parentObject *dynamic.Object) (*dynamic.Object, error) {
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: objectType.TypeName(),
-	})
+// resolveItemsConcurrently resolves the items of a list with at most limit items in flight.
+// items keep their position in the list, so only the order of reported errors depends on timing.
+// upstream calls share the context of the operation and are cancelled with it.
+// once an item of a list of non-null items fails, no further items are started.
+// items are only resolved on goroutines of their own while the operation is below its maximum of concurrent items,
+// otherwise they are resolved one after another, so that nested lists don't multiply their concurrency
+func (ec *executionContext) resolveItemsConcurrently(ctx context.Context, field graphql.CollectedField, list *dynamic.Array, limit int) (dynamic.Value, error) {
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, limit)
+		errs     = make([]error, len(list.Data))
+		failed   int32
+		mu       sync.Mutex
+		panicked interface{}
+	)
+	nonNull := isNonNull(list.List.OfType)
+	object := graphql.GetResolverContext(ctx).Object
+	resolveItem := func(i int, item dynamic.Value) {
+		defer func() {
+			// panics are raised again on the goroutine serving the request, which recovers from them
+			if r := recover(); r != nil {
+				mu.Lock()
+				if panicked == nil {
+					panicked = r
+				}
+				mu.Unlock()
+				atomic.StoreInt32(&failed, 1)
+			}
+		}()
+		resolved, err := ec.resolveValue(withPathElement(ctx, object, field, i), field, item, limit)
+		if err != nil {
+			if nonNull {
+				errs[i] = err
+				atomic.StoreInt32(&failed, 1)
+				return
+			}
+			resolved = &dynamic.Null{}
+		}
+		list.Data[i] = resolved
+	}
+	for i, item := range list.Data {
+		if !needsResolving(item) {
+			continue
+		}
+		sem <- struct{}{}
+		if atomic.LoadInt32(&failed) == 1 {
+			break
+		}
+		if !ec.startConcurrentItem() {
+			resolveItem(i, item)
+			<-sem
+			continue
+		}
+		wg.Add(1)
+		go func(i int, item dynamic.Value) {
+			defer func() {
+				ec.concurrentItemDone()
+				<-sem
+				wg.Done()
+			}()
+			resolveItem(i, item)
+		}(i, item)
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// startConcurrentItem reserves one of the items the operation may resolve concurrently.
+// false if as many items are already in flight
+func (ec *executionContext) startConcurrentItem() bool {
+	if ec.concurrentItems == nil {
+		return true
+	}
+	select {
+	case ec.concurrentItems <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ec *executionContext) concurrentItemDone() {
+	if ec.concurrentItems != nil {
+		<-ec.concurrentItems
+	}
+}
+
+// only objects and lists have fields to resolve
+func needsResolving(item dynamic.Value) bool {
+	switch item.(type) {
+	case *dynamic.Object, *dynamic.Array:
+		return true
+	}
+	return false
+}
+
+// resolveObject returns errNullPropagated if a non-null field of the object could not be resolved
+func (ec *executionContext) resolveObject(ctx context.Context, objectType *schema.Object, sel []query.Selection, parentObject *dynamic.Object) (dynamic.Value, error) {
+	ctx = withPathElement(ctx, objectType.TypeName(), graphql.CollectedField{}, nil)
 
 	// get just the fields we need
 	// also, resolve nested resolvers if they exist
-	implementors := getImplementors(objectType)
-	fields := graphql.CollectFields(ec.Doc, sel, implementors, ec.Variables)
+	plans := ec.selectionPlan(objectType, sel)
 
-	data := dynamic.NewOrderedMap()
+	data := &dynamic.OrderedMap{
+		Keys:   make([]string, 0, len(plans)),
+		Values: make([]dynamic.Value, 0, len(plans)),
+	}
 
-	for _, field := range fields {
-		switch field.Name {
+	for _, plan := range plans {
+		switch plan.field.Name {
 		// request for the object's typeName
 		case "__typename":
 			val := &dynamic.String{
 				Data: objectType.TypeName(),
 			}
-			data.Set(field.Name, val)
+			data.Set(plan.field.Name, val)
 		default:
-			val, err := ec.resolveField(ctx, objectType, field, parentObject)
+			val, err := ec.resolveField(ctx, objectType, plan, parentObject, data)
 			if err != nil {
-				return nil, errors.Wrapf(err, "resolving field %v", strconv.Quote(field.Name))
+				return nil, err
 			}
-			data.Set(field.Name, val)
+			data.Set(plan.field.Name, val)
 		}
 
 	}
@@ -176,6 +472,46 @@ func (ec *executionContext) resolveObject(ctx context.Context, objectType *schem
 	return &dynamic.Object{Object: objectType, Data: data}, nil
 }
 
+// operationErr returns an error if execution of the operation should stop
+func (ec *executionContext) operationErr(ctx context.Context) error {
+	if max := ec.opts.MaxResolutions; max > 0 && atomic.LoadInt64(&ec.resolutions) > int64(max) {
+		return ValidationError(errors.Errorf("operation exceeded the maximum of %v resolved fields, returning partial results", max))
+	}
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return errors.Errorf("operation timed out after %v, returning partial results", ec.timeout)
+	}
+	return errors.Errorf("operation cancelled")
+}
+
+// withPathElement returns a context whose resolver context points at the next element of the path.
+// a nil element keeps the current path
+func withPathElement(ctx context.Context, object string, field graphql.CollectedField, element interface{}) context.Context {
+	var path []interface{}
+	if parent := graphql.GetResolverContext(ctx); parent != nil {
+		path = parent.Path
+	}
+	if element != nil {
+		// copy, paths are shared between the contexts of sibling fields
+		path = append(append(make([]interface{}, 0, len(path)+1), path...), element)
+	}
+	rctx := &graphql.ResolverContext{
+		Object: object,
+		Args:   field.Args,
+		Field:  field,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rctx.Path = path
+	return ctx
+}
+
+func isNonNull(typ common.Type) bool {
+	_, ok := typ.(*common.NonNull)
+	return ok
+}
+
 func getImplementors(typ schema.NamedType) []string {
 	implementors := []string{typ.TypeName()}
 	switch typ := typ.(type) {
@@ -204,6 +540,7 @@ var mutationImplementors = []string{"Mutation"}
 
 // nolint: gocyclo, errcheck, gas, goconst
 func (ec *executionContext) _Mutation(ctx context.Context, sel []query.Selection) graphql.Marshaler {
+	cachePolicy(ctx).forbid()
 	fields := graphql.CollectFields(ec.Doc, sel, mutationImplementors, ec.Variables)
 
 	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
@@ -218,9 +555,10 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel []query.Selection
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Mutation")
 		default:
-			val, err := ec.resolveField(ctx, ec.EntryPoints["mutation"].(*schema.Object), field, nil)
+			// errors are reported by resolveField
+			mutationType := ec.EntryPoints["mutation"].(*schema.Object)
+			val, err := ec.resolveField(ctx, mutationType, ec.newFieldPlan(mutationType, field), nil, nil)
 			if err != nil {
-				ec.Error(ctx, err)
 				out.Values[i] = graphql.Null
 				continue
 			}
@@ -238,6 +576,7 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
+	reportExamples(ctx, ec.Schema, "")
 	res := ec.introspectSchema()
 	if res == nil {
 		return graphql.Null
@@ -263,6 +602,7 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
+	reportExamples(ctx, ec.Schema, args["name"].(string))
 	res := ec.introspectType(args["name"].(string))
 	if res == nil {
 		return graphql.Null
@@ -997,7 +1337,7 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 }
 
 func (ec *executionContext) introspectSchema() *introspection.Schema {
-	return introspection.WrapSchema(ec.Schema)
+	return introspection.WrapSchema(ec.Schema.Schema)
 }
 
 func (ec *executionContext) introspectType(name string) *introspection.Type {
@@ -0,0 +1,80 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/handler"
+)
+
+const benchSchema = `
+type Query {
+	items(limit: Int): [Item]
+}
+type Item {
+	id: ID!
+	name: String
+	price: Float
+	tags: [String]
+}
+`
+
+// run with: go test ./pkg/exec -run xxx -bench LargeList -benchmem
+func BenchmarkLargeList(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("items=%v", size), func(b *testing.B) {
+			benchmarkList(b, size)
+		})
+	}
+}
+
+func benchmarkList(b *testing.B, size int) {
+	items := make([]map[string]interface{}, size)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"id":    fmt.Sprintf("item-%v", i),
+			"name":  fmt.Sprintf("Item %v", i),
+			"price": float64(i) / 10,
+			"tags":  []string{"a", "b"},
+		}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		b.Fatal(err)
+	}
+	sch := MustParseSchema(benchSchema)
+	// only the list is resolved, item fields are read from the parent
+	resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+		if typeName != "Query" {
+			return nil, nil
+		}
+		return func(ctx context.Context, params Params) ([]byte, error) {
+			return data, nil
+		}, nil
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	h := handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{}))
+	body, err := json.Marshal(map[string]string{"query": `{items(limit: 10){id name price tags}}`})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest("POST", "/query", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			b.Fatalf("unexpected status %v: %s", rec.Code, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,763 @@
+package exec_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/solo-io/sqoop/pkg/dynamic"
+	. "github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/test"
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/handler"
+	gqlquery "github.com/vektah/gqlgen/neelance/query"
+)
+
+var _ = Describe("ExecutableSchema", func() {
+	var (
+		proxy        *httptest.Server
+		server       *httptest.Server
+		proxyAddr    string
+		friendsDelay time.Duration
+		heroCalls    int32
+	)
+	BeforeEach(func() {
+		friendsDelay = 0
+		atomic.StoreInt32(&heroCalls, 0)
+		m := mux.NewRouter()
+		m.HandleFunc("/Query.hero", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&heroCalls, 1)
+			w.Write([]byte(`{"__typename":"Human","id":"1000","name":"Luke Skywalker","friend_ids":["1002","2001"]}`))
+		})
+		m.HandleFunc("/Human.friends", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(friendsDelay)
+			// the droid is missing its name
+			w.Write([]byte(`[{"__typename":"Human","id":"1002","name":"Han Solo"},{"__typename":"Droid","id":"2001"}]`))
+		})
+		proxy = httptest.NewServer(m)
+		proxyAddr = strings.TrimPrefix(proxy.URL, "http://")
+		server = httptest.NewServer(handler.GraphQL(test.StarWarsExecutableSchema(proxyAddr)))
+	})
+	AfterEach(func() {
+		server.Close()
+		proxy.Close()
+	})
+	It("reports the path to failed list items and nulls only the failed item", func() {
+		result := query(server.URL, `{hero{name friends{name}}}`)
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "Luke Skywalker",
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Han Solo"},
+					nil,
+				},
+			},
+		}))
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Path).To(Equal([]interface{}{"hero", "friends", float64(1), "name"}))
+	})
+	It("returns partial data when the operation times out", func() {
+		friendsDelay = time.Second
+		execSchema := NewExecutableSchema(test.StarWarsSchema, test.StarWarsExecutableResolvers(proxyAddr), Options{
+			OperationTimeout: 100 * time.Millisecond,
+		})
+		timeoutServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer timeoutServer.Close()
+		result := query(timeoutServer.URL, `{hero{name friends{name}}}`)
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name":    "Luke Skywalker",
+				"friends": nil,
+			},
+		}))
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Message).To(ContainSubstring("operation timed out after 100ms"))
+	})
+	It("lets operations request a deadline up to the maximum with @timeout", func() {
+		friendsDelay = time.Second
+		execSchema := NewExecutableSchema(test.StarWarsSchema, test.StarWarsExecutableResolvers(proxyAddr), Options{
+			OperationTimeout:    10 * time.Second,
+			MaxOperationTimeout: 20 * time.Second,
+		})
+		timeoutServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer timeoutServer.Close()
+		result := query(timeoutServer.URL, `query Hero @timeout(ms: 100) {hero{name friends{name}}}`)
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name":    "Luke Skywalker",
+				"friends": nil,
+			},
+		}))
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Message).To(ContainSubstring("operation timed out after 100ms"))
+
+		execSchema = NewExecutableSchema(test.StarWarsSchema, test.StarWarsExecutableResolvers(proxyAddr), Options{
+			OperationTimeout:    50 * time.Millisecond,
+			MaxOperationTimeout: 100 * time.Millisecond,
+		})
+		clampedServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer clampedServer.Close()
+		result = query(clampedServer.URL, `query Hero @timeout(ms: 60000) {hero{name friends{name}}}`)
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Message).To(ContainSubstring("operation timed out after 100ms"))
+	})
+	It("discards partial data in all-or-nothing mode", func() {
+		execSchema := NewExecutableSchema(test.StarWarsSchema, test.StarWarsExecutableResolvers(proxyAddr), Options{
+			AllOrNothing: true,
+		})
+		strictServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer strictServer.Close()
+		result := query(strictServer.URL, `{hero{name friends{name}}}`)
+		Expect(result.Data).To(BeNil())
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Path).To(Equal([]interface{}{"hero", "friends", float64(1), "name"}))
+	})
+	It("resolves the health field from the health of sqoop, unless the schema declares its own", func() {
+		sch := MustParseSchema(`
+type Query {
+	greeting: String
+}
+`)
+		Expect(sch.AddHealthField()).To(BeTrue())
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			Expect(fieldName).NotTo(Equal(HealthFieldName))
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte("hello"), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{
+			Health: func() Health {
+				return Health{Version: "1.2.3", Uptime: 90 * time.Second, SchemasLoaded: 2, UpstreamsHealthy: true}
+			},
+		})
+		healthServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer healthServer.Close()
+		result := query(healthServer.URL, `{greeting _sqoop{version uptime schemasLoaded upstreamsHealthy}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"greeting": "hello",
+			"_sqoop": map[string]interface{}{
+				"version":          "1.2.3",
+				"uptime":           float64(90),
+				"schemasLoaded":    float64(2),
+				"upstreamsHealthy": true,
+			},
+		}))
+
+		declared := MustParseSchema(`
+type Query {
+	_sqoop: String
+}
+`)
+		Expect(declared.AddHealthField()).To(BeFalse())
+	})
+	It("applies directive handlers to resolved values", func() {
+		sch := MustParseSchema(`
+directive @uppercase on FIELD_DEFINITION
+type Query {
+	greeting: String @uppercase
+	farewell: String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte("hello " + fieldName), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{
+			Directives: DirectiveHandlers{
+				"uppercase": func(ctx context.Context, value interface{}, args map[string]interface{}) (interface{}, error) {
+					return strings.ToUpper(value.(string)), nil
+				},
+			},
+		})
+		directiveServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer directiveServer.Close()
+		result := query(directiveServer.URL, `{greeting farewell}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"greeting": "HELLO GREETING",
+			"farewell": "hello farewell",
+		}))
+	})
+	It("passes the fields of the parent resolved before a field to its resolver", func() {
+		sch := MustParseSchema(`
+type Query {
+	user: User
+}
+type User {
+	name: String
+	plan: String
+	perks: String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			switch typeName + "." + fieldName {
+			case "Query.user":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					Expect(params.Siblings).To(BeNil())
+					return []byte(`{"name":"Leia"}`), nil
+				}, nil
+			case "User.plan":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					return []byte("premium"), nil
+				}, nil
+			case "User.perks":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					Expect(params.Siblings.Keys).To(Equal([]string{"name", "plan"}))
+					return []byte("perks of the " + params.Siblings.Get("plan").(*dynamic.String).Data + " plan"), nil
+				}, nil
+			}
+			return nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		siblingsServer := httptest.NewServer(handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{})))
+		defer siblingsServer.Close()
+		result := query(siblingsServer.URL, `{user{name plan perks}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data["user"]).To(Equal(map[string]interface{}{
+			"name":  "Leia",
+			"plan":  "premium",
+			"perks": "perks of the premium plan",
+		}))
+	})
+	It("resolves fields whose resolver returns no data to null", func() {
+		sch := MustParseSchema(`
+type Query {
+	name: String
+	hero: Human
+}
+type Human {
+	name: String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return nil, nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		nullServer := httptest.NewServer(handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{})))
+		defer nullServer.Close()
+		result := query(nullServer.URL, `{name hero{name}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data).To(Equal(map[string]interface{}{"name": nil, "hero": nil}))
+	})
+	It("computes the cache policy from the cacheControl directives of resolved fields", func() {
+		sch := MustParseSchema(`
+directive @cacheControl(maxAge: Int) on FIELD_DEFINITION
+type Query {
+	daily: String @cacheControl(maxAge: 86400)
+	hourly: String @cacheControl(maxAge: 3600)
+	uncached: String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte(fieldName), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		gqlHandler := handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{}))
+		var (
+			cacheControl  string
+			cookies       map[string]string
+			authenticated bool
+		)
+		cacheServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, policy := WithCachePolicy(r.Context())
+			if cookies != nil {
+				ctx = WithCookies(ctx, cookies)
+			}
+			if authenticated {
+				ctx = WithAuthenticated(ctx)
+			}
+			gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+			cacheControl = policy.Header()
+		}))
+		defer cacheServer.Close()
+		query(cacheServer.URL, `{daily hourly}`)
+		Expect(cacheControl).To(Equal("max-age=3600"))
+		// root fields without a hint are not cached
+		query(cacheServer.URL, `{daily uncached}`)
+		Expect(cacheControl).To(Equal("no-cache"))
+		query(cacheServer.URL, `{uncached}`)
+		Expect(cacheControl).To(Equal("no-cache"))
+		query(cacheServer.URL, `{__typename}`)
+		Expect(cacheControl).To(BeEmpty())
+
+		// resolvers could read the credentials of the request, so only the client may cache the response
+		cookies = map[string]string{"session": "secret"}
+		query(cacheServer.URL, `{daily hourly}`)
+		Expect(cacheControl).To(Equal("private, max-age=3600"))
+
+		// authenticated requests, e.g. with an api key, whose credential carries no claims
+		cookies = nil
+		authenticated = true
+		query(cacheServer.URL, `{daily hourly}`)
+		Expect(cacheControl).To(Equal("private, max-age=3600"))
+	})
+	It("reports the examples of fields and arguments of introspected types", func() {
+		sch := MustParseSchema(`
+type Query {
+	hero(episode: Episode @example(value: JEDI), limit: Int = 10): Character @example(value: {name: "R2-D2", friends: ["Luke"]})
+	greeting: String
+}
+type Character {
+	name: String @example(value: "Luke Skywalker") @deprecated(reason: "use fullName")
+}
+enum Episode { NEWHOPE EMPIRE JEDI }
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte(`"hello"`), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		gqlHandler := handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{}))
+		var examples map[string]interface{}
+		examplesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, collected := WithExamples(r.Context())
+			gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+			examples = collected.Examples()
+		}))
+		defer examplesServer.Close()
+		result := query(examplesServer.URL, `{__schema{queryType{name}}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(examples).To(Equal(map[string]interface{}{
+			"Query.hero":           map[string]interface{}{"name": "R2-D2", "friends": []interface{}{"Luke"}},
+			"Query.hero(episode:)": "JEDI",
+			"Character.name":       "Luke Skywalker",
+		}))
+		query(examplesServer.URL, `{__type(name: "Character"){name}}`)
+		Expect(examples).To(Equal(map[string]interface{}{"Character.name": "Luke Skywalker"}))
+		query(examplesServer.URL, `{greeting}`)
+		Expect(examples).To(BeNil())
+
+		_, err = ParseSchema(`type Query { greeting: String } enum Episode { JEDI @example(value: JEDI) }`)
+		Expect(err).To(MatchError(ContainSubstring("only supported on fields and arguments")))
+	})
+	It("accepts schemas which declare the example directive", func() {
+		sch, err := ParseSchema(`
+directive @example(value: String) on FIELD_DEFINITION | ARGUMENT_DEFINITION
+type Query {
+	greeting(name: String @example(value: "Leia")): String @example(value: "hello")
+}
+`)
+		Expect(err).NotTo(HaveOccurred())
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte(`"hello"`), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		gqlHandler := handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{}))
+		var examples map[string]interface{}
+		examplesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, collected := WithExamples(r.Context())
+			gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+			examples = collected.Examples()
+		}))
+		defer examplesServer.Close()
+		result := query(examplesServer.URL, `{__schema{queryType{name}}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(examples).To(Equal(map[string]interface{}{
+			"Query.greeting":        "hello",
+			"Query.greeting(name:)": "Leia",
+		}))
+	})
+	It("collects the status each resolver received from its upstream by field path", func() {
+		gqlHandler := handler.GraphQL(test.StarWarsExecutableSchema(proxyAddr))
+		var statuses map[string]int
+		statusServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, upstreamStatuses := WithUpstreamStatuses(r.Context())
+			gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+			statuses = upstreamStatuses.Statuses()
+		}))
+		defer statusServer.Close()
+		query(statusServer.URL, `{hero{name friends{name}}}`)
+		Expect(statuses).To(Equal(map[string]int{"hero": 200, "hero.friends": 200}))
+	})
+	It("collects the time each resolver spent calling its upstream by field path", func() {
+		gqlHandler := handler.GraphQL(test.StarWarsExecutableSchema(proxyAddr))
+		var timings map[string]float64
+		timingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, upstreamTimings := WithUpstreamTimings(r.Context())
+			gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+			timings = upstreamTimings.Timings()
+		}))
+		defer timingServer.Close()
+		query(timingServer.URL, `{hero{name friends{name}}}`)
+		Expect(timings).To(HaveKeyWithValue("hero", BeNumerically(">", 0)))
+		Expect(timings).To(HaveKeyWithValue("hero.friends", BeNumerically(">", 0)))
+	})
+	It("makes identical upstream calls of a query once when calls are deduplicated", func() {
+		result := query(server.URL, `{a: hero{name} b: hero{id}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(atomic.LoadInt32(&heroCalls)).To(Equal(int32(2)))
+		execSchema := NewExecutableSchema(test.StarWarsSchema, test.StarWarsExecutableResolvers(proxyAddr), Options{
+			DedupUpstreamCalls: true,
+		})
+		dedupServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer dedupServer.Close()
+		result = query(dedupServer.URL, `{a: hero{name} b: hero{id}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data).To(Equal(map[string]interface{}{
+			"a": map[string]interface{}{"name": "Luke Skywalker"},
+			"b": map[string]interface{}{"id": "1000"},
+		}))
+		Expect(atomic.LoadInt32(&heroCalls)).To(Equal(int32(3)))
+	})
+	Describe("deduplicated upstream calls", func() {
+		var (
+			upstream *httptest.Server
+			calls    int32
+		)
+		BeforeEach(func() {
+			calls = 0
+			upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+				w.Write([]byte("hello"))
+			}))
+		})
+		AfterEach(func() {
+			upstream.Close()
+		})
+		// serves a schema whose fields make the same upstream call, each under the context returned by setup
+		serve := func(setup func(ctx context.Context, fieldName string) (context.Context, context.CancelFunc)) *httptest.Server {
+			sch := MustParseSchema(`
+type Query {
+	first: String
+	second: String
+}
+`)
+			resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					ctx, cancel := setup(ctx, fieldName)
+					defer cancel()
+					req, err := http.NewRequest("GET", upstream.URL, nil)
+					if err != nil {
+						return nil, err
+					}
+					res, err := DoUpstream(ctx, http.DefaultClient, req, nil)
+					if err != nil {
+						return nil, err
+					}
+					defer res.Body.Close()
+					return ReadResponseBody(ctx, res.Body)
+				}, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return httptest.NewServer(handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{DedupUpstreamCalls: true})))
+		}
+
+		It("does not fail the shared call when the timeout of the resolver which made it first expires", func() {
+			srv := serve(func(ctx context.Context, fieldName string) (context.Context, context.CancelFunc) {
+				if fieldName == "first" {
+					return context.WithTimeout(ctx, 10*time.Millisecond)
+				}
+				return context.WithCancel(ctx)
+			})
+			defer srv.Close()
+			result := query(srv.URL, `{first second}`)
+			Expect(result.Errors).To(HaveLen(1))
+			Expect(result.Errors[0].Path).To(Equal([]interface{}{"first"}))
+			Expect(result.Data["second"]).To(Equal("hello"))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+		It("reads the shared response under the body limit of each resolver", func() {
+			limits := map[string]int64{"first": 2, "second": 0}
+			for _, order := range []string{`{first second}`, `{second first}`} {
+				srv := serve(func(ctx context.Context, fieldName string) (context.Context, context.CancelFunc) {
+					ctx = WithBodyLimits(ctx, BodyLimits{MaxResponseBytes: limits[fieldName]})
+					return context.WithCancel(ctx)
+				})
+				result := query(srv.URL, order)
+				srv.Close()
+				Expect(result.Errors).To(HaveLen(1))
+				Expect(result.Errors[0].Message).To(ContainSubstring("larger than the limit of 2 bytes"))
+				Expect(result.Data["second"]).To(Equal("hello"))
+			}
+		})
+	})
+	It("reuses cached plans for repeated operations with new variables", func() {
+		sch := MustParseSchema(`
+type Query {
+	greeter: Greeter
+}
+type Greeter {
+	greeting(name: String): String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				if fieldName == "greeter" {
+					return []byte(`{}`), nil
+				}
+				return []byte(fmt.Sprintf("hello %v", params.Arg("name"))), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{PlanCacheSize: 10})
+		q := `query($name: String) { greeter { greeting(name: $name) } }`
+		doc, qErr := gqlquery.Parse(q)
+		Expect(qErr).To(BeNil())
+		op, err := doc.GetOperation("")
+		Expect(err).NotTo(HaveOccurred())
+		execute := func(name string) string {
+			ctx := graphql.WithRequestContext(context.Background(),
+				graphql.NewRequestContext(doc, q, map[string]interface{}{"name": name}))
+			res := execSchema.Query(ctx, op)
+			Expect(res.Errors).To(BeEmpty())
+			return string(res.Data)
+		}
+		Expect(execute("Leia")).To(MatchJSON(`{"greeter":{"greeting":"hello Leia"}}`))
+		Expect(execute("Luke")).To(MatchJSON(`{"greeter":{"greeting":"hello Luke"}}`))
+		Expect(execute("Leia")).To(MatchJSON(`{"greeter":{"greeting":"hello Leia"}}`))
+	})
+	It("does not share the arguments of cached plans with resolvers", func() {
+		sch := MustParseSchema(`
+type Query {
+	greeting(input: Greeting): String
+}
+input Greeting {
+	name: String
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				input := params.Args["input"].(map[string]interface{})
+				name := input["name"]
+				// as a template calling set would
+				input["name"] = "changed"
+				return []byte(name.(string)), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{PlanCacheSize: 10})
+		q := `{ greeting(input: {name: "Leia"}) }`
+		doc, qErr := gqlquery.Parse(q)
+		Expect(qErr).To(BeNil())
+		op, err := doc.GetOperation("")
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 2; i++ {
+			ctx := graphql.WithRequestContext(context.Background(), graphql.NewRequestContext(doc, q, nil))
+			res := execSchema.Query(ctx, op)
+			Expect(res.Errors).To(BeEmpty())
+			Expect(string(res.Data)).To(MatchJSON(`{"greeting":"Leia"}`))
+		}
+	})
+	It("resolves list items concurrently, keeping their order", func() {
+		sch := MustParseSchema(`
+type Query {
+	items: [Item]
+}
+type Item {
+	id: Int
+	detail: String
+}
+`)
+		var inFlight, maxInFlight int32
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			switch typeName + "." + fieldName {
+			case "Query.items":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					return []byte(`[{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}]`), nil
+				}, nil
+			case "Item.detail":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					n := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+							break
+						}
+					}
+					// later items finish first
+					id := int(params.Parent.Data.Get("id").(*dynamic.Float).Data)
+					time.Sleep(time.Duration(6-id) * 10 * time.Millisecond)
+					return []byte(fmt.Sprintf("item %v", id)), nil
+				}, nil
+			}
+			return nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{
+			ListConcurrency: func(typeName, fieldName string) int {
+				if typeName == "Query" && fieldName == "items" {
+					return 2
+				}
+				return 0
+			},
+		})
+		listServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer listServer.Close()
+		result := query(listServer.URL, `{items{detail}}`)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Data["items"]).To(Equal([]interface{}{
+			map[string]interface{}{"detail": "item 1"},
+			map[string]interface{}{"detail": "item 2"},
+			map[string]interface{}{"detail": "item 3"},
+			map[string]interface{}{"detail": "item 4"},
+			map[string]interface{}{"detail": "item 5"},
+		}))
+		Expect(maxInFlight).To(Equal(int32(2)))
+	})
+	It("bounds the items of nested lists resolved concurrently by the operation", func() {
+		sch := MustParseSchema(`
+type Query {
+	groups: [Group]
+}
+type Group {
+	items: [Item]
+}
+type Item {
+	id: Int
+	detail: String
+}
+`)
+		var inFlight, maxInFlight int32
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			switch typeName + "." + fieldName {
+			case "Query.groups":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					group := `{"items":[{"id":1},{"id":2},{"id":3}]}`
+					return []byte("[" + strings.Join([]string{group, group, group}, ",") + "]"), nil
+				}, nil
+			case "Item.detail":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					n := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					id := int(params.Parent.Data.Get("id").(*dynamic.Float).Data)
+					return []byte(fmt.Sprintf("item %v", id)), nil
+				}, nil
+			}
+			return nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{
+			ListConcurrency: func(typeName, fieldName string) int {
+				return 3
+			},
+			MaxConcurrentItems: 3,
+		})
+		listServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer listServer.Close()
+		result := query(listServer.URL, `{groups{items{detail}}}`)
+		Expect(result.Errors).To(BeEmpty())
+		items := []interface{}{
+			map[string]interface{}{"detail": "item 1"},
+			map[string]interface{}{"detail": "item 2"},
+			map[string]interface{}{"detail": "item 3"},
+		}
+		group := map[string]interface{}{"items": items}
+		Expect(result.Data["groups"]).To(Equal([]interface{}{group, group, group}))
+		Expect(maxInFlight).To(BeNumerically("<=", 3))
+	})
+	It("stops resolving fields once the operation exceeds the maximum resolutions", func() {
+		sch := MustParseSchema(`
+type Query {
+	items: [Item]
+}
+type Item {
+	detail: String
+}
+`)
+		var calls int32
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			switch typeName + "." + fieldName {
+			case "Query.items":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					return []byte(`[{},{},{},{},{}]`), nil
+				}, nil
+			case "Item.detail":
+				return func(ctx context.Context, params Params) ([]byte, error) {
+					atomic.AddInt32(&calls, 1)
+					return []byte("detail"), nil
+				}, nil
+			}
+			return nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{MaxResolutions: 4})
+		limitedServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer limitedServer.Close()
+		result := query(limitedServer.URL, `{items{detail}}`)
+		Expect(calls).To(Equal(int32(3)))
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Message).To(ContainSubstring("operation exceeded the maximum of 4 resolved fields"))
+		Expect(result.Data["items"]).To(Equal([]interface{}{
+			map[string]interface{}{"detail": "detail"},
+			map[string]interface{}{"detail": "detail"},
+			map[string]interface{}{"detail": "detail"},
+			map[string]interface{}{"detail": nil},
+			map[string]interface{}{"detail": nil},
+		}))
+	})
+	It("truncates or fails lists with more than the maximum number of items", func() {
+		sch := MustParseSchema(`
+type Query {
+	items: [Int]
+	strict: [Int]
+}
+`)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				return []byte(`[1,2,3,4,5]`), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		execSchema := NewExecutableSchema(sch, resolvers, Options{
+			ListLimit: func(typeName, fieldName string) ListLimit {
+				return ListLimit{MaxItems: 3, Fail: fieldName == "strict"}
+			},
+		})
+		limitedServer := httptest.NewServer(handler.GraphQL(execSchema))
+		defer limitedServer.Close()
+		result := query(limitedServer.URL, `{items strict}`)
+		Expect(result.Data["items"]).To(Equal([]interface{}{1.0, 2.0, 3.0}))
+		Expect(result.Data["strict"]).To(BeNil())
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Message).To(ContainSubstring(`field "strict" returned 5 items, more than the maximum of 3`))
+	})
+})
+
+type queryResult struct {
+	Data   map[string]interface{}
+	Errors []struct {
+		Message string
+		Path    []interface{}
+	}
+}
+
+func query(url, q string) queryResult {
+	body, err := json.Marshal(map[string]string{"query": q})
+	Expect(err).NotTo(HaveOccurred())
+	res, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	Expect(err).NotTo(HaveOccurred())
+	defer res.Body.Close()
+	var result queryResult
+	Expect(json.NewDecoder(res.Body).Decode(&result)).NotTo(HaveOccurred())
+	return result
+}
@@ -0,0 +1,302 @@
+package exec
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the neelance parser does not support type extensions, so extend declarations are merged into the
+// definitions they extend before parsing, e.g. type Query { hero: Character } extend type Query { droid: Droid }
+// is parsed as a single Query type with both fields. the fields, enum values, interfaces and directives
+// of an extension are added to its definition, wherever in the schema either is declared
+var extendKeyword = regexp.MustCompile(`\bextend\b`)
+
+// the kinds of definitions which may be extended
+var extensibleKinds = map[string]bool{"type": true, "interface": true, "enum": true, "input": true}
+
+// keywords which start a top level definition, ending the header of an extension without a body
+var definitionKeywords = map[string]bool{
+	"schema": true, "type": true, "interface": true, "union": true, "enum": true,
+	"input": true, "scalar": true, "directive": true, "extend": true,
+}
+
+type sdlToken struct {
+	text       string
+	start, end int
+	// the nesting of braces, parentheses and brackets around the token. brackets are at the depth outside them
+	depth int
+}
+
+func (t sdlToken) isName() bool {
+	c := t.text[0]
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func (t sdlToken) isString() bool {
+	return t.text[0] == '"'
+}
+
+// lexSDL splits a schema into tokens, dropping whitespace, commas and comments. it doesn't validate the
+// schema, which is left to the parser
+func lexSDL(sdl string) []sdlToken {
+	var (
+		tokens []sdlToken
+		depth  int
+	)
+	isNameChar := func(c byte) bool {
+		return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+	}
+	for i := 0; i < len(sdl); {
+		c := sdl[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+			continue
+		case c == '#':
+			for i < len(sdl) && sdl[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(sdl[i:], `"""`):
+			end := strings.Index(sdl[i+3:], `"""`)
+			if end < 0 {
+				i = len(sdl)
+			} else {
+				i += end + 6
+			}
+		case c == '"':
+			for i++; i < len(sdl) && sdl[i] != '"' && sdl[i] != '\n'; i++ {
+				if sdl[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		case isNameChar(c) || c == '-':
+			for i++; i < len(sdl) && (isNameChar(sdl[i]) || sdl[i] == '.'); i++ {
+			}
+		default:
+			i++
+		}
+		if i > len(sdl) {
+			i = len(sdl)
+		}
+		tok := sdlToken{text: sdl[start:i], start: start, end: i, depth: depth}
+		switch c {
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth--
+			tok.depth = depth
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// sdlDefinition is a top level definition, or an extension of one
+type sdlDefinition struct {
+	kind, name string
+	// the span of the definition, from its keyword to its closing brace
+	start, end int
+	// the end of the name, and of the last interface it implements, if any
+	nameEnd, interfacesEnd int
+	interfaces             []string
+	// whether interfaces are separated with &
+	ampersands bool
+	// the directives of the definition, as written
+	directives string
+	// the offsets of the opening and closing braces. -1 if the definition has no body
+	open, close int
+	// the body between the braces, and the names of the fields or values it declares
+	body    string
+	members []string
+}
+
+// parseDefinition parses the definition whose kind is the ith token
+func parseDefinition(sdl string, tokens []sdlToken, i int) *sdlDefinition {
+	def := &sdlDefinition{kind: tokens[i].text, start: tokens[i].start, open: -1, close: -1}
+	if i+1 >= len(tokens) || !tokens[i+1].isName() {
+		return nil
+	}
+	def.name = tokens[i+1].text
+	def.nameEnd = tokens[i+1].end
+	def.interfacesEnd = def.nameEnd
+	def.end = def.nameEnd
+	var (
+		implements      bool
+		directivesStart = -1
+		j               int
+	)
+	for j = i + 2; j < len(tokens); j++ {
+		tok := tokens[j]
+		if tok.depth == 0 && (tok.text == "{" || tok.isString() || definitionKeywords[tok.text] && tokens[j-1].text != "@") {
+			break
+		}
+		def.end = tok.end
+		switch {
+		case tok.depth > 0:
+		case tok.text == "implements":
+			implements = true
+		case tok.text == "&":
+			def.ampersands = true
+		case tok.text == "@":
+			implements = false
+			if directivesStart < 0 {
+				directivesStart = tok.start
+			}
+		case implements && tok.isName():
+			def.interfaces = append(def.interfaces, tok.text)
+			def.interfacesEnd = tok.end
+		}
+	}
+	if directivesStart >= 0 {
+		def.directives = strings.TrimSpace(sdl[directivesStart:def.end])
+	}
+	if j >= len(tokens) || tokens[j].text != "{" {
+		return def
+	}
+	def.open = tokens[j].start
+	for j++; j < len(tokens); j++ {
+		tok := tokens[j]
+		if tok.depth == 0 && tok.text == "}" {
+			def.close = tok.start
+			def.end = tok.end
+			def.body = sdl[def.open+1 : def.close]
+			break
+		}
+		if tok.depth != 1 || !tok.isName() || tokens[j-1].text == "@" {
+			continue
+		}
+		// fields are followed by their arguments or type. anything else is a type, default value or enum value
+		if def.kind == "enum" || j+1 < len(tokens) && (tokens[j+1].text == ":" || tokens[j+1].text == "(") {
+			def.members = append(def.members, tok.text)
+		}
+	}
+	return def
+}
+
+func (def *sdlDefinition) memberKind() string {
+	if def.kind == "enum" {
+		return "value"
+	}
+	return "field"
+}
+
+type sdlEdit struct {
+	start, end int
+	text       string
+}
+
+// applyExtensions merges the extend declarations of a schema into the definitions they extend
+func applyExtensions(sdl string) (string, error) {
+	if !extendKeyword.MatchString(sdl) {
+		return sdl, nil
+	}
+	tokens := lexSDL(sdl)
+	var (
+		definitions = make(map[string]*sdlDefinition)
+		extensions  []*sdlDefinition
+	)
+	for i, tok := range tokens {
+		if tok.depth != 0 || !definitionKeywords[tok.text] || i > 0 && (tokens[i-1].text == "@" || tokens[i-1].text == "extend") {
+			continue
+		}
+		if tok.text == "extend" {
+			if i+1 >= len(tokens) || !extensibleKinds[tokens[i+1].text] {
+				return "", errors.Errorf("only type, interface, enum and input definitions can be extended")
+			}
+			ext := parseDefinition(sdl, tokens, i+1)
+			if ext == nil {
+				return "", errors.Errorf("extension of %v is missing the name of the definition it extends", tokens[i+1].text)
+			}
+			ext.start = tok.start
+			extensions = append(extensions, ext)
+			continue
+		}
+		if def := parseDefinition(sdl, tokens, i); def != nil && definitions[def.name] == nil {
+			definitions[def.name] = def
+		}
+	}
+
+	var edits []sdlEdit
+	members := make(map[*sdlDefinition]map[string]bool)
+	interfaces := make(map[*sdlDefinition]map[string]bool)
+	for _, ext := range extensions {
+		def, ok := definitions[ext.name]
+		if !ok {
+			return "", errors.Errorf("cannot extend %v %v, it is not defined", ext.kind, ext.name)
+		}
+		if def.kind != ext.kind {
+			return "", errors.Errorf("cannot extend %v %v, it is defined as %v %v", ext.kind, ext.name, def.kind, def.name)
+		}
+		if def.open < 0 {
+			return "", errors.Errorf("cannot extend %v %v, its definition has no body", ext.kind, ext.name)
+		}
+		if members[def] == nil {
+			members[def] = stringSet(def.members)
+			interfaces[def] = stringSet(def.interfaces)
+		}
+		for _, member := range ext.members {
+			if members[def][member] {
+				return "", errors.Errorf("extension of %v %v redefines %v %v", ext.kind, ext.name, ext.memberKind(), member)
+			}
+			members[def][member] = true
+		}
+		for _, iface := range ext.interfaces {
+			if interfaces[def][iface] {
+				return "", errors.Errorf("extension of %v %v adds interface %v, which it already implements", ext.kind, ext.name, iface)
+			}
+			interfaces[def][iface] = true
+		}
+
+		edits = append(edits, sdlEdit{start: ext.start, end: ext.end})
+		if len(ext.interfaces) > 0 {
+			separator := " "
+			if def.ampersands || ext.ampersands {
+				separator = " & "
+			}
+			added := strings.Join(ext.interfaces, separator)
+			if len(def.interfaces) == 0 {
+				added = " implements " + added
+				def.interfaces = ext.interfaces
+			} else {
+				added = separator + added
+			}
+			edits = append(edits, sdlEdit{start: def.interfacesEnd, end: def.interfacesEnd, text: added})
+		}
+		if ext.directives != "" {
+			edits = append(edits, sdlEdit{start: def.open, end: def.open, text: ext.directives + " "})
+		}
+		if strings.TrimSpace(ext.body) != "" {
+			edits = append(edits, sdlEdit{start: def.close, end: def.close, text: ext.body + "\n"})
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+	var (
+		out  bytes.Buffer
+		last int
+	)
+	for _, edit := range edits {
+		out.WriteString(sdl[last:edit.start])
+		out.WriteString(edit.text)
+		last = edit.end
+	}
+	out.WriteString(sdl[last:])
+	return out.String(), nil
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+const (
+	// HealthFieldName is the field added to the query type by AddHealthField
+	HealthFieldName = "_sqoop"
+	healthTypeName  = "_SqoopHealth"
+)
+
+// Health is the state of Sqoop reported by the health field
+type Health struct {
+	Version string
+	Uptime  time.Duration
+	// the number of schemas being served
+	SchemasLoaded int
+	// false while the resolver routes of the current config have not been written to Gloo
+	UpstreamsHealthy bool
+}
+
+// AddHealthField adds the _sqoop field to the query type, so monitoring can probe Sqoop through any endpoint with
+// { _sqoop { version uptime schemasLoaded upstreamsHealthy } }. the field is resolved from Options.Health
+// rather than a resolver. nothing is added if the schema already declares the field or its type, leaving the
+// schema's own definitions untouched. returns whether the field was added
+func (s *Schema) AddHealthField() bool {
+	queryType, ok := s.EntryPoints["query"].(*schema.Object)
+	if !ok || queryType.Fields.Get(HealthFieldName) != nil || s.Types[healthTypeName] != nil {
+		return false
+	}
+	nonNull := func(typeName string) common.Type {
+		return &common.NonNull{OfType: s.Types[typeName]}
+	}
+	healthType := &schema.Object{
+		Name: healthTypeName,
+		Desc: "The state of the Sqoop instance serving the request",
+		Fields: schema.FieldList{
+			{Name: "version", Type: nonNull("String")},
+			{Name: "uptime", Type: nonNull("Float"), Desc: "Seconds since Sqoop started"},
+			{Name: "schemasLoaded", Type: nonNull("Int"), Desc: "The number of schemas being served"},
+			{Name: "upstreamsHealthy", Type: nonNull("Boolean"), Desc: "Whether the routes to upstreams are up to date"},
+		},
+	}
+	s.Types[healthTypeName] = healthType
+	queryType.Fields = append(queryType.Fields, &schema.Field{
+		Name: HealthFieldName,
+		Type: healthType,
+		Desc: "The state of the Sqoop instance serving the request",
+	})
+	s.healthField = true
+	return true
+}
+
+// isHealthField returns true for the field added by AddHealthField, which has no resolver
+func isHealthField(field *schema.Field) bool {
+	healthType, ok := field.Type.(*schema.Object)
+	return ok && field.Name == HealthFieldName && healthType.Name == healthTypeName
+}
+
+func (ec *executionContext) _Query__sqoop(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	if ec.opts.Health == nil {
+		return graphql.Null
+	}
+	// the state changes from one request to the next
+	cachePolicy(ctx).forbid()
+	health := ec.opts.Health()
+	fields := graphql.CollectFields(ec.Doc, field.Selections, []string{healthTypeName}, ec.Variables)
+	out := graphql.NewOrderedMap(len(fields))
+	for i, f := range fields {
+		out.Keys[i] = f.Alias
+		switch f.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString(healthTypeName)
+		case "version":
+			out.Values[i] = graphql.MarshalString(health.Version)
+		case "uptime":
+			out.Values[i] = graphql.MarshalFloat(health.Uptime.Seconds())
+		case "schemasLoaded":
+			out.Values[i] = graphql.MarshalInt(health.SchemasLoaded)
+		case "upstreamsHealthy":
+			out.Values[i] = graphql.MarshalBoolean(health.UpstreamsHealthy)
+		default:
+			out.Values[i] = graphql.Null
+		}
+	}
+	return out
+}
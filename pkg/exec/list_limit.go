@@ -0,0 +1,36 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/dynamic"
+)
+
+// ListLimit bounds the number of items of a list returned by a field
+type ListLimit struct {
+	// zero means no limit
+	MaxItems int
+	// fail the field instead of truncating the list
+	Fail bool
+	// report truncated lists with AddWarning
+	Warn bool
+}
+
+// apply truncates a list with more than the maximum number of items, or fails it. the items are dropped before
+// they are resolved, so the fields of dropped items never call their upstreams
+func (l ListLimit) apply(ctx context.Context, fieldName string, val dynamic.Value) (dynamic.Value, error) {
+	list, ok := val.(*dynamic.Array)
+	if !ok || l.MaxItems <= 0 || len(list.Data) <= l.MaxItems {
+		return val, nil
+	}
+	if l.Fail {
+		return nil, UpstreamError(errors.Errorf("field %q returned %v items, more than the maximum of %v",
+			fieldName, len(list.Data), l.MaxItems))
+	}
+	if l.Warn {
+		AddWarning(ctx, "field %q returned %v items, truncated to the maximum of %v", fieldName, len(list.Data), l.MaxItems)
+	}
+	list.Data = list.Data[:l.MaxItems]
+	return list, nil
+}
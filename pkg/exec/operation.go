@@ -0,0 +1,20 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+type operationKey struct{}
+
+// WithOperation returns a context for executing the given operation
+func WithOperation(ctx context.Context, op *query.Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// Operation returns the operation being executed, or nil if there is none
+func Operation(ctx context.Context) *query.Operation {
+	op, _ := ctx.Value(operationKey{}).(*query.Operation)
+	return op
+}
@@ -0,0 +1,242 @@
+package exec
+
+import (
+	"sync"
+
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// fieldPlan holds everything about a selected field that doesn't depend on the object it is resolved on.
+// Plans are built once per selection set and object type, then reused for every object resolved with them,
+// e.g. each item of a list
+type fieldPlan struct {
+	field graphql.CollectedField
+	// nil for meta fields like __typename
+	schemaField *schema.Field
+	// coerced arguments of the field, or the error coercing them. resolvers are passed a copy, as templates
+	// may modify the maps of their arguments, e.g. with set
+	args    map[string]interface{}
+	argsErr error
+	// from the @cacheControl directive of the field, if any
+	maxAge    int
+	hasMaxAge bool
+	// the number of items of a list returned by the field which may be resolved concurrently
+	listConcurrency int
+	listLimit       ListLimit
+}
+
+// selection sets are identified by the first selection of the slice parsed from the query document
+type planKey struct {
+	objectType *schema.Object
+	first      *query.Selection
+	len        int
+}
+
+type planCache struct {
+	mu    sync.Mutex
+	plans map[planKey][]*fieldPlan
+}
+
+// operationPlans caches plans across requests for each operation.
+// The router's document cache hands out the same parsed document for repeated queries, so operations
+// are identified by pointer. The cache belongs to an executable schema and is discarded with it
+// whenever the schema changes
+type operationPlans struct {
+	mu   sync.Mutex
+	size int
+	ops  map[*query.Operation]*planCache
+}
+
+func newOperationPlans(size int) *operationPlans {
+	if size <= 0 {
+		return nil
+	}
+	return &operationPlans{
+		size: size,
+		ops:  make(map[*query.Operation]*planCache),
+	}
+}
+
+// get the shared plans for an operation. when the cache is full it is cleared,
+// which drops the plans of documents which are no longer cached by the router
+func (p *operationPlans) get(op *query.Operation) *planCache {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if plans, ok := p.ops[op]; ok {
+		return plans
+	}
+	if len(p.ops) >= p.size {
+		p.ops = make(map[*query.Operation]*planCache)
+	}
+	plans := &planCache{plans: make(map[planKey][]*fieldPlan)}
+	p.ops[op] = plans
+	return plans
+}
+
+// selectionPlan returns the plans for the fields selected on objectType by sel
+func (ec *executionContext) selectionPlan(objectType *schema.Object, sel []query.Selection) []*fieldPlan {
+	key := planKey{objectType: objectType, len: len(sel)}
+	if len(sel) > 0 {
+		key.first = &sel[0]
+	}
+	ec.plans.mu.Lock()
+	defer ec.plans.mu.Unlock()
+	if plans, ok := ec.plans.plans[key]; ok {
+		return plans
+	}
+	plans := ec.sharedSelectionPlan(key, objectType, sel)
+	if plans == nil {
+		plans = ec.buildSelectionPlan(objectType, sel)
+	}
+	if ec.plans.plans == nil {
+		ec.plans.plans = make(map[planKey][]*fieldPlan)
+	}
+	ec.plans.plans[key] = plans
+	return plans
+}
+
+// sharedSelectionPlan returns the plans for a selection set from the operation's shared plans,
+// or nil if the selection set depends on the variables of the request
+func (ec *executionContext) sharedSelectionPlan(key planKey, objectType *schema.Object, sel []query.Selection) []*fieldPlan {
+	if ec.shared == nil {
+		return nil
+	}
+	ec.shared.mu.Lock()
+	defer ec.shared.mu.Unlock()
+	// selection sets which depend on variables are stored as nil
+	if plans, ok := ec.shared.plans[key]; ok {
+		return plans
+	}
+	var plans []*fieldPlan
+	if !usesVariables(ec.Doc, sel, make(map[string]bool)) {
+		plans = ec.buildSelectionPlan(objectType, sel)
+	}
+	ec.shared.plans[key] = plans
+	return plans
+}
+
+func (ec *executionContext) buildSelectionPlan(objectType *schema.Object, sel []query.Selection) []*fieldPlan {
+	fields := graphql.CollectFields(ec.Doc, sel, getImplementors(objectType), ec.Variables)
+	plans := make([]*fieldPlan, len(fields))
+	for i, field := range fields {
+		plans[i] = ec.newFieldPlan(objectType, field)
+	}
+	return plans
+}
+
+// usesVariables reports whether the fields collected from a selection set depend on variables,
+// either through their arguments or through @skip and @include. nested selection sets are planned separately
+func usesVariables(doc *query.Document, sel []query.Selection, visited map[string]bool) bool {
+	for _, s := range sel {
+		switch s := s.(type) {
+		case *query.Field:
+			if directivesUseVariables(s.Directives) {
+				return true
+			}
+			for _, arg := range s.Arguments {
+				if literalUsesVariables(arg.Value) {
+					return true
+				}
+			}
+		case *query.InlineFragment:
+			if directivesUseVariables(s.Directives) || usesVariables(doc, s.Selections, visited) {
+				return true
+			}
+		case *query.FragmentSpread:
+			if directivesUseVariables(s.Directives) {
+				return true
+			}
+			if visited[s.Name.Name] {
+				continue
+			}
+			visited[s.Name.Name] = true
+			if frag := doc.Fragments.Get(s.Name.Name); frag != nil && usesVariables(doc, frag.Selections, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func directivesUseVariables(directives common.DirectiveList) bool {
+	for _, d := range directives {
+		for _, arg := range d.Args {
+			if literalUsesVariables(arg.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func literalUsesVariables(lit common.Literal) bool {
+	switch lit := lit.(type) {
+	case *common.Variable:
+		return true
+	case *common.ListLit:
+		for _, entry := range lit.Entries {
+			if literalUsesVariables(entry) {
+				return true
+			}
+		}
+	case *common.ObjectLit:
+		for _, field := range lit.Fields {
+			if literalUsesVariables(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ec *executionContext) newFieldPlan(objectType *schema.Object, field graphql.CollectedField) *fieldPlan {
+	plan := &fieldPlan{
+		field:       field,
+		schemaField: objectType.Fields.Get(field.Name),
+		args:        field.Args,
+	}
+	if plan.schemaField != nil {
+		plan.args, plan.argsErr = ec.CoerceArgs(plan.schemaField, field.Args)
+	}
+	plan.maxAge, plan.hasMaxAge = fieldMaxAge(plan.schemaField)
+	if plan.schemaField != nil && ec.opts.ListConcurrency != nil {
+		plan.listConcurrency = ec.opts.ListConcurrency(objectType.Name, field.Name)
+	}
+	if plan.schemaField != nil && ec.opts.ListLimit != nil {
+		plan.listLimit = ec.opts.ListLimit(objectType.Name, field.Name)
+	}
+	return plan
+}
+
+// argsCopy copies the arguments of the field for a single resolution
+func (p *fieldPlan) argsCopy() map[string]interface{} {
+	if p.args == nil {
+		return nil
+	}
+	return copyValue(p.args).(map[string]interface{})
+}
+
+// copyValue copies the maps and lists of a coerced value. other values are immutable
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = copyValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = copyValue(val)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,55 @@
+package exec
+
+import (
+	"context"
+	"net/http"
+)
+
+// Revalidation lets a cached result be revalidated with the upstream it came from, using the entity tag
+// of the upstream response the result was cached from
+type Revalidation struct {
+	// the entity tag of the cached result, sent in If-None-Match. empty if there is none
+	ETag string
+	// the entity tag of the upstream response, if it had one
+	ResponseETag string
+	// whether the upstream answered 304 Not Modified to the revalidation
+	NotModified bool
+}
+
+type revalidationKey struct{}
+
+// WithRevalidation returns a context in which resolvers revalidate the result cached with the given entity tag,
+// and record the entity tag of their response
+func WithRevalidation(ctx context.Context, etag string) (context.Context, *Revalidation) {
+	rv := &Revalidation{ETag: etag}
+	return context.WithValue(ctx, revalidationKey{}, rv), rv
+}
+
+// WithoutRevalidation returns a context in which resolvers don't revalidate, for resolvers which make several
+// upstream requests to produce a single result
+func WithoutRevalidation(ctx context.Context) context.Context {
+	if ctx.Value(revalidationKey{}) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, revalidationKey{}, (*Revalidation)(nil))
+}
+
+// RevalidateRequest sets If-None-Match on an upstream request if the result being resolved is cached
+// with an entity tag
+func RevalidateRequest(ctx context.Context, req *http.Request) {
+	if rv, _ := ctx.Value(revalidationKey{}).(*Revalidation); rv != nil && rv.ETag != "" {
+		req.Header.Set("If-None-Match", rv.ETag)
+	}
+}
+
+// RecordRevalidation records the entity tag of an upstream response. it returns true if the upstream answered
+// 304 Not Modified to a revalidation, in which case the resolver returns no data and the cached result is served
+func RecordRevalidation(ctx context.Context, res *http.Response) bool {
+	rv, _ := ctx.Value(revalidationKey{}).(*Revalidation)
+	if rv == nil {
+		return false
+	}
+	rv.ResponseETag = res.Header.Get("ETag")
+	rv.NotModified = res.StatusCode == http.StatusNotModified && rv.ETag != ""
+	return rv.NotModified
+}
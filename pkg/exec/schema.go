@@ -0,0 +1,127 @@
+package exec
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// the neelance parser does not accept directives on type definitions,
+// so @oneOf is stripped from input declarations before parsing
+var oneOfDeclaration = regexp.MustCompile(`\binput\s+([_A-Za-z][_0-9A-Za-z]*)\s*@oneOf\b`)
+
+// Schema wraps a parsed GraphQL schema along with the
+// type-level directives the underlying parser does not support
+type Schema struct {
+	*schema.Schema
+
+	// input object types declared with @oneOf
+	oneOfInputs map[string]bool
+	// whether the query type has the health field
+	healthField bool
+	// whether fields of input objects in variables which are not defined by the schema are dropped rather than rejected
+	ignoreUnknownInputFields bool
+	// the values of @example on fields and arguments, by coordinate
+	examples map[string]interface{}
+}
+
+func ParseSchema(sdl string) (*Schema, error) {
+	sdl, err := applyExtensions(sdl)
+	if err != nil {
+		return nil, err
+	}
+	oneOfInputs := make(map[string]bool)
+	for _, match := range oneOfDeclaration.FindAllStringSubmatch(sdl, -1) {
+		oneOfInputs[match[1]] = true
+	}
+	sdl = oneOfDeclaration.ReplaceAllString(sdl, "input $1")
+	sdl, examples, err := extractExamples(sdl)
+	if err != nil {
+		return nil, err
+	}
+	sdl = declareTimeout(sdl)
+
+	parsedSchema := schema.New()
+	if err := parsedSchema.Parse(sdl); err != nil {
+		return nil, locateSchemaFile(sdl, err)
+	}
+	return &Schema{Schema: parsedSchema, oneOfInputs: oneOfInputs, examples: examples}, nil
+}
+
+func MustParseSchema(sdl string) *Schema {
+	s, err := ParseSchema(sdl)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// IsOneOf returns true if the input object was declared with @oneOf
+func (s *Schema) IsOneOf(inputName string) bool {
+	return s.oneOfInputs[inputName]
+}
+
+// CoerceArgs validates the arguments provided for a field against the input types declared in the schema
+func (s *Schema) CoerceArgs(field *schema.Field, args map[string]interface{}) (map[string]interface{}, error) {
+	for _, arg := range field.Args {
+		val, ok := args[arg.Name.Name]
+		if !ok {
+			continue
+		}
+		if err := s.coerceInput(arg.Type, val); err != nil {
+			return nil, errors.Wrapf(err, "invalid value for argument %v", arg.Name.Name)
+		}
+	}
+	return args, nil
+}
+
+func (s *Schema) coerceInput(typ common.Type, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	switch typ := typ.(type) {
+	case *common.NonNull:
+		return s.coerceInput(typ.OfType, val)
+	case *common.List:
+		list, ok := val.([]interface{})
+		if !ok {
+			// single values are coerced to a list of one
+			return s.coerceInput(typ.OfType, val)
+		}
+		for i, item := range list {
+			if err := s.coerceInput(typ.OfType, item); err != nil {
+				return errors.Wrapf(err, "list item %v", i)
+			}
+		}
+	case *schema.InputObject:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("expected input object %v", typ.Name)
+		}
+		if s.IsOneOf(typ.Name) {
+			if err := validateOneOf(typ, obj); err != nil {
+				return err
+			}
+		}
+		for _, field := range typ.Values {
+			if err := s.coerceInput(field.Type, obj[field.Name.Name]); err != nil {
+				return errors.Wrapf(err, "field %v", field.Name.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateOneOf(typ *schema.InputObject, obj map[string]interface{}) error {
+	if len(obj) != 1 {
+		return errors.Errorf("exactly one field must be set for @oneOf input %v, got %v", typ.Name, len(obj))
+	}
+	for name, val := range obj {
+		if val == nil {
+			return errors.Errorf("field %v of @oneOf input %v must not be null", name, typ.Name)
+		}
+	}
+	return nil
+}
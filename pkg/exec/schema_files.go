@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+)
+
+// the files of a schema directory are concatenated, each preceded by a comment naming it, so that errors
+// parsing the schema can be traced back to the file they were found in
+const schemaFileMarker = "# sqoop:file "
+
+// the extensions of the files read from a schema directory
+var schemaFileExtensions = map[string]bool{".graphql": true, ".graphqls": true}
+
+// ReadSchemaDir concatenates the .graphql and .graphqls files of a directory, in order of their names, into
+// a single schema. extensions are resolved across files when the schema is parsed
+func ReadSchemaDir(dir string) (string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading schema directory %v", dir)
+	}
+	buf := &bytes.Buffer{}
+	for _, info := range infos {
+		if info.IsDir() || !schemaFileExtensions[filepath.Ext(info.Name())] {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			return "", errors.Wrapf(err, "reading schema file %v", info.Name())
+		}
+		buf.WriteString(schemaFileMarker + info.Name() + "\n")
+		buf.Write(b)
+		buf.WriteString("\n")
+	}
+	if buf.Len() == 0 {
+		return "", errors.Errorf("schema directory %v contains no .graphql files", dir)
+	}
+	return buf.String(), nil
+}
+
+// locateSchemaFile names the file of a schema read with ReadSchemaDir in which the parser failed, and
+// rewrites the location of the error to the line of that file. lines are counted from the marker preceding
+// the error, so they may be off where extensions were merged into definitions earlier in the same file
+func locateSchemaFile(sdl string, err error) error {
+	queryErr, ok := err.(*gqlerrors.QueryError)
+	if !ok || len(queryErr.Locations) == 0 || !strings.Contains(sdl, schemaFileMarker) {
+		return err
+	}
+	lines := strings.Split(sdl, "\n")
+	loc := queryErr.Locations[0]
+	if loc.Line < 1 || loc.Line > len(lines) {
+		return err
+	}
+	for i := loc.Line - 1; i >= 0; i-- {
+		if !strings.HasPrefix(lines[i], schemaFileMarker) {
+			continue
+		}
+		file := strings.TrimPrefix(lines[i], schemaFileMarker)
+		return errors.Errorf("%v (file %v, line %v, column %v)", queryErr.Message, file, loc.Line-i-1, loc.Column)
+	}
+	return err
+}
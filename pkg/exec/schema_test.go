@@ -0,0 +1,179 @@
+package exec_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+const oneOfSchema = `
+type Query {
+    user(by: UserBy!): String
+    users(by: [UserBy]): String
+}
+input UserBy @oneOf {
+    id: ID
+    email: String
+}
+`
+
+var _ = Describe("Schema", func() {
+	var (
+		sch       *Schema
+		userField *schema.Field
+	)
+	BeforeEach(func() {
+		var err error
+		sch, err = ParseSchema(oneOfSchema)
+		Expect(err).NotTo(HaveOccurred())
+		userField = sch.Types["Query"].(*schema.Object).Fields.Get("user")
+	})
+	It("parses @oneOf input objects", func() {
+		Expect(sch.IsOneOf("UserBy")).To(BeTrue())
+		Expect(sch.Types).To(HaveKey("UserBy"))
+	})
+	It("accepts exactly one field", func() {
+		_, err := sch.CoerceArgs(userField, map[string]interface{}{
+			"by": map[string]interface{}{"email": "luke@tatooine.org"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("rejects zero fields", func() {
+		_, err := sch.CoerceArgs(userField, map[string]interface{}{
+			"by": map[string]interface{}{},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exactly one field must be set for @oneOf input UserBy"))
+	})
+	It("rejects multiple fields", func() {
+		_, err := sch.CoerceArgs(userField, map[string]interface{}{
+			"by": map[string]interface{}{"id": "1000", "email": "luke@tatooine.org"},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("rejects a null field", func() {
+		_, err := sch.CoerceArgs(userField, map[string]interface{}{
+			"by": map[string]interface{}{"id": nil},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("validates @oneOf inputs inside lists", func() {
+		usersField := sch.Types["Query"].(*schema.Object).Fields.Get("users")
+		_, err := sch.CoerceArgs(usersField, map[string]interface{}{
+			"by": []interface{}{
+				map[string]interface{}{"id": "1000"},
+				map[string]interface{}{},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+const extendedSchema = `
+type Query {
+    hero: Character
+}
+interface Character {
+    name: String
+}
+interface Node {
+    id: ID!
+}
+type Droid implements Character {
+    name: String
+}
+enum Episode { NEWHOPE EMPIRE }
+input PetBy {
+    id: ID
+}
+
+extend type Query {
+    droid(id: ID!): Droid
+    episodes: [Episode]
+}
+extend type Droid implements Node {
+    id: ID!
+}
+extend enum Episode { JEDI }
+extend input PetBy @oneOf {
+    name: String
+}
+`
+
+var _ = Describe("Schema extensions", func() {
+	It("merges extensions into the definitions they extend", func() {
+		sch, err := ParseSchema(extendedSchema)
+		Expect(err).NotTo(HaveOccurred())
+		query := sch.Types["Query"].(*schema.Object)
+		Expect(query.Fields.Get("hero")).NotTo(BeNil())
+		Expect(query.Fields.Get("droid")).NotTo(BeNil())
+		droid := sch.Types["Droid"].(*schema.Object)
+		Expect(droid.Fields.Get("id")).NotTo(BeNil())
+		var interfaces []string
+		for _, iface := range droid.Interfaces {
+			interfaces = append(interfaces, iface.Name)
+		}
+		Expect(interfaces).To(ConsistOf("Character", "Node"))
+		Expect(sch.Types["Episode"].(*schema.Enum).Values).To(HaveLen(3))
+		Expect(sch.Types["PetBy"].(*schema.InputObject).Values).To(HaveLen(2))
+		Expect(sch.IsOneOf("PetBy")).To(BeTrue())
+	})
+	It("rejects extensions of types which are not defined", func() {
+		_, err := ParseSchema(`type Query { hero: String } extend type Mutation { addHero: String }`)
+		Expect(err).To(MatchError("cannot extend type Mutation, it is not defined"))
+		_, err = ParseSchema(`type Query { hero: String } extend input Query { hero: String }`)
+		Expect(err).To(MatchError("cannot extend input Query, it is defined as type Query"))
+	})
+	It("rejects extensions which redefine fields and values", func() {
+		_, err := ParseSchema(`type Query { hero: String } extend type Query { hero(id: ID): String }`)
+		Expect(err).To(MatchError("extension of type Query redefines field hero"))
+		_, err = ParseSchema(`type Query { hero: String } enum Episode { JEDI } extend enum Episode { JEDI }`)
+		Expect(err).To(MatchError("extension of enum Episode redefines value JEDI"))
+	})
+})
+
+var _ = Describe("Schema directories", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "schemadir")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	writeFile := func(name, contents string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)).To(Succeed())
+	}
+	It("parses the graphql files of the directory as one schema", func() {
+		writeFile("a_query.graphql", "type Query {\n    hero: Character\n}\n")
+		writeFile("b_droids.graphqls", "extend type Query {\n    droid: Character\n}\n")
+		writeFile("c_character.graphql", "type Character {\n    name: String\n}\n")
+		writeFile("README.md", "not a schema")
+		sdl, err := ReadSchemaDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		sch, err := ParseSchema(sdl)
+		Expect(err).NotTo(HaveOccurred())
+		query := sch.Types["Query"].(*schema.Object)
+		Expect(query.Fields.Get("hero")).NotTo(BeNil())
+		Expect(query.Fields.Get("droid")).NotTo(BeNil())
+	})
+	It("reports the file which failed to parse", func() {
+		writeFile("a_query.graphql", "type Query {\n    hero: String\n}\n")
+		writeFile("b_broken.graphql", "type Broken {\n    name: String\n    bad field\n}\n")
+		sdl, err := ReadSchemaDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ParseSchema(sdl)
+		Expect(err).To(MatchError(ContainSubstring("(file b_broken.graphql, line 3,")))
+	})
+	It("rejects directories without graphql files", func() {
+		_, err := ReadSchemaDir(dir)
+		Expect(err).To(MatchError(ContainSubstring("contains no .graphql files")))
+	})
+})
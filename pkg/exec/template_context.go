@@ -0,0 +1,34 @@
+package exec
+
+import "context"
+
+// names of the well known values of the request being served which resolver templates can read from .ctx,
+// e.g. {{ .ctx.tenant }}
+const (
+	ContextRequestID = "requestId"
+	ContextSubject   = "subject"
+	ContextTenant    = "tenant"
+	ContextTraceID   = "traceId"
+)
+
+type templateContextKey struct{}
+
+// WithContextValue returns a context in which resolver templates can read the value from .ctx under the given name.
+// values are set by request middleware, empty values are left out
+func WithContextValue(ctx context.Context, name, value string) context.Context {
+	if value == "" {
+		return ctx
+	}
+	values := make(map[string]string)
+	for k, v := range TemplateContext(ctx) {
+		values[k] = v
+	}
+	values[name] = value
+	return context.WithValue(ctx, templateContextKey{}, values)
+}
+
+// TemplateContext returns the values resolver templates can read from .ctx, by name. nil if there are none
+func TemplateContext(ctx context.Context) map[string]string {
+	values, _ := ctx.Value(templateContextKey{}).(map[string]string)
+	return values
+}
@@ -0,0 +1,71 @@
+package exec
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// declared in every schema which doesn't declare it itself, so operations can request a deadline,
+// e.g. query Hero @timeout(ms: 500) { hero { name } }
+const timeoutDirective = `
+directive @timeout(ms: Int!) on QUERY | MUTATION
+`
+
+var timeoutDeclaration = regexp.MustCompile(`\bdirective\s+@timeout\b`)
+
+func declareTimeout(sdl string) string {
+	if timeoutDeclaration.MatchString(sdl) {
+		return sdl
+	}
+	return sdl + timeoutDirective
+}
+
+// operationTimeout returns the time the operation may take: the timeout it requests with @timeout, up to
+// the maximum allowed, or the default timeout of the schema. zero means no limit
+func (e *executableSchema) operationTimeout(op *query.Operation, vars map[string]interface{}) (time.Duration, error) {
+	var directive *common.Directive
+	for _, d := range op.Directives {
+		if d.Name.Name == "timeout" {
+			directive = d
+		}
+	}
+	if directive == nil {
+		return e.opts.OperationTimeout, nil
+	}
+	var ms int64
+	for _, arg := range directive.Args {
+		if arg.Name.Name != "ms" {
+			continue
+		}
+		switch val := arg.Value.Value(vars).(type) {
+		case int32:
+			ms = int64(val)
+		case int:
+			ms = int64(val)
+		case int64:
+			ms = val
+		case float64:
+			ms = int64(val)
+		case json.Number:
+			ms, _ = val.Int64()
+		}
+	}
+	if ms <= 0 {
+		return 0, errors.Errorf("@timeout must request a positive number of milliseconds")
+	}
+	timeout := time.Duration(ms) * time.Millisecond
+	max := e.opts.MaxOperationTimeout
+	if max == 0 {
+		// without a maximum, operations may only tighten the default
+		max = e.opts.OperationTimeout
+	}
+	if max > 0 && timeout > max {
+		timeout = max
+	}
+	return timeout, nil
+}
@@ -0,0 +1,86 @@
+package exec
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// Trace records the fields resolved by a single operation, for debugging
+type Trace struct {
+	mu     sync.Mutex
+	fields []*TracedField
+}
+
+// TracedField is a single resolved field. Its duration includes resolving its subfields
+type TracedField struct {
+	Path       []interface{} `json:"path"`
+	Field      string        `json:"field"`
+	DurationMs float64       `json:"durationMs"`
+	// the urls called by the resolver of the field
+	Upstreams []string `json:"upstreams,omitempty"`
+	Error     string   `json:"error,omitempty"`
+
+	trace *Trace
+	start time.Time
+}
+
+type traceKey struct{}
+
+type tracedFieldKey struct{}
+
+// WithTrace returns a context which traces the operation executed with it
+func WithTrace(ctx context.Context) (context.Context, *Trace) {
+	trace := &Trace{}
+	return context.WithValue(ctx, traceKey{}, trace), trace
+}
+
+// Fields returns the traced fields in the order they started resolving
+func (t *Trace) Fields() []*TracedField {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*TracedField(nil), t.fields...)
+}
+
+// TraceUpstream records a url called while resolving the field of ctx, if the operation is traced
+func TraceUpstream(ctx context.Context, url string) {
+	field, _ := ctx.Value(tracedFieldKey{}).(*TracedField)
+	if field == nil {
+		return
+	}
+	field.trace.mu.Lock()
+	field.Upstreams = append(field.Upstreams, url)
+	field.trace.mu.Unlock()
+}
+
+// start tracing the field of ctx. returns a nil field if the operation is not traced
+func startTrace(ctx context.Context, field string) (context.Context, *TracedField) {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	if trace == nil {
+		return ctx, nil
+	}
+	traced := &TracedField{
+		Path:  append([]interface{}(nil), graphql.GetResolverContext(ctx).Path...),
+		Field: field,
+		trace: trace,
+		start: time.Now(),
+	}
+	trace.mu.Lock()
+	trace.fields = append(trace.fields, traced)
+	trace.mu.Unlock()
+	return context.WithValue(ctx, tracedFieldKey{}, traced), traced
+}
+
+func (f *TracedField) finish(err error) {
+	if f == nil {
+		return
+	}
+	f.trace.mu.Lock()
+	defer f.trace.mu.Unlock()
+	f.DurationMs = float64(time.Since(f.start)) / float64(time.Millisecond)
+	if err != nil {
+		f.Error = err.Error()
+	}
+}
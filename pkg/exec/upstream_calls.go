@@ -0,0 +1,128 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"expvar"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// the number of upstream calls which shared the response of an identical call made by the same query
+var deduplicatedCalls = expvar.NewInt("sqoop_deduplicated_upstream_calls")
+
+// upstreamCalls holds the responses of the upstream calls made by a query, so that resolvers making a call
+// which was already made, e.g. for a field selected under several aliases, share its response rather than
+// repeating it. responses are held until the query completes
+type upstreamCalls struct {
+	// the context of the query. calls are sent under it rather than the context of the resolver which
+	// happened to make them first, so that its timeout or cancellation doesn't fail the others
+	ctx   context.Context
+	mu    sync.Mutex
+	calls map[string]*upstreamCall
+}
+
+type upstreamCall struct {
+	// closed once the call completes
+	done chan struct{}
+	// the response body limit the body was read with
+	limit int64
+	res   *http.Response
+	body  []byte
+	err   error
+}
+
+type upstreamCallsKey struct{}
+
+// withUpstreamCalls returns a context whose resolvers share identical upstream calls. calls still in flight
+// are cancelled by cancel, once the query completes
+func withUpstreamCalls(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	calls := &upstreamCalls{ctx: ctx, calls: make(map[string]*upstreamCall)}
+	return context.WithValue(ctx, upstreamCallsKey{}, calls), cancel
+}
+
+// DoUpstream sends an upstream request with client, unless the query of ctx already sent an identical request:
+// the same method, url, headers and body. identical requests share a single response, whether they are sent
+// concurrently or one after another. requests are only deduplicated for queries executed with
+// Options.DedupUpstreamCalls, never for mutations, whose calls are expected to have effects.
+//
+// a shared call is sent under the context of the query, so it is only bounded by the operation timeout.
+// every caller waits for it no longer than its own context allows, and reads the shared body under its own
+// limit. the body is read under the limit of the first caller; a caller with a larger limit makes its own
+// call if the body turned out to be larger than that
+func DoUpstream(ctx context.Context, client *http.Client, req *http.Request, body []byte) (*http.Response, error) {
+	calls, _ := ctx.Value(upstreamCallsKey{}).(*upstreamCalls)
+	if calls == nil {
+		return client.Do(req.WithContext(ctx))
+	}
+	limit := bodyLimits(ctx).MaxResponseBytes
+	key := upstreamCallKey(req, body)
+	calls.mu.Lock()
+	call, made := calls.calls[key]
+	if !made {
+		call = &upstreamCall{done: make(chan struct{}), limit: limit}
+		calls.calls[key] = call
+	}
+	calls.mu.Unlock()
+	if made {
+		deduplicatedCalls.Add(1)
+	} else {
+		go call.send(calls.ctx, client, req)
+	}
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if _, tooLarge := call.err.(*responseTooLarge); tooLarge && (limit <= 0 || limit > call.limit) {
+		return client.Do(req.WithContext(ctx))
+	}
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.response(req), nil
+}
+
+func (c *upstreamCall) send(ctx context.Context, client *http.Client, req *http.Request) {
+	defer close(c.done)
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		c.err = err
+		return
+	}
+	defer res.Body.Close()
+	c.res = res
+	c.body, c.err = readBody(res.Body, c.limit)
+}
+
+// response returns a copy of the response of the call, with a body of its own
+func (c *upstreamCall) response(req *http.Request) *http.Response {
+	res := *c.res
+	res.Header = make(http.Header, len(c.res.Header))
+	for name, values := range c.res.Header {
+		res.Header[name] = append([]string(nil), values...)
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+	res.Request = req
+	return &res
+}
+
+func upstreamCallKey(req *http.Request, body []byte) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	key := &bytes.Buffer{}
+	key.WriteString(req.Method + " " + req.URL.String() + "\n")
+	for _, name := range names {
+		key.WriteString(name + ": " + strings.Join(req.Header[name], ", ") + "\n")
+	}
+	key.WriteString("\n")
+	key.Write(body)
+	return key.String()
+}
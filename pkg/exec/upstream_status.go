@@ -0,0 +1,65 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// UpstreamStatuses collects the http status each resolver of an operation received from its upstream,
+// by the path of the field, e.g. "hero.friends.1.name"
+type UpstreamStatuses struct {
+	mu       sync.Mutex
+	statuses map[string]int
+}
+
+type upstreamStatusesKey struct{}
+
+// WithUpstreamStatuses returns a context which collects the upstream statuses of the operation executed with it
+func WithUpstreamStatuses(ctx context.Context) (context.Context, *UpstreamStatuses) {
+	statuses := &UpstreamStatuses{statuses: make(map[string]int)}
+	return context.WithValue(ctx, upstreamStatusesKey{}, statuses), statuses
+}
+
+// RecordUpstreamStatus records the status of a response received while resolving the field of ctx,
+// if the operation collects statuses
+func RecordUpstreamStatus(ctx context.Context, status int) {
+	statuses, _ := ctx.Value(upstreamStatusesKey{}).(*UpstreamStatuses)
+	if statuses == nil {
+		return
+	}
+	statuses.mu.Lock()
+	statuses.statuses[fieldPath(ctx)] = status
+	statuses.mu.Unlock()
+}
+
+// fieldPath returns the path of the field being resolved, e.g. "hero.friends.1.name"
+func fieldPath(ctx context.Context) string {
+	var path []string
+	if rctx := graphql.GetResolverContext(ctx); rctx != nil {
+		for _, element := range rctx.Path {
+			path = append(path, fmt.Sprint(element))
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// Statuses returns the collected statuses by field path. nil if no upstream was called
+func (s *UpstreamStatuses) Statuses() map[string]int {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.statuses) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(s.statuses))
+	for path, status := range s.statuses {
+		out[path] = status
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package exec
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UpstreamTimings collects the time the resolvers of an operation spent calling their upstreams, by the path
+// of the field, e.g. "hero.friends.1.name". a lightweight alternative to tracing for finding the slow upstream
+// of an operation
+type UpstreamTimings struct {
+	mu      sync.Mutex
+	timings map[string]time.Duration
+}
+
+type upstreamTimingsKey struct{}
+
+// WithUpstreamTimings returns a context which collects the upstream timings of the operation executed with it
+func WithUpstreamTimings(ctx context.Context) (context.Context, *UpstreamTimings) {
+	timings := &UpstreamTimings{timings: make(map[string]time.Duration)}
+	return context.WithValue(ctx, upstreamTimingsKey{}, timings), timings
+}
+
+// RecordUpstreamTiming adds the duration of an upstream call made while resolving the field of ctx,
+// if the operation collects timings. the calls of a field which calls its upstream several times are added up
+func RecordUpstreamTiming(ctx context.Context, duration time.Duration) {
+	timings, _ := ctx.Value(upstreamTimingsKey{}).(*UpstreamTimings)
+	if timings == nil {
+		return
+	}
+	path := fieldPath(ctx)
+	timings.mu.Lock()
+	timings.timings[path] += duration
+	timings.mu.Unlock()
+}
+
+// Timings returns the collected timings in milliseconds by field path. nil if no upstream was called
+func (t *UpstreamTimings) Timings() map[string]float64 {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.timings) == 0 {
+		return nil
+	}
+	out := make(map[string]float64, len(t.timings))
+	for path, duration := range t.timings {
+		out[path] = float64(duration) / float64(time.Millisecond)
+	}
+	return out
+}
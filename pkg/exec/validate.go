@@ -0,0 +1,137 @@
+package exec
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/dynamic"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// validateValue checks that a resolved value matches the type declared in the schema.
+// the fields of objects are not validated here, they are validated as they are resolved
+func validateValue(typ common.Type, val dynamic.Value, path string) error {
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		if isNull(val) {
+			return errors.Errorf("%v: expected non-null %v, got null", path, nonNull.OfType)
+		}
+		return validateValue(nonNull.OfType, val, path)
+	}
+	if isNull(val) {
+		return nil
+	}
+	switch typ := typ.(type) {
+	case *common.List:
+		array, ok := val.(*dynamic.Array)
+		if !ok {
+			return errors.Errorf("%v: expected list %v, got %v", path, typ, kindOf(val))
+		}
+		for i, item := range array.Data {
+			if err := validateValue(typ.OfType, item, fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+	case *schema.Object:
+		obj, ok := val.(*dynamic.Object)
+		if !ok || obj.Object != typ {
+			return errors.Errorf("%v: expected object %v, got %v", path, typ.Name, kindOf(val))
+		}
+	case *schema.Interface:
+		obj, ok := val.(*dynamic.Object)
+		if !ok || !implements(obj.Object, typ) {
+			return errors.Errorf("%v: expected implementation of %v, got %v", path, typ.Name, kindOf(val))
+		}
+	case *schema.Enum:
+		enum, ok := val.(*dynamic.Enum)
+		if !ok {
+			return errors.Errorf("%v: expected enum %v, got %v", path, typ.Name, kindOf(val))
+		}
+		for _, enumValue := range typ.Values {
+			if enumValue.Name == enum.Data {
+				return nil
+			}
+		}
+		return errors.Errorf("%v: %q is not a member of enum %v", path, enum.Data, typ.Name)
+	case *schema.Scalar:
+		return validateScalar(typ, val, path)
+	}
+	return nil
+}
+
+func validateScalar(scalar *schema.Scalar, val dynamic.Value, path string) error {
+	var valid bool
+	switch scalar.Name {
+	case "Int":
+		switch v := val.(type) {
+		case *dynamic.Int:
+			valid = v.Data >= math.MinInt32 && v.Data <= math.MaxInt32
+		case *dynamic.Float:
+			// json numbers are decoded as floats
+			valid = v.Data == math.Trunc(v.Data) && v.Data >= math.MinInt32 && v.Data <= math.MaxInt32
+		}
+	case "Float":
+		switch val.(type) {
+		case *dynamic.Int, *dynamic.Float:
+			valid = true
+		}
+	case "String":
+		_, valid = val.(*dynamic.String)
+	case "ID":
+		switch v := val.(type) {
+		case *dynamic.String, *dynamic.Int:
+			valid = true
+		case *dynamic.Float:
+			valid = v.Data == math.Trunc(v.Data)
+		}
+	case "Boolean":
+		_, valid = val.(*dynamic.Bool)
+	default:
+		// custom scalars are passed through as-is
+		valid = true
+	}
+	if !valid {
+		return errors.Errorf("%v: expected %v, got %v", path, scalar.Name, kindOf(val))
+	}
+	return nil
+}
+
+func implements(obj *schema.Object, iface *schema.Interface) bool {
+	for _, possibleType := range iface.PossibleTypes {
+		if possibleType == obj {
+			return true
+		}
+	}
+	return false
+}
+
+func isNull(val dynamic.Value) bool {
+	if val == nil {
+		return true
+	}
+	_, ok := val.(*dynamic.Null)
+	return ok
+}
+
+func kindOf(val dynamic.Value) string {
+	switch val := val.(type) {
+	case *dynamic.Object:
+		return "object " + val.Name
+	case *dynamic.Array:
+		return "list"
+	case *dynamic.Int:
+		return fmt.Sprintf("int %v", val.Data)
+	case *dynamic.Float:
+		return fmt.Sprintf("float %v", val.Data)
+	case *dynamic.String:
+		return "string"
+	case *dynamic.Bool:
+		return "boolean"
+	case *dynamic.Enum:
+		return "enum"
+	case *dynamic.Time:
+		return "time"
+	}
+	return fmt.Sprintf("%T", val)
+}
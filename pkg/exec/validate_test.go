@@ -0,0 +1,137 @@
+package exec_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/handler"
+)
+
+// the results of the fields of Result are decoded from the json returned by the resolver of Query.result,
+// rather than parsed as the raw scalar a resolver of the field itself would return
+const strictSchema = `
+scalar Date
+type Query {
+	result: Result
+	required: String!
+	names: [String!]
+}
+type Result {
+	count: Int
+	ratio: Float
+	name: String
+	id: ID
+	enabled: Boolean
+	episode: Episode
+	released: Date
+}
+enum Episode { NEWHOPE EMPIRE JEDI }
+`
+
+var _ = Describe("StrictOutput", func() {
+	var (
+		server  *httptest.Server
+		results map[string]string
+	)
+	BeforeEach(func() {
+		results = make(map[string]string)
+		sch := MustParseSchema(strictSchema)
+		resolvers, err := NewExecutableResolvers(sch.Schema, func(typeName, fieldName string) (RawResolver, error) {
+			if typeName != "Query" {
+				return nil, nil
+			}
+			return func(ctx context.Context, params Params) ([]byte, error) {
+				if fieldName == "result" {
+					result := "{"
+					for field, value := range results {
+						result += strconv.Quote(field) + ":" + value
+					}
+					return []byte(result + "}"), nil
+				}
+				if results[fieldName] == "null" {
+					return nil, nil
+				}
+				return []byte(results[fieldName]), nil
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		server = httptest.NewServer(handler.GraphQL(NewExecutableSchema(sch, resolvers, Options{StrictOutput: true})))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	resolve := func(field, result string) (interface{}, string) {
+		results = map[string]string{field: result}
+		q := "{" + field + "}"
+		if field != "required" && field != "names" {
+			q = "{result{" + field + "}}"
+		}
+		res := query(server.URL, q)
+		if len(res.Errors) == 0 {
+			if data, ok := res.Data["result"].(map[string]interface{}); ok {
+				return data[field], ""
+			}
+			return res.Data[field], ""
+		}
+		Expect(res.Errors).To(HaveLen(1))
+		return nil, res.Errors[0].Message
+	}
+	valid := func(field, result string) {
+		_, err := resolve(field, result)
+		Expect(err).To(BeEmpty(), "%v resolved to %v", field, result)
+	}
+	invalid := func(field, result, message string) {
+		_, err := resolve(field, result)
+		Expect(err).To(ContainSubstring(message), "%v resolved to %v", field, result)
+	}
+
+	It("accepts Ints within 32 bits", func() {
+		valid("count", `42`)
+		valid("count", `-2147483648`)
+		invalid("count", `2147483648`, "Result.count: expected Int, got float 2.147483648e+09")
+		invalid("count", `1.5`, "Result.count: expected Int, got float 1.5")
+		invalid("count", `"42"`, "Result.count: expected Int, got string")
+	})
+	It("accepts numbers as Floats", func() {
+		valid("ratio", `1.5`)
+		valid("ratio", `2`)
+		invalid("ratio", `true`, "Result.ratio: expected Float, got boolean")
+	})
+	It("accepts only strings as Strings", func() {
+		valid("name", `"Luke"`)
+		invalid("name", `42`, "Result.name: expected String, got float 42")
+	})
+	It("accepts strings and whole numbers as IDs", func() {
+		valid("id", `"1000"`)
+		valid("id", `1000`)
+		invalid("id", `10.5`, "Result.id: expected ID, got float 10.5")
+		invalid("id", `false`, "Result.id: expected ID, got boolean")
+	})
+	It("accepts only booleans as Booleans", func() {
+		valid("enabled", `true`)
+		invalid("enabled", `"true"`, "Result.enabled: expected Boolean, got string")
+	})
+	It("rejects null for non-null fields and list items", func() {
+		valid("required", `"set"`)
+		invalid("required", `null`, "Query.required: expected non-null String, got null")
+		valid("names", `["Luke", "Leia"]`)
+		valid("names", `null`)
+		invalid("names", `["Luke", null]`, "Query.names[1]: expected non-null String, got null")
+		invalid("names", `["Luke", 42]`, "Query.names[1]: expected String, got float 42")
+	})
+	It("accepts only members of enums", func() {
+		valid("episode", `"JEDI"`)
+		invalid("episode", `"PHANTOM"`, `Result.episode: "PHANTOM" is not a member of enum Episode`)
+	})
+	It("passes custom scalars through", func() {
+		data, err := resolve("released", `1977`)
+		Expect(err).To(BeEmpty())
+		Expect(data).To(Equal(float64(1977)))
+		valid("released", `"1977-05-25"`)
+	})
+})
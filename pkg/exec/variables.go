@@ -0,0 +1,200 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/vektah/gqlgen/neelance/common"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// CoerceVariables checks the variables provided for an operation against the types the operation declares,
+// applying defaults for variables which were not provided. Every invalid value is reported, not just the first,
+// each naming the variable and the path to the value within it
+func (s *Schema) CoerceVariables(op *query.Operation, variables map[string]interface{}) (map[string]interface{}, []*gqlerrors.QueryError) {
+	coerced := make(map[string]interface{}, len(variables))
+	for name, val := range variables {
+		coerced[name] = val
+	}
+	var errs []*gqlerrors.QueryError
+	for _, v := range op.Vars {
+		name := v.Name.Name
+		typ, err := common.ResolveType(v.Type, s.Resolve)
+		if err != nil {
+			// reported by validation
+			continue
+		}
+		report := func(path, format string, args ...interface{}) {
+			errs = append(errs, &gqlerrors.QueryError{
+				Message:   fmt.Sprintf("variable $%v got invalid value at %v: %v", name, path, fmt.Sprintf(format, args...)),
+				Locations: []gqlerrors.Location{v.Loc},
+			})
+		}
+		val, ok := variables[name]
+		if !ok {
+			if v.Default != nil {
+				coerced[name] = v.Default.Value(nil)
+				continue
+			}
+			if _, nonNull := typ.(*common.NonNull); nonNull {
+				report("$"+name, "required value was not provided")
+			}
+			continue
+		}
+		s.checkVariable(typ, val, "$"+name, report)
+		if s.ignoreUnknownInputFields {
+			coerced[name] = dropUnknownFields(typ, val)
+		}
+	}
+	return coerced, errs
+}
+
+// IgnoreUnknownInputFields drops the fields of input objects in variables which are not defined by the schema,
+// instead of rejecting the operation as the spec requires. this lets clients which send extra fields keep
+// working, but also hides fields misspelled by clients, which are silently left unset
+func (s *Schema) IgnoreUnknownInputFields() {
+	s.ignoreUnknownInputFields = true
+}
+
+// dropUnknownFields returns a copy of a variable without the fields of input objects not defined by their type.
+// values which are not input objects, or don't contain them, are returned as they are
+func dropUnknownFields(typ common.Type, val interface{}) interface{} {
+	switch typ := typ.(type) {
+	case *common.NonNull:
+		return dropUnknownFields(typ.OfType, val)
+	case *common.List:
+		list, ok := val.([]interface{})
+		if !ok {
+			return dropUnknownFields(typ.OfType, val)
+		}
+		out := make([]interface{}, len(list))
+		for i, item := range list {
+			out[i] = dropUnknownFields(typ.OfType, item)
+		}
+		return out
+	case *schema.InputObject:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return val
+		}
+		out := make(map[string]interface{}, len(obj))
+		for name, fieldVal := range obj {
+			if field := typ.Values.Get(name); field != nil {
+				out[name] = dropUnknownFields(field.Type, fieldVal)
+			}
+		}
+		return out
+	}
+	return val
+}
+
+func (s *Schema) checkVariable(typ common.Type, val interface{}, path string, report func(path, format string, args ...interface{})) {
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		if val == nil {
+			report(path, "expected non-null %v, got null", nonNull.OfType)
+			return
+		}
+		typ = nonNull.OfType
+	}
+	if val == nil {
+		return
+	}
+	switch typ := typ.(type) {
+	case *common.List:
+		list, ok := val.([]interface{})
+		if !ok {
+			// single values are coerced to a list of one
+			s.checkVariable(typ.OfType, val, path, report)
+			return
+		}
+		for i, item := range list {
+			s.checkVariable(typ.OfType, item, fmt.Sprintf("%v[%v]", path, i), report)
+		}
+	case *schema.InputObject:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			report(path, "expected input object %v", typ.Name)
+			return
+		}
+		if s.ignoreUnknownInputFields {
+			obj = dropUnknownFields(typ, obj).(map[string]interface{})
+		}
+		if s.IsOneOf(typ.Name) {
+			if err := validateOneOf(typ, obj); err != nil {
+				report(path, "%v", err)
+			}
+		}
+		for _, field := range typ.Values {
+			fieldVal, provided := obj[field.Name.Name]
+			if _, nonNull := field.Type.(*common.NonNull); nonNull && !provided && field.Default == nil {
+				report(path+"."+field.Name.Name, "required field was not provided")
+				continue
+			}
+			if provided {
+				s.checkVariable(field.Type, fieldVal, path+"."+field.Name.Name, report)
+			}
+		}
+		for name := range obj {
+			if typ.Values.Get(name) == nil {
+				report(path+"."+name, "field is not defined by input object %v", typ.Name)
+			}
+		}
+	case *schema.Enum:
+		str, ok := val.(string)
+		if !ok {
+			report(path, "expected enum %v", typ.Name)
+			return
+		}
+		for _, enumValue := range typ.Values {
+			if enumValue.Name == str {
+				return
+			}
+		}
+		report(path, "%q is not a member of enum %v", str, typ.Name)
+	case *schema.Scalar:
+		if !validVariableScalar(typ, val) {
+			report(path, "expected %v, got %T", typ.Name, val)
+		}
+	}
+}
+
+func validVariableScalar(scalar *schema.Scalar, val interface{}) bool {
+	switch scalar.Name {
+	case "Int":
+		switch v := val.(type) {
+		case int, int32, int64:
+			return true
+		case float64:
+			return v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32
+		case json.Number:
+			_, err := v.Int64()
+			return err == nil
+		}
+		return false
+	case "Float":
+		switch val.(type) {
+		case int, int32, int64, float32, float64, json.Number:
+			return true
+		}
+		return false
+	case "String":
+		_, ok := val.(string)
+		return ok
+	case "ID":
+		switch v := val.(type) {
+		case string, int, int32, int64, json.Number:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+		return false
+	case "Boolean":
+		_, ok := val.(bool)
+		return ok
+	}
+	// custom scalars are passed through as-is
+	return true
+}
@@ -0,0 +1,96 @@
+package exec_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/solo-io/sqoop/pkg/exec"
+	gqlquery "github.com/vektah/gqlgen/neelance/query"
+)
+
+const variablesSchema = `
+type Query {
+    search(filter: Filter, limit: Int, sort: Sort): String
+}
+enum Sort {
+    ASC
+    DESC
+}
+input Filter {
+    name: String!
+    address: Address
+}
+input Address {
+    city: String
+    zip: Int!
+}
+`
+
+var _ = Describe("CoerceVariables", func() {
+	var sch *Schema
+	BeforeEach(func() {
+		sch = MustParseSchema(variablesSchema)
+	})
+	operation := func(q string) *gqlquery.Operation {
+		doc, err := gqlquery.Parse(q)
+		Expect(err).To(BeNil())
+		op, opErr := doc.GetOperation("")
+		Expect(opErr).NotTo(HaveOccurred())
+		return op
+	}
+	It("reports every invalid variable with the path to the invalid value", func() {
+		op := operation(`query($filter: Filter!, $limit: Int!, $sort: Sort, $after: String!) {
+			search(filter: $filter, limit: $limit, sort: $sort)
+		}`)
+		_, errs := sch.CoerceVariables(op, map[string]interface{}{
+			"filter": map[string]interface{}{
+				"address": map[string]interface{}{"zip": "94105"},
+			},
+			"limit": 1.5,
+			"sort":  "SIDEWAYS",
+		})
+		var messages []string
+		for _, err := range errs {
+			messages = append(messages, err.Message)
+		}
+		Expect(messages).To(ConsistOf(
+			"variable $filter got invalid value at $filter.name: required field was not provided",
+			"variable $filter got invalid value at $filter.address.zip: expected Int, got string",
+			"variable $limit got invalid value at $limit: expected Int, got float64",
+			`variable $sort got invalid value at $sort: "SIDEWAYS" is not a member of enum Sort`,
+			"variable $after got invalid value at $after: required value was not provided",
+		))
+	})
+	It("applies defaults for variables which were not provided", func() {
+		op := operation(`query($limit: Int = 10) { search(limit: $limit) }`)
+		vars, errs := sch.CoerceVariables(op, nil)
+		Expect(errs).To(BeEmpty())
+		Expect(vars).To(HaveKeyWithValue("limit", BeNumerically("==", 10)))
+	})
+	It("rejects fields of input objects the schema doesn't define unless unknown fields are ignored", func() {
+		op := operation(`query($filter: Filter) { search(filter: $filter) }`)
+		variables := map[string]interface{}{
+			"filter": map[string]interface{}{
+				"name":     "luke",
+				"nickname": "farmboy",
+				"address":  map[string]interface{}{"zip": 94105, "street": "main"},
+			},
+		}
+		_, errs := sch.CoerceVariables(op, variables)
+		var messages []string
+		for _, err := range errs {
+			messages = append(messages, err.Message)
+		}
+		Expect(messages).To(ConsistOf(
+			"variable $filter got invalid value at $filter.nickname: field is not defined by input object Filter",
+			"variable $filter got invalid value at $filter.address.street: field is not defined by input object Address",
+		))
+		sch.IgnoreUnknownInputFields()
+		vars, errs := sch.CoerceVariables(op, variables)
+		Expect(errs).To(BeEmpty())
+		Expect(vars["filter"]).To(Equal(map[string]interface{}{
+			"name":    "luke",
+			"address": map[string]interface{}{"zip": 94105},
+		}))
+	})
+})
@@ -0,0 +1,58 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/vektah/gqlgen/graphql"
+)
+
+// Warning tells clients that a field resolved successfully, but not to everything its resolver returned,
+// e.g. because a list was truncated
+type Warning struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Warnings collects the warnings of an operation, reported in extensions.warnings of its response
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+type warningsKey struct{}
+
+// WithWarnings returns a context which collects the warnings of the operation executed with it
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	warnings := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, warnings), warnings
+}
+
+// AddWarning reports a warning with the path of the field being resolved, if the operation collects warnings
+func AddWarning(ctx context.Context, format string, args ...interface{}) {
+	warnings, _ := ctx.Value(warningsKey{}).(*Warnings)
+	if warnings == nil {
+		return
+	}
+	warning := Warning{Message: fmt.Sprintf(format, args...)}
+	if rctx := graphql.GetResolverContext(ctx); rctx != nil {
+		warning.Path = append([]interface{}(nil), rctx.Path...)
+	}
+	warnings.mu.Lock()
+	warnings.warnings = append(warnings.warnings, warning)
+	warnings.mu.Unlock()
+}
+
+// Warnings returns the collected warnings. nil if there were none
+func (w *Warnings) Warnings() []Warning {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.warnings) == 0 {
+		return nil
+	}
+	return append([]Warning(nil), w.warnings...)
+}
@@ -0,0 +1,173 @@
+package graphql
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"expvar"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+var (
+	authCacheHits   = expvar.NewInt("sqoop_auth_cache_hits")
+	authCacheMisses = expvar.NewInt("sqoop_auth_cache_misses")
+)
+
+// Authenticator validates the credentials presented by clients, e.g. by verifying a JWT
+// or asking an external authorization service
+type Authenticator interface {
+	// Authenticate decides whether a credential is accepted. errors are not cached,
+	// a credential which is not accepted should be reported with a decision which doesn't allow it.
+	// a nil decision is treated as an error
+	Authenticate(ctx context.Context, credential string) (*AuthDecision, error)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator
+type AuthenticatorFunc func(ctx context.Context, credential string) (*AuthDecision, error)
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, credential string) (*AuthDecision, error) {
+	return f(ctx, credential)
+}
+
+// AuthDecision is the result of authenticating a credential
+type AuthDecision struct {
+	Allowed bool
+	// when the credential stops being valid, e.g. the exp claim of a JWT.
+	// decisions are never cached beyond it. zero if the credential doesn't expire
+	Expires time.Time
+	// the authenticated subject and their tenant, if known. resolver templates can read them from .ctx
+	Subject string
+	Tenant  string
+	// the validated claims of the credential, e.g. the claims of a JWT. resolver templates can read them from
+	// .claims, and resolvers can send them to upstreams with claimHeaders
+	Claims map[string]interface{}
+}
+
+// AuthCacheOptions configure caching of authentication decisions by credential
+type AuthCacheOptions struct {
+	// how long decisions are cached. zero disables caching
+	TTL time.Duration
+	// the maximum number of credentials whose decisions are cached
+	Size int
+}
+
+// ExternalAuth rejects requests whose credential, read from the given header, is not allowed by the authenticator.
+// decisions are cached by a hash of the credential, so repeated requests from a client skip the authenticator
+func ExternalAuth(header string, authenticator Authenticator, cacheOpts AuthCacheOptions) Middleware {
+	cache := newAuthCache(cacheOpts)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			credential := r.Header.Get(header)
+			if credential == "" {
+				http.Error(w, "missing credentials", http.StatusUnauthorized)
+				return
+			}
+			key := sha256.Sum256([]byte(credential))
+			decision, ok := cache.get(key)
+			if !ok {
+				var err error
+				decision, err = authenticator.Authenticate(r.Context(), credential)
+				if err == nil && decision == nil {
+					err = errors.New("authenticator returned no decision")
+				}
+				if err != nil {
+					log.Warnf("authenticating request: %v", err)
+					http.Error(w, "unable to authenticate request", http.StatusInternalServerError)
+					return
+				}
+				cache.add(key, decision)
+			}
+			if !decision.Allowed || expired(decision, time.Now()) {
+				http.Error(w, "invalid credentials", http.StatusUnauthorized)
+				return
+			}
+			ctx := exec.WithAuthenticated(r.Context())
+			ctx = exec.WithContextValue(ctx, exec.ContextSubject, decision.Subject)
+			ctx = exec.WithContextValue(ctx, exec.ContextTenant, decision.Tenant)
+			ctx = exec.WithClaims(ctx, decision.Claims)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func expired(decision *AuthDecision, now time.Time) bool {
+	return !decision.Expires.IsZero() && !now.Before(decision.Expires)
+}
+
+// authCache is a bounded LRU cache of authentication decisions. nil if caching is disabled
+type authCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[[sha256.Size]byte]*list.Element
+	order   *list.List
+}
+
+type authCacheEntry struct {
+	key      [sha256.Size]byte
+	decision *AuthDecision
+	expires  time.Time
+}
+
+func newAuthCache(opts AuthCacheOptions) *authCache {
+	if opts.TTL <= 0 || opts.Size <= 0 {
+		return nil
+	}
+	return &authCache{
+		ttl:     opts.TTL,
+		size:    opts.Size,
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *authCache) get(key [sha256.Size]byte) (*AuthDecision, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		authCacheMisses.Add(1)
+		return nil, false
+	}
+	entry := el.Value.(*authCacheEntry)
+	if !time.Now().Before(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		authCacheMisses.Add(1)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	authCacheHits.Add(1)
+	return entry.decision, true
+}
+
+func (c *authCache) add(key [sha256.Size]byte, decision *AuthDecision) {
+	if c == nil {
+		return
+	}
+	expires := time.Now().Add(c.ttl)
+	// the credential's own expiry bounds how long its decision is kept
+	if !decision.Expires.IsZero() && decision.Expires.Before(expires) {
+		expires = decision.Expires
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+	}
+	c.entries[key] = c.order.PushFront(&authCacheEntry{key: key, decision: decision, expires: expires})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*authCacheEntry).key)
+	}
+}
@@ -0,0 +1,164 @@
+package graphql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CompressionOptions control gzip compression of GraphQL responses.
+// The zero value disables compression
+type CompressionOptions struct {
+	// compress responses of at least this many bytes. zero disables compression
+	MinSize int
+	// the gzip compression level, from 1 (fastest) to 9 (smallest). defaults to 6
+	Level int
+}
+
+func (o CompressionOptions) Validate() error {
+	if o.Level == 0 {
+		return nil
+	}
+	if o.Level < gzip.BestSpeed || o.Level > gzip.BestCompression {
+		return errors.Errorf("compression level must be between %v and %v", gzip.BestSpeed, gzip.BestCompression)
+	}
+	return nil
+}
+
+// compress responses for clients which accept gzip. responses are buffered until they
+// reach the minimum size, or until they are flushed, which sends smaller responses as is
+func withCompression(opts CompressionOptions, h http.Handler) http.Handler {
+	if opts.MinSize <= 0 {
+		return h
+	}
+	level := opts.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	writers := &sync.Pool{
+		New: func() interface{} {
+			gz, _ := gzip.NewWriterLevel(ioutil.Discard, level)
+			return gz
+		},
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, minSize: opts.MinSize, writers: writers, status: http.StatusOK}
+		defer cw.close()
+		h.ServeHTTP(cw, r)
+	})
+}
+
+// whether the Accept-Encoding header allows gzip, e.g. "gzip, deflate" or "*;q=0.5"
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	minSize int
+	writers *sync.Pool
+
+	status int
+	buf    bytes.Buffer
+	// set once the response is committed
+	committed bool
+	gz        *gzip.Writer
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.committed {
+		if w.gz != nil {
+			return w.gz.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf.Write(p)
+	if w.buf.Len() >= w.minSize {
+		if err := w.commit(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// send the headers and the buffered body, compressed or not
+func (w *compressWriter) commit(compress bool) error {
+	w.committed = true
+	// 204s and 304s have no body
+	if compress && w.status != http.StatusNoContent && w.status != http.StatusNotModified {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.gz = w.writers.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.gz != nil {
+		_, err = w.gz.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// Flush sends what has been written so far, so streamed responses are not held back.
+// a response which hasn't reached the minimum size by its first flush is not compressed
+func (w *compressWriter) Flush() {
+	if !w.committed {
+		w.commit(false)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) close() {
+	if !w.committed {
+		w.commit(false)
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		w.writers.Put(w.gz)
+	}
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"expvar"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	requestKindQuery        = "query"
+	requestKindSubscription = "subscription"
+)
+
+var (
+	// requests being served by every endpoint, by kind. subscriptions are counted separately since they
+	// hold their connection for much longer than queries and mutations
+	inFlightRequests = expvar.NewMap("sqoop_requests_in_flight")
+	// requests rejected because too many of their kind were in flight
+	rejectedRequests = expvar.NewMap("sqoop_requests_rejected_in_flight")
+)
+
+// ConcurrencyOptions cap the number of requests served at once across all endpoints.
+// requests beyond a cap are rejected with 503 Service Unavailable. zero means no limit
+type ConcurrencyOptions struct {
+	// queries and mutations
+	MaxRequests int
+	// subscriptions
+	MaxSubscriptions int
+}
+
+type concurrencyLimiter struct {
+	opts          ConcurrencyOptions
+	requests      int64
+	subscriptions int64
+}
+
+func newConcurrencyLimiter(opts ConcurrencyOptions) *concurrencyLimiter {
+	return &concurrencyLimiter{opts: opts}
+}
+
+// count the requests in flight, rejecting those beyond the cap of their kind
+func (l *concurrencyLimiter) limit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind, inFlight, max := requestKindQuery, &l.requests, l.opts.MaxRequests
+		if isSubscriptionRequest(r) {
+			kind, inFlight, max = requestKindSubscription, &l.subscriptions, l.opts.MaxSubscriptions
+		}
+		if n := atomic.AddInt64(inFlight, 1); max > 0 && n > int64(max) {
+			atomic.AddInt64(inFlight, -1)
+			rejectedRequests.Add(kind, 1)
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "too many requests in flight", http.StatusServiceUnavailable)
+			return
+		}
+		inFlightRequests.Add(kind, 1)
+		defer func() {
+			atomic.AddInt64(inFlight, -1)
+			inFlightRequests.Add(kind, -1)
+		}()
+		h.ServeHTTP(w, r)
+	})
+}
+
+// subscriptions are served over websockets or as server-sent events
+func isSubscriptionRequest(r *http.Request) bool {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
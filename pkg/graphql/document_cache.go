@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"bytes"
+	"container/list"
+	"expvar"
+	"sync"
+
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// keyed by schema. the time saved by caching is about the hits times the mean time spent parsing
+// and validating a query which missed the cache
+var (
+	documentCacheHits     = expvar.NewMap("sqoop_query_cache_hits")
+	documentCacheMisses   = expvar.NewMap("sqoop_query_cache_misses")
+	documentParseDuration = expvar.NewMap("sqoop_query_parse_duration_us")
+)
+
+// a parsed and validated query document. documents which failed to parse or
+// validate are cached along with their errors
+type parsedDocument struct {
+	doc  *query.Document
+	errs []*gqlerrors.QueryError
+}
+
+// documentCache is a bounded LRU cache of parsed documents for a single schema.
+// Validation depends on the schema, so a new cache is used whenever the schema changes
+type documentCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	key string
+	doc *parsedDocument
+}
+
+func newDocumentCache(size int) *documentCache {
+	return &documentCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *documentCache) get(key string) (*parsedDocument, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).doc, true
+}
+
+func (c *documentCache) add(key string, doc *parsedDocument) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).doc = doc
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, doc: doc})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// normalizeQuery collapses whitespace, commas and comments outside of string literals,
+// so queries which differ only in formatting share a cache entry
+func normalizeQuery(q string) string {
+	var (
+		b        bytes.Buffer
+		inString bool
+		escaped  bool
+		pending  bool
+	)
+	b.Grow(len(q))
+	for i := 0; i < len(q); i++ {
+		c := q[i]
+		if inString {
+			b.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r', ',':
+			pending = true
+			continue
+		case '#':
+			for i < len(q) && q[i] != '\n' && q[i] != '\r' {
+				i++
+			}
+			pending = true
+			continue
+		}
+		if pending && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		pending = false
+		if c == '"' {
+			inString = true
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("documentCache", func() {
+	It("normalizes whitespace, commas and comments outside of strings", func() {
+		Expect(normalizeQuery("query Hero {\n  hero {\n    name,\n    id\n  }\n}\n")).To(Equal("query Hero { hero { name id } }"))
+		Expect(normalizeQuery("  {hero{name}}  ")).To(Equal("{hero{name}}"))
+		Expect(normalizeQuery("{ # the hero\n\thero { name } }")).To(Equal("{ hero { name } }"))
+		Expect(normalizeQuery(`{ search(text: "a,  b # c") { id } }`)).To(Equal(`{ search(text: "a,  b # c") { id } }`))
+		Expect(normalizeQuery(`{ search(text: "a\"  b") { id } }`)).To(Equal(`{ search(text: "a\"  b") { id } }`))
+	})
+	It("evicts the least recently used document", func() {
+		c := newDocumentCache(2)
+		a, b, d := &parsedDocument{}, &parsedDocument{}, &parsedDocument{}
+		c.add("a", a)
+		c.add("b", b)
+		cached, ok := c.get("a")
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(a))
+
+		c.add("d", d)
+		_, ok = c.get("b")
+		Expect(ok).To(BeFalse())
+		cached, ok = c.get("a")
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(a))
+		cached, ok = c.get("d")
+		Expect(ok).To(BeTrue())
+		Expect(cached).To(BeIdenticalTo(d))
+	})
+	It("replaces the document of an existing key without evicting", func() {
+		c := newDocumentCache(2)
+		a, b := &parsedDocument{}, &parsedDocument{}
+		c.add("a", &parsedDocument{})
+		c.add("b", b)
+		c.add("a", a)
+		Expect(c.order.Len()).To(Equal(2))
+		cached, _ := c.get("a")
+		Expect(cached).To(BeIdenticalTo(a))
+		cached, _ = c.get("b")
+		Expect(cached).To(BeIdenticalTo(b))
+	})
+	It("parses queries which differ only in formatting once", func() {
+		h := &queryHandler{exec: test.StarWarsExecutableSchema("no-address-defined"), cache: newDocumentCache(10)}
+		parsed := h.parse("{ hero { name } }")
+		Expect(parsed.errs).To(BeEmpty())
+		Expect(h.parse("{\n  hero {\n    name\n  }\n}")).To(BeIdenticalTo(parsed))
+		Expect(h.cache.order.Len()).To(Equal(1))
+	})
+	It("keeps the documents of unchanged schemas and drops those of changed schemas", func() {
+		router := NewRouter(Options{QueryCacheSize: 10})
+		starWars := test.StarWarsExecutableSchema("no-address-defined")
+		endpoint := &Endpoint{SchemaName: "StarWars", RootPath: "/root", QueryPath: "/query", ExecSchema: starWars}
+		router.UpdateEndpoints(endpoint)
+		cache := router.documentCaches[starWars]
+		Expect(cache).NotTo(BeNil())
+		cache.add("{hero{name}}", &parsedDocument{})
+
+		router.UpdateEndpoints(endpoint)
+		Expect(router.documentCaches[starWars]).To(BeIdenticalTo(cache))
+
+		changed := test.StarWarsExecutableSchema("no-address-defined")
+		router.UpdateEndpoints(&Endpoint{SchemaName: "StarWars", RootPath: "/root", QueryPath: "/query", ExecSchema: changed})
+		Expect(router.documentCaches).To(HaveLen(1))
+		Expect(router.documentCaches[changed]).NotTo(BeIdenticalTo(cache))
+		_, ok := router.documentCaches[changed].get("{hero{name}}")
+		Expect(ok).To(BeFalse())
+	})
+})
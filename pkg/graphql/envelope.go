@@ -0,0 +1,15 @@
+package graphql
+
+// Envelope wraps the standard GraphQL response in the body expected by clients which can't consume
+// GraphQL responses directly. The returned value is encoded as json in place of the response
+type Envelope func(res *Response) interface{}
+
+type okPayload struct {
+	OK      bool      `json:"ok"`
+	Payload *Response `json:"payload"`
+}
+
+// OKPayloadEnvelope wraps responses as {"ok": <true if there are no errors>, "payload": {"data": ..., "errors": ...}}
+func OKPayloadEnvelope(res *Response) interface{} {
+	return okPayload{OK: len(res.Errors) == 0, Payload: res}
+}
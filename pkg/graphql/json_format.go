@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// JSONOptions control how GraphQL responses are written.
+// The zero value writes responses exactly as they are encoded by gqlgen: compact,
+// HTML-safe, with object keys in the order the fields were selected
+type JSONOptions struct {
+	// indent each level of the response with this string
+	Indent string
+	// write <, > and & literally instead of as unicode escapes
+	DisableHTMLEscape bool
+	// encodes responses. defaults to encoding/json
+	Encoder ResponseEncoder
+}
+
+// ResponseEncoder encodes GraphQL responses, e.g. with a faster library than encoding/json such as jsoniter,
+// whose ConfigCompatibleWithStandardLibrary implements it. The data of an operation is already encoded by
+// gqlgen when the response is encoded, so the encoder writes its errors and extensions, and the envelope of
+// the endpoint. Its output must be compact json with the escaping of encoding/json
+type ResponseEncoder interface {
+	Marshal(v interface{}) ([]byte, error)
+}
+
+type standardEncoder struct{}
+
+func (standardEncoder) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (o JSONOptions) isDefault() bool {
+	return o.Indent == "" && !o.DisableHTMLEscape
+}
+
+// reformat the json written by h. responses are buffered in full, so
+// the handler is only wrapped if any options are set
+func withJSONOptions(opts JSONOptions, h http.Handler) http.Handler {
+	if opts.isDefault() {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+		h.ServeHTTP(buf, r)
+		body := buf.body.Bytes()
+		if opts.DisableHTMLEscape {
+			body = unescapeHTML(body)
+		}
+		if opts.Indent != "" {
+			var indented bytes.Buffer
+			// leave responses which aren't json untouched
+			if err := json.Indent(&indented, body, "", opts.Indent); err == nil {
+				indented.WriteByte('\n')
+				body = indented.Bytes()
+			}
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(buf.status)
+		w.Write(body)
+	})
+}
+
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) WriteHeader(status int)      { b.status = status }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+var htmlEscapes = map[string]byte{
+	`\u003c`: '<',
+	`\u003e`: '>',
+	`\u0026`: '&',
+}
+
+// undo the HTML escaping done by encoding/json. escape sequences are consumed
+// whole so an escaped backslash followed by "u003c" is left alone
+func unescapeHTML(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`\u00`)) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 >= len(data) {
+			out = append(out, data[i])
+			continue
+		}
+		if i+6 <= len(data) {
+			if c, ok := htmlEscapes[string(data[i:i+6])]; ok {
+				out = append(out, c)
+				i += 5
+				continue
+			}
+		}
+		out = append(out, data[i], data[i+1])
+		i++
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"fmt"
+
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// checkAliases rejects documents which select the same field under more than max aliases
+// in a single selection set. fields selected through fragments count towards the selection
+// set the fragment is spread into
+func checkAliases(doc *query.Document, max int) []*gqlerrors.QueryError {
+	if max <= 0 {
+		return nil
+	}
+	c := &aliasChecker{doc: doc, max: max}
+	for _, op := range doc.Operations {
+		c.checkSelectionSet(op.Selections)
+	}
+	return c.errs
+}
+
+type aliasChecker struct {
+	doc  *query.Document
+	max  int
+	errs []*gqlerrors.QueryError
+}
+
+func (c *aliasChecker) checkSelectionSet(selections []query.Selection) {
+	var fields []*query.Field
+	c.collectFields(selections, &fields, make(map[string]bool))
+	aliases := make(map[string]int)
+	for _, field := range fields {
+		if field.Alias.Name != field.Name.Name {
+			aliases[field.Name.Name]++
+			if aliases[field.Name.Name] == c.max+1 {
+				c.errs = append(c.errs, &gqlerrors.QueryError{
+					Message:   "field \"" + field.Name.Name + "\" is selected under more aliases than the maximum allowed",
+					Locations: []gqlerrors.Location{field.Alias.Loc},
+				})
+			}
+		}
+		if len(field.Selections) > 0 {
+			c.checkSelectionSet(field.Selections)
+		}
+	}
+}
+
+// flatten fragments into the fields of the selection set they appear in
+func (c *aliasChecker) collectFields(selections []query.Selection, fields *[]*query.Field, visited map[string]bool) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *query.Field:
+			*fields = append(*fields, sel)
+		case *query.InlineFragment:
+			c.collectFields(sel.Selections, fields, visited)
+		case *query.FragmentSpread:
+			if visited[sel.Name.Name] {
+				continue
+			}
+			visited[sel.Name.Name] = true
+			if frag := c.doc.Fragments.Get(sel.Name.Name); frag != nil {
+				c.collectFields(frag.Selections, fields, visited)
+			}
+		}
+	}
+}
+
+// checkFragmentDepth rejects documents whose fragment spreads are nested more than max deep, counting spreads
+// within fields and inline fragments of a fragment, and documents whose fragments spread themselves. nested
+// spreads multiply the fields a small document selects, so they are checked before the document is validated
+func checkFragmentDepth(doc *query.Document, max int) []*gqlerrors.QueryError {
+	if max <= 0 {
+		return nil
+	}
+	c := &fragmentDepthChecker{doc: doc, depths: make(map[string]int), visiting: make(map[string]bool)}
+	var errs []*gqlerrors.QueryError
+	for _, op := range doc.Operations {
+		depth, outermost, err := c.selectionDepth(op.Selections)
+		if err != nil {
+			return []*gqlerrors.QueryError{err}
+		}
+		if depth > max {
+			errs = append(errs, &gqlerrors.QueryError{
+				Message:   fmt.Sprintf("fragment spreads are nested %v deep, more than the maximum of %v", depth, max),
+				Locations: []gqlerrors.Location{outermost.Name.Loc},
+			})
+		}
+	}
+	return errs
+}
+
+type fragmentDepthChecker struct {
+	doc *query.Document
+	// the depth of the spreads nested within each fragment whose depth has been computed
+	depths map[string]int
+	// the fragments whose depth is being computed, to detect cycles
+	visiting map[string]bool
+}
+
+// selectionDepth returns the depth of the spreads nested within selections, and the outermost spread of the
+// deepest nesting
+func (c *fragmentDepthChecker) selectionDepth(selections []query.Selection) (int, *query.FragmentSpread, *gqlerrors.QueryError) {
+	var (
+		max       int
+		outermost *query.FragmentSpread
+	)
+	for _, sel := range selections {
+		var (
+			depth  int
+			spread *query.FragmentSpread
+			err    *gqlerrors.QueryError
+		)
+		switch sel := sel.(type) {
+		case *query.Field:
+			depth, spread, err = c.selectionDepth(sel.Selections)
+		case *query.InlineFragment:
+			depth, spread, err = c.selectionDepth(sel.Selections)
+		case *query.FragmentSpread:
+			depth, err = c.fragmentDepth(sel)
+			depth, spread = depth+1, sel
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if depth > max {
+			max, outermost = depth, spread
+		}
+	}
+	return max, outermost, nil
+}
+
+// fragmentDepth returns the depth of the spreads nested within the spread fragment
+func (c *fragmentDepthChecker) fragmentDepth(spread *query.FragmentSpread) (int, *gqlerrors.QueryError) {
+	name := spread.Name.Name
+	if depth, ok := c.depths[name]; ok {
+		return depth, nil
+	}
+	if c.visiting[name] {
+		return 0, &gqlerrors.QueryError{
+			Message:   fmt.Sprintf("fragment %q is spread within itself", name),
+			Locations: []gqlerrors.Location{spread.Name.Loc},
+		}
+	}
+	frag := c.doc.Fragments.Get(name)
+	if frag == nil {
+		// unknown fragments are reported by validation
+		return 0, nil
+	}
+	c.visiting[name] = true
+	depth, _, err := c.selectionDepth(frag.Selections)
+	c.visiting[name] = false
+	if err != nil {
+		return 0, err
+	}
+	c.depths[name] = depth
+	return depth, nil
+}
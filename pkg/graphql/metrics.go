@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/solo-io/sqoop/pkg/metrics"
+)
+
+// OperationNamer returns the name an operation is grouped under in metrics,
+// given the operationName of the request, which may be empty, and the query
+type OperationNamer func(operationName, query string) string
+
+// MetricsOptions configure the per-operation metrics served at /debug/vars of the admin listener
+type MetricsOptions struct {
+	// defaults to DefaultOperationNamer
+	OperationNamer OperationNamer
+	// the maximum number of operation names tracked per schema. operations
+	// beyond the limit are counted as "other". zero means no limit
+	MaxOperations int
+	// operation names which haven't been executed for this long stop being tracked, making room for others.
+	// defaults to an hour
+	OperationIdleTimeout time.Duration
+	// record the size of each response in sqoop_operation_response_bytes
+	RecordSizes bool
+}
+
+const (
+	otherOperations = "other"
+	// operations which failed to parse or validate, or named an operation the query doesn't define.
+	// they are counted together, so clients can't claim names with queries which can't be executed
+	invalidOperations = "invalid"
+)
+
+const defaultOperationIdleTimeout = time.Hour
+
+// counters keyed by <schema>/<operation>. the keys of operations which stop being tracked are deleted
+var (
+	operationCount    = metrics.NewCounters("sqoop_operations")
+	operationErrors   = metrics.NewCounters("sqoop_operation_errors")
+	operationDuration = metrics.NewCounters("sqoop_operation_duration_ms")
+	responseSizes     = metrics.NewHistogram("sqoop_operation_response_bytes", metrics.SizeBuckets)
+)
+
+// DefaultOperationNamer names operations by their operationName. anonymous operations
+// are named by a hash of their normalized query, so reformatting a query doesn't change its name
+func DefaultOperationNamer(operationName, query string) string {
+	if operationName != "" {
+		return operationName
+	}
+	sum := sha256.Sum256([]byte(normalizeQuery(query)))
+	return "anonymous-" + hex.EncodeToString(sum[:4])
+}
+
+// operationMetrics records the operations executed against a single schema.
+// while debug endpoints are enabled, the most recent query of each operation name is kept, so it can be replayed
+type operationMetrics struct {
+	schema      string
+	opts        MetricsOptions
+	keepQueries bool
+
+	mu         sync.Mutex
+	operations map[string]*operationSlot
+	lastSweep  time.Time
+}
+
+// an operation name being tracked
+type operationSlot struct {
+	params   queryParams
+	lastSeen time.Time
+}
+
+func newOperationMetrics(schema string, opts MetricsOptions, keepQueries bool) *operationMetrics {
+	if opts.OperationNamer == nil {
+		opts.OperationNamer = DefaultOperationNamer
+	}
+	if opts.OperationIdleTimeout <= 0 {
+		opts.OperationIdleTimeout = defaultOperationIdleTimeout
+	}
+	return &operationMetrics{
+		schema:      schema,
+		opts:        opts,
+		keepQueries: keepQueries,
+		operations:  make(map[string]*operationSlot),
+	}
+}
+
+// label names the operation in metrics, tracking its name if there is room.
+// it must only be called for operations which parsed and validated
+func (m *operationMetrics) label(params queryParams, now time.Time) string {
+	name := m.opts.OperationNamer(params.OperationName, params.Query)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	slot, ok := m.operations[name]
+	if !ok {
+		full := m.opts.MaxOperations > 0 && len(m.operations) >= m.opts.MaxOperations
+		if full || now.Sub(m.lastSweep) >= m.opts.OperationIdleTimeout {
+			m.sweep(now)
+			full = m.opts.MaxOperations > 0 && len(m.operations) >= m.opts.MaxOperations
+		}
+		if full {
+			return otherOperations
+		}
+		slot = &operationSlot{}
+		m.operations[name] = slot
+	}
+	// variables may contain sensitive data and are not kept
+	if m.keepQueries {
+		slot.params = queryParams{Query: params.Query, OperationName: params.OperationName}
+	}
+	slot.lastSeen = now
+	return name
+}
+
+// stop tracking operations which have been idle for longer than the idle timeout, and remove their metrics
+func (m *operationMetrics) sweep(now time.Time) {
+	m.lastSweep = now
+	for name, slot := range m.operations {
+		if now.Sub(slot.lastSeen) >= m.opts.OperationIdleTimeout {
+			delete(m.operations, name)
+			key := m.key(name)
+			operationCount.Delete(key)
+			operationErrors.Delete(key)
+			operationDuration.Delete(key)
+			responseSizes.Delete(key)
+		}
+	}
+}
+
+func (m *operationMetrics) key(label string) string {
+	return m.schema + "/" + label
+}
+
+// the most recent query executed with the given operation name. only kept while debug endpoints are enabled
+func (m *operationMetrics) operation(name string) (queryParams, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	slot, ok := m.operations[name]
+	if !ok {
+		return queryParams{}, false
+	}
+	return slot.params, true
+}
+
+func (m *operationMetrics) record(label string, duration time.Duration, failed bool, size int) {
+	key := m.key(label)
+	operationCount.Add(key, 1)
+	if failed {
+		operationErrors.Add(key, 1)
+	}
+	operationDuration.Add(key, int64(duration/time.Millisecond))
+	if m.opts.RecordSizes {
+		responseSizes.Observe(key, int64(size))
+	}
+}
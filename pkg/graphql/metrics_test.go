@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"encoding/json"
+	"expvar"
+	"time"
+)
+
+var _ = Describe("operationMetrics", func() {
+	var (
+		m   *operationMetrics
+		now time.Time
+	)
+	BeforeEach(func() {
+		m = newOperationMetrics("test", MetricsOptions{MaxOperations: 2, OperationIdleTimeout: time.Hour}, true)
+		now = time.Now()
+	})
+	op := func(name string) queryParams {
+		return queryParams{OperationName: name, Query: "query " + name + " { __typename }"}
+	}
+
+	It("counts operations beyond the limit as other", func() {
+		Expect(m.label(op("A"), now)).To(Equal("A"))
+		Expect(m.label(op("B"), now)).To(Equal("B"))
+		Expect(m.label(op("C"), now)).To(Equal(otherOperations))
+		Expect(m.label(op("A"), now)).To(Equal("A"))
+		_, ok := m.operation("C")
+		Expect(ok).To(BeFalse())
+	})
+	It("frees the names of idle operations for others", func() {
+		m.label(op("A"), now)
+		m.label(op("B"), now.Add(30*time.Minute))
+		Expect(m.label(op("C"), now.Add(50*time.Minute))).To(Equal(otherOperations))
+
+		Expect(m.label(op("C"), now.Add(time.Hour))).To(Equal("C"))
+		_, ok := m.operation("A")
+		Expect(ok).To(BeFalse())
+		params, ok := m.operation("B")
+		Expect(ok).To(BeTrue())
+		Expect(params).To(Equal(op("B")))
+	})
+	It("keeps operations which are executed again", func() {
+		m.label(op("A"), now)
+		m.label(op("B"), now)
+		m.label(op("A"), now.Add(50*time.Minute))
+		Expect(m.label(op("C"), now.Add(time.Hour))).To(Equal("C"))
+		_, ok := m.operation("A")
+		Expect(ok).To(BeTrue())
+		_, ok = m.operation("B")
+		Expect(ok).To(BeFalse())
+	})
+	It("forgets idle operations without a limit on the number of names", func() {
+		m = newOperationMetrics("test", MetricsOptions{OperationIdleTimeout: time.Hour}, true)
+		m.label(op("A"), now)
+		m.label(op("B"), now.Add(2*time.Hour))
+		_, ok := m.operation("A")
+		Expect(ok).To(BeFalse())
+	})
+	It("removes the metrics of operations which stop being tracked", func() {
+		published := func(name string) map[string]int64 {
+			var out map[string]int64
+			Expect(json.Unmarshal([]byte(expvar.Get(name).String()), &out)).NotTo(HaveOccurred())
+			return out
+		}
+		m.record(m.label(op("A"), now), time.Millisecond, true, 0)
+		m.record(m.label(op("B"), now.Add(30*time.Minute)), time.Millisecond, false, 0)
+		Expect(published("sqoop_operations")).To(HaveKey("test/A"))
+		Expect(published("sqoop_operation_errors")).To(HaveKey("test/A"))
+
+		m.label(op("C"), now.Add(time.Hour))
+		for _, name := range []string{"sqoop_operations", "sqoop_operation_errors", "sqoop_operation_duration_ms"} {
+			Expect(published(name)).NotTo(HaveKey("test/A"))
+		}
+		Expect(published("sqoop_operations")).To(HaveKeyWithValue("test/B", int64(1)))
+	})
+})
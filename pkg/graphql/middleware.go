@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// Middleware wraps the query handler of an endpoint
+type Middleware func(http.Handler) http.Handler
+
+// the first middleware is the outermost
+func chain(middleware []Middleware, h http.Handler) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// CORS answers preflight requests and sets CORS headers for requests from the allowed origins
+func CORS(allowOrigins, allowHeaders []string, maxAge time.Duration) Middleware {
+	allowAll := false
+	origins := make(map[string]bool)
+	for _, origin := range allowOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		origins[origin] = true
+	}
+	headers := strings.Join(append([]string{"Content-Type"}, allowHeaders...), ", ")
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !(allowAll || origins[origin]) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+			if r.Method != "OPTIONS" || r.Header.Get("Access-Control-Request-Method") == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			if maxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(maxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// RateLimit rejects requests beyond requestsPerSecond with 429 Too Many Requests,
+// allowing bursts of up to burst requests
+func RateLimit(requestsPerSecond, burst int) Middleware {
+	if burst < 1 {
+		burst = requestsPerSecond
+	}
+	bucket := &tokenBucket{
+		rate:   float64(requestsPerSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !bucket.take() {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// APIKeyAuth rejects requests which don't present the key returned by apiKey in the given header.
+// apiKey is called on every request so rotated keys take effect immediately
+func APIKeyAuth(header string, apiKey func() (string, error)) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			expected, err := apiKey()
+			if err != nil {
+				log.Warnf("reading api key: %v", err)
+				http.Error(w, "unable to authenticate request", http.StatusInternalServerError)
+				return
+			}
+			presented := r.Header.Get(header)
+			if presented == "" || expected == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(expected)) != 1 {
+				http.Error(w, "invalid or missing api key", http.StatusUnauthorized)
+				return
+			}
+			h.ServeHTTP(w, r.WithContext(exec.WithAuthenticated(r.Context())))
+		})
+	}
+}
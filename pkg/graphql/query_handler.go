@@ -0,0 +1,348 @@
+package graphql
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/debuglog"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/util"
+	"github.com/vektah/gqlgen/graphql"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/validation"
+)
+
+type queryParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// queryHandler serves GraphQL queries like gqlgen's handler, but caches parsed and validated
+// documents so repeated queries skip parsing and validation.
+//
+// Each error of a response is tagged with its category in extensions.category, and the status code
+// tells whether the operation was executed:
+//   - 200 if the operation was executed, even if some or all of its fields failed
+//   - 400 if the request could not be decoded or asked for an unsupported operation type
+//   - 413 if a multipart request body is too large
+//   - 415 if the request body has an unsupported content type
+//   - 422 if the query failed to parse or validate, the operation was not found, or its variables were invalid
+//   - 500 if executing the operation panicked
+type queryHandler struct {
+	exec               graphql.ExecutableSchema
+	cache              *documentCache
+	resolverMiddleware graphql.ResolverMiddleware
+	metrics            *operationMetrics
+	maxAliases         int
+	maxFragmentDepth   int
+	validationRules    ValidationRules
+	envelope           Envelope
+	encoder            ResponseEncoder
+	schemaName         string
+	// decides which operations are traced. nil if tracing is disabled
+	sampler     *sampler
+	exportTrace TraceExporter
+	// requests presenting this token in the debug token header get the http status received by each
+	// resolver in extensions.upstreamStatuses. empty if debug endpoints are disabled
+	debugToken  string
+	statusCodes StatusCodePolicy
+	// whether introspecting the schema reports the examples of its fields and arguments
+	examples bool
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "OPTIONS" {
+		w.Header().Set("Allow", "OPTIONS, GET, POST")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	var params queryParams
+	switch r.Method {
+	case "GET":
+		params.Query = r.URL.Query().Get("query")
+		params.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &params.Variables); err != nil {
+				h.sendErrorf(w, http.StatusBadRequest, "variables could not be decoded")
+				return
+			}
+		}
+	case "POST":
+		if status, err := decodePost(r, &params); err != nil {
+			h.sendErrorf(w, status, "%v", err)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// operations are failed until they return without errors
+	var (
+		failed = true
+		valid  bool
+		size   int
+	)
+	if h.metrics != nil {
+		start := time.Now()
+		defer func() {
+			// only operations which can be executed are tracked by name
+			label := invalidOperations
+			if valid {
+				label = h.metrics.label(params, start)
+			}
+			h.metrics.record(label, time.Since(start), failed, size)
+		}()
+	}
+
+	ctx := r.Context()
+	if h.sampler.sample(r.Header) {
+		var trace *exec.Trace
+		ctx, trace = exec.WithTrace(ctx)
+		start := time.Now()
+		defer func() {
+			h.exportTrace(&OperationTrace{
+				Schema:     h.schemaName,
+				Operation:  params.OperationName,
+				RequestID:  util.RequestID(ctx),
+				Start:      start,
+				DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+				Fields:     trace.Fields(),
+			})
+		}()
+	}
+	var (
+		statuses *exec.UpstreamStatuses
+		timings  *exec.UpstreamTimings
+	)
+	if h.debugToken != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(debugTokenHeader)), []byte(h.debugToken)) == 1 {
+		ctx, statuses = exec.WithUpstreamStatuses(ctx)
+		ctx, timings = exec.WithUpstreamTimings(ctx)
+	}
+	ctx, warnings := exec.WithWarnings(ctx)
+	var examples *exec.Examples
+	if h.examples {
+		ctx, examples = exec.WithExamples(ctx)
+	}
+	res, status, valid := h.execute(ctx, params)
+	status = h.statusCodes.status(res, status)
+	failed = len(res.Errors) > 0
+	if upstreamStatuses := statuses.Statuses(); upstreamStatuses != nil {
+		res.setExtension("upstreamStatuses", upstreamStatuses)
+	}
+	if upstreamTimings := timings.Timings(); upstreamTimings != nil {
+		res.setExtension("timings", upstreamTimings)
+	}
+	if warnings := warnings.Warnings(); warnings != nil {
+		res.setExtension("warnings", warnings)
+	}
+	if examples := examples.Examples(); examples != nil {
+		res.setExtension("examples", examples)
+	}
+	b := h.encode(res)
+	size = len(b)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	w.Write(b)
+}
+
+// execute the operation, returning the response, its http status and whether the query parsed and validated
+// and defines the operation
+func (h *queryHandler) execute(ctx context.Context, params queryParams) (res *Response, status int, valid bool) {
+	parsed := h.parse(params.Query)
+	if len(parsed.errs) > 0 {
+		return invalidDocumentResponse(parsed.errs), http.StatusUnprocessableEntity, false
+	}
+	op, err := parsed.doc.GetOperation(params.OperationName)
+	if err != nil {
+		return errorResponse(exec.ErrorCategoryValidation, "%v", err), http.StatusUnprocessableEntity, false
+	}
+	valid = true
+
+	categories := newErrorCategories()
+	reqCtx := graphql.NewRequestContext(parsed.doc, params.Query, params.Variables)
+	if h.resolverMiddleware != nil {
+		reqCtx.ResolverMiddleware = h.resolverMiddleware
+	}
+	reqCtx.ErrorPresenter = categories.presenter(reqCtx.ErrorPresenter)
+	ctx = graphql.WithRequestContext(ctx, reqCtx)
+	ctx = debuglog.WithSchema(ctx, h.schemaName)
+	defer func() {
+		if err := recover(); err != nil {
+			userErr := reqCtx.Recover(ctx, err)
+			res, status = errorResponse(exec.ErrorCategoryInternal, "%v", userErr), http.StatusInternalServerError
+		}
+	}()
+
+	var gqlRes *graphql.Response
+	switch op.Type {
+	case query.Query:
+		gqlRes = h.exec.Query(ctx, op)
+	case query.Mutation:
+		gqlRes = h.exec.Mutation(ctx, op)
+	default:
+		return errorResponse(exec.ErrorCategoryValidation, "unsupported operation type"), http.StatusBadRequest, false
+	}
+	res = categories.response(gqlRes)
+	if res.Data == nil && len(res.Errors) > 0 && !categories.executed() {
+		// the variables were rejected before execution started
+		return res, http.StatusUnprocessableEntity, true
+	}
+	return res, http.StatusOK, true
+}
+
+// the largest multipart request accepted. files are not supported, so the request only needs to fit the
+// operations field, and is held in memory
+const maxMultipartBytes = 8 << 20
+
+// decodePost reads the query parameters from the body of a POST request, according to its content type:
+//   - application/json, or no content type: the parameters encoded as json
+//   - application/graphql: the query itself. the operation name may be given in the url
+//   - multipart/form-data: the parameters encoded as json in the operations field, as sent by clients
+//     which support file uploads. files are not supported, so requests which map them to variables are rejected,
+//     as are requests larger than maxMultipartBytes
+func decodePost(r *http.Request, params *queryParams) (int, error) {
+	mediaType := ""
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		var err error
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			return http.StatusBadRequest, errors.Wrap(err, "invalid content type")
+		}
+	}
+	switch mediaType {
+	case "", "application/json":
+		if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+			return http.StatusBadRequest, errors.Wrap(err, "json body could not be decoded")
+		}
+	case "application/graphql":
+		query, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return http.StatusBadRequest, errors.Wrap(err, "reading body")
+		}
+		params.Query = string(query)
+		params.OperationName = r.URL.Query().Get("operationName")
+	case "multipart/form-data":
+		body := &io.LimitedReader{R: r.Body, N: maxMultipartBytes + 1}
+		r.Body = ioutil.NopCloser(body)
+		err := r.ParseMultipartForm(maxMultipartBytes)
+		if body.N <= 0 {
+			return http.StatusRequestEntityTooLarge, errors.Errorf("multipart body is larger than %v bytes", maxMultipartBytes)
+		}
+		if err != nil {
+			return http.StatusBadRequest, errors.Wrap(err, "multipart body could not be decoded")
+		}
+		operations := r.MultipartForm.Value["operations"]
+		if len(operations) != 1 {
+			return http.StatusBadRequest, errors.New("multipart requests must have a single operations field")
+		}
+		if err := json.Unmarshal([]byte(operations[0]), params); err != nil {
+			return http.StatusBadRequest, errors.Wrap(err, "operations could not be decoded")
+		}
+		if len(r.MultipartForm.File) > 0 {
+			return http.StatusBadRequest, errors.New("file uploads are not supported")
+		}
+	default:
+		return http.StatusUnsupportedMediaType, errors.Errorf("unsupported content type %v", mediaType)
+	}
+	return 0, nil
+}
+
+func (h *queryHandler) parse(q string) *parsedDocument {
+	key := normalizeQuery(q)
+	if h.cache != nil {
+		if parsed, ok := h.cache.get(key); ok {
+			documentCacheHits.Add(h.schemaName, 1)
+			return parsed
+		}
+		documentCacheMisses.Add(h.schemaName, 1)
+	}
+	start := time.Now()
+	parsed := h.parseAndValidate(q)
+	documentParseDuration.Add(h.schemaName, int64(time.Since(start)/time.Microsecond))
+	if h.cache != nil {
+		h.cache.add(key, parsed)
+	}
+	return parsed
+}
+
+// parseAndValidate recovers from panics of the parser and validation on malformed queries, rejecting the query
+// rather than failing the request
+func (h *queryHandler) parseAndValidate(q string) (parsed *parsedDocument) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Warnf("recovered from a panic parsing a query for schema %v: %v\n%s", h.schemaName, r, debug.Stack())
+			parsed = &parsedDocument{errs: []*gqlerrors.QueryError{{Message: "query could not be parsed"}}}
+		}
+	}()
+	parsed = &parsedDocument{}
+	doc, qErr := query.Parse(q)
+	if qErr != nil {
+		parsed.errs = []*gqlerrors.QueryError{qErr}
+		return parsed
+	}
+	parsed.doc = doc
+	if parsed.errs = checkFragmentDepth(doc, h.maxFragmentDepth); len(parsed.errs) > 0 {
+		return parsed
+	}
+	// includes checking that selections of the same response name can be merged
+	parsed.errs = validation.Validate(h.exec.Schema(), doc)
+	if len(parsed.errs) == 0 {
+		parsed.errs = checkAliases(doc, h.maxAliases)
+	}
+	if len(parsed.errs) == 0 {
+		parsed.errs = h.validationRules.validate(h.exec.Schema(), doc)
+	}
+	return parsed
+}
+
+func errorResponse(category exec.ErrorCategory, format string, args ...interface{}) *Response {
+	return &Response{Errors: []*Error{newError(&gqlerrors.QueryError{Message: fmt.Sprintf(format, args...)}, category)}}
+}
+
+// invalidDocumentResponse reports the errors of a document which failed to parse or validate
+func invalidDocumentResponse(errs []*gqlerrors.QueryError) *Response {
+	res := &Response{}
+	for _, err := range errs {
+		res.Errors = append(res.Errors, newError(err, exec.ErrorCategoryValidation))
+	}
+	return res
+}
+
+// sendErrorf responds to requests which could not be decoded, in the envelope of the endpoint
+func (h *queryHandler) sendErrorf(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(h.encode(errorResponse(exec.ErrorCategoryValidation, format, args...)))
+}
+
+// encode the response in the envelope of the endpoint, if any. encoding/json is used unless another encoder is set
+func (h *queryHandler) encode(res *Response) []byte {
+	var body interface{} = res
+	if h.envelope != nil {
+		body = h.envelope(res)
+	}
+	encoder := h.encoder
+	if encoder == nil {
+		encoder = standardEncoder{}
+	}
+	b, err := encoder.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
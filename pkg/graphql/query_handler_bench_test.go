@@ -0,0 +1,113 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/test"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+)
+
+const benchQuery = `
+query HeroAndFriends($episode: Episode) {
+	hero(episode: $episode) {
+		name
+		...CharacterFields
+		friends {
+			...CharacterFields
+			friends {
+				name
+			}
+		}
+	}
+	human(id: "1000") {
+		name
+		height
+		starships {
+			name
+			length
+		}
+	}
+}
+
+fragment CharacterFields on Character {
+	id
+	name
+	appearsIn
+	... on Droid {
+		primaryFunction
+	}
+	... on Human {
+		height
+		mass
+	}
+}
+`
+
+// compares parsing and validating a query on every request with looking it up in the document cache.
+// run with: go test ./pkg/graphql -run xxx -bench Parse -benchmem
+func BenchmarkParse(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		benchmarkParse(b, nil)
+	})
+	b.Run("cached", func(b *testing.B) {
+		benchmarkParse(b, newDocumentCache(10))
+	})
+}
+
+func benchmarkParse(b *testing.B, cache *documentCache) {
+	h := &queryHandler{exec: test.StarWarsExecutableSchema("no-address-defined"), cache: cache}
+	if parsed := h.parse(benchQuery); len(parsed.errs) > 0 {
+		b.Fatalf("invalid query: %v", parsed.errs)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.parse(benchQuery)
+	}
+}
+
+// measures encoding a large response with the standard encoder, and the cost of reformatting it with JSONOptions.
+// pass another ResponseEncoder to benchmarkEncode to compare it.
+// run with: go test ./pkg/graphql -run xxx -bench Encode -benchmem
+func BenchmarkEncode(b *testing.B) {
+	b.Run("encoding/json", func(b *testing.B) {
+		benchmarkEncode(b, standardEncoder{}, JSONOptions{})
+	})
+	b.Run("indented", func(b *testing.B) {
+		benchmarkEncode(b, standardEncoder{}, JSONOptions{Indent: "  ", DisableHTMLEscape: true})
+	})
+}
+
+func benchmarkEncode(b *testing.B, encoder ResponseEncoder, opts JSONOptions) {
+	res := &Response{
+		Data:       json.RawMessage(`{"hero":{"name":"Luke Skywalker"}}`),
+		Extensions: map[string]interface{}{},
+	}
+	timings := make(map[string]float64)
+	for i := 0; i < 1000; i++ {
+		path := fmt.Sprintf("hero.friends.%v.name", i)
+		timings[path] = float64(i)
+		res.Errors = append(res.Errors, newError(&gqlerrors.QueryError{
+			Message: "upstream <starwars> failed for " + path,
+			Path:    []interface{}{"hero", "friends", i, "name"},
+		}, exec.ErrorCategoryUpstream))
+	}
+	res.setExtension("timings", timings)
+	h := &queryHandler{encoder: encoder}
+	handler := withJSONOptions(opts, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(h.encode(res))
+	}))
+	req := httptest.NewRequest("POST", "/query", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("queryHandler", func() {
+	var h *queryHandler
+	BeforeEach(func() {
+		h = &queryHandler{
+			exec:       test.StarWarsExecutableSchema("no-address-defined"),
+			cache:      newDocumentCache(10),
+			schemaName: "StarWars",
+		}
+	})
+	serve := func(method, target, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	get := func(params url.Values) *httptest.ResponseRecorder {
+		return serve("GET", "/query?"+params.Encode(), "", "")
+	}
+
+	It("reads the query from the url of GET requests", func() {
+		rec := get(url.Values{"query": {"query A { __typename } query B { __typename }"}, "operationName": {"B"}})
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal(`{"data":{"__typename":"Query"}}`))
+	})
+	It("reads the query from the body of POST requests", func() {
+		rec := serve("POST", "/query", "application/json", `{"query": "{ __typename }"}`)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal(`{"data":{"__typename":"Query"}}`))
+
+		rec = serve("POST", "/query?operationName=B", "application/graphql", "query A { __typename } query B { __typename }")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal(`{"data":{"__typename":"Query"}}`))
+	})
+	It("rejects requests which can't be decoded with 400", func() {
+		rec := get(url.Values{"query": {"{ __typename }"}, "variables": {"{"}})
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("variables could not be decoded"))
+
+		rec = serve("POST", "/query", "application/json", `{"query": `)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("json body could not be decoded"))
+
+		rec = serve("POST", "/query", "application/json; charset", `{}`)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("invalid content type"))
+	})
+	It("rejects unsupported content types with 415", func() {
+		rec := serve("POST", "/query", "text/plain", "{ __typename }")
+		Expect(rec.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(rec.Body.String()).To(ContainSubstring("unsupported content type text/plain"))
+	})
+	It("rejects queries which fail to parse or validate with 422", func() {
+		rec := get(url.Values{"query": {"{ hero { name "}})
+		Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(rec.Body.String()).To(ContainSubstring(`"errors"`))
+
+		rec = get(url.Values{"query": {"{ villain { name } }"}})
+		Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(rec.Body.String()).To(ContainSubstring(`Cannot query field \"villain\" on type \"Query\".`))
+
+		rec = get(url.Values{"query": {"query A { __typename }"}, "operationName": {"B"}})
+		Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+	})
+	It("caches documents which failed to parse along with their errors", func() {
+		first := get(url.Values{"query": {"{ hero { name "}})
+		Expect(h.cache.order.Len()).To(Equal(1))
+		second := get(url.Values{"query": {"{ hero {  name "}})
+		Expect(h.cache.order.Len()).To(Equal(1))
+		Expect(second.Code).To(Equal(first.Code))
+		Expect(second.Body.String()).To(Equal(first.Body.String()))
+	})
+	It("answers OPTIONS and rejects other methods", func() {
+		rec := serve("OPTIONS", "/query", "", "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Allow")).To(Equal("OPTIONS, GET, POST"))
+
+		rec = serve("DELETE", "/query", "", "")
+		Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+// DebugOptions enable endpoints for reproducing production issues
+type DebugOptions struct {
+	// serve POST <RootPath>/debug/replay and GET /debug/traces on the admin handler
+	Enabled bool
+	// clients sending this token in the X-Sqoop-Debug-Token header get the status and timing of each
+	// upstream call in the extensions of responses. debug endpoints are not served without a token
+	Token string
+}
+
+const debugTokenHeader = "X-Sqoop-Debug-Token"
+
+type replayRequest struct {
+	// the name of a recently executed operation, as reported in metrics
+	Operation string                 `json:"operation"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type replayResponse struct {
+	Query  string              `json:"query"`
+	Result *Response           `json:"result"`
+	Trace  []*exec.TracedField `json:"trace"`
+}
+
+// POST <RootPath>/debug/replay of the admin handler executes the most recent query with the given operation
+// name with new variables, and returns the result along with a trace of the resolved fields.
+// mutations are never replayed
+func replayHandler(h *queryHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req replayRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("request could not be decoded: %v", err), http.StatusBadRequest)
+			return
+		}
+		params, ok := h.metrics.operation(req.Operation)
+		if !ok {
+			http.Error(w, fmt.Sprintf("operation %v has not been executed", req.Operation), http.StatusNotFound)
+			return
+		}
+		if parsed := h.parse(params.Query); parsed.doc != nil {
+			if op, err := parsed.doc.GetOperation(params.OperationName); err == nil && op.Type != query.Query {
+				http.Error(w, "only queries can be replayed", http.StatusBadRequest)
+				return
+			}
+		}
+		params.Variables = req.Variables
+		ctx, trace := exec.WithTrace(r.Context())
+		res, _, _ := h.execute(ctx, params)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(replayResponse{
+			Query:  params.Query,
+			Result: res,
+			Trace:  trace.Fields(),
+		}); err != nil {
+			panic(err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/graphql"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+)
+
+// Response is the GraphQL response served to clients. It differs from gqlgen's response
+// in that errors and the response itself carry extensions
+type Response struct {
+	Data       json.RawMessage        `json:"data"`
+	Errors     []*Error               `json:"errors,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (r *Response) setExtension(name string, value interface{}) {
+	if r.Extensions == nil {
+		r.Extensions = make(map[string]interface{})
+	}
+	r.Extensions[name] = value
+}
+
+// Error is a GraphQL error with its category in extensions.category, and its code in extensions.code if it has one
+type Error struct {
+	*gqlerrors.QueryError
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func newError(err *gqlerrors.QueryError, category exec.ErrorCategory) *Error {
+	return &Error{
+		QueryError: err,
+		Extensions: map[string]interface{}{"category": category},
+	}
+}
+
+// errorCategories remembers the category and code of each error presented while executing an operation.
+// errors reported by gqlgen lose the error they were presented from, so they can't be categorized later
+type errorCategories struct {
+	mu         sync.Mutex
+	categories map[*gqlerrors.QueryError]exec.ErrorCategory
+	codes      map[*gqlerrors.QueryError]string
+}
+
+func newErrorCategories() *errorCategories {
+	return &errorCategories{
+		categories: make(map[*gqlerrors.QueryError]exec.ErrorCategory),
+		codes:      make(map[*gqlerrors.QueryError]string),
+	}
+}
+
+func (c *errorCategories) presenter(present graphql.ErrorPresenterFunc) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) error {
+		queryErr := toQueryError(present(ctx, err))
+		c.mu.Lock()
+		c.categories[queryErr] = exec.CategoryOf(err)
+		if code := exec.CodeOf(err); code != "" {
+			c.codes[queryErr] = code
+		}
+		c.mu.Unlock()
+		return queryErr
+	}
+}
+
+// executed reports whether any errors were presented, i.e. whether the operation was executed
+func (c *errorCategories) executed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.categories) > 0
+}
+
+// response categorizes the errors of res. errors which were not presented while executing the operation
+// were returned before it started, when the request was validated
+func (c *errorCategories) response(res *graphql.Response) *Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := &Response{Data: res.Data}
+	for _, resErr := range res.Errors {
+		err := toQueryError(resErr)
+		category, ok := c.categories[err]
+		if !ok {
+			category = exec.ErrorCategoryValidation
+		}
+		presented := newError(err, category)
+		if code, ok := c.codes[err]; ok {
+			presented.Extensions["code"] = code
+		}
+		out.Errors = append(out.Errors, presented)
+	}
+	return out
+}
+
+// toQueryError converts an error presented by gqlgen to a query error, keeping its message and path
+func toQueryError(err error) *gqlerrors.QueryError {
+	switch err := err.(type) {
+	case *gqlerrors.QueryError:
+		return err
+	case *graphql.ResolverError:
+		return &gqlerrors.QueryError{Message: err.Message, Path: err.Path}
+	}
+	return &gqlerrors.QueryError{Message: err.Error()}
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/resolvers/cache"
+	"github.com/solo-io/sqoop/pkg/util"
 	"github.com/vektah/gqlgen/graphql"
 	"github.com/vektah/gqlgen/handler"
 	"fmt"
@@ -16,14 +19,68 @@ import (
 
 type Router struct {
 	routes *routerSwapper
+	// routes of each endpoint for operators, served by the admin listener
+	admin *routerSwapper
+	opts   Options
+	// parsed documents of each executable schema being served
+	documentCaches map[graphql.ExecutableSchema]*documentCache
+	// metrics of each schema, kept across updates so the operation limit holds
+	metrics map[string]*operationMetrics
+	// nil if tracing is disabled
+	sampler *sampler
+	// the exporter of sampled traces and, if the default exporter is used, the traces it keeps
+	exportTrace  TraceExporter
+	recentTraces *recentTraces
+	// shared by every endpoint, so the caps hold across schemas and updates
+	limiter *concurrencyLimiter
 }
 
-func NewRouter() *Router {
-	return &Router{
+// Options configure how the router serves every endpoint
+type Options struct {
+	JSON        JSONOptions
+	Compression CompressionOptions
+	// the number of parsed query documents to cache per endpoint. zero disables caching.
+	// hits, misses and the time spent parsing are reported in sqoop_query_cache_* and sqoop_query_parse_duration_us
+	QueryCacheSize int
+	// the maximum number of aliases under which a field may be selected in a single selection set.
+	// zero means no limit
+	MaxAliases int
+	// the maximum depth of fragment spreads nested within fragments. zero means no limit
+	MaxFragmentDepth int
+	Metrics          MetricsOptions
+	Debug            DebugOptions
+	Tracing          TracingOptions
+	// the request cookies resolvers may see. other cookies are dropped before resolvers run
+	AllowedCookies []string
+	Concurrency    ConcurrencyOptions
+	// decides the http status of failed operations. defaults to StatusCodesSpec
+	StatusCodes StatusCodePolicy
+	// custom rules which queries must pass, in addition to the standard validation
+	ValidationRules ValidationRules
+	// report the @example values of the fields and arguments of introspected types in extensions.examples
+	IntrospectionExamples bool
+}
+
+func NewRouter(opts Options) *Router {
+	r := &Router{
 		routes: &routerSwapper{
 			mux: mux.NewRouter(),
 		},
+		admin: &routerSwapper{
+			mux: mux.NewRouter(),
+		},
+		opts:           opts,
+		documentCaches: make(map[graphql.ExecutableSchema]*documentCache),
+		metrics:        make(map[string]*operationMetrics),
+		sampler:        newSampler(opts.Tracing),
+		exportTrace:    opts.Tracing.Exporter,
+		limiter:        newConcurrencyLimiter(opts.Concurrency),
+	}
+	if r.exportTrace == nil {
+		r.recentTraces = newRecentTraces(recentTracesSize)
+		r.exportTrace = r.recentTraces.export
 	}
+	return r
 }
 
 type Endpoint struct {
@@ -35,26 +92,184 @@ type Endpoint struct {
 	QueryPath string
 	// the executable schema to serve
 	ExecSchema graphql.ExecutableSchema
+	// version label of the schema, if any
+	Version string
+	// value of the Sunset header to send with responses, if any
+	Sunset string
+	// cached resolver results, which can be invalidated at <RootPath>/cache of the admin handler
+	ResolverCache *cache.Cache
+	// applied to requests to the query path, outermost first
+	Middleware []Middleware
+	// wraps responses from the query path. responses use the standard envelope if nil
+	Envelope Envelope
 }
 
 func (s *Router) UpdateEndpoints(endpoints ...*Endpoint) {
 	m := mux.NewRouter()
+	admin := mux.NewRouter()
+	// keep the documents cached for schemas which haven't changed
+	documentCaches := make(map[graphql.ExecutableSchema]*documentCache)
 	for _, endpoint := range endpoints {
-		m.Handle(endpoint.RootPath, handler.Playground(endpoint.SchemaName, endpoint.QueryPath))
-		m.Handle(endpoint.QueryPath, handler.GraphQL(endpoint.ExecSchema,
-			handler.ResolverMiddleware(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
+		if s.opts.QueryCacheSize <= 0 {
+			break
+		}
+		documentCaches[endpoint.ExecSchema] = s.documentCaches[endpoint.ExecSchema]
+		if documentCaches[endpoint.ExecSchema] == nil {
+			documentCaches[endpoint.ExecSchema] = newDocumentCache(s.opts.QueryCacheSize)
+		}
+	}
+	s.documentCaches = documentCaches
+	for _, endpoint := range endpoints {
+		if s.metrics[endpoint.SchemaName] == nil {
+			s.metrics[endpoint.SchemaName] = newOperationMetrics(endpoint.SchemaName, s.opts.Metrics, s.opts.Debug.Enabled && s.opts.Debug.Token != "")
+		}
+		m.Handle(endpoint.RootPath, withSunset(endpoint.Sunset, handler.Playground(endpoint.SchemaName, endpoint.QueryPath)))
+		schemaName := endpoint.SchemaName
+		qh := &queryHandler{
+			exec:             endpoint.ExecSchema,
+			cache:            documentCaches[endpoint.ExecSchema],
+			metrics:          s.metrics[endpoint.SchemaName],
+			maxAliases:       s.opts.MaxAliases,
+			maxFragmentDepth: s.opts.MaxFragmentDepth,
+			validationRules:  s.opts.ValidationRules,
+			examples:         s.opts.IntrospectionExamples,
+			envelope:         endpoint.Envelope,
+			encoder:          s.opts.JSON.Encoder,
+			schemaName:       endpoint.SchemaName,
+			sampler:          s.sampler,
+			exportTrace:      s.exportTrace,
+			statusCodes:      s.opts.StatusCodes,
+			resolverMiddleware: func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 				rc := graphql.GetResolverContext(ctx)
-				log.Printf("%v: Entered", endpoint.SchemaName, rc.Object, rc.Field.Name)
+				requestID := util.RequestID(ctx)
+				log.Printf("%v [%v]: Entered", schemaName, requestID, rc.Object, rc.Field.Name)
 				res, err = next(ctx)
-				log.Printf("%v: Left", endpoint.SchemaName, rc.Object, rc.Field.Name, "=>", res, err)
+				log.Printf("%v [%v]: Left", schemaName, requestID, rc.Object, rc.Field.Name, "=>", res, err)
 				return res, err
-			}),
-		))
+			},
+		}
+		m.Handle(endpoint.QueryPath, s.limiter.limit(withRequestID(withCookies(s.opts.AllowedCookies, chain(endpoint.Middleware, withSunset(endpoint.Sunset, withCompression(s.opts.Compression, withJSONOptions(s.opts.JSON, withCacheControl(qh)))))))))
+		if s.opts.Debug.Enabled && s.opts.Debug.Token != "" {
+			qh.debugToken = s.opts.Debug.Token
+			admin.Handle(endpoint.RootPath+"/debug/replay", withRequestID(replayHandler(qh))).Methods("POST")
+		}
+		if endpoint.ResolverCache != nil {
+			admin.Handle(endpoint.RootPath+"/cache", invalidateCache(endpoint.ResolverCache)).Methods("DELETE")
+		}
+	}
+	if s.opts.Debug.Enabled && s.opts.Debug.Token != "" && s.recentTraces != nil {
+		admin.Handle("/debug/traces", recentTracesHandler(s.recentTraces)).Methods("GET")
 	}
 	m.Methods("GET").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, landingPage(endpoints))
 	})
 	s.routes.swap(m)
+	s.admin.swap(admin)
+}
+
+// AdminHandler serves the routes of each endpoint for operators, e.g. invalidating its resolver cache at
+// <RootPath>/cache, and the debug endpoints. it must only be served where GraphQL clients can't reach it
+func (s *Router) AdminHandler() http.Handler {
+	return http.HandlerFunc(s.admin.serveHTTP)
+}
+
+// use the request id provided by the client, or generate one.
+// the id is echoed back to the client and forwarded to resolvers. templates can read it, and the trace id
+// of the request if it has one, from .ctx
+func withRequestID(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(util.RequestIDHeader)
+		if id == "" {
+			id = util.NewRequestID()
+		}
+		w.Header().Set(util.RequestIDHeader, id)
+		ctx := util.WithRequestID(r.Context(), id)
+		ctx = exec.WithContextValue(ctx, exec.ContextRequestID, id)
+		ctx = exec.WithContextValue(ctx, exec.ContextTraceID, incomingTraceID(r.Header))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// make the allowed cookies of the request available to resolvers
+func withCookies(allowed []string, h http.Handler) http.Handler {
+	if len(allowed) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies := make(map[string]string)
+		for _, name := range allowed {
+			if cookie, err := r.Cookie(name); err == nil {
+				cookies[name] = cookie.Value
+			}
+		}
+		h.ServeHTTP(w, r.WithContext(exec.WithCookies(r.Context(), cookies)))
+	})
+}
+
+// set Cache-Control from the @cacheControl directives of the fields resolved by the operation
+func withCacheControl(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, policy := exec.WithCachePolicy(r.Context())
+		h.ServeHTTP(&cacheControlWriter{ResponseWriter: w, policy: policy}, r.WithContext(ctx))
+	})
+}
+
+// the operation has been executed by the time the response is written
+type cacheControlWriter struct {
+	http.ResponseWriter
+	policy      *exec.CachePolicy
+	wroteHeader bool
+}
+
+func (w *cacheControlWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if header := w.policy.Header(); header != "" && status == http.StatusOK {
+			w.Header().Set("Cache-Control", header)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheControlWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// DELETE <RootPath>/cache?type=<type>&field=<field>&key=<key>
+// removes a single entry, all entries of a field if key is omitted,
+// or the whole cache if type and field are omitted
+func invalidateCache(c *cache.Cache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		typeName, fieldName := query.Get("type"), query.Get("field")
+		key, hasKey := query["key"]
+		switch {
+		case typeName == "" && fieldName == "":
+			c.Purge()
+		case typeName == "" || fieldName == "":
+			http.Error(w, "type and field must be specified together", http.StatusBadRequest)
+			return
+		case hasKey:
+			c.Invalidate(typeName, fieldName, key[0])
+		default:
+			c.InvalidateField(typeName, fieldName)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// advertise the deprecation of an endpoint (RFC 8594)
+func withSunset(sunset string, h http.Handler) http.Handler {
+	if sunset == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Sunset", sunset)
+		h.ServeHTTP(w, r)
+	})
 }
 
 func (s *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -5,12 +5,25 @@ import (
 	. "github.com/onsi/gomega"
 
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"expvar"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	. "github.com/solo-io/sqoop/pkg/graphql"
+	"github.com/solo-io/sqoop/pkg/resolvers/cache"
 	"github.com/solo-io/sqoop/test"
+	"github.com/vektah/gqlgen/graphql"
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
 )
 
 var _ = Describe("Router", func() {
@@ -19,7 +32,7 @@ var _ = Describe("Router", func() {
 		server *httptest.Server
 	)
 	BeforeEach(func() {
-		router = NewRouter()
+		router = NewRouter(Options{})
 		server = httptest.NewServer(router)
 	})
 	AfterEach(func() {
@@ -57,6 +70,626 @@ var _ = Describe("Router", func() {
 				`performing http post: Post http://no-address-defined/Query.hero: dial tcp: lookup no-address-defined on`))
 		}
 	})
+	It("formats responses according to the json options", func() {
+		router = NewRouter(Options{JSON: JSONOptions{Indent: "  ", DisableHTMLEscape: true}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBufferString("<html>"))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix("{\n  \"data\": null,\n  \"errors\": ["))
+		Expect(string(data)).To(ContainSubstring("invalid character '<'"))
+	})
+	It("encodes responses with the encoder of the json options", func() {
+		encoder := &countingEncoder{}
+		router = NewRouter(Options{JSON: JSONOptions{Encoder: encoder}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query":"{__typename}"}`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"data":{"__typename":"Query"}}`))
+		Expect(atomic.LoadInt32(&encoder.calls)).To(Equal(int32(1)))
+	})
+	It("rejects queries which alias a field more than the maximum number of times", func() {
+		router = NewRouter(Options{MaxAliases: 2})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		query := `{"query":"{ a: hero { name } b: hero { name } ...more } fragment more on Query { c: hero { name } }"}`
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBufferString(query))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`field \"hero\" is selected under more aliases than the maximum allowed`))
+	})
+	It("rejects queries whose fragment spreads are nested more than the maximum depth", func() {
+		router = NewRouter(Options{MaxFragmentDepth: 2})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		post := func(query string) (int, string) {
+			body, err := json.Marshal(map[string]string{"query": query})
+			Expect(err).NotTo(HaveOccurred())
+			res, err := http.Post(server.URL+"/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(res.Body)
+			Expect(err).NotTo(HaveOccurred())
+			return res.StatusCode, string(data)
+		}
+		status, data := post(`{ hero { ...a } } fragment a on Character { ...b } ` +
+			`fragment b on Character { friends { ...c } } fragment c on Character { name }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring("fragment spreads are nested 3 deep, more than the maximum of 2"))
+		status, data = post(`{ hero { ...a } } fragment a on Character { ...b } fragment b on Character { friends { ...a } }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring(`fragment \"a\" is spread within itself`))
+		status, _ = post(`{ hero { ...a } } fragment a on Character { ...b } fragment b on Character { name }`)
+		Expect(status).To(Equal(http.StatusOK))
+	})
+	It("rejects queries which fail a custom validation rule", func() {
+		namedOperations := func(_ *schema.Schema, _ *query.Document, op *query.Operation) []*gqlerrors.QueryError {
+			if op.Name.Name != "" {
+				return nil
+			}
+			return []*gqlerrors.QueryError{{Message: "operations must be named"}}
+		}
+		router = NewRouter(Options{ValidationRules: ValidationRules{"named-operations": namedOperations}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		post := func(query string) (int, string) {
+			body, err := json.Marshal(map[string]string{"query": query})
+			Expect(err).NotTo(HaveOccurred())
+			res, err := http.Post(server.URL+"/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(res.Body)
+			Expect(err).NotTo(HaveOccurred())
+			return res.StatusCode, string(data)
+		}
+		status, data := post(`{ hero { name } }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring("operations must be named"))
+		status, _ = post(`query Hero { hero { name } }`)
+		Expect(status).To(Equal(http.StatusOK))
+	})
+	It("fails operations without data with an error status under the errors status code policy", func() {
+		router = NewRouter(Options{StatusCodes: StatusCodesErrors})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusBadGateway))
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query":"{ hero { nope } }"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+	It("rejects queries which make parsing or validation panic instead of failing the request", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: panickingSchema{test.StarWarsExecutableSchema("no-address-defined")},
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("query could not be parsed"))
+	})
+	It("rejects selections of the same response name which can't be merged, as validation requires", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		post := func(query string) (int, string) {
+			body, err := json.Marshal(map[string]string{"query": query})
+			Expect(err).NotTo(HaveOccurred())
+			res, err := http.Post(server.URL+"/query", "application/json", bytes.NewBuffer(body))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(res.Body)
+			Expect(err).NotTo(HaveOccurred())
+			return res.StatusCode, string(data)
+		}
+		status, data := post(`{ hero { name ...c } } fragment c on Character { name: id }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring(`conflict because name and id are different fields`))
+		status, data = post(`{ hero(episode: JEDI) { name } hero(episode: EMPIRE) { name } }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring(`conflict because they have differing arguments`))
+		status, data = post(`{ hero { ... on Human { x: height } ... on Droid { x: primaryFunction } } }`)
+		Expect(status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(data).To(ContainSubstring(`conflict because they return conflicting types`))
+		// the same field through a fragment, and different arguments on types which never overlap, are merged
+		status, data = post(`{ hero { name ...c ` +
+			`... on Human { x: friendsConnection(first: 1) { totalCount } } ` +
+			`... on Droid { x: friendsConnection(first: 2) { totalCount } } } } ` +
+			`fragment c on Character { name friends { id } }`)
+		Expect(status).NotTo(Equal(http.StatusUnprocessableEntity))
+		Expect(data).NotTo(ContainSubstring("conflict"))
+	})
+	It("wraps responses in the envelope of the endpoint", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Envelope:   OKPayloadEnvelope,
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query":"{__typename}"}`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"ok":true,"payload":{"data":{"__typename":"Query"}}}`))
+
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query":`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+		var enveloped struct {
+			OK      *bool
+			Payload struct {
+				Errors []map[string]interface{}
+			}
+		}
+		Expect(json.NewDecoder(res.Body).Decode(&enveloped)).To(Succeed())
+		Expect(enveloped.OK).To(Equal(new(bool)))
+		Expect(enveloped.Payload.Errors).To(HaveLen(1))
+		Expect(enveloped.Payload.Errors[0]["message"]).To(ContainSubstring("json body could not be decoded"))
+	})
+	It("categorizes errors as caused by the request or by upstreams", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"extensions":{"category":"upstream"}`))
+
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query":"{ villain { name } }"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		data, err = ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"extensions":{"category":"validation"}`))
+	})
+	It("exports traces of sampled operations, honoring incoming sampling decisions", func() {
+		var traces []*OperationTrace
+		router = NewRouter(Options{Tracing: TracingOptions{
+			SampleRate: 1,
+			Exporter: func(trace *OperationTrace) {
+				traces = append(traces, trace)
+			},
+		}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		_, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(traces).To(HaveLen(1))
+		Expect(traces[0].Schema).To(Equal("StarWars"))
+		Expect(traces[0].Fields).NotTo(BeEmpty())
+
+		req, err := http.NewRequest("POST", server.URL+"/query", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+		_, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(traces).To(HaveLen(1))
+	})
+	It("compresses large responses for clients which accept gzip", func() {
+		router = NewRouter(Options{Compression: CompressionOptions{MinSize: 10}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		req, err := http.NewRequest("POST", server.URL+"/query", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept-Encoding", "gzip")
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("Content-Encoding")).To(Equal("gzip"))
+		gz, err := gzip.NewReader(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ioutil.ReadAll(gz)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix(`{"data":{"hero":null}`))
+
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(queryString))
+		req.Header.Set("Accept-Encoding", "gzip;q=0")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("Content-Encoding")).To(BeEmpty())
+	})
+	It("applies endpoint middleware to the query path", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Middleware: []Middleware{
+				CORS([]string{"https://example.com"}, nil, time.Minute),
+				RateLimit(1, 1),
+			},
+		})
+		req, err := http.NewRequest("OPTIONS", server.URL+"/query", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Origin", "https://example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+		Expect(res.Header.Get("Access-Control-Allow-Origin")).To(Equal("https://example.com"))
+		Expect(res.Header.Get("Access-Control-Max-Age")).To(Equal("60"))
+
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusTooManyRequests))
+	})
+	It("rejects requests beyond the in-flight cap, counting subscriptions separately", func() {
+		router = NewRouter(Options{Concurrency: ConcurrencyOptions{MaxRequests: 1}})
+		server.Config.Handler = router
+		entered, release := make(chan struct{}, 1), make(chan struct{})
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Middleware: []Middleware{func(h http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("block") != "" {
+						entered <- struct{}{}
+						<-release
+					}
+					h.ServeHTTP(w, r)
+				})
+			}},
+		})
+		blocked := make(chan int)
+		go func() {
+			defer GinkgoRecover()
+			res, err := http.Post(server.URL+"/query?block=1", "", bytes.NewBuffer(queryString))
+			Expect(err).NotTo(HaveOccurred())
+			blocked <- res.StatusCode
+		}()
+		<-entered
+		Expect(expvar.Get("sqoop_requests_in_flight").(*expvar.Map).Get("query").String()).To(Equal("1"))
+
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(res.Header.Get("Retry-After")).To(Equal("1"))
+
+		req, err := http.NewRequest("POST", server.URL+"/query", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", "text/event-stream")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).NotTo(Equal(http.StatusServiceUnavailable))
+
+		close(release)
+		Expect(<-blocked).To(Equal(http.StatusOK))
+		res, err = http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+	})
+	It("caches authentication decisions by credential, up to the expiry of the credential", func() {
+		calls := make(map[string]int)
+		expires := time.Now().Add(50 * time.Millisecond)
+		authenticator := AuthenticatorFunc(func(ctx context.Context, credential string) (*AuthDecision, error) {
+			calls[credential]++
+			switch credential {
+			case "Bearer valid":
+				return &AuthDecision{Allowed: true}, nil
+			case "Bearer expiring":
+				return &AuthDecision{Allowed: true, Expires: expires}, nil
+			case "Bearer undecided":
+				return nil, nil
+			}
+			return &AuthDecision{}, nil
+		})
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Middleware: []Middleware{
+				ExternalAuth("Authorization", authenticator, AuthCacheOptions{TTL: time.Minute, Size: 10}),
+			},
+		})
+		status := func(credential string) int {
+			req, err := http.NewRequest("POST", server.URL+"/query", bytes.NewBuffer(queryString))
+			Expect(err).NotTo(HaveOccurred())
+			if credential != "" {
+				req.Header.Set("Authorization", credential)
+			}
+			res, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			res.Body.Close()
+			return res.StatusCode
+		}
+		Expect(status("")).To(Equal(http.StatusUnauthorized))
+		for i := 0; i < 3; i++ {
+			Expect(status("Bearer valid")).To(Equal(http.StatusOK))
+			Expect(status("Bearer invalid")).To(Equal(http.StatusUnauthorized))
+			Expect(status("Bearer undecided")).To(Equal(http.StatusInternalServerError))
+		}
+		Expect(calls["Bearer valid"]).To(Equal(1))
+		Expect(calls["Bearer invalid"]).To(Equal(1))
+		// a missing decision is an error, which is not cached
+		Expect(calls["Bearer undecided"]).To(Equal(3))
+
+		Expect(status("Bearer expiring")).To(Equal(http.StatusOK))
+		time.Sleep(100 * time.Millisecond)
+		Expect(status("Bearer expiring")).To(Equal(http.StatusUnauthorized))
+		Expect(calls["Bearer expiring"]).To(Equal(2))
+	})
+	It("accepts queries as json, as application/graphql and in multipart requests", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query?operationName=Typename", "application/graphql", bytes.NewBufferString(`query Typename {__typename}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"data":{"__typename":"Query"}}`))
+
+		multipartBody := &bytes.Buffer{}
+		form := multipart.NewWriter(multipartBody)
+		Expect(form.WriteField("operations", `{"query":"{__typename}"}`)).To(Succeed())
+		Expect(form.Close()).To(Succeed())
+		res, err = http.Post(server.URL+"/query", form.FormDataContentType(), multipartBody)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res, err = http.Post(server.URL+"/query", "application/json; charset=utf-8", bytes.NewBufferString(`{"query":"{__typename}"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		res, err = http.Post(server.URL+"/query", "text/xml", bytes.NewBufferString(`<query/>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+	})
+	It("rejects multipart requests larger than the limit", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		multipartBody := &bytes.Buffer{}
+		form := multipart.NewWriter(multipartBody)
+		Expect(form.WriteField("operations", `{"query":"{__typename}"}`)).To(Succeed())
+		Expect(form.WriteField("padding", strings.Repeat("a", 9<<20))).To(Succeed())
+		Expect(form.Close()).To(Succeed())
+		res, err := http.Post(server.URL+"/query", form.FormDataContentType(), multipartBody)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+	})
+	It("sets the sunset header for deprecated endpoints", func() {
+		sunset := "Sat, 31 Dec 2018 23:59:59 GMT"
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/starwars/v1",
+			QueryPath:  "/starwars/v1/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Version:    "v1",
+			Sunset:     sunset,
+		}, &Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/starwars/v2",
+			QueryPath:  "/starwars/v2/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+			Version:    "v2",
+		})
+		res, err := http.Post(server.URL+"/starwars/v1/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("Sunset")).To(Equal(sunset))
+		res, err = http.Post(server.URL+"/starwars/v2/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("Sunset")).To(BeEmpty())
+	})
+	It("generates request ids and echoes them to the client", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "StarWars",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		res, err := http.Post(server.URL+"/query", "", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("X-Request-Id")).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+
+		req, err := http.NewRequest("POST", server.URL+"/query", bytes.NewBuffer(queryString))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-Id", "my-request")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Header.Get("X-Request-Id")).To(Equal("my-request"))
+	})
+	It("groups operation metrics by name, capping the number of names of valid operations", func() {
+		router = NewRouter(Options{Metrics: MetricsOptions{MaxOperations: 2}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "Metrics",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		for _, body := range []string{
+			`{"query": "query Villain {villain{name}}", "operationName": "Villain"}`,
+			`{"query": "query Hero {hero{name}}", "operationName": "Missing"}`,
+			`{"query": "query Hero {hero{name}}", "operationName": "Hero"}`,
+			`{"query": "{hero{name}}"}`,
+			`{"query": "  {hero{name}}\n"}`,
+			`{"query": "{hero{id}}"}`,
+		} {
+			_, err := http.Post(server.URL+"/query", "", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		vars := httptest.NewServer(expvar.Handler())
+		defer vars.Close()
+		res, err := http.Get(vars.URL)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"Metrics/Hero":1`))
+		Expect(string(data)).To(MatchRegexp(`"Metrics/anonymous-[0-9a-f]{8}":2`))
+		Expect(string(data)).To(ContainSubstring(`"Metrics/other":1`))
+		Expect(string(data)).To(ContainSubstring(`"Metrics/invalid":2`))
+		Expect(string(data)).NotTo(ContainSubstring(`"Metrics/Villain"`))
+		Expect(string(data)).NotTo(ContainSubstring(`"Metrics/Missing"`))
+
+		// metrics include the command line, so they are only served on the admin listener
+		res, err = http.Get(server.URL + "/debug/vars")
+		Expect(err).NotTo(HaveOccurred())
+		data, err = ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("cmdline"))
+	})
+	It("serves cache invalidation only on the admin handler", func() {
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName:    "StarWars",
+			RootPath:      "/root",
+			QueryPath:     "/query",
+			ExecSchema:    test.StarWarsExecutableSchema("no-address-defined"),
+			ResolverCache: cache.NewCache(),
+		})
+		req, err := http.NewRequest("DELETE", server.URL+"/root/cache", nil)
+		Expect(err).NotTo(HaveOccurred())
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).NotTo(Equal(http.StatusNoContent))
+
+		admin := httptest.NewServer(router.AdminHandler())
+		defer admin.Close()
+		req, err = http.NewRequest("DELETE", admin.URL+"/root/cache?type=Query&field=hero", nil)
+		Expect(err).NotTo(HaveOccurred())
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+	})
+	It("replays recent queries with a trace on the admin handler when debug endpoints are enabled", func() {
+		router = NewRouter(Options{Debug: DebugOptions{Enabled: true, Token: "secret"}})
+		server.Config.Handler = router
+		router.UpdateEndpoints(&Endpoint{
+			SchemaName: "Replay",
+			RootPath:   "/root",
+			QueryPath:  "/query",
+			ExecSchema: test.StarWarsExecutableSchema("no-address-defined"),
+		})
+		_, err := http.Post(server.URL+"/query", "", bytes.NewBufferString(`{"query": "query Hero {hero{name}}", "operationName": "Hero"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		// the debug token doesn't expose debug endpoints to GraphQL clients
+		req, err := http.NewRequest("POST", server.URL+"/root/debug/replay", bytes.NewBufferString(`{"operation": "Hero"}`))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Sqoop-Debug-Token", "secret")
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).NotTo(Equal(http.StatusOK))
+		req, err = http.NewRequest("GET", server.URL+"/debug/traces", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Sqoop-Debug-Token", "secret")
+		res, err = http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		// the landing page
+		Expect(res.Header.Get("Content-Type")).NotTo(Equal("application/json"))
+
+		admin := httptest.NewServer(router.AdminHandler())
+		defer admin.Close()
+		res, err = http.Get(admin.URL + "/debug/traces")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("Content-Type")).To(Equal("application/json"))
+		res, err = http.Post(admin.URL+"/root/debug/replay", "", bytes.NewBufferString(`{"operation": "Hero"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		var replay struct {
+			Query string
+			Trace []struct {
+				Field     string
+				Upstreams []string
+				Error     string
+			}
+		}
+		Expect(json.NewDecoder(res.Body).Decode(&replay)).NotTo(HaveOccurred())
+		Expect(replay.Query).To(Equal("query Hero {hero{name}}"))
+		Expect(replay.Trace).To(HaveLen(1))
+		Expect(replay.Trace[0].Field).To(Equal("Query.hero"))
+		Expect(replay.Trace[0].Upstreams).To(Equal([]string{"http://no-address-defined/Query.hero"}))
+		Expect(replay.Trace[0].Error).To(ContainSubstring("performing http post"))
+	})
 })
 
+// panics when queries are validated against it
+type panickingSchema struct {
+	graphql.ExecutableSchema
+}
+
+func (panickingSchema) Schema() *schema.Schema {
+	panic("validation bug")
+}
+
+// encodes with encoding/json, counting the responses encoded
+type countingEncoder struct {
+	calls int32
+}
+
+func (e *countingEncoder) Marshal(v interface{}) ([]byte, error) {
+	atomic.AddInt32(&e.calls, 1)
+	return json.Marshal(v)
+}
+
 var queryString = []byte(`{"query": "{hero{name}}"}`)
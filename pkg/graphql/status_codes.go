@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// StatusCodePolicy decides the http status of responses to operations which failed
+type StatusCodePolicy string
+
+const (
+	// StatusCodesSpec responds 200 to every operation which was executed, as the GraphQL over HTTP spec
+	// expects, reporting failures only in the errors array. this is the default
+	StatusCodesSpec StatusCodePolicy = "spec"
+	// StatusCodesErrors responds 400 to requests which failed to validate, and an error status to operations
+	// which produced errors and no data, for clients, gateways and monitors which only look at the status
+	StatusCodesErrors StatusCodePolicy = "errors"
+)
+
+// Validate returns an error if the policy is unknown. the empty policy is StatusCodesSpec
+func (p StatusCodePolicy) Validate() error {
+	switch p {
+	case "", StatusCodesSpec, StatusCodesErrors:
+		return nil
+	}
+	return errors.Errorf("unknown status code policy %v, must be %v or %v", p, StatusCodesSpec, StatusCodesErrors)
+}
+
+// status returns the http status of res, given the status it gets under the spec policy
+func (p StatusCodePolicy) status(res *Response, status int) int {
+	if p != StatusCodesErrors {
+		return status
+	}
+	if status == http.StatusUnprocessableEntity {
+		return http.StatusBadRequest
+	}
+	if status != http.StatusOK || len(res.Errors) == 0 || hasData(res.Data) {
+		return status
+	}
+	status = http.StatusBadRequest
+	for _, err := range res.Errors {
+		switch err.Extensions["category"] {
+		case exec.ErrorCategoryInternal:
+			return http.StatusInternalServerError
+		case exec.ErrorCategoryUpstream:
+			status = http.StatusBadGateway
+		}
+	}
+	return status
+}
+
+// hasData returns false if data is null, or an object whose fields are all null
+func hasData(data json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return false
+	}
+	for _, value := range fields {
+		if !bytes.Equal(value, []byte("null")) {
+			return true
+		}
+	}
+	return false
+}
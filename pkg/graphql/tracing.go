@@ -0,0 +1,190 @@
+package graphql
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// TracingOptions sample operations for tracing. Sampled operations record the fields they resolve,
+// unsampled operations skip tracing entirely
+type TracingOptions struct {
+	// the fraction of operations to sample, from 0 to 1. zero disables tracing, including for requests
+	// whose trace headers ask for sampling
+	SampleRate float64
+	// the maximum number of operations sampled per second. zero means no limit on operations sampled at the
+	// sample rate, while operations whose trace headers ask for sampling are limited to defaultMaxForcedPerSecond
+	MaxPerSecond int
+	// receives the traces of sampled operations. if nil, the most recent traces are kept
+	// and served at /debug/traces when debug endpoints are enabled
+	Exporter TraceExporter
+}
+
+// OperationTrace is the trace of a single sampled operation
+type OperationTrace struct {
+	Schema     string              `json:"schema"`
+	Operation  string              `json:"operation"`
+	RequestID  string              `json:"requestId"`
+	Start      time.Time           `json:"start"`
+	DurationMs float64             `json:"durationMs"`
+	Fields     []*exec.TracedField `json:"fields"`
+}
+
+// TraceExporter is called with the trace of every sampled operation once it completes
+type TraceExporter func(trace *OperationTrace)
+
+// the number of traces kept by the default exporter
+const recentTracesSize = 100
+
+// the number of operations sampled per second because their trace headers ask for it, if MaxPerSecond is not set.
+// otherwise clients could have every operation they send traced
+const defaultMaxForcedPerSecond = 10
+
+type sampler struct {
+	rate float64
+	// nil if the number of samples is not limited
+	limit *tokenBucket
+	// limits samples asked for by callers if the number of samples is not limited
+	forcedLimit *tokenBucket
+	mu          sync.Mutex
+	rand        *rand.Rand
+}
+
+func newSampler(opts TracingOptions) *sampler {
+	if opts.SampleRate <= 0 {
+		return nil
+	}
+	s := &sampler{
+		rate: opts.SampleRate,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if opts.MaxPerSecond > 0 {
+		s.limit = newSampleLimit(opts.MaxPerSecond)
+	} else {
+		s.forcedLimit = newSampleLimit(defaultMaxForcedPerSecond)
+	}
+	return s
+}
+
+func newSampleLimit(perSecond int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(perSecond),
+		burst:  float64(perSecond),
+		tokens: float64(perSecond),
+		last:   time.Now(),
+	}
+}
+
+// sample decides whether to trace a request. a sampling decision made by the caller is honored,
+// within the limit on samples per second
+func (s *sampler) sample(header http.Header) bool {
+	if s == nil {
+		return false
+	}
+	sampled, decided := incomingSamplingDecision(header)
+	if !decided {
+		s.mu.Lock()
+		sampled = s.rand.Float64() < s.rate
+		s.mu.Unlock()
+	}
+	if !sampled {
+		return false
+	}
+	if s.limit != nil {
+		return s.limit.take()
+	}
+	return !decided || s.forcedLimit.take()
+}
+
+// the sampling decision in W3C trace context or B3 headers, if any
+func incomingSamplingDecision(header http.Header) (sampled bool, decided bool) {
+	// version-traceid-parentid-flags, where the lowest bit of the flags is the sampled flag
+	if parts := strings.Split(header.Get("traceparent"), "-"); len(parts) == 4 && len(parts[3]) == 2 {
+		if flags, err := strconv.ParseUint(parts[3], 16, 8); err == nil {
+			return flags&1 == 1, true
+		}
+	}
+	switch header.Get("X-B3-Sampled") {
+	case "1", "true":
+		return true, true
+	case "0", "false":
+		return false, true
+	}
+	if header.Get("X-B3-Flags") == "1" {
+		return true, true
+	}
+	// single header format: traceid-spanid-sampled[-parentid], or only the sampling state
+	var state string
+	switch parts := strings.Split(header.Get("b3"), "-"); len(parts) {
+	case 1:
+		state = parts[0]
+	case 3, 4:
+		state = parts[2]
+	}
+	switch state {
+	case "1", "d":
+		return true, true
+	case "0":
+		return false, true
+	}
+	return false, false
+}
+
+// the trace id in W3C trace context or B3 headers, if any
+func incomingTraceID(header http.Header) string {
+	if parts := strings.Split(header.Get("traceparent"), "-"); len(parts) == 4 {
+		return parts[1]
+	}
+	if traceID := header.Get("X-B3-TraceId"); traceID != "" {
+		return traceID
+	}
+	if parts := strings.Split(header.Get("b3"), "-"); len(parts) >= 2 {
+		return parts[0]
+	}
+	return ""
+}
+
+// recentTraces keeps the most recently exported traces
+type recentTraces struct {
+	mu     sync.Mutex
+	traces []*OperationTrace
+	next   int
+}
+
+func newRecentTraces(size int) *recentTraces {
+	return &recentTraces{traces: make([]*OperationTrace, 0, size)}
+}
+
+func (t *recentTraces) export(trace *OperationTrace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.traces) < cap(t.traces) {
+		t.traces = append(t.traces, trace)
+		return
+	}
+	t.traces[t.next] = trace
+	t.next = (t.next + 1) % len(t.traces)
+}
+
+// oldest first
+func (t *recentTraces) list() []*OperationTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append(append([]*OperationTrace(nil), t.traces[t.next:]...), t.traces[:t.next]...)
+}
+
+// GET /debug/traces of the admin handler lists the most recent sampled traces
+func recentTracesHandler(traces *recentTraces) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(traces.list()); err != nil {
+			panic(err)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package graphql
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"net/http"
+)
+
+var _ = Describe("sampler", func() {
+	traceparent := func(flags string) http.Header {
+		header := http.Header{}
+		header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-"+flags)
+		return header
+	}
+
+	It("reads the sampled flag of traceparent headers as hex", func() {
+		for flags, sampled := range map[string]bool{"00": false, "01": true, "02": false, "03": true, "0b": true, "0a": false, "ff": true} {
+			decision, decided := incomingSamplingDecision(traceparent(flags))
+			Expect(decided).To(BeTrue(), flags)
+			Expect(decision).To(Equal(sampled), flags)
+		}
+		_, decided := incomingSamplingDecision(traceparent("zz"))
+		Expect(decided).To(BeFalse())
+	})
+	It("limits samples asked for by callers without a limit on samples per second", func() {
+		s := newSampler(TracingOptions{SampleRate: 0.0001})
+		var sampled int
+		for i := 0; i < 100; i++ {
+			if s.sample(traceparent("01")) {
+				sampled++
+			}
+		}
+		Expect(sampled).To(Equal(defaultMaxForcedPerSecond))
+	})
+	It("applies the limit on samples per second to samples asked for by callers", func() {
+		s := newSampler(TracingOptions{SampleRate: 1, MaxPerSecond: 3})
+		var sampled int
+		for i := 0; i < 100; i++ {
+			if s.sample(traceparent("01")) {
+				sampled++
+			}
+		}
+		Expect(sampled).To(Equal(3))
+	})
+})
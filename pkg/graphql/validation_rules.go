@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	"sort"
+
+	gqlerrors "github.com/vektah/gqlgen/neelance/errors"
+	"github.com/vektah/gqlgen/neelance/query"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// ValidationRule enforces a convention of an API, e.g. that mutations take an idempotency key, on each
+// operation of a query. rules run after the standard validation of the query, and any errors they return
+// reject the query before it is executed. the document holds the fragments spread in the operation
+type ValidationRule func(schema *schema.Schema, doc *query.Document, op *query.Operation) []*gqlerrors.QueryError
+
+// ValidationRules are registered by name, and run in order of their names
+type ValidationRules map[string]ValidationRule
+
+func (rules ValidationRules) validate(sch *schema.Schema, doc *query.Document) []*gqlerrors.QueryError {
+	if len(rules) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []*gqlerrors.QueryError
+	for _, op := range doc.Operations {
+		for _, name := range names {
+			errs = append(errs, rules[name](sch, doc, op)...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"expvar"
+	"sync"
+)
+
+// Counters are labeled counters which, unlike an expvar.Map, can stop tracking a label.
+// They are published as a single expvar with the value of each label
+type Counters struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+// NewCounters creates counters and publishes them under the given expvar name
+func NewCounters(name string) *Counters {
+	c := &Counters{values: make(map[string]int64)}
+	expvar.Publish(name, expvar.Func(c.snapshot))
+	return c
+}
+
+func (c *Counters) Add(label string, delta int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[label] += delta
+}
+
+// Delete stops tracking the label. it is tracked again from zero when next added to
+func (c *Counters) Delete(label string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, label)
+}
+
+func (c *Counters) snapshot() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]int64, len(c.values))
+	for label, value := range c.values {
+		out[label] = value
+	}
+	return out
+}
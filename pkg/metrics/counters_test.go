@@ -0,0 +1,23 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"expvar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/solo-io/sqoop/pkg/metrics"
+)
+
+var _ = Describe("Counters", func() {
+	It("publishes the value of each label until it is deleted", func() {
+		c := NewCounters("test_counters")
+		c.Add("a", 1)
+		c.Add("a", 2)
+		c.Add("b", 5)
+		c.Delete("b")
+		var out map[string]int64
+		Expect(json.Unmarshal([]byte(expvar.Get("test_counters").String()), &out)).NotTo(HaveOccurred())
+		Expect(out).To(Equal(map[string]int64{"a": 3}))
+	})
+})
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// SizeBuckets are the upper bounds of the buckets used for response sizes, in bytes
+var SizeBuckets = []int64{256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20}
+
+// Histogram counts labeled observations in buckets. It is published as an expvar
+// with the count, sum and cumulative bucket counts of each label
+type Histogram struct {
+	bounds []int64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	// counts[i] is the number of observations <= bounds[i]. the last count is +Inf
+	counts []int64
+	count  int64
+	sum    int64
+}
+
+// NewHistogram creates a histogram and publishes it under the given expvar name
+func NewHistogram(name string, bounds []int64) *Histogram {
+	sorted := append([]int64(nil), bounds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	h := &Histogram{
+		bounds: sorted,
+		series: make(map[string]*series),
+	}
+	expvar.Publish(name, h)
+	return h
+}
+
+func (h *Histogram) Observe(label string, value int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[label]
+	if !ok {
+		s = &series{counts: make([]int64, len(h.bounds)+1)}
+		h.series[label] = s
+	}
+	for i, bound := range h.bounds {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.counts[len(h.bounds)]++
+	s.count++
+	s.sum += value
+}
+
+// Delete stops tracking the label. it is tracked again from zero when next observed
+func (h *Histogram) Delete(label string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.series, label)
+}
+
+type seriesJSON struct {
+	Count   int64            `json:"count"`
+	Sum     int64            `json:"sum"`
+	Buckets map[string]int64 `json:"buckets"`
+}
+
+// String implements expvar.Var
+func (h *Histogram) String() string {
+	h.mu.Lock()
+	out := make(map[string]seriesJSON, len(h.series))
+	for label, s := range h.series {
+		buckets := make(map[string]int64, len(s.counts))
+		for i, bound := range h.bounds {
+			buckets[strconv.FormatInt(bound, 10)] = s.counts[i]
+		}
+		buckets["+Inf"] = s.counts[len(h.bounds)]
+		out[label] = seriesJSON{Count: s.count, Sum: s.sum, Buckets: buckets}
+	}
+	h.mu.Unlock()
+	b, err := json.Marshal(out)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,39 @@
+package metrics_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/solo-io/sqoop/pkg/metrics"
+)
+
+var _ = Describe("Histogram", func() {
+	It("counts observations in cumulative buckets per label", func() {
+		h := NewHistogram("test_histogram", []int64{100, 10})
+		h.Observe("a", 5)
+		h.Observe("a", 50)
+		h.Observe("a", 500)
+		h.Observe("b", 10)
+		var out map[string]struct {
+			Count   int64
+			Sum     int64
+			Buckets map[string]int64
+		}
+		Expect(json.Unmarshal([]byte(h.String()), &out)).NotTo(HaveOccurred())
+		Expect(out["a"].Count).To(Equal(int64(3)))
+		Expect(out["a"].Sum).To(Equal(int64(555)))
+		Expect(out["a"].Buckets).To(Equal(map[string]int64{"10": 1, "100": 2, "+Inf": 3}))
+		Expect(out["b"].Buckets).To(Equal(map[string]int64{"10": 1, "100": 1, "+Inf": 1}))
+	})
+	It("stops publishing deleted labels", func() {
+		h := NewHistogram("test_deleted_histogram", []int64{10})
+		h.Observe("a", 5)
+		h.Observe("b", 5)
+		h.Delete("a")
+		var out map[string]interface{}
+		Expect(json.Unmarshal([]byte(h.String()), &out)).NotTo(HaveOccurred())
+		Expect(out).To(HaveLen(1))
+		Expect(out).To(HaveKey("b"))
+	})
+})
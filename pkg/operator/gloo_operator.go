@@ -1,6 +1,10 @@
 package operator
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/storage"
@@ -27,6 +31,9 @@ func NewGlooOperator(gloo storage.Interface, virtualServiceName string, roleName
 type route struct {
 	path         string
 	destinations []destination
+	// zero leaves the proxy's defaults in place
+	timeout    time.Duration
+	maxRetries uint32
 }
 
 type destination struct {
@@ -77,8 +84,84 @@ func (operator *GlooOperator) ConfigureGloo() error {
 	return nil
 }
 
+// RoutePaths returns the paths of the routes which will be applied on the next call to ConfigureGloo
+func (operator *GlooOperator) RoutePaths() []string {
+	var paths []string
+	for _, route := range operator.cachedRoutes {
+		paths = append(paths, route.path)
+	}
+	return paths
+}
+
 func (operator *GlooOperator) ApplyResolvers(resolverMap *sqoopv1.ResolverMap) {
-	operator.cachedRoutes = append(operator.cachedRoutes, buildRoutes(resolverMap)...)
+	operator.ApplyResolversWithPrefix("", resolverMap)
+}
+
+// ApplyResolversWithPrefix prefixes the paths of the routes created for the resolver map
+func (operator *GlooOperator) ApplyResolversWithPrefix(prefix string, resolverMap *sqoopv1.ResolverMap) {
+	operator.cachedRoutes = append(operator.cachedRoutes, buildRoutes(prefix, resolverMap)...)
+}
+
+// DiscardRoutes drops the routes applied since the last successful call to ConfigureGloo,
+// e.g. when they could not be written and are superseded by a new config
+func (operator *GlooOperator) DiscardRoutes() {
+	operator.cachedRoutes = nil
+}
+
+// returned by ValidateResolvers when the upstreams could not be listed from gloo,
+// so it is unknown whether the destinations of the resolvers exist
+type upstreamsUnavailableErr struct {
+	err error
+}
+
+func (err *upstreamsUnavailableErr) Error() string {
+	return fmt.Sprintf("listing gloo upstreams: %v", err.err.Error())
+}
+
+func IsUpstreamsUnavailable(err error) bool {
+	switch err.(type) {
+	case *upstreamsUnavailableErr:
+		return true
+	}
+	return false
+}
+
+// ValidateResolvers checks that the Gloo upstreams and functions named by the resolver map's
+// resolvers exist, so that misconfigured resolvers are reported rather than routed to nowhere
+func (operator *GlooOperator) ValidateResolvers(resolverMap *sqoopv1.ResolverMap) error {
+	routes := buildRoutes("", resolverMap)
+	if len(routes) == 0 {
+		return nil
+	}
+	upstreams, err := operator.gloo.V1().Upstreams().List()
+	if err != nil {
+		return &upstreamsUnavailableErr{err: err}
+	}
+	byName := make(map[string]*v1.Upstream)
+	for _, us := range upstreams {
+		byName[us.Name] = us
+	}
+	for _, route := range routes {
+		for _, dest := range route.destinations {
+			us, ok := byName[dest.upstreamName]
+			if !ok {
+				return errors.Errorf("resolver for route %v: upstream %v not found", route.path, dest.upstreamName)
+			}
+			if dest.functionName != "" && !hasFunction(us, dest.functionName) {
+				return errors.Errorf("resolver for route %v: upstream %v has no function %v", route.path, dest.upstreamName, dest.functionName)
+			}
+		}
+	}
+	return nil
+}
+
+func hasFunction(us *v1.Upstream, name string) bool {
+	for _, fn := range us.Functions {
+		if fn.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func routesEqual(list1, list2 []*v1.Route) bool {
@@ -153,26 +236,12 @@ func resolverRoute(route route) (*v1.Route, error) {
 
 	switch {
 	case len(destinations) == 1:
-		singleDestination = &v1.Destination{
-			DestinationType: &v1.Destination_Function{
-				Function: &v1.FunctionDestination{
-					UpstreamName: destinations[0].upstreamName,
-					FunctionName: destinations[0].functionName,
-				},
-			},
-		}
+		singleDestination = glooDestination(destinations[0])
 	case len(destinations) > 1:
 		for _, dest := range destinations {
 			multiDestination = append(multiDestination, &v1.WeightedDestination{
-				Destination: &v1.Destination{
-					DestinationType: &v1.Destination_Function{
-						Function: &v1.FunctionDestination{
-							UpstreamName: dest.upstreamName,
-							FunctionName: dest.functionName,
-						},
-					},
-				},
-				Weight: dest.weight,
+				Destination: glooDestination(dest),
+				Weight:      dest.weight,
 			})
 		}
 	}
@@ -188,5 +257,47 @@ func resolverRoute(route route) (*v1.Route, error) {
 		},
 		MultipleDestinations: multiDestination,
 		SingleDestination:    singleDestination,
+		Extensions:           routeExtensions(route),
 	}, nil
 }
+
+// routeExtensions sets the timeout and retries of the route in the route extensions of Gloo
+func routeExtensions(route route) *types.Struct {
+	if route.timeout == 0 && route.maxRetries == 0 {
+		return nil
+	}
+	fields := make(map[string]*types.Value)
+	if route.timeout > 0 {
+		// durations are encoded in seconds
+		fields["timeout"] = &types.Value{Kind: &types.Value_StringValue{
+			StringValue: fmt.Sprintf("%gs", route.timeout.Seconds()),
+		}}
+	}
+	if route.maxRetries > 0 {
+		fields["max_retries"] = &types.Value{Kind: &types.Value_NumberValue{
+			NumberValue: float64(route.maxRetries),
+		}}
+	}
+	return &types.Struct{Fields: fields}
+}
+
+// destinations without a function are routed to the upstream itself
+func glooDestination(dest destination) *v1.Destination {
+	if dest.functionName == "" {
+		return &v1.Destination{
+			DestinationType: &v1.Destination_Upstream{
+				Upstream: &v1.UpstreamDestination{
+					Name: dest.upstreamName,
+				},
+			},
+		}
+	}
+	return &v1.Destination{
+		DestinationType: &v1.Destination_Function{
+			Function: &v1.FunctionDestination{
+				UpstreamName: dest.upstreamName,
+				FunctionName: dest.functionName,
+			},
+		},
+	}
+}
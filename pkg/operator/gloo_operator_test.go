@@ -11,6 +11,7 @@ import (
 	"github.com/solo-io/gloo/pkg/api/types/v1"
 	"github.com/solo-io/gloo/pkg/storage"
 	"github.com/solo-io/gloo/pkg/storage/file"
+	sqoopv1 "github.com/solo-io/sqoop/pkg/api/types/v1"
 	. "github.com/solo-io/sqoop/pkg/operator"
 	"github.com/solo-io/sqoop/test"
 )
@@ -35,6 +36,14 @@ var _ = Describe("GlooOperator", func() {
 	AfterEach(func() {
 		os.RemoveAll(tmpDir)
 	})
+	It("prefixes the routes of resolver maps applied with a prefix", func() {
+		operator.ApplyResolvers(test.StarWarsResolverMap())
+		operator.ApplyResolversWithPrefix("/starwars-v2", test.StarWarsResolverMap())
+		paths := operator.RoutePaths()
+		Expect(paths).To(HaveLen(10))
+		Expect(paths).To(ContainElement("/Droid.friends"))
+		Expect(paths).To(ContainElement("/starwars-v2/Droid.friends"))
+	})
 	It("creates the virtualservice with all the required routes", func() {
 		operator.ApplyResolvers(test.StarWarsResolverMap())
 		err := operator.ConfigureGloo()
@@ -157,4 +166,84 @@ var _ = Describe("GlooOperator", func() {
 		Expect(role.Listeners[0].VirtualServices).To(HaveLen(1))
 		Expect(role.Listeners[0].VirtualServices[0]).To(Equal(vServiceName))
 	})
+	Context("validating resolvers", func() {
+		It("errors when a named upstream does not exist", func() {
+			err := operator.ValidateResolvers(test.StarWarsResolverMap())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("upstream starwars-rest not found"))
+		})
+		It("errors when a named function does not exist on the upstream", func() {
+			_, err := gloo.V1().Upstreams().Create(&v1.Upstream{
+				Name:      "starwars-rest",
+				Type:      "static",
+				Functions: []*v1.Function{{Name: "GetHero"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = operator.ValidateResolvers(test.StarWarsResolverMap())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has no function"))
+		})
+		It("routes resolvers without a function to the upstream", func() {
+			_, err := gloo.V1().Upstreams().Create(&v1.Upstream{
+				Name: "starwars-rest",
+				Type: "static",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			resolverMap := &sqoopv1.ResolverMap{
+				Name: "upstream-only",
+				Types: map[string]*sqoopv1.TypeResolver{
+					"Query": {
+						Fields: map[string]*sqoopv1.Resolver{
+							"hero": {
+								Resolver: &sqoopv1.Resolver_GlooResolver{
+									GlooResolver: &sqoopv1.GlooResolver{
+										Function: &sqoopv1.GlooResolver_Upstream{Upstream: "starwars-rest"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(operator.ValidateResolvers(resolverMap)).NotTo(HaveOccurred())
+			operator.ApplyResolvers(resolverMap)
+			Expect(operator.ConfigureGloo()).NotTo(HaveOccurred())
+			virtualService, err := gloo.V1().VirtualServices().Get(vServiceName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(virtualService.Routes).To(HaveLen(1))
+			Expect(virtualService.Routes[0].SingleDestination).To(Equal(&v1.Destination{
+				DestinationType: &v1.Destination_Upstream{
+					Upstream: &v1.UpstreamDestination{Name: "starwars-rest"},
+				},
+			}))
+		})
+	})
+	It("programs the timeout and retries of resolvers on their routes", func() {
+		operator.ApplyResolvers(&sqoopv1.ResolverMap{
+			Name: "timeouts",
+			Types: map[string]*sqoopv1.TypeResolver{
+				"Query": {
+					Fields: map[string]*sqoopv1.Resolver{
+						"hero": {
+							Resolver: &sqoopv1.Resolver_GlooResolver{
+								GlooResolver: &sqoopv1.GlooResolver{
+									Function:   &sqoopv1.GlooResolver_Upstream{Upstream: "starwars-rest"},
+									TimeoutMs:  1500,
+									MaxRetries: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		Expect(operator.ConfigureGloo()).NotTo(HaveOccurred())
+		virtualService, err := gloo.V1().VirtualServices().Get(vServiceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(virtualService.Routes).To(HaveLen(1))
+		Expect(virtualService.Routes[0].Extensions).To(Equal(&types.Struct{Fields: map[string]*types.Value{
+			"timeout":     {Kind: &types.Value_StringValue{StringValue: "1.5s"}},
+			"max_retries": {Kind: &types.Value_NumberValue{NumberValue: 2}},
+		}}))
+	})
 })
@@ -3,6 +3,7 @@ package operator
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 )
@@ -11,18 +12,42 @@ func RoutePath(typeName, fieldName string) string {
 	return fmt.Sprintf("/%v.%v", typeName, fieldName)
 }
 
-func buildRoutes(resolverMap *v1.ResolverMap) []route {
+// VariantRoutePath is the route for a variant of a conditional resolver
+func VariantRoutePath(typeName, fieldName string, variant int) string {
+	return fmt.Sprintf("%v/%v", RoutePath(typeName, fieldName), variant)
+}
+
+// SourceRoutePath is the route for a source of a merge resolver
+func SourceRoutePath(typeName, fieldName string, source int) string {
+	return fmt.Sprintf("%v/sources/%v", RoutePath(typeName, fieldName), source)
+}
+
+// routes are prefixed when a resolver map is bound to a schema at its own path,
+// so maps resolving the same fields of a schema don't share routes
+func buildRoutes(prefix string, resolverMap *v1.ResolverMap) []route {
 	var routes []route
 	for typeName, typeResolver := range resolverMap.Types {
 		for fieldName, fieldResolver := range typeResolver.Fields {
-			glooResolver, ok := fieldResolver.Resolver.(*v1.Resolver_GlooResolver)
-			if !ok {
-				continue
+			switch resolver := fieldResolver.Resolver.(type) {
+			case *v1.Resolver_GlooResolver:
+				routes = append(routes, glooRoute(prefix+RoutePath(typeName, fieldName), resolver.GlooResolver))
+			case *v1.Resolver_ConditionalResolver:
+				for i, variant := range resolver.ConditionalResolver.Variants {
+					if glooResolver := variant.GetResolver().GetGlooResolver(); glooResolver != nil {
+						routes = append(routes, glooRoute(prefix+VariantRoutePath(typeName, fieldName, i), glooResolver))
+					}
+				}
+				// the default resolver takes the place of the field's resolver
+				if glooResolver := resolver.ConditionalResolver.GetDefaultResolver().GetGlooResolver(); glooResolver != nil {
+					routes = append(routes, glooRoute(prefix+RoutePath(typeName, fieldName), glooResolver))
+				}
+			case *v1.Resolver_MergeResolver:
+				for i, source := range resolver.MergeResolver.Sources {
+					if glooResolver := source.GetResolver().GetGlooResolver(); glooResolver != nil {
+						routes = append(routes, glooRoute(prefix+SourceRoutePath(typeName, fieldName, i), glooResolver))
+					}
+				}
 			}
-			routes = append(routes, route{
-				path:         RoutePath(typeName, fieldName),
-				destinations: destinationsForFunction(glooResolver.GlooResolver),
-			})
 		}
 	}
 	sort.SliceStable(routes, func(i, j int) bool {
@@ -31,6 +56,15 @@ func buildRoutes(resolverMap *v1.ResolverMap) []route {
 	return routes
 }
 
+func glooRoute(path string, resolver *v1.GlooResolver) route {
+	return route{
+		path:         path,
+		destinations: destinationsForFunction(resolver),
+		timeout:      time.Duration(resolver.TimeoutMs) * time.Millisecond,
+		maxRetries:   resolver.MaxRetries,
+	}
+}
+
 func destinationsForFunction(resolver *v1.GlooResolver) []destination {
 	switch function := resolver.Function.(type) {
 	case *v1.GlooResolver_SingleFunction:
@@ -50,6 +84,12 @@ func destinationsForFunction(resolver *v1.GlooResolver) []destination {
 			})
 		}
 		return dests
+	case *v1.GlooResolver_Upstream:
+		return []destination{
+			{
+				upstreamName: function.Upstream,
+			},
+		}
 	}
 	panic("unknown function time")
 }
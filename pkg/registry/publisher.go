@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	apiKeyHeader   = "X-Api-Key"
+	publishTimeout = 10 * time.Second
+	// how much of an error response to include in errors
+	maxErrorBody = 1024
+)
+
+// Publisher pushes the SDL of served schemas to an external schema registry
+type Publisher struct {
+	url     string
+	apiKey  string
+	variant string
+	client  *http.Client
+}
+
+type publishRequest struct {
+	Graph   string `json:"graph"`
+	Variant string `json:"variant,omitempty"`
+	SDL     string `json:"sdl"`
+}
+
+func NewPublisher(url, apiKey, variant string) *Publisher {
+	return &Publisher{
+		url:     url,
+		apiKey:  apiKey,
+		variant: variant,
+		client:  &http.Client{Timeout: publishTimeout},
+	}
+}
+
+// Publish sends the SDL of a schema to the registry, using the schema name as the graph name
+func (p *Publisher) Publish(graph, sdl string) error {
+	body, err := json.Marshal(publishRequest{
+		Graph:   graph,
+		Variant: p.variant,
+		SDL:     sdl,
+	})
+	if err != nil {
+		return errors.Wrap(err, "encoding publish request")
+	}
+	req, err := http.NewRequest("POST", p.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating publish request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set(apiKeyHeader, p.apiKey)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "publishing schema %v to %v", graph, p.url)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return errors.Errorf("publishing schema %v to %v: registry responded with %v: %s", graph, p.url, res.Status, msg)
+	}
+	io.Copy(ioutil.Discard, res.Body)
+	return nil
+}
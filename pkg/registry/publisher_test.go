@@ -0,0 +1,48 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/solo-io/sqoop/pkg/registry"
+)
+
+var _ = Describe("Publisher", func() {
+	var (
+		server   *httptest.Server
+		status   int
+		received map[string]string
+		apiKey   string
+	)
+	BeforeEach(func() {
+		status = http.StatusOK
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey = r.Header.Get("X-Api-Key")
+			json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(status)
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	It("publishes the sdl with the graph and variant name", func() {
+		err := NewPublisher(server.URL, "secret", "prod").Publish("starwars", "type Query { hero: String }")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apiKey).To(Equal("secret"))
+		Expect(received).To(Equal(map[string]string{
+			"graph":   "starwars",
+			"variant": "prod",
+			"sdl":     "type Query { hero: String }",
+		}))
+	})
+	It("returns an error when the registry rejects the schema", func() {
+		status = http.StatusBadRequest
+		err := NewPublisher(server.URL, "", "").Publish("starwars", "type Query { hero: String }")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("400"))
+	})
+})
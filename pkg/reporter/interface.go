@@ -9,6 +9,8 @@ type ConfigObjectReport struct {
 	Err       error
 }
 
+// Interface is implemented by sinks which receive the reports of every config reload,
+// such as the storage status writer or a webhook
 type Interface interface {
 	WriteReports(statuses []ConfigObjectReport) error
 }
@@ -0,0 +1,75 @@
+package reporter
+
+import (
+	"expvar"
+	"fmt"
+
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+var sinkErrors = expvar.NewMap("sqoop_reporter_sink_errors")
+
+type multiReporter struct {
+	primary Interface
+	sinks   []*asyncSink
+}
+
+// NewMultiReporter writes reports to the primary sink, usually the storage status writer, and passes them on
+// to each of the other sinks. the primary sink is written synchronously and its errors are returned.
+// other sinks are written in the background and fail independently: their errors are logged and counted, and
+// a slow sink only sees the latest reports, so no sink can block the event loop
+func NewMultiReporter(primary Interface, sinks ...Interface) Interface {
+	r := &multiReporter{primary: primary}
+	for _, sink := range sinks {
+		r.sinks = append(r.sinks, newAsyncSink(sink))
+	}
+	return r
+}
+
+func (r *multiReporter) WriteReports(reports []ConfigObjectReport) error {
+	for _, sink := range r.sinks {
+		sink.write(reports)
+	}
+	return r.primary.WriteReports(reports)
+}
+
+type asyncSink struct {
+	name    string
+	sink    Interface
+	pending chan []ConfigObjectReport
+}
+
+func newAsyncSink(sink Interface) *asyncSink {
+	s := &asyncSink{
+		name:    fmt.Sprintf("%T", sink),
+		sink:    sink,
+		pending: make(chan []ConfigObjectReport, 1),
+	}
+	go s.run()
+	return s
+}
+
+// write queues reports for the sink, replacing reports it hasn't picked up yet
+func (s *asyncSink) write(reports []ConfigObjectReport) {
+	for {
+		select {
+		case s.pending <- reports:
+			return
+		default:
+		}
+		// the queued reports are superseded
+		select {
+		case <-s.pending:
+		default:
+		}
+	}
+}
+
+func (s *asyncSink) run() {
+	for reports := range s.pending {
+		if err := s.sink.WriteReports(reports); err != nil {
+			sinkErrors.Add(s.name, 1)
+			log.Warnf("reporter sink %v failed: %v", s.name, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package reporter_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	. "github.com/solo-io/sqoop/pkg/reporter"
+)
+
+type sinkFunc func(reports []ConfigObjectReport) error
+
+func (f sinkFunc) WriteReports(reports []ConfigObjectReport) error {
+	return f(reports)
+}
+
+var _ = Describe("Sinks", func() {
+	reports := []ConfigObjectReport{
+		{CfgObject: &v1.Schema{Name: "starwars"}},
+		{CfgObject: &v1.Schema{Name: "pets"}, Err: errors.New("failed to parse schema")},
+	}
+	It("writes the primary sink without waiting for other sinks", func() {
+		block := make(chan struct{})
+		defer close(block)
+		var primaryWrites, failingWrites int32
+		primary := sinkFunc(func([]ConfigObjectReport) error {
+			atomic.AddInt32(&primaryWrites, 1)
+			return nil
+		})
+		blocking := sinkFunc(func([]ConfigObjectReport) error {
+			<-block
+			return nil
+		})
+		failing := sinkFunc(func([]ConfigObjectReport) error {
+			atomic.AddInt32(&failingWrites, 1)
+			return errors.New("unavailable")
+		})
+		rep := NewMultiReporter(primary, blocking, failing)
+		for i := 0; i < 3; i++ {
+			Expect(rep.WriteReports(reports)).To(Succeed())
+		}
+		Expect(atomic.LoadInt32(&primaryWrites)).To(Equal(int32(3)))
+		Eventually(func() int32 { return atomic.LoadInt32(&failingWrites) }).Should(BeNumerically(">=", 1))
+	})
+	It("posts the outcome to a webhook when it changes", func() {
+		var posts []map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]interface{}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			posts = append(posts, payload)
+		}))
+		defer server.Close()
+		sink := NewWebhookSink(server.URL)
+		Expect(sink.WriteReports(reports)).To(Succeed())
+		Expect(sink.WriteReports(reports)).To(Succeed())
+		Expect(posts).To(HaveLen(1))
+		Expect(posts[0]).To(HaveKeyWithValue("rejected", 1.0))
+		Expect(posts[0]).To(HaveKeyWithValue("text", "sqoop rejected 1 of 2 schemas and resolver maps:\nschema pets: failed to parse schema"))
+		Expect(sink.WriteReports(reports[:1])).To(Succeed())
+		Expect(posts).To(HaveLen(2))
+		Expect(posts[1]).To(HaveKeyWithValue("text", "sqoop accepted all 1 schemas and resolver maps"))
+	})
+	It("reports failed webhook deliveries without the path of the webhook url", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusForbidden)
+		}))
+		defer server.Close()
+		err := NewWebhookSink(server.URL + "/services/T000/B000/secret-token").WriteReports(reports)
+		Expect(err).To(MatchError(ContainSubstring("posting reports to " + server.URL + ": webhook responded with 403")))
+		Expect(err.Error()).NotTo(ContainSubstring("secret-token"))
+
+		server.Close()
+		err = NewWebhookSink(server.URL + "/services/T000/B000/secret-token").WriteReports(reports)
+		Expect(err).To(MatchError(ContainSubstring("posting reports to " + server.URL)))
+		Expect(err.Error()).NotTo(ContainSubstring("secret-token"))
+	})
+})
@@ -0,0 +1,97 @@
+package reporter
+
+import (
+	"expvar"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+)
+
+// number of error categories included in the summary
+const topCategories = 3
+
+// gauges for the most recent reload, served with the other expvars
+var (
+	acceptedGauge = expvar.NewInt("sqoop_config_objects_accepted")
+	rejectedGauge = expvar.NewInt("sqoop_config_objects_rejected")
+)
+
+// Summary aggregates the reports of a single reload
+type Summary struct {
+	Accepted             int
+	Rejected             int
+	RejectedSchemas      []string
+	RejectedResolverMaps []string
+	// number of errors per category, see errorCategory
+	ErrorCategories map[string]int
+}
+
+func Summarize(reports []ConfigObjectReport) Summary {
+	summary := Summary{ErrorCategories: make(map[string]int)}
+	for _, report := range reports {
+		if report.Err == nil {
+			summary.Accepted++
+			continue
+		}
+		summary.Rejected++
+		switch report.CfgObject.(type) {
+		case *v1.Schema:
+			summary.RejectedSchemas = append(summary.RejectedSchemas, report.CfgObject.GetName())
+		case *v1.ResolverMap:
+			summary.RejectedResolverMaps = append(summary.RejectedResolverMaps, report.CfgObject.GetName())
+		}
+		errs := []error{report.Err}
+		if multi, ok := report.Err.(*multierror.Error); ok {
+			errs = multi.Errors
+		}
+		for _, err := range errs {
+			summary.ErrorCategories[errorCategory(err)]++
+		}
+	}
+	sort.Strings(summary.RejectedSchemas)
+	sort.Strings(summary.RejectedResolverMaps)
+	return summary
+}
+
+// errors are categorized by their outermost message, e.g. "schema was not accepted"
+func errorCategory(err error) string {
+	return strings.SplitN(err.Error(), ": ", 2)[0]
+}
+
+// Publish sets the gauges for the reload
+func (s Summary) Publish() {
+	acceptedGauge.Set(int64(s.Accepted))
+	rejectedGauge.Set(int64(s.Rejected))
+}
+
+func (s Summary) String() string {
+	type category struct {
+		name  string
+		count int
+	}
+	var categories []category
+	for name, count := range s.ErrorCategories {
+		categories = append(categories, category{name: name, count: count})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].count != categories[j].count {
+			return categories[i].count > categories[j].count
+		}
+		return categories[i].name < categories[j].name
+	})
+	if len(categories) > topCategories {
+		categories = categories[:topCategories]
+	}
+	var top []string
+	for _, c := range categories {
+		top = append(top, fmt.Sprintf("%q=%v", c.name, c.count))
+	}
+	return fmt.Sprintf("accepted=%v rejected=%v rejected_schemas=[%v] rejected_resolver_maps=[%v] top_errors=[%v]",
+		s.Accepted, s.Rejected,
+		strings.Join(s.RejectedSchemas, ","),
+		strings.Join(s.RejectedResolverMaps, ","),
+		strings.Join(top, ","))
+}
@@ -0,0 +1,32 @@
+package reporter_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	. "github.com/solo-io/sqoop/pkg/reporter"
+)
+
+var _ = Describe("Summary", func() {
+	It("aggregates reports by outcome and error category", func() {
+		summary := Summarize([]ConfigObjectReport{
+			{CfgObject: &v1.Schema{Name: "starwars"}},
+			{CfgObject: &v1.Schema{Name: "pets"}, Err: multierror.Append(nil,
+				errors.Wrap(errors.New("syntax error"), "failed to parse schema"),
+				errors.Errorf("path /pets is already served by schema petstore"))},
+			{CfgObject: &v1.Schema{Name: "books"}, Err: errors.Wrap(errors.New("bad"), "failed to parse schema")},
+			{CfgObject: &v1.ResolverMap{Name: "pets-resolvers"}, Err: errors.New("no resolver")},
+		})
+		Expect(summary.Accepted).To(Equal(1))
+		Expect(summary.Rejected).To(Equal(3))
+		Expect(summary.RejectedSchemas).To(Equal([]string{"books", "pets"}))
+		Expect(summary.RejectedResolverMaps).To(Equal([]string{"pets-resolvers"}))
+		Expect(summary.ErrorCategories).To(HaveKeyWithValue("failed to parse schema", 2))
+		Expect(summary.String()).To(Equal(`accepted=1 rejected=3 rejected_schemas=[books,pets] ` +
+			`rejected_resolver_maps=[pets-resolvers] top_errors=["failed to parse schema"=2,"no resolver"=1,` +
+			`"path /pets is already served by schema petstore"=1]`))
+	})
+})
@@ -0,0 +1,122 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+	// how much of an error response to include in errors
+	maxErrorBody = 1024
+)
+
+// WebhookSink posts the outcome of each reload to a url as json, when it differs from the last one posted.
+// the payload includes a text summary, so it can be sent to a chat webhook such as Slack's as is.
+// WriteReports must not be called concurrently, NewMultiReporter writes each sink from a single goroutine
+type WebhookSink struct {
+	url string
+	// the scheme and host of the url, which is all that is logged of it. the path and query of webhook urls
+	// commonly carry credentials, e.g. of Slack webhooks
+	dest   string
+	client *http.Client
+	last   []webhookReport
+}
+
+type webhookPayload struct {
+	Text     string          `json:"text"`
+	Accepted int             `json:"accepted"`
+	Rejected int             `json:"rejected"`
+	Reports  []webhookReport `json:"reports"`
+}
+
+type webhookReport struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		dest:   webhookDest(url),
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func webhookDest(webhookURL string) string {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return "webhook"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func (w *WebhookSink) WriteReports(reports []ConfigObjectReport) error {
+	payload := webhookPayload{Reports: []webhookReport{}}
+	for _, report := range reports {
+		r := webhookReport{Name: report.CfgObject.GetName(), Accepted: report.Err == nil}
+		switch report.CfgObject.(type) {
+		case *v1.Schema:
+			r.Kind = "schema"
+		case *v1.ResolverMap:
+			r.Kind = "resolver map"
+		}
+		if report.Err != nil {
+			r.Reason = report.Err.Error()
+			payload.Rejected++
+		} else {
+			payload.Accepted++
+		}
+		payload.Reports = append(payload.Reports, r)
+	}
+	if w.last != nil && reflect.DeepEqual(w.last, payload.Reports) {
+		return nil
+	}
+	payload.Text = webhookText(payload)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "encoding webhook payload")
+	}
+	res, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// the errors of the client include the full url
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return errors.Wrapf(err, "posting reports to %v", w.dest)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return errors.Errorf("posting reports to %v: webhook responded with %v: %s", w.dest, res.Status, msg)
+	}
+	io.Copy(ioutil.Discard, res.Body)
+	w.last = payload.Reports
+	return nil
+}
+
+func webhookText(payload webhookPayload) string {
+	if payload.Rejected == 0 {
+		return fmt.Sprintf("sqoop accepted all %v schemas and resolver maps", payload.Accepted)
+	}
+	lines := []string{fmt.Sprintf("sqoop rejected %v of %v schemas and resolver maps:", payload.Rejected, payload.Accepted+payload.Rejected)}
+	for _, report := range payload.Reports {
+		if !report.Accepted {
+			lines = append(lines, fmt.Sprintf("%v %v: %v", report.Kind, report.Name, report.Reason))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
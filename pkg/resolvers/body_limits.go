@@ -0,0 +1,35 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// the body limits of resolvers created with options which don't set their own. generous, but finite
+const (
+	DefaultMaxRequestBytes  = 8 << 20
+	DefaultMaxResponseBytes = 64 << 20
+)
+
+// limitBodies bounds the bodies of the upstream requests and responses of the resolver by the limits of the
+// resolver, or of the factory where the resolver doesn't set its own
+func (rf *ResolverFactory) limitBodies(limits *v1.BodyLimits, resolver exec.RawResolver) exec.RawResolver {
+	bodyLimits := rf.opts.BodyLimits
+	if bodyLimits.MaxRequestBytes <= 0 {
+		bodyLimits.MaxRequestBytes = DefaultMaxRequestBytes
+	}
+	if bodyLimits.MaxResponseBytes <= 0 {
+		bodyLimits.MaxResponseBytes = DefaultMaxResponseBytes
+	}
+	if limits.GetMaxRequestBytes() > 0 {
+		bodyLimits.MaxRequestBytes = int64(limits.GetMaxRequestBytes())
+	}
+	if limits.GetMaxResponseBytes() > 0 {
+		bodyLimits.MaxResponseBytes = int64(limits.GetMaxResponseBytes())
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		return resolver(exec.WithBodyLimits(ctx, bodyLimits), params)
+	}
+}
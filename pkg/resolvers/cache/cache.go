@@ -0,0 +1,220 @@
+package cache
+
+import (
+	"context"
+	"expvar"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/util"
+)
+
+const (
+	defaultTTL        = time.Minute
+	defaultMaxEntries = 1000
+	// results may differ per tenant and subject, so the default key includes them. the rest of .ctx
+	// (request and trace ids) differs per request and would defeat the cache
+	defaultKey = "{{ marshal .Args }}/{{ marshal .Parent }}/{{ marshal .Cookies }}/{{ marshal .claims }}/" +
+		"{{ marshal .ctx.tenant }}/{{ marshal .ctx.subject }}"
+)
+
+var (
+	// expired entries revalidated with their upstream, by field
+	revalidations = expvar.NewMap("sqoop_cache_revalidations")
+	// revalidations the upstream answered with 304 Not Modified, by field
+	notModified = expvar.NewMap("sqoop_cache_not_modified")
+)
+
+// Cache stores resolver results for all cached fields of a resolver map.
+// Results are stored per field, keyed by the rendered key template of the field's resolver
+type Cache struct {
+	mu     sync.Mutex
+	fields map[string]*fieldCache
+}
+
+type fieldCache struct {
+	ttl        time.Duration
+	maxEntries int
+	// keep expired entries with an entity tag for revalidation
+	revalidate bool
+	entries    map[string]entry
+}
+
+type entry struct {
+	data []byte
+	// the entity tag of the upstream response, if it had one and the field is revalidated
+	etag    string
+	expires time.Time
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		fields: make(map[string]*fieldCache),
+	}
+}
+
+// NewCachingResolver wraps a resolver so that its results are served from the cache
+// while they are valid. errors are never cached. if the cache revalidates, expired results
+// are served again when their upstream answers a conditional request with 304 Not Modified
+func (c *Cache) NewCachingResolver(typeName, fieldName string, cfg *v1.ResolverCache, resolver exec.RawResolver) (exec.RawResolver, error) {
+	keyTemplate := cfg.KeyTemplate
+	if keyTemplate == "" {
+		keyTemplate = defaultKey
+	}
+	tmpl, err := util.Template(keyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing cache key template")
+	}
+	ttl := defaultTTL
+	if cfg.TtlSeconds > 0 {
+		ttl = time.Duration(cfg.TtlSeconds) * time.Second
+	}
+	maxEntries := defaultMaxEntries
+	if cfg.MaxEntries > 0 {
+		maxEntries = int(cfg.MaxEntries)
+	}
+	name := fieldKey(typeName, fieldName)
+	c.mu.Lock()
+	c.fields[name] = &fieldCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		revalidate: cfg.Revalidate,
+		entries:    make(map[string]entry),
+	}
+	c.mu.Unlock()
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		key, err := renderKey(tmpl, params)
+		if err != nil {
+			return nil, errors.Wrap(err, "rendering cache key")
+		}
+		cached, fresh := c.get(name, key)
+		if fresh {
+			return cached.data, nil
+		}
+		if !cfg.Revalidate {
+			data, err := resolver(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			c.set(name, key, data, "")
+			return data, nil
+		}
+		ctx, rv := exec.WithRevalidation(ctx, cached.etag)
+		data, err := resolver(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if cached.etag != "" {
+			revalidations.Add(name, 1)
+		}
+		if rv.NotModified {
+			notModified.Add(name, 1)
+			c.set(name, key, cached.data, cached.etag)
+			return cached.data, nil
+		}
+		c.set(name, key, data, rv.ResponseETag)
+		return data, nil
+	}, nil
+}
+
+func renderKey(tmpl *template.Template, params exec.Params) (string, error) {
+	buf, err := util.ExecTemplate(tmpl, params)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// get returns the entry for the key, and whether it is still valid.
+// expired entries are only returned if they can be revalidated
+func (c *Cache) get(name, key string) (entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fc, ok := c.fields[name]
+	if !ok {
+		return entry{}, false
+	}
+	e, ok := fc.entries[key]
+	if !ok {
+		return entry{}, false
+	}
+	if !time.Now().Before(e.expires) {
+		if fc.revalidate && e.etag != "" {
+			return e, false
+		}
+		delete(fc.entries, key)
+		return entry{}, false
+	}
+	return e, true
+}
+
+func (c *Cache) set(name, key string, data []byte, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fc, ok := c.fields[name]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if _, exists := fc.entries[key]; !exists && len(fc.entries) >= fc.maxEntries {
+		fc.evict(now)
+	}
+	fc.entries[key] = entry{data: data, etag: etag, expires: now.Add(fc.ttl)}
+}
+
+// drop expired entries, or the entry closest to expiring if none have expired
+func (fc *fieldCache) evict(now time.Time) {
+	var (
+		found     bool
+		oldestKey string
+		oldest    time.Time
+	)
+	for key, e := range fc.entries {
+		if !now.Before(e.expires) {
+			delete(fc.entries, key)
+			continue
+		}
+		if !found || e.expires.Before(oldest) {
+			found, oldestKey, oldest = true, key, e.expires
+		}
+	}
+	if len(fc.entries) >= fc.maxEntries {
+		delete(fc.entries, oldestKey)
+	}
+}
+
+// Invalidate removes the entry with the given key from the cache of a field
+func (c *Cache) Invalidate(typeName, fieldName, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fc, ok := c.fields[fieldKey(typeName, fieldName)]; ok {
+		delete(fc.entries, key)
+	}
+}
+
+// InvalidateField removes all entries cached for a field
+func (c *Cache) InvalidateField(typeName, fieldName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fc, ok := c.fields[fieldKey(typeName, fieldName)]; ok {
+		fc.entries = make(map[string]entry)
+	}
+}
+
+// Purge removes all entries from the cache
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, fc := range c.fields {
+		fc.entries = make(map[string]entry)
+	}
+}
+
+func fieldKey(typeName, fieldName string) string {
+	return typeName + "." + fieldName
+}
@@ -0,0 +1,148 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers/cache"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
+	"github.com/solo-io/sqoop/pkg/resolvers/httpresolver"
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		c     *Cache
+		calls int
+		fail  bool
+	)
+	countingResolver := func(ctx context.Context, params exec.Params) ([]byte, error) {
+		calls++
+		if fail {
+			return nil, errors.Errorf("upstream failed")
+		}
+		return []byte(fmt.Sprintf(`{"calls":%v}`, calls)), nil
+	}
+	BeforeEach(func() {
+		c = NewCache()
+		calls = 0
+		fail = false
+	})
+	newResolver := func(cfg *v1.ResolverCache) exec.RawResolver {
+		resolver, err := c.NewCachingResolver("Human", "friends", cfg, countingResolver)
+		Expect(err).NotTo(HaveOccurred())
+		return resolver
+	}
+	It("serves repeated calls with the same key from the cache", func() {
+		resolver := newResolver(&v1.ResolverCache{KeyTemplate: `{{ index .Args "best_scene" }}`})
+		first, err := resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(Equal(first))
+		Expect(calls).To(Equal(1))
+
+		_, err = resolver(context.Background(), exec.Params{Args: map[string]interface{}{"best_scene": "hoth"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+	It("keys the default key by tenant and subject", func() {
+		resolver := newResolver(&v1.ResolverCache{})
+		forTenant := func(tenant, subject, requestID string) exec.Params {
+			return exec.Params{
+				Args:    map[string]interface{}{"best_scene": "hoth"},
+				Context: map[string]string{exec.ContextTenant: tenant, exec.ContextSubject: subject, exec.ContextRequestID: requestID},
+			}
+		}
+		a, err := resolver(context.Background(), forTenant("a", "luke", "1"))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), forTenant("b", "luke", "2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).NotTo(Equal(a))
+		Expect(calls).To(Equal(2))
+
+		// other requests of the same tenant and subject are served from the cache
+		cached, err := resolver(context.Background(), forTenant("a", "luke", "3"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(Equal(a))
+		Expect(calls).To(Equal(2))
+
+		_, err = resolver(context.Background(), forTenant("a", "leia", "4"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+	It("does not cache errors", func() {
+		resolver := newResolver(&v1.ResolverCache{})
+		fail = true
+		_, err := resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).To(HaveOccurred())
+		fail = false
+		_, err = resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+	It("evicts entries when the cache is full", func() {
+		resolver := newResolver(&v1.ResolverCache{KeyTemplate: `{{ index .Args "id" }}`, MaxEntries: 1})
+		for _, id := range []string{"1000", "1001", "1000"} {
+			_, err := resolver(context.Background(), exec.Params{Args: map[string]interface{}{"id": id}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(calls).To(Equal(3))
+	})
+	It("invalidates entries", func() {
+		resolver := newResolver(&v1.ResolverCache{KeyTemplate: `{{ index .Args "best_scene" }}`})
+		resolver(context.Background(), test.LukeSkywalkerParams)
+		c.Invalidate("Human", "friends", "cloud city")
+		resolver(context.Background(), test.LukeSkywalkerParams)
+		c.InvalidateField("Human", "friends")
+		resolver(context.Background(), test.LukeSkywalkerParams)
+		c.Purge()
+		resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(calls).To(Equal(4))
+	})
+	It("rejects invalid key templates", func() {
+		_, err := c.NewCachingResolver("Human", "friends", &v1.ResolverCache{KeyTemplate: "{{ .Args"}, countingResolver)
+		Expect(err).To(HaveOccurred())
+	})
+	It("revalidates expired entries with the etag of their upstream response", func() {
+		var requests, notModified int
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Write([]byte(`{"version":1}`))
+		}))
+		defer upstream.Close()
+		httpResolver, err := httpresolver.NewHTTPResolver(&v1.HttpResolver{BaseUrl: upstream.URL, UrlTemplate: "/friends"},
+			&egress.Policy{AllowPrivateNetworks: true})
+		Expect(err).NotTo(HaveOccurred())
+		resolver, err := c.NewCachingResolver("Human", "friends", &v1.ResolverCache{TtlSeconds: 1, Revalidate: true}, httpResolver)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"version":1}`))
+		time.Sleep(1100 * time.Millisecond)
+		data, err = resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"version":1}`))
+		Expect(requests).To(Equal(2))
+		Expect(notModified).To(Equal(1))
+		// revalidated entries stay valid for another ttl
+		_, err = resolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(Equal(2))
+	})
+})
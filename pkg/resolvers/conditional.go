@@ -0,0 +1,124 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/util"
+)
+
+type variant struct {
+	condition *condition
+	resolver  exec.RawResolver
+}
+
+type condition struct {
+	argsPresent []string
+	argsAbsent  []string
+	template    *template.Template
+}
+
+func (rf *ResolverFactory) createConditionalResolver(typeName, fieldName string, conditional *v1.ConditionalResolver) (exec.RawResolver, error) {
+	var variants []variant
+	for i, v := range conditional.Variants {
+		if v.Resolver == nil {
+			return nil, errors.Errorf("variant %v of conditional resolver must specify a resolver", i)
+		}
+		cond, err := newCondition(v.When)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid condition for variant %v", i)
+		}
+		resolver, err := rf.createResolver(typeName, fieldName, rf.opts.RoutePrefix+operator.VariantRoutePath(typeName, fieldName, i), v.Resolver)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating resolver for variant %v", i)
+		}
+		variants = append(variants, variant{condition: cond, resolver: resolver})
+	}
+	var defaultResolver exec.RawResolver
+	if conditional.DefaultResolver != nil {
+		var err error
+		defaultResolver, err = rf.createResolver(typeName, fieldName, rf.opts.RoutePrefix+operator.RoutePath(typeName, fieldName), conditional.DefaultResolver)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating default resolver")
+		}
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		for i, v := range variants {
+			match, err := v.condition.matches(params)
+			if err != nil {
+				return nil, errors.Wrapf(err, "evaluating condition for variant %v", i)
+			}
+			if !match {
+				continue
+			}
+			if v.resolver == nil {
+				return nil, errors.Errorf("no resolver defined for variant %v", i)
+			}
+			return v.resolver(ctx, params)
+		}
+		if defaultResolver == nil {
+			return nil, errors.Errorf("no variant of conditional resolver for %v.%v matched the query", typeName, fieldName)
+		}
+		return defaultResolver(ctx, params)
+	}, nil
+}
+
+// resolve the field to null without calling the resolver unless the condition matches
+func precondition(when *v1.Condition, resolver exec.RawResolver) (exec.RawResolver, error) {
+	cond, err := newCondition(when)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		ok, err := cond.matches(params)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return resolver(ctx, params)
+	}, nil
+}
+
+func newCondition(when *v1.Condition) (*condition, error) {
+	// an empty condition always matches
+	if when == nil {
+		return &condition{}, nil
+	}
+	cond := &condition{
+		argsPresent: when.ArgsPresent,
+		argsAbsent:  when.ArgsAbsent,
+	}
+	if when.Template != "" {
+		tmpl, err := util.Template(when.Template)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing condition template")
+		}
+		cond.template = tmpl
+	}
+	return cond, nil
+}
+
+func (c *condition) matches(params exec.Params) (bool, error) {
+	for _, arg := range c.argsPresent {
+		if params.Arg(arg) == nil {
+			return false, nil
+		}
+	}
+	for _, arg := range c.argsAbsent {
+		if params.Arg(arg) != nil {
+			return false, nil
+		}
+	}
+	if c.template == nil {
+		return true, nil
+	}
+	buf, err := util.ExecTemplate(c.template, params)
+	if err != nil {
+		return false, errors.Wrap(err, "executing condition template")
+	}
+	return strings.TrimSpace(buf.String()) == "true", nil
+}
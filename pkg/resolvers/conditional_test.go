@@ -0,0 +1,122 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/dynamic"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+func templateResolver(tmpl string) *v1.Resolver {
+	return &v1.Resolver{
+		Resolver: &v1.Resolver_TemplateResolver{
+			TemplateResolver: &v1.TemplateResolver{InlineTemplate: tmpl},
+		},
+	}
+}
+
+var _ = Describe("ConditionalResolver", func() {
+	var factory *ResolverFactory
+	BeforeEach(func() {
+		conditional := &v1.ConditionalResolver{
+			Variants: []*v1.ResolverVariant{
+				{
+					When:     &v1.Condition{ArgsPresent: []string{"id"}},
+					Resolver: templateResolver(`by id {{ index .Args "id" }}`),
+				},
+				{
+					When:     &v1.Condition{ArgsPresent: []string{"email"}, ArgsAbsent: []string{"id"}},
+					Resolver: templateResolver(`by email {{ index .Args "email" }}`),
+				},
+				{
+					When:     &v1.Condition{Template: `{{ eq (index .Args "name") "luke" }}`},
+					Resolver: templateResolver(`by template`),
+				},
+			},
+			DefaultResolver: templateResolver(`default`),
+		}
+		factory = NewResolverFactory("no-address-defined", nil, &v1.ResolverMap{
+			Name: "conditional",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{
+					"user": {Resolver: &v1.Resolver_ConditionalResolver{ConditionalResolver: conditional}},
+				}},
+			},
+		}, Options{})
+	})
+	resolve := func(args map[string]interface{}) string {
+		resolver, err := factory.CreateResolver("Query", "user")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{Args: args})
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+	It("selects the first matching variant", func() {
+		Expect(resolve(map[string]interface{}{"id": "1000", "email": "luke@tatooine.org"})).To(Equal("by id 1000"))
+		Expect(resolve(map[string]interface{}{"email": "luke@tatooine.org"})).To(Equal("by email luke@tatooine.org"))
+		Expect(resolve(map[string]interface{}{"name": "luke"})).To(Equal("by template"))
+	})
+	It("falls back to the default resolver", func() {
+		Expect(resolve(map[string]interface{}{"name": "leia"})).To(Equal("default"))
+		Expect(resolve(map[string]interface{}{"id": nil})).To(Equal("default"))
+	})
+})
+
+var _ = Describe("Precondition", func() {
+	It("resolves to null without calling the resolver unless the precondition matches", func() {
+		resolver := templateResolver(`called`)
+		resolver.Precondition = &v1.Condition{Template: `{{ eq (index .Args "tier") "premium" }}`}
+		factory := NewResolverFactory("no-address-defined", nil, &v1.ResolverMap{
+			Name: "precondition",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"perks": resolver}},
+			},
+		}, Options{})
+		raw, err := factory.CreateResolver("Query", "perks")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := raw(context.Background(), exec.Params{Args: map[string]interface{}{"tier": "premium"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("called"))
+		b, err = raw(context.Background(), exec.Params{Args: map[string]interface{}{"tier": "basic"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(BeNil())
+	})
+	It("evaluates preconditions over the parent and the fields resolved before the field", func() {
+		object := func(key, value string) *dynamic.OrderedMap {
+			return &dynamic.OrderedMap{Keys: []string{key}, Values: []dynamic.Value{&dynamic.String{Data: value}}}
+		}
+		byParent := templateResolver(`called`)
+		byParent.Precondition = &v1.Condition{Template: `{{ eq .Parent.type "premium" }}`}
+		bySibling := templateResolver(`called`)
+		bySibling.Precondition = &v1.Condition{Template: `{{ eq .Siblings.plan "premium" }}`}
+		factory := NewResolverFactory("no-address-defined", nil, &v1.ResolverMap{
+			Name: "precondition",
+			Types: map[string]*v1.TypeResolver{
+				"User": {Fields: map[string]*v1.Resolver{"perks": byParent, "discounts": bySibling}},
+			},
+		}, Options{})
+
+		raw, err := factory.CreateResolver("User", "perks")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := raw(context.Background(), exec.Params{Parent: &dynamic.Object{Data: object("type", "premium")}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("called"))
+		b, err = raw(context.Background(), exec.Params{Parent: &dynamic.Object{Data: object("type", "basic")}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(BeNil())
+
+		raw, err = factory.CreateResolver("User", "discounts")
+		Expect(err).NotTo(HaveOccurred())
+		b, err = raw(context.Background(), exec.Params{Siblings: object("plan", "premium")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("called"))
+		b, err = raw(context.Background(), exec.Params{Siblings: object("plan", "basic")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(BeNil())
+	})
+})
@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+)
+
+// WithDefaults returns a copy of the resolver map in which every http resolver inherits
+// the http defaults of the map. settings of a resolver take precedence over the defaults.
+// Gloo resolvers are left as they are: the base url, allowed hosts and headers describe the destination of
+// http resolvers, while gloo resolvers always call the proxy. the timeout of a gloo resolver is also the
+// timeout of its route in gloo, which is built from the resolver map as written, so defaulting it here
+// would make the resolver give up at a different time than the proxy
+func WithDefaults(resolverMap *v1.ResolverMap) *v1.ResolverMap {
+	if resolverMap.HttpDefaults == nil {
+		return resolverMap
+	}
+	withDefaults := proto.Clone(resolverMap).(*v1.ResolverMap)
+	for _, typeResolver := range withDefaults.Types {
+		for _, resolver := range typeResolver.Fields {
+			applyHTTPDefaults(resolver, withDefaults.HttpDefaults)
+			for _, source := range resolver.GetMergeResolver().GetSources() {
+				applyHTTPDefaults(source.GetResolver(), withDefaults.HttpDefaults)
+			}
+			conditional := resolver.GetConditionalResolver()
+			if conditional == nil {
+				continue
+			}
+			for _, variant := range conditional.Variants {
+				applyHTTPDefaults(variant.GetResolver(), withDefaults.HttpDefaults)
+			}
+			applyHTTPDefaults(conditional.DefaultResolver, withDefaults.HttpDefaults)
+		}
+	}
+	return withDefaults
+}
+
+func applyHTTPDefaults(resolver *v1.Resolver, defaults *v1.HttpDefaults) {
+	httpResolver := resolver.GetHttpResolver()
+	if httpResolver == nil {
+		return
+	}
+	if httpResolver.BaseUrl == "" {
+		httpResolver.BaseUrl = defaults.BaseUrl
+	}
+	if httpResolver.TimeoutMs == 0 {
+		httpResolver.TimeoutMs = defaults.TimeoutMs
+	}
+	httpResolver.AllowedHosts = append(httpResolver.AllowedHosts, defaults.AllowedHosts...)
+	if len(defaults.Headers) > 0 && httpResolver.Headers == nil {
+		httpResolver.Headers = make(map[string]string)
+	}
+	for name, value := range defaults.Headers {
+		if _, ok := httpResolver.Headers[name]; !ok {
+			httpResolver.Headers[name] = value
+		}
+	}
+}
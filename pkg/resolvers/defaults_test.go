@@ -0,0 +1,60 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("WithDefaults", func() {
+	httpResolver := func(r *v1.HttpResolver) *v1.Resolver {
+		return &v1.Resolver{Resolver: &v1.Resolver_HttpResolver{HttpResolver: r}}
+	}
+	It("merges the http defaults of the map into each http resolver", func() {
+		resolverMap := &v1.ResolverMap{
+			Name: "defaults",
+			HttpDefaults: &v1.HttpDefaults{
+				BaseUrl:   "https://api.example.com/v1",
+				Headers:   map[string]string{"Accept": "application/json", "X-Client": "sqoop"},
+				TimeoutMs: 500,
+			},
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{
+					"inherits": httpResolver(&v1.HttpResolver{UrlTemplate: "/users"}),
+					"overrides": httpResolver(&v1.HttpResolver{
+						UrlTemplate: "/reviews",
+						BaseUrl:     "https://reviews.example.com",
+						Headers:     map[string]string{"X-Client": "reviews"},
+						TimeoutMs:   100,
+					}),
+				}},
+			},
+		}
+		withDefaults := WithDefaults(resolverMap)
+		inherits := withDefaults.Types["Query"].Fields["inherits"].GetHttpResolver()
+		Expect(inherits.BaseUrl).To(Equal("https://api.example.com/v1"))
+		Expect(inherits.TimeoutMs).To(Equal(uint32(500)))
+		Expect(inherits.Headers).To(Equal(map[string]string{"Accept": "application/json", "X-Client": "sqoop"}))
+		overrides := withDefaults.Types["Query"].Fields["overrides"].GetHttpResolver()
+		Expect(overrides.BaseUrl).To(Equal("https://reviews.example.com"))
+		Expect(overrides.TimeoutMs).To(Equal(uint32(100)))
+		Expect(overrides.Headers).To(Equal(map[string]string{"Accept": "application/json", "X-Client": "reviews"}))
+		// the original map is not modified
+		Expect(resolverMap.Types["Query"].Fields["inherits"].GetHttpResolver().BaseUrl).To(BeEmpty())
+	})
+	It("leaves gloo resolvers as they are", func() {
+		glooResolver := &v1.GlooResolver{Function: &v1.GlooResolver_Upstream{Upstream: "users"}}
+		resolverMap := &v1.ResolverMap{
+			Name:         "defaults",
+			HttpDefaults: &v1.HttpDefaults{BaseUrl: "https://api.example.com/v1", TimeoutMs: 500},
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{
+					"users": {Resolver: &v1.Resolver_GlooResolver{GlooResolver: glooResolver}},
+				}},
+			},
+		}
+		Expect(WithDefaults(resolverMap).Types["Query"].Fields["users"].GetGlooResolver()).To(Equal(glooResolver))
+	})
+})
@@ -0,0 +1,178 @@
+package egress
+
+import (
+	"expvar"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+const requestTimeout = 30 * time.Second
+
+// number of requests rejected by a policy, served with the other expvars
+var blockedRequests = expvar.NewInt("sqoop_egress_blocked_requests")
+
+// destinations which are never allowed unless the policy allows private networks
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// Policy restricts the destinations of requests whose URL is computed from data,
+// such as a URL returned by an upstream. It applies in addition to the allowed hosts
+// of each resolver
+type Policy struct {
+	// if not empty, only these hosts may be called. see HostList
+	AllowedHosts HostList
+	// schemes which may be used. defaults to http and https
+	AllowedSchemes []string
+	// allow calling loopback, link-local and private addresses
+	AllowPrivateNetworks bool
+}
+
+// HostList is a list of host names. a leading "*." matches any subdomain, e.g. "*.example.com"
+type HostList []string
+
+func NewHostList(hosts []string) HostList {
+	var list HostList
+	for _, host := range hosts {
+		list = append(list, strings.ToLower(host))
+	}
+	return list
+}
+
+func (l HostList) Allows(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range l {
+		if allowed == host {
+			return true
+		}
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error if the url may not be called by a resolver allowing the given hosts.
+// rejected urls are logged and counted
+func (p *Policy) Check(u *url.URL, resolverHosts HostList) error {
+	if err := p.check(u, resolverHosts); err != nil {
+		blocked(err)
+		return err
+	}
+	return nil
+}
+
+func (p *Policy) check(u *url.URL, resolverHosts HostList) error {
+	if !p.allowsScheme(u.Scheme) {
+		return errors.Errorf("url %v uses a scheme which is not allowed", u)
+	}
+	if u.User != nil {
+		return errors.Errorf("url must not contain credentials")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.Errorf("url %v has no host", u)
+	}
+	if !resolverHosts.Allows(host) || (len(p.AllowedHosts) > 0 && !p.AllowedHosts.Allows(host)) {
+		return errors.Errorf("host %v is not allowed", host)
+	}
+	return nil
+}
+
+func (p *Policy) allowsScheme(scheme string) bool {
+	schemes := p.AllowedSchemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https"}
+	}
+	for _, allowed := range schemes {
+		if strings.EqualFold(allowed, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client returns an http client for a resolver allowing the given hosts.
+// redirects are checked like the original url, and connections to private
+// addresses are refused after host names are resolved
+func (p *Policy) Client(resolverHosts HostList) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !p.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			if err := checkAddress(address); err != nil {
+				blocked(err)
+				return err
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// replaces the limit of the default policy
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return p.Check(req.URL, resolverHosts)
+		},
+	}
+}
+
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid address %v", address)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.Errorf("invalid address %v", address)
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return errors.Errorf("address %v is in private network %v", ip, network)
+		}
+	}
+	return nil
+}
+
+func blocked(err error) {
+	blockedRequests.Add(1)
+	log.Warnf("blocked resolver request: %v", err)
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,96 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// replace the members of enum arguments with the codes expected by the upstream before calling the resolver
+func (rf *ResolverFactory) mapEnumArguments(typeName, fieldName string, enumArgs map[string]*v1.EnumCodes, resolver exec.RawResolver) (exec.RawResolver, error) {
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil, errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil, errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	codes := make(map[string]map[string]string)
+	for argName, enumCodes := range enumArgs {
+		arg := field.Args.Get(argName)
+		if arg == nil {
+			return nil, errors.Errorf("enumArguments is set for %v, which is not an argument of %v.%v", argName, typeName, fieldName)
+		}
+		enum, ok := namedType(arg.Type).(*schema.Enum)
+		if !ok {
+			return nil, errors.Errorf("enumArguments is set for %v.%v(%v), which is not an enum", typeName, fieldName, argName)
+		}
+		if err := checkEnumMembers(enum, enumCodes.GetCodes()); err != nil {
+			return nil, errors.Wrapf(err, "enumArguments for %v.%v(%v)", typeName, fieldName, argName)
+		}
+		codes[argName] = enumCodes.GetCodes()
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		// arguments are shared by every object the field is resolved on, so they are copied rather than modified
+		args := make(map[string]interface{}, len(params.Args))
+		for name, val := range params.Args {
+			if argCodes, ok := codes[name]; ok {
+				val = enumCode(argCodes, val)
+			}
+			args[name] = val
+		}
+		params.Args = args
+		return resolver(ctx, params)
+	}, nil
+}
+
+func namedType(typ common.Type) common.Type {
+	switch t := typ.(type) {
+	case *common.NonNull:
+		return namedType(t.OfType)
+	case *common.List:
+		return namedType(t.OfType)
+	}
+	return typ
+}
+
+func checkEnumMembers(enum *schema.Enum, codes map[string]string) error {
+	members := make(map[string]bool)
+	for _, value := range enum.Values {
+		members[value.Name] = true
+	}
+	var unknown []string
+	for member := range codes {
+		if !members[member] {
+			unknown = append(unknown, member)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("%v are not members of enum %v", unknown, enum.Name)
+	}
+	return nil
+}
+
+// enum members are strings, lists of enums are mapped item by item
+func enumCode(codes map[string]string, val interface{}) interface{} {
+	switch val := val.(type) {
+	case string:
+		if code, ok := codes[val]; ok {
+			return code
+		}
+	case []interface{}:
+		mapped := make([]interface{}, len(val))
+		for i, item := range val {
+			mapped[i] = enumCode(codes, item)
+		}
+		return mapped
+	}
+	return val
+}
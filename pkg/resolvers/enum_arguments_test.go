@@ -0,0 +1,54 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("EnumArguments", func() {
+	sch := exec.MustParseSchema(`
+enum Status {
+	IN_STOCK
+	SOLD_OUT
+}
+type Query {
+	products(status: Status!): String
+}
+schema {
+	query: Query
+}
+`)
+	factory := func(codes map[string]string) *ResolverFactory {
+		resolver := templateResolver(`{{ .Args.status }}`)
+		resolver.EnumArguments = map[string]*v1.EnumCodes{"status": {Codes: codes}}
+		return NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "enums",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"products": resolver}},
+			},
+		}, Options{})
+	}
+	It("replaces enum members with their upstream codes", func() {
+		resolver, err := factory(map[string]string{"IN_STOCK": "in-stock"}).CreateResolver("Query", "products")
+		Expect(err).NotTo(HaveOccurred())
+		args := map[string]interface{}{"status": "IN_STOCK"}
+		b, err := resolver(context.Background(), exec.Params{Args: args})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("in-stock"))
+		Expect(args["status"]).To(Equal("IN_STOCK"))
+
+		b, err = resolver(context.Background(), exec.Params{Args: map[string]interface{}{"status": "SOLD_OUT"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("SOLD_OUT"))
+	})
+	It("rejects codes for values which are not members of the enum", func() {
+		_, err := factory(map[string]string{"BACKORDERED": "backordered"}).CreateResolver("Query", "products")
+		Expect(err).To(MatchError("enumArguments for Query.products(status): [BACKORDERED] are not members of enum Status"))
+	})
+})
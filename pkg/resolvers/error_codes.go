@@ -0,0 +1,140 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// DefaultErrorCodes map the statuses of failed upstream responses to the codes reported in extensions.code,
+// unless the factory is created with its own error codes
+var DefaultErrorCodes = map[string]string{
+	"400": "BAD_REQUEST",
+	"401": "UNAUTHENTICATED",
+	"403": "FORBIDDEN",
+	"404": "NOT_FOUND",
+	"409": "CONFLICT",
+	"429": "RATE_LIMITED",
+	"4xx": "BAD_REQUEST",
+	"503": "UNAVAILABLE",
+	"504": "TIMEOUT",
+	"5xx": "INTERNAL",
+}
+
+type statusCode struct {
+	min, max int
+	code     string
+}
+
+// statusCodes are ordered from the narrowest range of statuses to the widest
+type statusCodes []statusCode
+
+// parseErrorCodes parses codes by status, e.g. "404", range of statuses, e.g. "500-599", or class of
+// statuses, e.g. "5xx"
+func parseErrorCodes(codes map[string]string) (statusCodes, error) {
+	var parsed statusCodes
+	for statuses, code := range codes {
+		if code == "" {
+			return nil, errors.Errorf("the error code of statuses %v is empty", statuses)
+		}
+		min, max, err := parseStatusRange(statuses)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, statusCode{min: min, max: max, code: code})
+	}
+	sort.Slice(parsed, func(i, j int) bool {
+		if width, other := parsed[i].max-parsed[i].min, parsed[j].max-parsed[j].min; width != other {
+			return width < other
+		}
+		return parsed[i].min < parsed[j].min
+	})
+	return parsed, nil
+}
+
+func parseStatusRange(statuses string) (int, int, error) {
+	invalid := errors.Errorf("invalid statuses %q, must be a status, a range of statuses like 500-599 or a class of statuses like 5xx", statuses)
+	if len(statuses) == 3 && strings.HasSuffix(statuses, "xx") {
+		class, err := strconv.Atoi(statuses[:1])
+		if err != nil || class < 1 || class > 5 {
+			return 0, 0, invalid
+		}
+		return class * 100, class*100 + 99, nil
+	}
+	bounds := strings.SplitN(statuses, "-", 2)
+	min, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, invalid
+	}
+	max := min
+	if len(bounds) == 2 {
+		if max, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+			return 0, 0, invalid
+		}
+	}
+	if min < 100 || max > 599 || min > max {
+		return 0, 0, invalid
+	}
+	return min, max, nil
+}
+
+func (codes statusCodes) code(status int) string {
+	for _, code := range codes {
+		if code.min <= status && status <= code.max {
+			return code.code
+		}
+	}
+	return ""
+}
+
+// ParseErrorCodes parses error codes given as <statuses>=<code>, e.g. "404=NOT_FOUND" or "5xx=INTERNAL"
+func ParseErrorCodes(pairs []string) (map[string]string, error) {
+	codes := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid error code %q, must be <statuses>=<code>", pair)
+		}
+		codes[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	if _, err := parseErrorCodes(codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// mapErrorCodes tags the errors of failed upstream responses with the code mapped from their status, by the
+// codes of the resolver or else those of the factory. errors which already have a code, e.g. one found by an
+// error envelope, keep it
+func (rf *ResolverFactory) mapErrorCodes(typeName, fieldName string, resolverCodes map[string]string, resolver exec.RawResolver) (exec.RawResolver, error) {
+	codes, err := parseErrorCodes(resolverCodes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "errorCodes of %v.%v", typeName, fieldName)
+	}
+	factoryCodes := rf.opts.ErrorCodes
+	if factoryCodes == nil {
+		factoryCodes = DefaultErrorCodes
+	}
+	defaults, err := parseErrorCodes(factoryCodes)
+	if err != nil {
+		return nil, err
+	}
+	codes = append(codes, defaults...)
+	if len(codes) == 0 {
+		return resolver, nil
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		data, err := resolver(ctx, params)
+		if err == nil || exec.CodeOf(err) != "" {
+			return data, err
+		}
+		if status := exec.StatusOf(err); status != 0 {
+			err = exec.WithErrorCode(err, codes.code(status))
+		}
+		return data, err
+	}, nil
+}
@@ -0,0 +1,78 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
+)
+
+var _ = Describe("ErrorCodes", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	profile(status: Int): String
+}
+schema {
+	query: Query
+}
+`)
+	var server *httptest.Server
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+			w.WriteHeader(status)
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	codeOf := func(status int, resolverCodes, factoryCodes map[string]string) string {
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "codes",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"profile": {
+					Resolver: &v1.Resolver_HttpResolver{HttpResolver: &v1.HttpResolver{
+						BaseUrl:     server.URL,
+						UrlTemplate: "/{{ .Args.status }}",
+					}},
+					ErrorCodes: resolverCodes,
+				}}},
+			},
+		}, Options{Egress: &egress.Policy{AllowPrivateNetworks: true}, ErrorCodes: factoryCodes})
+		raw, err := rf.CreateResolver("Query", "profile")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = raw(context.Background(), exec.Params{Args: map[string]interface{}{"status": status}})
+		Expect(err).To(HaveOccurred())
+		Expect(exec.CategoryOf(err)).To(Equal(exec.ErrorCategoryUpstream))
+		return exec.CodeOf(err)
+	}
+	It("maps the status of failed upstream responses to the default error codes", func() {
+		Expect(codeOf(404, nil, nil)).To(Equal("NOT_FOUND"))
+		Expect(codeOf(401, nil, nil)).To(Equal("UNAUTHENTICATED"))
+		Expect(codeOf(502, nil, nil)).To(Equal("INTERNAL"))
+	})
+	It("prefers the narrowest range of the resolver, then those of the factory", func() {
+		resolverCodes := map[string]string{"5xx": "UPSTREAM_DOWN", "520-529": "CDN_ERROR"}
+		factoryCodes := map[string]string{"404": "MISSING"}
+		Expect(codeOf(522, resolverCodes, factoryCodes)).To(Equal("CDN_ERROR"))
+		Expect(codeOf(503, resolverCodes, factoryCodes)).To(Equal("UPSTREAM_DOWN"))
+		Expect(codeOf(404, resolverCodes, factoryCodes)).To(Equal("MISSING"))
+		Expect(codeOf(401, resolverCodes, factoryCodes)).To(BeEmpty())
+	})
+	It("rejects invalid ranges of statuses", func() {
+		_, err := ParseErrorCodes([]string{"404=NOT_FOUND", "6xx=UNKNOWN"})
+		Expect(err).To(MatchError(ContainSubstring(`invalid statuses "6xx"`)))
+		codes, err := ParseErrorCodes([]string{"404=NOT_FOUND", "500-599=INTERNAL"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(codes).To(Equal(map[string]string{"404": "NOT_FOUND", "500-599": "INTERNAL"}))
+	})
+})
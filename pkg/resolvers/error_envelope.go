@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// fail the field with the error reported in the body of a response which is otherwise successful. responses
+// which are not json objects, or don't report an error, are passed on unchanged
+func (rf *ResolverFactory) errorEnvelope(typeName, fieldName string, envelope *v1.ErrorEnvelope, resolver exec.RawResolver) (exec.RawResolver, error) {
+	if envelope.ErrorField == "" {
+		return nil, errors.Errorf("errorEnvelope of %v.%v must specify the error field", typeName, fieldName)
+	}
+	errorPath := strings.Split(envelope.ErrorField, ".")
+	messagePath := errorPath
+	if envelope.MessageField != "" {
+		messagePath = strings.Split(envelope.MessageField, ".")
+	}
+	var codePath []string
+	if envelope.CodeField != "" {
+		codePath = strings.Split(envelope.CodeField, ".")
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		data, err := resolver(ctx, params)
+		if err != nil {
+			return data, err
+		}
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) == 0 || trimmed[0] != '{' {
+			return data, nil
+		}
+		var decoded interface{}
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			// left to fail when the field is resolved from the response
+			return data, nil
+		}
+		if !isEnvelopeError(lookupField(decoded, errorPath), envelope.ErrorValue) {
+			return data, nil
+		}
+		message, ok := lookupField(decoded, messagePath).(string)
+		if !ok || message == "" {
+			message = "upstream reported an error"
+		}
+		err = exec.UpstreamError(errors.New(message))
+		if codePath != nil {
+			err = exec.WithErrorCode(err, scalarString(lookupField(decoded, codePath)))
+		}
+		return nil, err
+	}, nil
+}
+
+func isEnvelopeError(val interface{}, errorValue string) bool {
+	if errorValue != "" {
+		return val != nil && scalarString(val) == errorValue
+	}
+	switch val := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != ""
+	case []interface{}:
+		return len(val) > 0
+	case map[string]interface{}:
+		return len(val) > 0
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("ErrorEnvelope", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	profile: Profile
+}
+type Profile {
+	name: String
+}
+schema {
+	query: Query
+}
+`)
+	resolve := func(response string, envelope *v1.ErrorEnvelope) (string, error) {
+		resolver := templateResolver(response)
+		resolver.ErrorEnvelope = envelope
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "envelopes",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"profile": resolver}},
+			},
+		}, Options{})
+		raw, err := rf.CreateResolver("Query", "profile")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := raw(context.Background(), exec.Params{})
+		return string(b), err
+	}
+	It("fails the field with the message and code of the error in the response", func() {
+		envelope := &v1.ErrorEnvelope{ErrorField: "status", ErrorValue: "error", MessageField: "errors.0.message", CodeField: "errors.0.code"}
+		_, err := resolve(`{"status": "error", "errors": [{"message": "profile not found", "code": 404}]}`, envelope)
+		Expect(err).To(MatchError("profile not found"))
+		Expect(exec.CategoryOf(err)).To(Equal(exec.ErrorCategoryUpstream))
+		Expect(exec.CodeOf(err)).To(Equal("404"))
+		b, err := resolve(`{"status": "ok", "name": "luke"}`, envelope)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"status": "ok", "name": "luke"}`))
+	})
+	It("treats any error field which is set as an error without an error value", func() {
+		envelope := &v1.ErrorEnvelope{ErrorField: "error"}
+		_, err := resolve(`{"error": "upstream unavailable"}`, envelope)
+		Expect(err).To(MatchError("upstream unavailable"))
+		Expect(exec.CodeOf(err)).To(BeEmpty())
+		_, err = resolve(`{"error": {"reason": "unavailable"}}`, envelope)
+		Expect(err).To(MatchError("upstream reported an error"))
+		for _, data := range []string{`{"error": null, "name": "luke"}`, `{"error": "", "name": "luke"}`, `{"error": false}`, `null`} {
+			_, err = resolve(data, envelope)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+})
@@ -1,27 +1,100 @@
 package resolvers
 
 import (
+	"context"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/metrics"
+	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/resolvers/cache"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
 	"github.com/solo-io/sqoop/pkg/resolvers/gloo"
+	"github.com/solo-io/sqoop/pkg/resolvers/httpresolver"
+	"github.com/solo-io/sqoop/pkg/resolvers/mock"
 	"github.com/solo-io/sqoop/pkg/resolvers/node"
+	"github.com/solo-io/sqoop/pkg/resolvers/signedurl"
 	"github.com/solo-io/sqoop/pkg/resolvers/template"
+	"github.com/solo-io/sqoop/pkg/resolvers/variables"
+	"github.com/solo-io/sqoop/pkg/secrets"
+	"github.com/vektah/gqlgen/neelance/schema"
 )
 
 type ResolverFactory struct {
 	glooResolverFactory *gloo.ResolverFactory
+	schema              *schema.Schema
 	resolverMap         *v1.ResolverMap
+	opts                Options
+	cache               *cache.Cache
+}
+
+// Options configure how resolvers are created from a resolver map
+type Options struct {
+	// resolve every field with mock data instead of its configured resolver
+	MockAll bool
+	// source of secrets referenced by resolvers. may be nil
+	Secrets *secrets.Store
+	// restricts requests to urls computed from data. may be nil
+	Egress *egress.Policy
+	// record the size of the data returned by each resolver
+	RecordSizes bool
+	// prefix of the gloo routes called by the resolvers, for resolver maps bound to a schema at their own path
+	RoutePrefix string
+	// decides whether the feature flags gating resolvers are enabled. if nil, gated resolvers are never called
+	Flags FlagProvider
+	// page sizes of connection fields whose resolvers don't set their own
+	PageSizes PageSizes
+	// bound the bodies of upstream requests and responses of resolvers which don't set their own limits.
+	// zero applies DefaultMaxRequestBytes and DefaultMaxResponseBytes
+	BodyLimits exec.BodyLimits
+	// codes of the errors of failed upstream responses by status, for resolvers which don't map a status
+	// themselves. nil applies DefaultErrorCodes
+	ErrorCodes map[string]string
 }
 
-func NewResolverFactory(proxyAddr string, resolverMap *v1.ResolverMap) *ResolverFactory {
+func NewResolverFactory(proxyAddr string, sch *schema.Schema, resolverMap *v1.ResolverMap, opts Options) *ResolverFactory {
 	return &ResolverFactory{
-		glooResolverFactory: gloo.NewResolverFactory(proxyAddr),
+		glooResolverFactory: gloo.NewResolverFactory(proxyAddr, opts.Secrets),
+		schema:              sch,
 		resolverMap:         resolverMap,
+		opts:                opts,
+		cache:               cache.NewCache(),
+	}
+}
+
+// Cache holds the results of all cached resolvers created by the factory
+func (rf *ResolverFactory) Cache() *cache.Cache {
+	return rf.cache
+}
+
+// ListConcurrency returns the number of items of a list returned by a field which may be resolved concurrently
+func (rf *ResolverFactory) ListConcurrency(typeName, fieldName string) int {
+	if rf.opts.MockAll {
+		return 0
+	}
+	fieldResolver := rf.resolverMap.Types[typeName].GetFields()[fieldName]
+	return int(fieldResolver.GetListConcurrency())
+}
+
+// ListLimit returns the bound on the number of items of a list returned by a field
+func (rf *ResolverFactory) ListLimit(typeName, fieldName string) exec.ListLimit {
+	if rf.opts.MockAll {
+		return exec.ListLimit{}
+	}
+	limit := rf.resolverMap.Types[typeName].GetFields()[fieldName].GetListLimit()
+	return exec.ListLimit{
+		MaxItems: int(limit.GetMaxItems()),
+		Fail:     limit.GetFail(),
+		Warn:     limit.GetWarn(),
 	}
 }
 
 func (rf *ResolverFactory) CreateResolver(typeName, fieldName string) (exec.RawResolver, error) {
+	if rf.opts.MockAll {
+		return mock.NewMockResolver(rf.schema, typeName, fieldName, nil)
+	}
 	if len(rf.resolverMap.Types) == 0 {
 		return nil, errors.Errorf("no types defined in resolver map %v", rf.resolverMap.Name)
 	}
@@ -37,13 +110,141 @@ func (rf *ResolverFactory) CreateResolver(typeName, fieldName string) (exec.RawR
 		return nil, errors.Errorf("field %v not found for type %v in resolver map %v",
 			fieldName, typeResolver, rf.resolverMap.Name)
 	}
+	if err := rf.validateTemplates(typeName, fieldName, fieldResolver); err != nil {
+		return nil, errors.Wrapf(err, "%v.%v", typeName, fieldName)
+	}
+	var (
+		resolver exec.RawResolver
+		err      error
+	)
+	if conditional, ok := fieldResolver.Resolver.(*v1.Resolver_ConditionalResolver); ok {
+		if len(fieldResolver.EnumArguments) > 0 {
+			// conditions are evaluated on the arguments as they were given in the query
+			return nil, errors.Errorf("enumArguments must be set on the variants of the conditional resolver for %v.%v", typeName, fieldName)
+		}
+		resolver, err = rf.createConditionalResolver(typeName, fieldName, conditional.ConditionalResolver)
+	} else if merge, ok := fieldResolver.Resolver.(*v1.Resolver_MergeResolver); ok {
+		if len(fieldResolver.EnumArguments) > 0 {
+			return nil, errors.Errorf("enumArguments must be set on the sources of the merge resolver for %v.%v", typeName, fieldName)
+		}
+		resolver, err = rf.createMergeResolver(typeName, fieldName, merge.MergeResolver)
+	} else {
+		resolver, err = rf.createResolver(typeName, fieldName, rf.opts.RoutePrefix+operator.RoutePath(typeName, fieldName), fieldResolver)
+	}
+	if err != nil || resolver == nil {
+		return resolver, err
+	}
+	resolver = rf.limitBodies(fieldResolver.BodyLimits, resolver)
+	resolver, err = rf.mapErrorCodes(typeName, fieldName, fieldResolver.ErrorCodes, resolver)
+	if err != nil {
+		return nil, err
+	}
+	// checked on every page, before the pages are merged
+	if fieldResolver.ErrorEnvelope != nil {
+		resolver, err = rf.errorEnvelope(typeName, fieldName, fieldResolver.ErrorEnvelope, resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fieldResolver.FollowPages != nil {
+		resolver, err = rf.followPages(typeName, fieldName, fieldResolver.FollowPages, resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fieldResolver.SplitString != nil {
+		resolver, err = rf.splitString(typeName, fieldName, fieldResolver.SplitString, resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fieldResolver.NullAsEmptyList {
+		resolver, err = rf.nullAsEmptyList(typeName, fieldName, resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rf.opts.RecordSizes {
+		// cache hits are not recorded
+		resolver = recordSize(typeName+"."+fieldName, resolver)
+	}
+	// cache hits don't call the upstream, so they are not timed
+	resolver = recordTiming(resolver)
+	if fieldResolver.Cache != nil {
+		resolver, err = rf.cache.NewCachingResolver(typeName, fieldName, fieldResolver.Cache, resolver)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// checked before the cache, so defaulted page sizes are part of the cache key
+	resolver, err = rf.limitPageSize(typeName, fieldName, fieldResolver.PageSize, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if fieldResolver.Precondition != nil {
+		resolver, err = precondition(fieldResolver.Precondition, resolver)
+		if err != nil {
+			return nil, errors.Wrapf(err, "precondition of %v.%v", typeName, fieldName)
+		}
+	}
+	if fieldResolver.FeatureGate == nil {
+		return resolver, nil
+	}
+	// checked before the cache, so cached results aren't served while the flag is disabled
+	return rf.featureGate(typeName, fieldName, fieldResolver.FeatureGate, resolver)
+}
+
+// sizes of the data returned by resolvers, by field
+var responseSizes = metrics.NewHistogram("sqoop_resolver_response_bytes", metrics.SizeBuckets)
+
+func recordSize(field string, resolver exec.RawResolver) exec.RawResolver {
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		data, err := resolver(ctx, params)
+		if err == nil {
+			responseSizes.Observe(field, int64(len(data)))
+		}
+		return data, err
+	}
+}
+
+// recordTiming reports the time spent calling the upstream of a field, for operations which collect timings
+func recordTiming(resolver exec.RawResolver) exec.RawResolver {
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		start := time.Now()
+		data, err := resolver(ctx, params)
+		exec.RecordUpstreamTiming(ctx, time.Since(start))
+		return data, err
+	}
+}
+
+func (rf *ResolverFactory) createResolver(typeName, fieldName, routePath string, fieldResolver *v1.Resolver) (exec.RawResolver, error) {
+	resolver, err := rf.createResolverForType(typeName, fieldName, routePath, fieldResolver)
+	if err != nil || resolver == nil || len(fieldResolver.EnumArguments) == 0 {
+		return resolver, err
+	}
+	return rf.mapEnumArguments(typeName, fieldName, fieldResolver.EnumArguments, resolver)
+}
+
+func (rf *ResolverFactory) createResolverForType(typeName, fieldName, routePath string, fieldResolver *v1.Resolver) (exec.RawResolver, error) {
 	switch resolver := fieldResolver.Resolver.(type) {
+	case *v1.Resolver_MockResolver:
+		return mock.NewMockResolver(rf.schema, typeName, fieldName, resolver.MockResolver)
+	case *v1.Resolver_SignedUrlResolver:
+		return signedurl.NewSignedURLResolver(resolver.SignedUrlResolver, rf.opts.Secrets)
 	case *v1.Resolver_NodejsResolver:
 		return node.NewNodeResolver(resolver.NodejsResolver)
 	case *v1.Resolver_TemplateResolver:
 		return template.NewTemplateResolver(resolver.TemplateResolver)
+	case *v1.Resolver_VariablesResolver:
+		return variables.NewVariablesResolver(resolver.VariablesResolver)
+	case *v1.Resolver_HttpResolver:
+		return httpresolver.NewHTTPResolver(resolver.HttpResolver, rf.opts.Egress)
 	case *v1.Resolver_GlooResolver:
-		return rf.glooResolverFactory.CreateResolver(typeName, fieldName, resolver.GlooResolver)
+		return rf.glooResolverFactory.CreateResolverForRoute(routePath, resolver.GlooResolver)
+	case *v1.Resolver_ConditionalResolver:
+		return nil, errors.Errorf("conditional resolvers cannot be nested")
+	case *v1.Resolver_MergeResolver:
+		return nil, errors.Errorf("merge resolvers cannot be nested")
 	}
 	// no resolver has been defined
 	return nil, nil
@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+)
+
+// FlagProvider decides whether feature flags are enabled.
+// It is consulted every time a gated field is resolved, so providers backed by a remote flag service should cache
+type FlagProvider interface {
+	Enabled(ctx context.Context, flag string) (bool, error)
+}
+
+// StaticFlags enables a fixed set of flags
+type StaticFlags map[string]bool
+
+func NewStaticFlags(enabled []string) StaticFlags {
+	flags := make(StaticFlags)
+	for _, flag := range enabled {
+		flags[flag] = true
+	}
+	return flags
+}
+
+func (f StaticFlags) Enabled(_ context.Context, flag string) (bool, error) {
+	return f[flag], nil
+}
+
+// EnvFlags reads flags from environment variables named by the prefix followed by the flag in upper case,
+// with dashes replaced by underscores, e.g. SQOOP_FEATURE_NEW_SEARCH for the flag new-search.
+// Unset variables disable the flag
+type EnvFlags struct {
+	Prefix string
+}
+
+func (f EnvFlags) Enabled(_ context.Context, flag string) (bool, error) {
+	name := f.Prefix + strings.ToUpper(strings.Replace(flag, "-", "_", -1))
+	val, ok := os.LookupEnv(name)
+	if !ok || val == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid value for feature flag %v", name)
+	}
+	return enabled, nil
+}
+
+// only call the resolver while the gate's flag is enabled. without a provider every flag is disabled
+func (rf *ResolverFactory) featureGate(typeName, fieldName string, gate *v1.FeatureGate, resolver exec.RawResolver) (exec.RawResolver, error) {
+	if gate.Flag == "" {
+		return nil, errors.Errorf("the feature gate of %v.%v must name a flag", typeName, fieldName)
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		var enabled bool
+		if rf.opts.Flags != nil {
+			var err error
+			enabled, err = rf.opts.Flags.Enabled(ctx, gate.Flag)
+			if err != nil {
+				return nil, errors.Wrapf(err, "evaluating feature flag %v", gate.Flag)
+			}
+		}
+		if enabled {
+			return resolver(ctx, params)
+		}
+		if gate.ErrorWhenDisabled {
+			return nil, exec.ValidationError(errors.Errorf("%v.%v is not available", typeName, fieldName))
+		}
+		return nil, nil
+	}, nil
+}
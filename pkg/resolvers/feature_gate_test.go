@@ -0,0 +1,51 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("FeatureGate", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	search: String
+}
+schema {
+	query: Query
+}
+`)
+	resolve := func(gate *v1.FeatureGate, flags FlagProvider) ([]byte, error) {
+		resolver := templateResolver(`found`)
+		resolver.FeatureGate = gate
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "gated",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"search": resolver}},
+			},
+		}, Options{Flags: flags})
+		raw, err := rf.CreateResolver("Query", "search")
+		Expect(err).NotTo(HaveOccurred())
+		return raw(context.Background(), exec.Params{})
+	}
+	It("calls the resolver while the flag is enabled", func() {
+		b, err := resolve(&v1.FeatureGate{Flag: "new-search"}, NewStaticFlags([]string{"new-search"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("found"))
+	})
+	It("resolves to null while the flag is disabled", func() {
+		b, err := resolve(&v1.FeatureGate{Flag: "new-search"}, NewStaticFlags(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(BeNil())
+	})
+	It("errors while the flag is disabled if configured to", func() {
+		_, err := resolve(&v1.FeatureGate{Flag: "new-search", ErrorWhenDisabled: true}, nil)
+		Expect(err).To(MatchError("Query.search is not available"))
+		Expect(exec.CategoryOf(err)).To(Equal(exec.ErrorCategoryValidation))
+	})
+})
@@ -0,0 +1,127 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+const defaultMaxPages = 10
+
+// call the resolver once for each page of a paginated upstream, passing the next page token of each page
+// as an argument when requesting the next, and return the items of all pages as a single list.
+// pagination stops at the last page, at the page or item limit, or when the operation's deadline passes
+func (rf *ResolverFactory) followPages(typeName, fieldName string, follow *v1.FollowPages, resolver exec.RawResolver) (exec.RawResolver, error) {
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil, errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil, errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	if _, ok := unwrapNonNull(field.Type).(*common.List); !ok {
+		return nil, errors.Errorf("followPages is set on %v.%v, which is not a list", typeName, fieldName)
+	}
+	if follow.ItemsField == "" || follow.NextField == "" || follow.NextArgument == "" {
+		return nil, errors.Errorf("followPages of %v.%v must specify the items field, next field and next argument", typeName, fieldName)
+	}
+	itemsPath := strings.Split(follow.ItemsField, ".")
+	nextPath := strings.Split(follow.NextField, ".")
+	maxPages := int(follow.MaxPages)
+	if maxPages == 0 {
+		maxPages = defaultMaxPages
+	}
+	maxItems := int(follow.MaxItems)
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		// a single entity tag can't revalidate several pages
+		ctx = exec.WithoutRevalidation(ctx)
+		items := []interface{}{}
+		for page := 1; page <= maxPages; page++ {
+			if err := ctx.Err(); err != nil {
+				return nil, errors.Wrapf(err, "requesting page %v", page)
+			}
+			data, err := resolver(ctx, params)
+			if err != nil {
+				return nil, errors.Wrapf(err, "requesting page %v", page)
+			}
+			trimmed := bytes.TrimSpace(data)
+			if page == 1 && (len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))) {
+				return data, nil
+			}
+			var decoded interface{}
+			decoder := json.NewDecoder(bytes.NewReader(trimmed))
+			// keep large numbers intact
+			decoder.UseNumber()
+			if err := decoder.Decode(&decoded); err != nil {
+				return nil, exec.UpstreamError(errors.Wrapf(err, "decoding page %v", page))
+			}
+			switch pageItems := lookupField(decoded, itemsPath).(type) {
+			case nil:
+			case []interface{}:
+				items = append(items, pageItems...)
+			default:
+				return nil, exec.UpstreamError(errors.Errorf("%v of page %v is not a list", follow.ItemsField, page))
+			}
+			if maxItems > 0 && len(items) >= maxItems {
+				items = items[:maxItems]
+				break
+			}
+			next := scalarString(lookupField(decoded, nextPath))
+			if next == "" {
+				break
+			}
+			// the arguments are shared with the caller
+			args := make(map[string]interface{}, len(params.Args)+1)
+			for name, val := range params.Args {
+				args[name] = val
+			}
+			args[follow.NextArgument] = next
+			params.Args = args
+		}
+		return json.Marshal(items)
+	}, nil
+}
+
+// lookupField returns the value at a path of fields in a decoded json object, or nil if there is none.
+// numbers in the path index into lists
+func lookupField(val interface{}, path []string) interface{} {
+	for _, name := range path {
+		switch container := val.(type) {
+		case map[string]interface{}:
+			val = container[name]
+		case []interface{}:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(container) {
+				return nil
+			}
+			val = container[i]
+		default:
+			return nil
+		}
+	}
+	return val
+}
+
+// scalarString formats a decoded json scalar, e.g. a page token. tokens and links are usually strings,
+// but some upstreams number their pages
+func scalarString(val interface{}) string {
+	switch val := val.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(val)
+}
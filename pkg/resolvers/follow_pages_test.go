@@ -0,0 +1,53 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("FollowPages", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	tags: [String]
+}
+schema {
+	query: Query
+}
+`)
+	// three pages of two items, linked by page number
+	pages := `{{ $page := or .Args.page "1" }}` +
+		`{"data": {"items": ["{{ $page }}a", "{{ $page }}b"]}, "meta": {"next": {{ if eq $page "1" }}2{{ else if eq $page "2" }}3{{ else }}null{{ end }}}}}`
+	resolve := func(follow *v1.FollowPages) (string, error) {
+		resolver := templateResolver(pages)
+		resolver.FollowPages = follow
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "pages",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"tags": resolver}},
+			},
+		}, Options{})
+		raw, err := rf.CreateResolver("Query", "tags")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := raw(context.Background(), exec.Params{})
+		return string(b), err
+	}
+	It("concatenates the items of every page", func() {
+		b, err := resolve(&v1.FollowPages{ItemsField: "data.items", NextField: "meta.next", NextArgument: "page"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`["1a","1b","2a","2b","3a","3b"]`))
+	})
+	It("stops at the page and item limits", func() {
+		b, err := resolve(&v1.FollowPages{ItemsField: "data.items", NextField: "meta.next", NextArgument: "page", MaxPages: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`["1a","1b","2a","2b"]`))
+		b, err = resolve(&v1.FollowPages{ItemsField: "data.items", NextField: "meta.next", NextArgument: "page", MaxItems: 3})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`["1a","1b","2a"]`))
+	})
+})
@@ -2,29 +2,45 @@ package gloo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"io/ioutil"
 	"net/http"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/debuglog"
 	"github.com/solo-io/sqoop/pkg/exec"
 	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/solo-io/sqoop/pkg/secrets"
 	"github.com/solo-io/sqoop/pkg/util"
 )
 
+// resolvers give up this long after the timeout of their route, so the proxy's timeout response is returned
+const proxyTimeoutMargin = 250 * time.Millisecond
+
 type ResolverFactory struct {
 	proxyAddr string
+	secrets   *secrets.Store
 }
 
-func NewResolverFactory(proxyAddr string) *ResolverFactory {
+// secrets may be nil if no secret storage is configured, in which case
+// resolvers with secret headers cannot be created
+func NewResolverFactory(proxyAddr string, secrets *secrets.Store) *ResolverFactory {
 	return &ResolverFactory{
 		proxyAddr: proxyAddr,
+		secrets:   secrets,
 	}
 }
 
 func (rf *ResolverFactory) CreateResolver(typeName, fieldName string, glooResolver *v1.GlooResolver) (exec.RawResolver, error) {
+	return rf.CreateResolverForRoute(operator.RoutePath(typeName, fieldName), glooResolver)
+}
+
+// CreateResolverForRoute creates a resolver which invokes its function through the given route on the proxy
+func (rf *ResolverFactory) CreateResolverForRoute(routePath string, glooResolver *v1.GlooResolver) (exec.RawResolver, error) {
 	requestBodyTemplate := glooResolver.RequestTemplate
 	responseBodyTemplate := glooResolver.ResponseTemplate
 	contentType := glooResolver.ContentType
@@ -50,11 +66,27 @@ func (rf *ResolverFactory) CreateResolver(typeName, fieldName string, glooResolv
 		}
 	}
 
-	return rf.newResolver(typeName, fieldName, contentType, requestTemplate, responseTemplate), nil
+	// fail on config load rather than on the first query if a secret is missing
+	for _, header := range glooResolver.SecretHeaders {
+		if rf.secrets == nil {
+			return nil, errors.Errorf("header %v references a secret but no secret storage is configured", header.Name)
+		}
+		if _, err := rf.secrets.Value(header.SecretRef); err != nil {
+			return nil, errors.Wrapf(err, "resolving secret for header %v", header.Name)
+		}
+	}
+
+	timeout := time.Duration(glooResolver.TimeoutMs) * time.Millisecond
+	return rf.newResolver(routePath, contentType, requestTemplate, responseTemplate, glooResolver.SecretHeaders, glooResolver.ForwardCookies, glooResolver.ClaimHeaders, timeout), nil
 }
 
-func (rf *ResolverFactory) newResolver(typeName, fieldName string, contentType string, requestTemplate, responseTemplate *template.Template) exec.RawResolver {
-	return func(params exec.Params) ([]byte, error) {
+func (rf *ResolverFactory) newResolver(routePath string, contentType string, requestTemplate, responseTemplate *template.Template, secretHeaders []*v1.SecretHeader, forwardCookies []string, claimHeaders map[string]string, timeout time.Duration) exec.RawResolver {
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout+proxyTimeoutMargin)
+			defer cancel()
+		}
 		body := &bytes.Buffer{}
 
 		switch {
@@ -64,6 +96,9 @@ func (rf *ResolverFactory) newResolver(typeName, fieldName string, contentType s
 				// TODO: sanitize
 				return nil, errors.Wrapf(err, "executing request template for params %v", params)
 			}
+			if err := validateJSONBody(contentType, buf.Bytes()); err != nil {
+				return nil, err
+			}
 			body = buf
 		case len(params.Args) > 0:
 			if err := json.NewEncoder(body).Encode(params.Args); err != nil {
@@ -71,20 +106,58 @@ func (rf *ResolverFactory) newResolver(typeName, fieldName string, contentType s
 			}
 		}
 
-		url := "http://" + rf.proxyAddr + operator.RoutePath(typeName, fieldName)
-		res, err := http.Post(url, contentType, body)
+		if err := exec.CheckRequestBody(ctx, body.Len()); err != nil {
+			return nil, err
+		}
+
+		url := "http://" + rf.proxyAddr + routePath
+		exec.TraceUpstream(ctx, url)
+		req, err := http.NewRequest(routeMethod, url, body)
 		if err != nil {
-			return nil, errors.Wrap(err, "performing http post")
+			return nil, errors.Wrap(err, "creating http request")
+		}
+		req.Header.Set("Content-Type", contentType)
+		if requestID := util.RequestID(ctx); requestID != "" {
+			req.Header.Set(util.RequestIDHeader, requestID)
+		}
+		exec.ForwardCookies(req, params, forwardCookies)
+		if err := exec.SetClaimHeaders(req, params, claimHeaders); err != nil {
+			return nil, err
 		}
+		exec.RevalidateRequest(ctx, req)
+		// claim headers identify the client, they are not logged either
+		secretHeaderNames := make([]string, len(secretHeaders), len(secretHeaders)+len(claimHeaders))
+		for name := range claimHeaders {
+			secretHeaderNames = append(secretHeaderNames, name)
+		}
+		for i, header := range secretHeaders {
+			// read on every request to pick up rotated secrets
+			value, err := rf.secrets.Value(header.SecretRef)
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving secret for header %v", header.Name)
+			}
+			req.Header.Set(header.Name, header.Prefix+value)
+			secretHeaderNames[i] = header.Name
+		}
+		debuglog.DumpRequest(ctx, req, body.Bytes(), secretHeaderNames...)
+		res, err := exec.DoUpstream(ctx, httpClient, req, body.Bytes())
+		if err != nil {
+			return nil, exec.UpstreamError(errors.Wrap(err, "performing http post"))
+		}
+		exec.RecordUpstreamStatus(ctx, res.StatusCode)
 
 		defer res.Body.Close()
-		data, err := ioutil.ReadAll(res.Body)
+		if exec.RecordRevalidation(ctx, res) {
+			return nil, nil
+		}
+		data, err := exec.ReadResponseBody(ctx, res.Body)
 		if err != nil {
-			return nil, errors.Wrap(err, "reading response body")
+			return nil, exec.UpstreamError(errors.Wrap(err, "reading response body"))
 		}
+		debuglog.DumpResponse(ctx, res, data)
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
-			return nil, errors.Errorf("unexpected status code: %v (%s)", res.StatusCode, data)
+			return nil, exec.UpstreamStatusError(errors.Errorf("unexpected status code: %v (%s)", res.StatusCode, data), res.StatusCode)
 		}
 		// empty response
 		if len(data) == 0 {
@@ -99,8 +172,8 @@ func (rf *ResolverFactory) newResolver(typeName, fieldName string, contentType s
 		// requires output to be json object
 		var result map[string]interface{}
 		if err := json.Unmarshal(data, &result); err != nil {
-			return nil, errors.Wrap(err, "failed to parse response as json object. "+
-				"response templates may only be used with JSON responses")
+			return nil, exec.UpstreamError(errors.Wrap(err, "failed to parse response as json object. "+
+				"response templates may only be used with JSON responses"))
 		}
 		input := struct {
 			Result map[string]interface{}
@@ -114,3 +187,16 @@ func (rf *ResolverFactory) newResolver(typeName, fieldName string, contentType s
 		return buf.Bytes(), nil
 	}
 }
+
+// validateJSONBody returns an error if a request template rendered a body which is not well formed json
+// for a function which expects json, rather than sending the function a body it would reject
+func validateJSONBody(contentType string, body []byte) error {
+	if !strings.Contains(contentType, "json") || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return errors.Wrap(err, "request template rendered invalid json")
+	}
+	return nil
+}
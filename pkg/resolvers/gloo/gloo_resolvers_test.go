@@ -5,14 +5,18 @@ import (
 	. "github.com/onsi/gomega"
 
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	. "github.com/solo-io/sqoop/pkg/resolvers/gloo"
+	"github.com/solo-io/sqoop/pkg/testutil"
+	"github.com/solo-io/sqoop/pkg/util"
 	"github.com/solo-io/sqoop/test"
 )
 
@@ -23,18 +27,20 @@ var _ = Describe("GlooResolvers", func() {
 		response        = []byte(`{"have":"a","nice":"day","okay":"?"}`)
 		resolverFactory *ResolverFactory
 		requestBody     *bytes.Buffer
+		requestHeaders  http.Header
 	)
 	BeforeEach(func() {
 		requestBody = &bytes.Buffer{}
 		m := mux.NewRouter()
 		m.HandleFunc("/mytype.myfield", func(w http.ResponseWriter, r *http.Request) {
 			io.Copy(requestBody, r.Body)
+			requestHeaders = r.Header
 			w.Write(response)
 		})
 		server = httptest.NewServer(m)
 		mockProxyAddr = strings.TrimPrefix(server.URL, "http://")
 
-		resolverFactory = NewResolverFactory(mockProxyAddr)
+		resolverFactory = NewResolverFactory(mockProxyAddr, nil)
 	})
 	AfterEach(func() {
 		server.Close()
@@ -43,27 +49,68 @@ var _ = Describe("GlooResolvers", func() {
 		typeName := "mytype"
 		fieldName := "myfield"
 		gResolver := &v1.GlooResolver{
-			RequestTemplate:  `REQUEST: best scene: {{ marshal (index .Args "best_scene") }} friendIds: {{ marshal (index .Parent "CharacterFields") }}`,
+			RequestTemplate:  `{"best_scene":{{ marshal (index .Args "best_scene") }},"friends":{{ marshal (index .Parent "CharacterFields") }}}`,
 			ResponseTemplate: `RESPONSE: {{ marshal (index .Result "nice") }}`,
 		}
 		Context("it returns a resolver which ", func() {
 			It("renders the template as the request body", func() {
 				rawResolver, err := resolverFactory.CreateResolver(typeName, fieldName, gResolver)
 				Expect(err).NotTo(HaveOccurred())
-				_, err = rawResolver(test.LukeSkywalkerParams)
+				_, err = rawResolver(context.Background(), test.LukeSkywalkerParams)
 				Expect(err).NotTo(HaveOccurred())
 				str := requestBody.String()
-				Expect(str).To(Equal(`REQUEST: best scene: "cloud city" friendIds: ` +
+				Expect(str).To(Equal(`{"best_scene":"cloud city","friends":` +
 					`{"AppearsIn":["NEWHOPE","EMPIRE","JEDI"],"FriendIds":["1002","1003","2000","2001"],` +
-					`"ID":"1000","Name":"Luke Skywalker","TypeName":"Human"}`))
+					`"ID":"1000","Name":"Luke Skywalker","TypeName":"Human"}}`))
+			})
+			It("forwards the request id", func() {
+				rawResolver, err := resolverFactory.CreateResolver(typeName, fieldName, gResolver)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = rawResolver(util.WithRequestID(context.Background(), "my-request"), test.LukeSkywalkerParams)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requestHeaders.Get(util.RequestIDHeader)).To(Equal("my-request"))
 			})
 			It("renders the result template on the json response body", func() {
 				rawResolver, err := resolverFactory.CreateResolver(typeName, fieldName, gResolver)
 				Expect(err).NotTo(HaveOccurred())
-				b, err := rawResolver(test.LukeSkywalkerParams)
+				b, err := rawResolver(context.Background(), test.LukeSkywalkerParams)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(b).To(Equal([]byte(`RESPONSE: "day"`)))
 			})
 		})
 	})
+	It("gives up shortly after the timeout of its route", func() {
+		upstream := testutil.NewMockUpstream()
+		defer upstream.Close()
+		upstream.Handle("/Query.hero", testutil.MockResponse{Body: `{"name":"Luke"}`, Latency: 5 * time.Second})
+		rawResolver, err := NewResolverFactory(upstream.Addr(), nil).CreateResolver("Query", "hero", &v1.GlooResolver{
+			Function:  &v1.GlooResolver_Upstream{Upstream: "starwars-rest"},
+			TimeoutMs: 100,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Now()
+		_, err = rawResolver(context.Background(), test.LukeSkywalkerParams)
+		Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(upstream.Requests("/Query.hero")).To(HaveLen(1))
+	})
+	Context("warming up connections", func() {
+		It("sends an empty request with the method of the route to each route", func() {
+			upstream := testutil.NewMockUpstream()
+			defer upstream.Close()
+			upstream.Handle("/mytype.myfield", testutil.MockResponse{}).Handle("/mytype.otherfield", testutil.MockResponse{})
+			err := WarmUp(upstream.Addr(), []string{"/mytype.myfield", "/mytype.otherfield"}, 2, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			for _, path := range []string{"/mytype.myfield", "/mytype.otherfield"} {
+				requests := upstream.Requests(path)
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].Method).To(Equal("POST"))
+				Expect(requests[0].Body).To(BeEmpty())
+			}
+		})
+		It("returns an error for unreachable proxies", func() {
+			err := WarmUp("no-address-defined", []string{"/mytype.myfield"}, 2, time.Second)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -0,0 +1,95 @@
+package gloo
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// all resolvers call functions through the same proxy, so the number
+// of idle connections kept per host must be large enough to keep a warm pool
+const maxIdleConnsPerHost = 64
+
+// the method resolvers call their routes with, and the only one the routes accept
+const routeMethod = "POST"
+
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	},
+}
+
+// WarmUp pre-establishes connections to the proxy for the given routes so the first
+// queries after a reload don't pay for connection setup. At most concurrency connections are
+// opened at once, and any warm-up still in progress after timeout is abandoned.
+// Each route is sent an empty request with the method resolvers call it with, so the function behind the route
+// is invoked without arguments
+func WarmUp(proxyAddr string, routePaths []string, concurrency int, timeout time.Duration) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > maxIdleConnsPerHost {
+		concurrency = maxIdleConnsPerHost
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	paths := make(chan string)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				if err := warmUpRoute(ctx, "http://"+proxyAddr+path); err != nil {
+					mu.Lock()
+					errs = multierror.Append(errs, errors.Wrapf(err, "warming up route %v", path))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, path := range routePaths {
+		select {
+		case paths <- path:
+		case <-ctx.Done():
+		}
+	}
+	close(paths)
+	wg.Wait()
+	return errs
+}
+
+func warmUpRoute(ctx context.Context, url string) error {
+	req, err := http.NewRequest(routeMethod, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// drain the body so the connection is returned to the pool
+	io.Copy(ioutil.Discard, res.Body)
+	return res.Body.Close()
+}
@@ -0,0 +1,116 @@
+package httpresolver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/debuglog"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
+	"github.com/solo-io/sqoop/pkg/util"
+)
+
+// the policy restricts the urls the resolver may call. a nil policy
+// only allows the resolver's hosts, and no private addresses
+func NewHTTPResolver(resolver *v1.HttpResolver, policy *egress.Policy) (exec.RawResolver, error) {
+	allowedHosts := resolver.AllowedHosts
+	var baseURL string
+	if resolver.BaseUrl != "" {
+		base, err := url.Parse(resolver.BaseUrl)
+		if err != nil || !base.IsAbs() {
+			return nil, errors.Errorf("base url %v must be an absolute url", resolver.BaseUrl)
+		}
+		baseURL = strings.TrimSuffix(resolver.BaseUrl, "/")
+		allowedHosts = append([]string{base.Hostname()}, allowedHosts...)
+	}
+	if len(allowedHosts) == 0 {
+		return nil, errors.Errorf("http resolvers must specify at least one allowed host or a base url")
+	}
+	urlTemplate, err := util.Template(resolver.UrlTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing url template")
+	}
+	if policy == nil {
+		policy = &egress.Policy{}
+	}
+	timeout := time.Duration(resolver.TimeoutMs) * time.Millisecond
+	// configured headers may carry api keys, and claim headers identify the client. neither is logged
+	var redactHeaders []string
+	for name := range resolver.Headers {
+		redactHeaders = append(redactHeaders, name)
+	}
+	for name := range resolver.ClaimHeaders {
+		redactHeaders = append(redactHeaders, name)
+	}
+	allowed := egress.NewHostList(allowedHosts)
+	client := policy.Client(allowed)
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		buf, err := util.ExecTemplate(urlTemplate, params)
+		if err != nil {
+			return nil, errors.Wrap(err, "executing url template")
+		}
+		rendered := strings.TrimSpace(buf.String())
+		u, err := url.Parse(rendered)
+		if err != nil {
+			return nil, errors.Wrap(err, "url template rendered an invalid url")
+		}
+		if !u.IsAbs() && baseURL != "" {
+			u, err = url.Parse(baseURL + "/" + strings.TrimPrefix(rendered, "/"))
+			if err != nil {
+				return nil, errors.Wrap(err, "url template rendered an invalid url")
+			}
+		}
+		if err := policy.Check(u, allowed); err != nil {
+			return nil, err
+		}
+		exec.TraceUpstream(ctx, u.String())
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating http request")
+		}
+		for name, value := range resolver.Headers {
+			req.Header.Set(name, value)
+		}
+		if requestID := util.RequestID(ctx); requestID != "" {
+			req.Header.Set(util.RequestIDHeader, requestID)
+		}
+		exec.ForwardCookies(req, params, resolver.ForwardCookies)
+		if err := exec.SetClaimHeaders(req, params, resolver.ClaimHeaders); err != nil {
+			return nil, err
+		}
+		exec.RevalidateRequest(ctx, req)
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		debuglog.DumpRequest(ctx, req, nil, redactHeaders...)
+		res, err := exec.DoUpstream(ctx, client, req, nil)
+		if err != nil {
+			return nil, exec.UpstreamError(errors.Wrap(err, "performing http get"))
+		}
+		exec.RecordUpstreamStatus(ctx, res.StatusCode)
+		defer res.Body.Close()
+		if exec.RecordRevalidation(ctx, res) {
+			return nil, nil
+		}
+		data, err := exec.ReadResponseBody(ctx, res.Body)
+		if err != nil {
+			return nil, exec.UpstreamError(errors.Wrap(err, "reading response body"))
+		}
+		debuglog.DumpResponse(ctx, res, data)
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, exec.UpstreamStatusError(errors.Errorf("unexpected status code: %v (%s)", res.StatusCode, data), res.StatusCode)
+		}
+		// empty response
+		if len(data) == 0 {
+			return nil, nil
+		}
+		return data, nil
+	}, nil
+}
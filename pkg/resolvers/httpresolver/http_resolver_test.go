@@ -0,0 +1,132 @@
+package httpresolver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/resolvers/egress"
+	. "github.com/solo-io/sqoop/pkg/resolvers/httpresolver"
+)
+
+var _ = Describe("HttpResolver", func() {
+	var (
+		server *httptest.Server
+		policy *egress.Policy
+	)
+	BeforeEach(func() {
+		// the test server listens on a loopback address
+		policy = &egress.Policy{AllowPrivateNetworks: true}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/reviews":
+				w.Write([]byte(`[{"stars":5}]`))
+			case "/session":
+				cookies := ""
+				for _, cookie := range r.Cookies() {
+					cookies += cookie.String() + ";"
+				}
+				w.Write([]byte(cookies))
+			case "/identity":
+				w.Write([]byte(r.Header.Get("X-User-Id") + "/" + r.Header.Get("X-Roles") + "/" + r.Header.Get("X-Org")))
+			case "/redirect":
+				http.Redirect(w, r, "http://metadata.internal/", http.StatusFound)
+			}
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	resolve := func(allowedHost, url string) ([]byte, error) {
+		resolver, err := NewHTTPResolver(&v1.HttpResolver{
+			UrlTemplate:  "{{ .Args.url }}",
+			AllowedHosts: []string{allowedHost},
+		}, policy)
+		Expect(err).NotTo(HaveOccurred())
+		return resolver(context.Background(), exec.Params{Args: map[string]interface{}{"url": url}})
+	}
+	It("fetches the rendered url", func() {
+		b, err := resolve("127.0.0.1", server.URL+"/reviews")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`[{"stars":5}]`))
+	})
+	It("rejects hosts which are not allowed", func() {
+		_, err := resolve("*.example.com", server.URL+"/reviews")
+		Expect(err).To(MatchError("host 127.0.0.1 is not allowed"))
+		_, err = resolve("127.0.0.1", "file:///etc/passwd")
+		Expect(err).To(MatchError("url file:///etc/passwd uses a scheme which is not allowed"))
+	})
+	It("applies the allowed hosts of the policy in addition to those of the resolver", func() {
+		policy.AllowedHosts = egress.NewHostList([]string{"*.example.com"})
+		_, err := resolve("127.0.0.1", server.URL+"/reviews")
+		Expect(err).To(MatchError("host 127.0.0.1 is not allowed"))
+	})
+	It("refuses to connect to private addresses unless the policy allows them", func() {
+		policy = &egress.Policy{}
+		_, err := resolve("127.0.0.1", server.URL+"/reviews")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("address 127.0.0.1 is in private network 127.0.0.0/8"))
+	})
+	It("does not follow redirects to hosts which are not allowed", func() {
+		_, err := resolve("127.0.0.1", server.URL+"/redirect")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("host metadata.internal is not allowed"))
+	})
+	It("resolves relative urls against the base url and allows its host", func() {
+		resolver, err := NewHTTPResolver(&v1.HttpResolver{
+			UrlTemplate: "/{{ .Args.path }}",
+			BaseUrl:     server.URL,
+			TimeoutMs:   1000,
+		}, policy)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{Args: map[string]interface{}{"path": "reviews"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`[{"stars":5}]`))
+	})
+	It("forwards the configured cookies", func() {
+		resolver, err := NewHTTPResolver(&v1.HttpResolver{
+			UrlTemplate:    server.URL + "/session",
+			AllowedHosts:   []string{"127.0.0.1"},
+			ForwardCookies: []string{"session", "missing"},
+		}, policy)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{Cookies: map[string]string{"session": "abc", "theme": "dark"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("session=abc;"))
+	})
+	It("sets headers to the claims of authenticated requests", func() {
+		resolver, err := NewHTTPResolver(&v1.HttpResolver{
+			UrlTemplate:  server.URL + "/identity",
+			AllowedHosts: []string{"127.0.0.1"},
+			ClaimHeaders: map[string]string{"X-User-Id": "sub", "X-Roles": "roles", "X-Org": "org.id"},
+		}, policy)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{Claims: map[string]interface{}{
+			"sub":   "luke",
+			"roles": []interface{}{"jedi"},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`luke/["jedi"]/`))
+		_, err = resolver(context.Background(), exec.Params{})
+		Expect(err).To(MatchError(ContainSubstring("the request was not authenticated")))
+	})
+	It("fails responses larger than the body limit of the context", func() {
+		resolver, err := NewHTTPResolver(&v1.HttpResolver{
+			UrlTemplate:  server.URL + "/reviews",
+			AllowedHosts: []string{"127.0.0.1"},
+		}, policy)
+		Expect(err).NotTo(HaveOccurred())
+		ctx := exec.WithBodyLimits(context.Background(), exec.BodyLimits{MaxResponseBytes: 8})
+		_, err = resolver(ctx, exec.Params{})
+		Expect(err).To(MatchError(ContainSubstring("response body is larger than the limit of 8 bytes")))
+		Expect(exec.CategoryOf(err)).To(Equal(exec.ErrorCategoryUpstream))
+		ctx = exec.WithBodyLimits(context.Background(), exec.BodyLimits{MaxResponseBytes: 13})
+		b, err := resolver(ctx, exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`[{"stars":5}]`))
+	})
+})
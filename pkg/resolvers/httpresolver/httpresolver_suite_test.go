@@ -0,0 +1,13 @@
+package httpresolver_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPResolver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTP Resolver Suite")
+}
@@ -0,0 +1,186 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/operator"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+type mergeSource struct {
+	resolver exec.RawResolver
+	keys     []string
+	optional bool
+}
+
+func (rf *ResolverFactory) createMergeResolver(typeName, fieldName string, merge *v1.MergeResolver) (exec.RawResolver, error) {
+	if err := rf.checkObjectField(typeName, fieldName); err != nil {
+		return nil, err
+	}
+	if len(merge.Sources) == 0 {
+		return nil, errors.Errorf("merge resolver for %v.%v must specify at least one source", typeName, fieldName)
+	}
+	owners := make(map[string]int)
+	var sources []mergeSource
+	for i, source := range merge.Sources {
+		if source.Resolver == nil {
+			return nil, errors.Errorf("source %v of merge resolver must specify a resolver", i)
+		}
+		for _, key := range source.Keys {
+			if owner, ok := owners[key]; ok {
+				return nil, errors.Errorf("key %v is owned by sources %v and %v of merge resolver", key, owner, i)
+			}
+			owners[key] = i
+		}
+		resolver, err := rf.createResolver(typeName, fieldName, rf.opts.RoutePrefix+operator.SourceRoutePath(typeName, fieldName, i), source.Resolver)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating resolver for source %v", i)
+		}
+		if resolver == nil {
+			return nil, errors.Errorf("no resolver defined for source %v of merge resolver", i)
+		}
+		sources = append(sources, mergeSource{resolver: resolver, keys: source.Keys, optional: source.Optional})
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		results, errs, err := callSources(ctx, sources, params)
+		if err != nil {
+			return nil, err
+		}
+		var merged map[string]interface{}
+		for i, source := range sources {
+			if errs[i] != nil {
+				exec.ReportError(ctx, errors.Wrapf(errs[i], "optional source %v of %v.%v failed", i, typeName, fieldName))
+				continue
+			}
+			obj, err := decodeObject(results[i])
+			if err != nil {
+				return nil, exec.UpstreamError(errors.Wrapf(err, "source %v", i))
+			}
+			if obj == nil {
+				continue
+			}
+			if len(source.keys) > 0 {
+				owned := make(map[string]interface{}, len(source.keys))
+				for _, key := range source.keys {
+					if val, ok := obj[key]; ok {
+						owned[key] = val
+					}
+				}
+				obj = owned
+			}
+			if merged == nil {
+				merged = make(map[string]interface{})
+			}
+			deepMerge(merged, obj)
+		}
+		if merged == nil {
+			return nil, nil
+		}
+		return json.Marshal(merged)
+	}, nil
+}
+
+// callSources calls every source concurrently. the first required source to fail cancels the others,
+// and its error is returned. otherwise the results and the errors of failed optional sources are returned by source
+func callSources(ctx context.Context, sources []mergeSource, params exec.Params) ([][]byte, []error, error) {
+	// a single entity tag can't revalidate several sources
+	ctx, cancel := context.WithCancel(exec.WithoutRevalidation(ctx))
+	defer cancel()
+	var (
+		wg          sync.WaitGroup
+		results     = make([][]byte, len(sources))
+		errs        = make([]error, len(sources))
+		mu          sync.Mutex
+		requiredErr error
+		panicked    interface{}
+	)
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source mergeSource) {
+			defer wg.Done()
+			defer func() {
+				// panics are raised again on the goroutine resolving the field
+				if r := recover(); r != nil {
+					mu.Lock()
+					if panicked == nil {
+						panicked = r
+					}
+					mu.Unlock()
+					cancel()
+				}
+			}()
+			results[i], errs[i] = source.resolver(ctx, params)
+			if errs[i] != nil && !source.optional {
+				mu.Lock()
+				if requiredErr == nil {
+					// the others fail with the cancellation
+					requiredErr = errors.Wrapf(errs[i], "source %v", i)
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}(i, source)
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+	if requiredErr != nil {
+		return nil, nil, requiredErr
+	}
+	return results, errs, nil
+}
+
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	var obj map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep large numbers intact
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.Wrap(err, "expected a json object")
+	}
+	return obj, nil
+}
+
+// deepMerge merges src into dst. objects present in both are merged, any other value of src replaces that of dst
+func deepMerge(dst, src map[string]interface{}) {
+	for key, val := range src {
+		srcObj, srcIsObj := val.(map[string]interface{})
+		dstObj, dstIsObj := dst[key].(map[string]interface{})
+		if srcIsObj && dstIsObj {
+			deepMerge(dstObj, srcObj)
+			continue
+		}
+		dst[key] = val
+	}
+}
+
+// merged values must be objects
+func (rf *ResolverFactory) checkObjectField(typeName, fieldName string) error {
+	if rf.schema == nil {
+		return nil
+	}
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	switch unwrapNonNull(field.Type).(type) {
+	case *schema.Object, *schema.Interface:
+		return nil
+	}
+	return errors.Errorf("merge resolver is set on %v.%v, which is not an object", typeName, fieldName)
+}
@@ -0,0 +1,85 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("MergeResolver", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	user: User
+}
+type User {
+	name: String
+	email: String
+	prefs: Prefs
+}
+type Prefs {
+	theme: String
+	lang: String
+}
+schema {
+	query: Query
+}
+`)
+	profile := `{"name": "luke", "email": "old@example.com", "prefs": {"theme": "dark"}}`
+	preferences := `{"email": "new@example.com", "prefs": {"lang": "en"}}`
+	failing := `{{ index .Args.missing "x" }}`
+	resolve := func(sources ...*v1.MergeSource) (string, error) {
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "merge",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"user": {
+					Resolver: &v1.Resolver_MergeResolver{MergeResolver: &v1.MergeResolver{Sources: sources}},
+				}}},
+			},
+		}, Options{})
+		raw, err := rf.CreateResolver("Query", "user")
+		if err != nil {
+			return "", err
+		}
+		b, err := raw(context.Background(), exec.Params{})
+		return string(b), err
+	}
+	It("deep-merges the results of its sources, later sources winning", func() {
+		b, err := resolve(
+			&v1.MergeSource{Resolver: templateResolver(profile)},
+			&v1.MergeSource{Resolver: templateResolver(preferences)},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"name": "luke", "email": "new@example.com", "prefs": {"theme": "dark", "lang": "en"}}`))
+	})
+	It("only takes the keys a source owns", func() {
+		b, err := resolve(
+			&v1.MergeSource{Resolver: templateResolver(profile), Keys: []string{"name", "email"}},
+			&v1.MergeSource{Resolver: templateResolver(preferences), Keys: []string{"prefs"}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"name": "luke", "email": "old@example.com", "prefs": {"lang": "en"}}`))
+		_, err = resolve(
+			&v1.MergeSource{Resolver: templateResolver(profile), Keys: []string{"email"}},
+			&v1.MergeSource{Resolver: templateResolver(preferences), Keys: []string{"email"}},
+		)
+		Expect(err).To(MatchError(ContainSubstring("key email is owned by sources 0 and 1")))
+	})
+	It("leaves out optional sources which fail, and fails with required ones", func() {
+		b, err := resolve(
+			&v1.MergeSource{Resolver: templateResolver(profile)},
+			&v1.MergeSource{Resolver: templateResolver(failing), Optional: true},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(profile))
+		_, err = resolve(
+			&v1.MergeSource{Resolver: templateResolver(profile)},
+			&v1.MergeSource{Resolver: templateResolver(failing)},
+		)
+		Expect(err).To(MatchError(ContainSubstring("source 1")))
+	})
+})
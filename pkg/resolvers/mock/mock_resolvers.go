@@ -0,0 +1,116 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+const (
+	defaultListLength = 3
+	// objects nested deeper than this are generated as null,
+	// which keeps generation finite for self-referencing types
+	maxDepth = 3
+)
+
+func NewMockResolver(sch *schema.Schema, typeName, fieldName string, resolver *v1.MockResolver) (exec.RawResolver, error) {
+	objectType, ok := sch.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil, errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil, errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	listLength := defaultListLength
+	if resolver != nil && resolver.ListLength > 0 {
+		listLength = int(resolver.ListLength)
+	}
+	g := &generator{listLength: listLength}
+	value := g.generate(field.Type, typeName+"."+fieldName, 0)
+	data, err := marshal(field.Type, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "generating mock data for %v.%v", typeName, fieldName)
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		return data, nil
+	}, nil
+}
+
+// scalars are returned by resolvers as raw strings, everything else as json
+func marshal(typ common.Type, value interface{}) ([]byte, error) {
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	if _, ok := typ.(*schema.Scalar); ok {
+		return []byte(fmt.Sprintf("%v", value)), nil
+	}
+	return json.Marshal(value)
+}
+
+type generator struct {
+	listLength int
+}
+
+func (g *generator) generate(typ common.Type, name string, depth int) interface{} {
+	switch typ := typ.(type) {
+	case *common.NonNull:
+		return g.generate(typ.OfType, name, depth)
+	case *common.List:
+		list := make([]interface{}, g.listLength)
+		for i := range list {
+			list[i] = g.generate(typ.OfType, fmt.Sprintf("%v[%v]", name, i), depth)
+		}
+		return list
+	case *schema.Object:
+		return g.generateObject(typ, depth)
+	case *schema.Interface:
+		if len(typ.PossibleTypes) == 0 {
+			return nil
+		}
+		return g.generateObject(typ.PossibleTypes[0], depth)
+	case *schema.Enum:
+		if len(typ.Values) == 0 {
+			return nil
+		}
+		return typ.Values[0].Name
+	case *schema.Scalar:
+		return generateScalar(typ, name)
+	}
+	// unions and other types are not supported by the executor
+	return nil
+}
+
+func (g *generator) generateObject(typ *schema.Object, depth int) interface{} {
+	if depth >= maxDepth {
+		return nil
+	}
+	obj := map[string]interface{}{
+		// required to resolve interfaces
+		"__typename": typ.Name,
+	}
+	for _, field := range typ.Fields {
+		obj[field.Name] = g.generate(field.Type, typ.Name+"."+field.Name, depth+1)
+	}
+	return obj
+}
+
+func generateScalar(scalar *schema.Scalar, name string) interface{} {
+	switch scalar.Name {
+	case "Int":
+		return 42
+	case "Float":
+		return 4.2
+	case "Boolean":
+		return true
+	case "ID":
+		return "mock-" + name
+	}
+	return "mock " + name
+}
@@ -0,0 +1,47 @@
+package mock_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"encoding/json"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers/mock"
+	"github.com/solo-io/sqoop/test"
+)
+
+var _ = Describe("MockResolvers", func() {
+	resolve := func(typeName, fieldName string, resolver *v1.MockResolver) []byte {
+		rawResolver, err := NewMockResolver(test.StarWarsSchema.Schema, typeName, fieldName, resolver)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := rawResolver(context.Background(), exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		return b
+	}
+	It("generates objects matching the schema type", func() {
+		var human map[string]interface{}
+		Expect(json.Unmarshal(resolve("Query", "human", nil), &human)).NotTo(HaveOccurred())
+		Expect(human["__typename"]).To(Equal("Human"))
+		Expect(human["name"]).To(BeAssignableToTypeOf(""))
+		Expect(human["height"]).To(BeAssignableToTypeOf(float64(0)))
+		Expect(human["appearsIn"]).To(HaveLen(3))
+		Expect(human["appearsIn"].([]interface{})[0]).To(Equal("NEWHOPE"))
+	})
+	It("generates lists of the configured length", func() {
+		var reviews []interface{}
+		Expect(json.Unmarshal(resolve("Query", "reviews", &v1.MockResolver{ListLength: 5}), &reviews)).NotTo(HaveOccurred())
+		Expect(reviews).To(HaveLen(5))
+	})
+	It("generates a concrete type for interfaces", func() {
+		var hero map[string]interface{}
+		Expect(json.Unmarshal(resolve("Query", "hero", nil), &hero)).NotTo(HaveOccurred())
+		Expect(hero["__typename"]).NotTo(BeEmpty())
+	})
+	It("returns scalars as raw values", func() {
+		Expect(string(resolve("Starship", "length", nil))).To(Equal("4.2"))
+		Expect(string(resolve("Human", "name", nil))).To(Equal("mock Human.name"))
+	})
+})
@@ -0,0 +1,43 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+var emptyList = []byte("[]")
+
+// replace null and empty results of a list field with an empty list
+func (rf *ResolverFactory) nullAsEmptyList(typeName, fieldName string, resolver exec.RawResolver) (exec.RawResolver, error) {
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil, errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil, errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	typ := field.Type
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	if _, ok := typ.(*common.List); !ok {
+		return nil, errors.Errorf("nullAsEmptyList is set on %v.%v, which is not a list", typeName, fieldName)
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		data, err := resolver(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+			return emptyList, nil
+		}
+		return data, nil
+	}, nil
+}
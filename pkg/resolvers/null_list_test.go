@@ -0,0 +1,45 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("NullAsEmptyList", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	tags: [String!]!
+	name: String
+}
+schema {
+	query: Query
+}
+`)
+	factory := func(fieldName string) *ResolverFactory {
+		resolver := templateResolver(`null`)
+		resolver.NullAsEmptyList = true
+		return NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "lists",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{fieldName: resolver}},
+			},
+		}, Options{})
+	}
+	It("replaces null results of list fields with an empty list", func() {
+		resolver, err := factory("tags").CreateResolver("Query", "tags")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("[]"))
+	})
+	It("rejects fields which are not lists", func() {
+		_, err := factory("name").CreateResolver("Query", "name")
+		Expect(err).To(MatchError("nullAsEmptyList is set on Query.name, which is not a list"))
+	})
+})
@@ -0,0 +1,117 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// PageSizes bound the pages requested through the first and last arguments of Relay connection fields
+type PageSizes struct {
+	// set first to this size when a query sets neither first nor last. zero leaves both unset
+	Default int
+	// fail queries which set first or last to a larger size. zero means no maximum
+	Max int
+}
+
+// pageSizes returns the page sizes of a field, and the argument set to the default page size. the argument is
+// empty if no page sizes apply to the field. the page sizes of the resolver apply to any field with a first or
+// last argument, those the factory was created with only to fields which return a type named *Connection
+func (rf *ResolverFactory) pageSizes(typeName, fieldName string, pageSize *v1.PageSize) (PageSizes, string, error) {
+	if pageSize == nil && rf.opts.PageSizes == (PageSizes{}) {
+		return PageSizes{}, "", nil
+	}
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return PageSizes{}, "", errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return PageSizes{}, "", errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	// fields with only a last argument are paginated from the end
+	var defaultArg string
+	switch {
+	case field.Args.Get("first") != nil:
+		defaultArg = "first"
+	case field.Args.Get("last") != nil:
+		defaultArg = "last"
+	}
+	if pageSize == nil {
+		if !strings.HasSuffix(namedType(field.Type).String(), "Connection") {
+			return PageSizes{}, "", nil
+		}
+		return rf.opts.PageSizes, defaultArg, nil
+	}
+	if defaultArg == "" {
+		return PageSizes{}, "", errors.Errorf("pageSize is set for %v.%v, which has neither a first nor a last argument", typeName, fieldName)
+	}
+	sizes := PageSizes{Default: int(pageSize.DefaultSize), Max: int(pageSize.MaxSize)}
+	if sizes.Max > 0 && sizes.Default > sizes.Max {
+		return PageSizes{}, "", errors.Errorf("the default page size of %v.%v is larger than its maximum page size", typeName, fieldName)
+	}
+	return sizes, defaultArg, nil
+}
+
+// limitPageSize defaults the page size of queries which set neither first nor last, and fails queries
+// requesting pages larger than the maximum before the resolver is called
+func (rf *ResolverFactory) limitPageSize(typeName, fieldName string, pageSize *v1.PageSize, resolver exec.RawResolver) (exec.RawResolver, error) {
+	sizes, defaultArg, err := rf.pageSizes(typeName, fieldName, pageSize)
+	if err != nil || defaultArg == "" {
+		return resolver, err
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		first, last := params.Args["first"], params.Args["last"]
+		if first == nil && last == nil {
+			if sizes.Default > 0 {
+				// arguments are shared by every object the field is resolved on, so they are copied rather than modified
+				args := make(map[string]interface{}, len(params.Args)+1)
+				for name, val := range params.Args {
+					args[name] = val
+				}
+				args[defaultArg] = int32(sizes.Default)
+				params.Args = args
+			}
+			return resolver(ctx, params)
+		}
+		for _, arg := range []struct {
+			name string
+			val  interface{}
+		}{{"first", first}, {"last", last}} {
+			size, ok := pageSizeArg(arg.val)
+			if !ok {
+				continue
+			}
+			if size < 0 {
+				return nil, exec.ValidationError(errors.Errorf("%v of %v.%v must not be negative", arg.name, typeName, fieldName))
+			}
+			if sizes.Max > 0 && size > int64(sizes.Max) {
+				return nil, exec.ValidationError(errors.Errorf("%v of %v.%v requests %v items, more than the maximum page size of %v",
+					arg.name, typeName, fieldName, size, sizes.Max))
+			}
+		}
+		return resolver(ctx, params)
+	}, nil
+}
+
+func pageSizeArg(val interface{}) (int64, bool) {
+	switch val := val.(type) {
+	case int32:
+		return int64(val), true
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	case float64:
+		return int64(val), true
+	case json.Number:
+		size, err := val.Int64()
+		return size, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("PageSize", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	items(first: Int, last: Int): ItemConnection
+	tags(first: Int): [String]
+}
+type ItemConnection {
+	totalCount: Int
+}
+schema {
+	query: Query
+}
+`)
+	resolve := func(field string, pageSize *v1.PageSize, opts Options, args map[string]interface{}) (string, error) {
+		resolver := templateResolver(`{"first": {{ or .Args.first "null" }}, "last": {{ or .Args.last "null" }}}`)
+		resolver.PageSize = pageSize
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "pages",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{field: resolver}},
+			},
+		}, opts)
+		raw, err := rf.CreateResolver("Query", field)
+		if err != nil {
+			return "", err
+		}
+		b, err := raw(context.Background(), exec.Params{Args: args})
+		return string(b), err
+	}
+	It("defaults the page size of queries which set neither first nor last", func() {
+		b, err := resolve("items", &v1.PageSize{DefaultSize: 20}, Options{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"first": 20, "last": null}`))
+		b, err = resolve("items", &v1.PageSize{DefaultSize: 20}, Options{}, map[string]interface{}{"last": int32(5)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"first": null, "last": 5}`))
+	})
+	It("fails queries requesting more than the maximum page size", func() {
+		_, err := resolve("items", &v1.PageSize{MaxSize: 10}, Options{}, map[string]interface{}{"last": int32(11)})
+		Expect(err).To(MatchError(ContainSubstring("more than the maximum page size of 10")))
+		Expect(exec.CategoryOf(err)).To(Equal(exec.ErrorCategoryValidation))
+		_, err = resolve("items", &v1.PageSize{MaxSize: 10}, Options{}, map[string]interface{}{"first": int32(10)})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("applies the page sizes of the factory to connection fields whose resolvers don't set their own", func() {
+		opts := Options{PageSizes: PageSizes{Default: 5, Max: 10}}
+		b, err := resolve("items", nil, opts, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{"first": 5, "last": null}`))
+		_, err = resolve("items", nil, opts, map[string]interface{}{"first": int32(50)})
+		Expect(err).To(HaveOccurred())
+		// tags is not a connection
+		_, err = resolve("tags", nil, opts, map[string]interface{}{"first": int32(50)})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("rejects page sizes for fields without first or last arguments", func() {
+		sch := exec.MustParseSchema(`type Query { tags: [String] } schema { query: Query }`)
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "pages",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{"tags": {
+					Resolver: &v1.Resolver_TemplateResolver{TemplateResolver: &v1.TemplateResolver{InlineTemplate: "[]"}},
+					PageSize: &v1.PageSize{MaxSize: 10},
+				}}},
+			},
+		}, Options{})
+		_, err := rf.CreateResolver("Query", "tags")
+		Expect(err).To(MatchError(ContainSubstring("has neither a first nor a last argument")))
+	})
+})
@@ -0,0 +1,13 @@
+package resolvers_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestResolvers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Resolvers Suite")
+}
@@ -0,0 +1,191 @@
+package signedurl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/secrets"
+	"github.com/solo-io/sqoop/pkg/util"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	defaultExpiry = 15 * time.Minute
+	// the longest expiry allowed by SigV4
+	maxExpiry = 7 * 24 * time.Hour
+	algorithm = "AWS4-HMAC-SHA256"
+)
+
+type credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+}
+
+type signer struct {
+	bucket    string
+	region    string
+	endpoint  string
+	pathStyle bool
+	expiry    time.Duration
+}
+
+func NewSignedURLResolver(resolver *v1.SignedUrlResolver, secretStore *secrets.Store) (exec.RawResolver, error) {
+	if resolver.Bucket == "" {
+		return nil, errors.Errorf("bucket must be specified")
+	}
+	if secretStore == nil {
+		return nil, errors.Errorf("signed url resolvers require secret storage to be configured")
+	}
+	keyTemplate, err := util.Template(resolver.KeyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing key template")
+	}
+	readCredentials := func() (credentials, error) {
+		accessKeyID, err := secretStore.Value(resolver.AccessKeyId)
+		if err != nil {
+			return credentials{}, errors.Wrap(err, "reading access key id")
+		}
+		secretAccessKey, err := secretStore.Value(resolver.SecretAccessKey)
+		if err != nil {
+			return credentials{}, errors.Wrap(err, "reading secret access key")
+		}
+		return credentials{accessKeyID: accessKeyID, secretAccessKey: secretAccessKey}, nil
+	}
+	// fail on config load if the credentials are missing
+	if _, err := readCredentials(); err != nil {
+		return nil, err
+	}
+	s := newSigner(resolver)
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		buf, err := util.ExecTemplate(keyTemplate, params)
+		if err != nil {
+			return nil, errors.Wrap(err, "executing key template")
+		}
+		key := strings.TrimPrefix(strings.TrimSpace(buf.String()), "/")
+		if key == "" {
+			return nil, errors.Errorf("key template rendered an empty key")
+		}
+		creds, err := readCredentials()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s.presign(creds, key, time.Now())), nil
+	}, nil
+}
+
+func newSigner(resolver *v1.SignedUrlResolver) *signer {
+	region := resolver.Region
+	if region == "" {
+		region = defaultRegion
+	}
+	endpoint := resolver.Endpoint
+	if endpoint == "" {
+		endpoint = "s3." + region + ".amazonaws.com"
+		if region == defaultRegion {
+			endpoint = "s3.amazonaws.com"
+		}
+	}
+	expiry := defaultExpiry
+	if resolver.ExpirySeconds > 0 {
+		expiry = time.Duration(resolver.ExpirySeconds) * time.Second
+	}
+	if expiry > maxExpiry {
+		expiry = maxExpiry
+	}
+	return &signer{
+		bucket:    resolver.Bucket,
+		region:    region,
+		endpoint:  endpoint,
+		pathStyle: resolver.PathStyle,
+		expiry:    expiry,
+	}
+}
+
+// presign creates a query-string authenticated GET url using AWS Signature Version 4
+func (s *signer) presign(creds credentials, key string, now time.Time) string {
+	now = now.UTC()
+	date := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{date, s.region, "s3", "aws4_request"}, "/")
+
+	host := s.bucket + "." + s.endpoint
+	path := "/" + encodePath(key)
+	if s.pathStyle {
+		host = s.endpoint
+		path = "/" + encodePath(s.bucket) + path
+	}
+
+	query := canonicalQuery(map[string]string{
+		"X-Amz-Algorithm":     algorithm,
+		"X-Amz-Credential":    creds.accessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       fmt.Sprintf("%d", int(s.expiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	})
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		path,
+		query,
+		"host:" + host,
+		"",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return "https://" + host + path + "?" + query + "&X-Amz-Signature=" + signature
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = encode(k) + "=" + encode(params[k])
+	}
+	return strings.Join(parts, "&")
+}
+
+// SigV4 uses RFC 3986 encoding, which differs from url.QueryEscape for spaces and '~'
+func encode(s string) string {
+	return strings.Replace(strings.Replace(url.QueryEscape(s), "+", "%20", -1), "%7E", "~", -1)
+}
+
+func encodePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = encode(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,13 @@
+package signedurl
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSignedURL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Signed URL Suite")
+}
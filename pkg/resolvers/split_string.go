@@ -0,0 +1,89 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+const defaultDelimiter = ","
+
+// split a string result of a field which is a list of scalars into the items of the list.
+// results may be json strings or plain text. null and list results are left as they are
+func (rf *ResolverFactory) splitString(typeName, fieldName string, split *v1.SplitString, resolver exec.RawResolver) (exec.RawResolver, error) {
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil, errors.Errorf("type %v is not an object type", typeName)
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil, errors.Errorf("type %v does not contain field %v", typeName, fieldName)
+	}
+	typ := field.Type
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	list, ok := typ.(*common.List)
+	if !ok {
+		return nil, errors.Errorf("splitString is set on %v.%v, which is not a list", typeName, fieldName)
+	}
+	elem := list.OfType
+	if nonNull, ok := elem.(*common.NonNull); ok {
+		elem = nonNull.OfType
+	}
+	switch elem.(type) {
+	case *schema.Scalar, *schema.Enum:
+	default:
+		return nil, errors.Errorf("splitString is set on %v.%v, which is not a list of scalars", typeName, fieldName)
+	}
+	delimiter := split.Delimiter
+	if delimiter == "" {
+		delimiter = defaultDelimiter
+	}
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		data, err := resolver(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		trimmed := bytes.TrimSpace(data)
+		// an empty plain text body, like an empty string, has no items
+		if len(trimmed) == 0 {
+			return []byte("[]"), nil
+		}
+		// already a list, or nothing to split
+		if trimmed[0] == '[' || bytes.Equal(trimmed, []byte("null")) {
+			return data, nil
+		}
+		str := string(data)
+		if trimmed[0] == '"' {
+			if err := json.Unmarshal(trimmed, &str); err != nil {
+				return nil, exec.UpstreamError(errors.Wrap(err, "decoding string to split"))
+			}
+		}
+		items := splitItems(str, delimiter, split.TrimSpace)
+		return json.Marshal(items)
+	}, nil
+}
+
+func splitItems(str, delimiter string, trimSpace bool) []string {
+	if trimSpace {
+		str = strings.TrimSpace(str)
+	}
+	if str == "" {
+		return []string{}
+	}
+	items := strings.Split(str, delimiter)
+	if trimSpace {
+		for i, item := range items {
+			items[i] = strings.TrimSpace(item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,58 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("SplitString", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	tags: [String!]!
+	name: String
+}
+schema {
+	query: Query
+}
+`)
+	factory := func(fieldName, tmpl string, split *v1.SplitString) *ResolverFactory {
+		resolver := templateResolver(tmpl)
+		resolver.SplitString = split
+		return NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "lists",
+			Types: map[string]*v1.TypeResolver{
+				"Query": {Fields: map[string]*v1.Resolver{fieldName: resolver}},
+			},
+		}, Options{})
+	}
+	resolve := func(tmpl string, split *v1.SplitString) string {
+		resolver, err := factory("tags", tmpl, split).CreateResolver("Query", "tags")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := resolver(context.Background(), exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+	It("splits json strings on the delimiter", func() {
+		Expect(resolve(`"red,green,blue"`, &v1.SplitString{})).To(Equal(`["red","green","blue"]`))
+	})
+	It("splits plain text and trims the items", func() {
+		Expect(resolve(`red; green ;blue`, &v1.SplitString{Delimiter: ";", TrimSpace: true})).To(Equal(`["red","green","blue"]`))
+	})
+	It("returns an empty list for empty strings", func() {
+		Expect(resolve(`""`, &v1.SplitString{})).To(Equal(`[]`))
+	})
+	It("returns an empty list for empty plain text", func() {
+		Expect(resolve(``, &v1.SplitString{})).To(Equal(`[]`))
+		Expect(resolve(" \n", &v1.SplitString{})).To(Equal(`[]`))
+	})
+	It("rejects fields which are not lists", func() {
+		_, err := factory("name", `""`, &v1.SplitString{}).CreateResolver("Query", "name")
+		Expect(err).To(MatchError("splitString is set on Query.name, which is not a list"))
+	})
+})
@@ -1,6 +1,8 @@
 package template
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	"github.com/solo-io/sqoop/pkg/exec"
@@ -12,7 +14,7 @@ func NewTemplateResolver(resolver *v1.TemplateResolver) (exec.RawResolver, error
 	if err != nil {
 		return nil, errors.Wrap(err, "parsing inline template")
 	}
-	return func(params exec.Params) ([]byte, error) {
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
 		buf, err := util.ExecTemplate(tmpl, params)
 		if err != nil {
 			return nil, errors.Wrap(err, "executing inline template")
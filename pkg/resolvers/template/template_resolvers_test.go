@@ -4,6 +4,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"context"
+
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	. "github.com/solo-io/sqoop/pkg/resolvers/template"
 	"github.com/solo-io/sqoop/test"
@@ -17,13 +19,14 @@ var _ = Describe("TemplateResolvers", func() {
 		It("returns a resolver which renders the template", func() {
 			rawResolver, err := NewTemplateResolver(tResolver)
 			Expect(err).NotTo(HaveOccurred())
-			b, err := rawResolver(test.LukeSkywalkerParams)
+			b, err := rawResolver(context.Background(), test.LukeSkywalkerParams)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(b).To(Equal([]byte(`{"Args":{"acting":5,"best_scene":"cloud city"},` +
+			Expect(b).To(Equal([]byte(`{"Args":{"acting":5,"best_scene":"cloud city"},"Cookies":null,` +
 				`"Parent":{"CharacterFields":{"AppearsIn":["NEWHOPE","EMPIRE","JEDI"],` +
 				`"FriendIds":["1002","1003","2000","2001"],"ID":"1000","Name":"Luke Skywalker","TypeName":"Human"},` +
 				`"Mass":77,"StarshipIds":["3001","3003"],"appearsIn":null,"friends":null,"friendsConnection":null,` +
-				`"height":null,"id":null,"mass":null,"name":null,"starships":null}}`)))
+				`"height":null,"id":null,"mass":null,"name":null,"starships":null},` +
+				`"Siblings":null,"claims":null,"ctx":null}`)))
 		})
 	})
 })
@@ -0,0 +1,184 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/util"
+	"github.com/vektah/gqlgen/neelance/common"
+	"github.com/vektah/gqlgen/neelance/schema"
+)
+
+// objects nested deeper than this are sampled as null
+const maxSampleDepth = 3
+
+// validateTemplates renders the templates of a resolver with sample arguments and a sample parent
+// generated from the schema, so templates which can't produce valid JSON or urls are reported with the
+// resolver map rather than by the first query to hit them. templates which fail to execute on the samples, or
+// read keys the samples don't have, e.g. fields of the upstream response which are not in the schema, are not
+// reported, as their output depends on data the samples can't predict
+func (rf *ResolverFactory) validateTemplates(typeName, fieldName string, resolver *v1.Resolver) error {
+	if rf.schema == nil {
+		return nil
+	}
+	objectType, ok := rf.schema.Types[typeName].(*schema.Object)
+	if !ok {
+		return nil
+	}
+	field := objectType.Fields.Get(fieldName)
+	if field == nil {
+		return nil
+	}
+	args := make(map[string]interface{})
+	for _, arg := range field.Args {
+		args[arg.Name.Name] = sampleValue(arg.Type, 0)
+	}
+	parent, _ := sampleValue(objectType, 0).(map[string]interface{})
+	if typeName == "Query" || typeName == "Mutation" {
+		parent = nil
+	}
+	render := func(tmpl string) (string, bool) {
+		parsed, err := util.Template(tmpl)
+		if err != nil {
+			// reported when the resolver is created
+			return "", false
+		}
+		// missing keys fail the template, except for those read with index, which render <no value>
+		buf, err := util.ExecTemplateValues(parsed.Option("missingkey=error"), args, parent)
+		if err != nil || strings.Contains(buf.String(), "<no value>") {
+			return "", false
+		}
+		return strings.TrimSpace(buf.String()), true
+	}
+	return validateResolverTemplates(resolver, field.Type, render)
+}
+
+func validateResolverTemplates(resolver *v1.Resolver, fieldType common.Type, render func(string) (string, bool)) error {
+	if resolver.SplitString != nil {
+		// the resolver returns a string which is split into the list
+		fieldType = nil
+	}
+	switch r := resolver.Resolver.(type) {
+	case *v1.Resolver_GlooResolver:
+		contentType := r.GlooResolver.ContentType
+		if r.GlooResolver.RequestTemplate == "" || (contentType != "" && !strings.Contains(contentType, "json")) {
+			return nil
+		}
+		if out, ok := render(r.GlooResolver.RequestTemplate); ok && out != "" && !json.Valid([]byte(out)) {
+			return errors.Errorf("request template renders invalid json for sample data: %v", out)
+		}
+	case *v1.Resolver_TemplateResolver:
+		// scalars are returned as they are rendered, everything else is parsed as json
+		if fieldType == nil {
+			return nil
+		}
+		if _, scalar := unwrapNonNull(fieldType).(*schema.Scalar); scalar {
+			return nil
+		}
+		if _, enum := unwrapNonNull(fieldType).(*schema.Enum); enum {
+			return nil
+		}
+		if out, ok := render(r.TemplateResolver.InlineTemplate); ok && out != "" && !json.Valid([]byte(out)) {
+			return errors.Errorf("inline template renders invalid json for sample data: %v", out)
+		}
+	case *v1.Resolver_HttpResolver:
+		out, ok := render(r.HttpResolver.UrlTemplate)
+		if !ok {
+			return nil
+		}
+		u, err := url.Parse(out)
+		if err != nil {
+			return errors.Wrap(err, "url template renders an invalid url for sample data")
+		}
+		// relative urls may be resolved against a base url
+		if u.IsAbs() && (u.Scheme != "http" && u.Scheme != "https" || u.Host == "") {
+			return errors.Errorf("url template renders an invalid url for sample data: %v", out)
+		}
+	case *v1.Resolver_ConditionalResolver:
+		for i, variant := range r.ConditionalResolver.Variants {
+			if variant.Resolver == nil {
+				continue
+			}
+			if err := validateResolverTemplates(variant.Resolver, fieldType, render); err != nil {
+				return errors.Wrapf(err, "variant %v", i)
+			}
+		}
+		if r.ConditionalResolver.DefaultResolver != nil {
+			if err := validateResolverTemplates(r.ConditionalResolver.DefaultResolver, fieldType, render); err != nil {
+				return errors.Wrap(err, "default resolver")
+			}
+		}
+	case *v1.Resolver_MergeResolver:
+		for i, source := range r.MergeResolver.Sources {
+			if source.Resolver == nil {
+				continue
+			}
+			if err := validateResolverTemplates(source.Resolver, fieldType, render); err != nil {
+				return errors.Wrapf(err, "source %v", i)
+			}
+		}
+	}
+	return nil
+}
+
+func unwrapNonNull(typ common.Type) common.Type {
+	if nonNull, ok := typ.(*common.NonNull); ok {
+		return nonNull.OfType
+	}
+	return typ
+}
+
+// sampleValue generates a value of the given type as it appears to templates.
+// IDs are sampled as numbers in strings, as templates commonly render them unquoted
+func sampleValue(typ common.Type, depth int) interface{} {
+	switch typ := typ.(type) {
+	case *common.NonNull:
+		return sampleValue(typ.OfType, depth)
+	case *common.List:
+		return []interface{}{sampleValue(typ.OfType, depth)}
+	case *schema.Object:
+		if depth >= maxSampleDepth {
+			return nil
+		}
+		obj := map[string]interface{}{"__typename": typ.Name}
+		for _, field := range typ.Fields {
+			obj[field.Name] = sampleValue(field.Type, depth+1)
+		}
+		return obj
+	case *schema.Interface:
+		if len(typ.PossibleTypes) == 0 {
+			return nil
+		}
+		return sampleValue(typ.PossibleTypes[0], depth)
+	case *schema.InputObject:
+		if depth >= maxSampleDepth {
+			return nil
+		}
+		obj := make(map[string]interface{})
+		for _, field := range typ.Values {
+			obj[field.Name.Name] = sampleValue(field.Type, depth+1)
+		}
+		return obj
+	case *schema.Enum:
+		if len(typ.Values) == 0 {
+			return nil
+		}
+		return typ.Values[0].Name
+	case *schema.Scalar:
+		switch typ.Name {
+		case "Int":
+			return 1
+		case "Float":
+			return 1.5
+		case "Boolean":
+			return true
+		case "ID":
+			return "1"
+		}
+		return "sample"
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package resolvers_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers"
+)
+
+var _ = Describe("Template validation", func() {
+	sch := exec.MustParseSchema(`
+type Query {
+	user(id: ID!): User
+	tags: [String]
+}
+type User {
+	name: String
+	avatar: String
+	friends: [User]
+}
+schema {
+	query: Query
+}
+`)
+	create := func(typeName, fieldName string, resolver *v1.Resolver) error {
+		rf := NewResolverFactory("no-address-defined", sch.Schema, &v1.ResolverMap{
+			Name: "templates",
+			Types: map[string]*v1.TypeResolver{
+				typeName: {Fields: map[string]*v1.Resolver{fieldName: resolver}},
+			},
+		}, Options{})
+		_, err := rf.CreateResolver(typeName, fieldName)
+		return err
+	}
+	glooResolver := func(requestTemplate string) *v1.Resolver {
+		return &v1.Resolver{Resolver: &v1.Resolver_GlooResolver{GlooResolver: &v1.GlooResolver{
+			RequestTemplate: requestTemplate,
+			Function:        &v1.GlooResolver_Upstream{Upstream: "users"},
+		}}}
+	}
+	It("accepts templates which render valid json for sample data", func() {
+		Expect(create("Query", "user", glooResolver(`{"id": {{ index .Args "id" }}}`))).To(Succeed())
+		Expect(create("Query", "tags", templateResolver(`["a", "b"]`))).To(Succeed())
+	})
+	It("rejects templates which render invalid json for sample data", func() {
+		err := create("Query", "user", glooResolver(`{"id": {{ index .Args "id" }}}}`))
+		Expect(err).To(MatchError(ContainSubstring("request template renders invalid json for sample data")))
+		err = create("Query", "tags", templateResolver(`["a", "b"]]`))
+		Expect(err).To(MatchError(ContainSubstring("inline template renders invalid json for sample data")))
+	})
+	It("accepts templates which read keys of the upstream response which are not in the schema", func() {
+		Expect(create("User", "friends", glooResolver(`{"ids": {{ .Parent.friend_ids }}}`))).To(Succeed())
+		Expect(create("User", "friends", glooResolver(`{"ids": {{ index .Parent "friend_ids" }}}`))).To(Succeed())
+		// keys of the schema are still checked
+		err := create("User", "friends", glooResolver(`{"name": {{ .Parent.name }}}`))
+		Expect(err).To(MatchError(ContainSubstring("request template renders invalid json for sample data")))
+	})
+	It("rejects url templates which render invalid urls for sample data", func() {
+		resolver := &v1.Resolver{Resolver: &v1.Resolver_HttpResolver{HttpResolver: &v1.HttpResolver{
+			UrlTemplate:  "htp://cdn.example.com/{{ .Parent.name }}.png",
+			AllowedHosts: []string{"cdn.example.com"},
+		}}}
+		Expect(create("User", "avatar", resolver)).To(MatchError(ContainSubstring("url template renders an invalid url")))
+	})
+})
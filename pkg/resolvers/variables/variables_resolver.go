@@ -0,0 +1,151 @@
+package variables
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/util"
+	"github.com/vektah/gqlgen/graphql"
+)
+
+func NewVariablesResolver(resolver *v1.VariablesResolver) (exec.RawResolver, error) {
+	if resolver.Variable != "" {
+		refs := []string{resolver.Variable}
+		return func(ctx context.Context, params exec.Params) ([]byte, error) {
+			vars, err := operationVariables(ctx, refs)
+			if err != nil {
+				return nil, err
+			}
+			value := vars[resolver.Variable]
+			if value == nil {
+				return nil, errors.Errorf("variable $%v has no value", resolver.Variable)
+			}
+			return marshal(value)
+		}, nil
+	}
+	tmpl, err := util.Template(resolver.InlineTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing inline template")
+	}
+	refs := referencedVariables(tmpl)
+	return func(ctx context.Context, params exec.Params) ([]byte, error) {
+		vars, err := operationVariables(ctx, refs)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, errors.Wrap(err, "executing inline template")
+		}
+		return buf.Bytes(), nil
+	}, nil
+}
+
+// the variables of the request, with defaults applied for variables which were not provided.
+// every referenced variable must be defined by the operation
+func operationVariables(ctx context.Context, refs []string) (map[string]interface{}, error) {
+	op := exec.Operation(ctx)
+	if op == nil {
+		return nil, errors.New("variables can only be resolved while executing an operation")
+	}
+	for _, ref := range refs {
+		if op.Vars.Get(ref) == nil {
+			return nil, errors.Errorf("operation does not define variable $%v", ref)
+		}
+	}
+	provided := graphql.GetRequestContext(ctx).Variables
+	vars := make(map[string]interface{}, len(op.Vars))
+	for _, v := range op.Vars {
+		if value, ok := provided[v.Name.Name]; ok {
+			vars[v.Name.Name] = value
+		} else if v.Default != nil {
+			vars[v.Name.Name] = v.Default.Value(nil)
+		} else {
+			vars[v.Name.Name] = nil
+		}
+	}
+	return vars, nil
+}
+
+// scalars are returned by resolvers as raw strings, everything else as json
+func marshal(value interface{}) ([]byte, error) {
+	switch value := value.(type) {
+	case map[string]interface{}, []interface{}:
+		return json.Marshal(value)
+	case float64:
+		// json numbers are decoded as floats. avoid exponents for large integers
+		return []byte(strconv.FormatFloat(value, 'f', -1, 64)), nil
+	}
+	return []byte(fmt.Sprintf("%v", value)), nil
+}
+
+// names of the variables used by the template. fields of the dot inside
+// range and with blocks refer to other values, and are not variables
+func referencedVariables(tmpl *template.Template) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	var walk func(node parse.Node, atRoot bool)
+	walk = func(node parse.Node, atRoot bool) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child, atRoot)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe, atRoot)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd, atRoot)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg, atRoot)
+			}
+		case *parse.ChainNode:
+			walk(n.Node, atRoot)
+		case *parse.FieldNode:
+			if atRoot {
+				add(n.Ident[0])
+			}
+		case *parse.VariableNode:
+			// $ always refers to the variables
+			if n.Ident[0] == "$" && len(n.Ident) > 1 {
+				add(n.Ident[1])
+			}
+		case *parse.IfNode:
+			walk(n.Pipe, atRoot)
+			walk(n.List, atRoot)
+			walk(n.ElseList, atRoot)
+		case *parse.RangeNode:
+			walk(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		case *parse.WithNode:
+			walk(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		}
+	}
+	walk(tmpl.Tree.Root, true)
+	return refs
+}
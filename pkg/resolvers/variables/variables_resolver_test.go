@@ -0,0 +1,50 @@
+package variables_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/resolvers/variables"
+	"github.com/vektah/gqlgen/graphql"
+	"github.com/vektah/gqlgen/neelance/query"
+)
+
+func operationContext(q string, vars map[string]interface{}) context.Context {
+	doc, err := query.Parse(q)
+	Expect(err).To(BeNil())
+	op, opErr := doc.GetOperation("")
+	Expect(opErr).NotTo(HaveOccurred())
+	ctx := graphql.WithRequestContext(context.Background(), graphql.NewRequestContext(doc, q, vars))
+	return exec.WithOperation(ctx, op)
+}
+
+var _ = Describe("VariablesResolver", func() {
+	It("returns the value of a variable", func() {
+		resolver, err := NewVariablesResolver(&v1.VariablesResolver{Variable: "name"})
+		Expect(err).NotTo(HaveOccurred())
+		ctx := operationContext(`query($name: String) { echo(value: $name) }`, map[string]interface{}{"name": "luke"})
+		b, err := resolver(ctx, exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("luke"))
+	})
+	It("renders a template with the variables, applying defaults", func() {
+		resolver, err := NewVariablesResolver(&v1.VariablesResolver{
+			InlineTemplate: `{"first":{{ marshal .first }},"count":{{ .count }}}`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		ctx := operationContext(`query($first: String, $count: Int = 3) { echo }`, map[string]interface{}{"first": "luke"})
+		b, err := resolver(ctx, exec.Params{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`{"first":"luke","count":3}`))
+	})
+	It("errors when the operation does not define a referenced variable", func() {
+		resolver, err := NewVariablesResolver(&v1.VariablesResolver{InlineTemplate: `{{ .first }} {{ .last }}`})
+		Expect(err).NotTo(HaveOccurred())
+		ctx := operationContext(`query($first: String) { echo }`, map[string]interface{}{"first": "luke"})
+		_, err = resolver(ctx, exec.Params{})
+		Expect(err).To(MatchError("operation does not define variable $last"))
+	})
+})
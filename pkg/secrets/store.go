@@ -0,0 +1,110 @@
+package secrets
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/gloo/pkg/storage/dependencies"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+)
+
+// Source reads the data of a secret by name
+type Source interface {
+	Get(name string) (map[string]string, error)
+}
+
+type glooSource struct {
+	secrets dependencies.SecretStorage
+}
+
+// NewGlooSource reads secrets from Gloo's secret storage, which is backed by
+// Kubernetes Secrets, Vault, or files depending on how it was bootstrapped
+func NewGlooSource(secrets dependencies.SecretStorage) Source {
+	return &glooSource{secrets: secrets}
+}
+
+func (s *glooSource) Get(name string) (map[string]string, error) {
+	secret, err := s.secrets.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// Store caches the secrets referenced by resolvers and refreshes them periodically,
+// so resolvers pick up rotated secrets without a config change
+type Store struct {
+	source Source
+
+	mu      sync.RWMutex
+	secrets map[string]map[string]string
+}
+
+func NewStore(source Source) *Store {
+	return &Store{
+		source:  source,
+		secrets: make(map[string]map[string]string),
+	}
+}
+
+// Value returns the value referenced by ref, reading the secret from the source
+// the first time it is referenced
+func (s *Store) Value(ref *v1.SecretRef) (string, error) {
+	if ref == nil || ref.Name == "" || ref.Key == "" {
+		return "", errors.Errorf("secret ref must specify a name and key")
+	}
+	s.mu.RLock()
+	data, ok := s.secrets[ref.Name]
+	s.mu.RUnlock()
+	if !ok {
+		var err error
+		data, err = s.source.Get(ref.Name)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading secret %v", ref.Name)
+		}
+		s.mu.Lock()
+		s.secrets[ref.Name] = data
+		s.mu.Unlock()
+	}
+	value, ok := data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("secret %v does not contain key %v", ref.Name, ref.Key)
+	}
+	return value, nil
+}
+
+// Run re-reads every referenced secret at the given interval until stop is closed.
+// A secret which can no longer be read keeps its last known value
+func (s *Store) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.refresh()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *Store) refresh() {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.secrets))
+	for name := range s.secrets {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+	for _, name := range names {
+		data, err := s.source.Get(name)
+		if err != nil {
+			log.Warnf("refreshing secret %v: %v", name, err)
+			continue
+		}
+		s.mu.Lock()
+		s.secrets[name] = data
+		s.mu.Unlock()
+	}
+}
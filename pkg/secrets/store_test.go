@@ -0,0 +1,54 @@
+package secrets_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	. "github.com/solo-io/sqoop/pkg/secrets"
+)
+
+type mapSource map[string]map[string]string
+
+func (s mapSource) Get(name string) (map[string]string, error) {
+	data, ok := s[name]
+	if !ok {
+		return nil, errors.Errorf("secret %v not found", name)
+	}
+	return data, nil
+}
+
+var _ = Describe("Store", func() {
+	var (
+		source mapSource
+		store  *Store
+	)
+	BeforeEach(func() {
+		source = mapSource{"api-keys": {"starwars": "v1"}}
+		store = NewStore(source)
+	})
+	It("reads values from the source", func() {
+		value, err := store.Value(&v1.SecretRef{Name: "api-keys", Key: "starwars"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("v1"))
+	})
+	It("returns an error for missing secrets and keys", func() {
+		_, err := store.Value(&v1.SecretRef{Name: "missing", Key: "starwars"})
+		Expect(err).To(HaveOccurred())
+		_, err = store.Value(&v1.SecretRef{Name: "api-keys", Key: "missing"})
+		Expect(err).To(HaveOccurred())
+	})
+	It("picks up rotated secrets on refresh", func() {
+		_, err := store.Value(&v1.SecretRef{Name: "api-keys", Key: "starwars"})
+		Expect(err).NotTo(HaveOccurred())
+		source["api-keys"] = map[string]string{"starwars": "v2"}
+		stop := make(chan struct{})
+		defer close(stop)
+		go store.Run(10*time.Millisecond, stop)
+		Eventually(func() (string, error) {
+			return store.Value(&v1.SecretRef{Name: "api-keys", Key: "starwars"})
+		}).Should(Equal("v2"))
+	})
+})
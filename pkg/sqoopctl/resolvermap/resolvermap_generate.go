@@ -0,0 +1,72 @@
+package resolvermap
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/sqoopctl"
+	"github.com/solo-io/sqoop/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var resolverMapGenerateOpts struct {
+	FromSchema      string
+	OutputFile      string
+	Format          string
+	IncludeDefaults bool
+}
+
+var resolverMapGenerateCmd = &cobra.Command{
+	Use:   "generate NAME --from-schema <path/to/your/graphql/schema>",
+	Short: "generate a skeleton resolver map for a GraphQL schema file without connecting to Sqoop",
+	Long: `Generates a resolver map with an empty resolver for each field of the schema, which can be edited and
+committed alongside the schema, then uploaded with sqoopctl resolvermap create`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || args[0] == "" {
+			return errors.Errorf("requires exactly 1 argument")
+		}
+		return generateResolverMap(args[0], resolverMapGenerateOpts.FromSchema, resolverMapGenerateOpts.OutputFile,
+			resolverMapGenerateOpts.Format, resolverMapGenerateOpts.IncludeDefaults)
+	},
+}
+
+func init() {
+	resolverMapGenerateCmd.PersistentFlags().StringVarP(&resolverMapGenerateOpts.FromSchema, "from-schema", "s", "", "path to a "+
+		"graphql schema file to generate the resolver map for")
+	resolverMapGenerateCmd.PersistentFlags().StringVarP(&resolverMapGenerateOpts.OutputFile, "output-file", "w", "", "write "+
+		"the resolver map to this file instead of stdout")
+	resolverMapGenerateCmd.PersistentFlags().StringVar(&resolverMapGenerateOpts.Format, "format", "yaml", "format "+
+		"of the generated resolver map, yaml or json")
+	resolverMapGenerateCmd.PersistentFlags().BoolVar(&resolverMapGenerateOpts.IncludeDefaults, "include-defaults", true, "include "+
+		"empty resolvers for the fields of non-root types, which are resolved from their parent by default")
+	resolverMapCmd.AddCommand(resolverMapGenerateCmd)
+}
+
+func generateResolverMap(name, schemaFile, outputFile, format string, includeDefaults bool) error {
+	if schemaFile == "" {
+		return errors.Errorf("schema file must be set")
+	}
+	sdl, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	sch, err := exec.ParseSchema(string(sdl))
+	if err != nil {
+		return errors.Wrap(err, "parsing schema")
+	}
+	resolverMap := util.GenerateResolverMapSkeleton(name, sch.Schema)
+	if !includeDefaults {
+		resolverMap = util.GenerateRootResolverMapSkeleton(name, sch.Schema)
+	}
+	out, err := sqoopctl.Marshal(resolverMap, format)
+	if err != nil {
+		return err
+	}
+	if outputFile == "" {
+		fmt.Printf("%s\n", out)
+		return nil
+	}
+	return ioutil.WriteFile(outputFile, out, 0644)
+}
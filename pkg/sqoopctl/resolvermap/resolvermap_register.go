@@ -26,8 +26,8 @@ Resolvers must be defined in yaml format. See the documentation at https://sqoop
 		if len(args) != 2 || args[0] == "" || args[1] == "" {
 			return errors.Errorf("must specify args TypeName and FieldName")
 		}
-		if upstreamName == "" || functionName == "" {
-			return errors.Errorf("must provide an upstream and function to create a resolver")
+		if upstreamName == "" {
+			return errors.Errorf("must provide an upstream to create a resolver")
 		}
 		msg, err := registerResolver(schemaName, args[0], args[1], upstreamName, functionName, requestTemplate, responseTemplate)
 		if err != nil {
@@ -39,7 +39,7 @@ Resolvers must be defined in yaml format. See the documentation at https://sqoop
 
 func init() {
 	resolverMapRegisterCmd.PersistentFlags().StringVarP(&upstreamName, "upstream", "u", "", "upstream where the function lives")
-	resolverMapRegisterCmd.PersistentFlags().StringVarP(&functionName, "function", "f", "", "function to use as resolver")
+	resolverMapRegisterCmd.PersistentFlags().StringVarP(&functionName, "function", "f", "", "function to use as resolver. if omitted, requests are routed to the upstream itself")
 	resolverMapRegisterCmd.PersistentFlags().StringVarP(&requestTemplate, "request-template", "b", "", "template to use for the request body")
 	resolverMapRegisterCmd.PersistentFlags().StringVarP(&responseTemplate, "response-template", "r", "", "template to use for the response body")
 	resolverMapRegisterCmd.PersistentFlags().StringVarP(&schemaName, "schema", "s", "", "name of the "+
@@ -49,18 +49,22 @@ func init() {
 }
 
 func registerResolver(schemaName, typeName, fieldName, upstreamName, functionName, requestTemplate, responseTemplate string) (*v1.ResolverMap, error) {
+	glooResolver := &v1.GlooResolver{
+		RequestTemplate:  requestTemplate,
+		ResponseTemplate: responseTemplate,
+		Function: &v1.GlooResolver_SingleFunction{
+			SingleFunction: &v1.Function{
+				Upstream: upstreamName,
+				Function: functionName,
+			},
+		},
+	}
+	if functionName == "" {
+		glooResolver.Function = &v1.GlooResolver_Upstream{Upstream: upstreamName}
+	}
 	resolver := &v1.Resolver{
 		Resolver: &v1.Resolver_GlooResolver{
-			GlooResolver: &v1.GlooResolver{
-				RequestTemplate:  requestTemplate,
-				ResponseTemplate: responseTemplate,
-				Function: &v1.GlooResolver_SingleFunction{
-					SingleFunction: &v1.Function{
-						Upstream: upstreamName,
-						Function: functionName,
-					},
-				},
-			},
+			GlooResolver: glooResolver,
 		},
 	}
 	cli, err := sqoopctl.MakeClient()
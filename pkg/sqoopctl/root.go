@@ -45,11 +45,7 @@ func init() {
 }
 
 func printAsYaml(msg proto.Message) error {
-	jsn, err := protoutil.Marshal(msg)
-	if err != nil {
-		return err
-	}
-	yam, err := yaml.JSONToYAML(jsn)
+	yam, err := Marshal(msg, "yaml")
 	if err != nil {
 		return err
 	}
@@ -58,7 +54,7 @@ func printAsYaml(msg proto.Message) error {
 }
 
 func printAsJSON(msg proto.Message) error {
-	jsn, err := protoutil.Marshal(msg)
+	jsn, err := Marshal(msg, "json")
 	if err != nil {
 		return err
 	}
@@ -66,6 +62,21 @@ func printAsJSON(msg proto.Message) error {
 	return nil
 }
 
+// Marshal encodes a storage object as yaml or json
+func Marshal(msg proto.Message, format string) ([]byte, error) {
+	jsn, err := protoutil.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(format) {
+	case "yaml":
+		return yaml.JSONToYAML(jsn)
+	case "json":
+		return jsn, nil
+	}
+	return nil, errors.Errorf("unknown format %v, must be yaml or json", format)
+}
+
 func printTable(msg proto.Message) error {
 	switch obj := msg.(type) {
 	case *v1.Schema:
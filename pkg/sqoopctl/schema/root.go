@@ -17,3 +17,18 @@ var schemaCmd = &cobra.Command{
 func init() {
 	sqoopctl.RootCmd.AddCommand(schemaCmd)
 }
+
+type versionOpts struct {
+	Version string
+	Alias   string
+	Sunset  string
+}
+
+func addVersionFlags(cmd *cobra.Command, opts *versionOpts) {
+	cmd.PersistentFlags().StringVar(&opts.Version, "version", "", "optional version label for the schema. "+
+		"versioned schemas are served at /<alias>/<version>")
+	cmd.PersistentFlags().StringVar(&opts.Alias, "alias", "", "path prefix under which versions of the schema "+
+		"are served. defaults to the schema name")
+	cmd.PersistentFlags().StringVar(&opts.Sunset, "sunset", "", "HTTP-date at which this version of the schema "+
+		"will be removed. sent to clients in the Sunset header")
+}
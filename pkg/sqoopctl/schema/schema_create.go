@@ -13,6 +13,7 @@ import (
 var schemaCreateOpts struct {
 	FromFile       string
 	UseResolverMap string
+	Version        versionOpts
 }
 
 var schemaCreateCmd = &cobra.Command{
@@ -22,7 +23,7 @@ var schemaCreateCmd = &cobra.Command{
 		if len(args) != 1 {
 			return errors.Errorf("requires exactly 1 argument")
 		}
-		if err := createSchema(args[0], schemaCreateOpts.FromFile, schemaCreateOpts.UseResolverMap); err != nil {
+		if err := createSchema(args[0], schemaCreateOpts.FromFile, schemaCreateOpts.UseResolverMap, schemaCreateOpts.Version); err != nil {
 			return err
 		}
 		fmt.Println("schema created successfully")
@@ -36,10 +37,11 @@ func init() {
 	schemaCreateCmd.PersistentFlags().StringVarP(&schemaCreateOpts.UseResolverMap, "resolvermap", "r", "", "The name of a "+
 		"ResolverMap to connect to this Schema. If none is specified, an empty ResolverMap will be generated for you, which "+
 		"you can then configure with sqoopctl")
+	addVersionFlags(schemaCreateCmd, &schemaCreateOpts.Version)
 	schemaCmd.AddCommand(schemaCreateCmd)
 }
 
-func createSchema(name, filename, resolvermap string) error {
+func createSchema(name, filename, resolvermap string, version versionOpts) error {
 	if name == "" {
 		return errors.Errorf("schema name must be set")
 	}
@@ -58,6 +60,9 @@ func createSchema(name, filename, resolvermap string) error {
 		Name:         name,
 		InlineSchema: string(inlineSchemaBytes),
 		ResolverMap:  resolvermap,
+		Version:      version.Version,
+		Alias:        version.Alias,
+		Sunset:       version.Sunset,
 	}
 	_, err = cli.V1().Schemas().Create(schema)
 	return err
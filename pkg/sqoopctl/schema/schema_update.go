@@ -13,6 +13,7 @@ import (
 var schemaUpdateOpts struct {
 	FromFile       string
 	UseResolverMap string
+	Version        versionOpts
 }
 
 var schemaUpdateCmd = &cobra.Command{
@@ -22,7 +23,7 @@ var schemaUpdateCmd = &cobra.Command{
 		if len(args) != 1 {
 			return errors.Errorf("requires exactly 1 argument")
 		}
-		if err := updateSchema(args[0], schemaUpdateOpts.FromFile, schemaUpdateOpts.UseResolverMap); err != nil {
+		if err := updateSchema(args[0], schemaUpdateOpts.FromFile, schemaUpdateOpts.UseResolverMap, schemaUpdateOpts.Version); err != nil {
 			return err
 		}
 		fmt.Println("schema updated successfully")
@@ -36,10 +37,11 @@ func init() {
 	schemaUpdateCmd.PersistentFlags().StringVarP(&schemaUpdateOpts.UseResolverMap, "resolvermap", "r", "", "The name of a "+
 		"ResolverMap to connect to this Schema. If none is specified, an empty ResolverMap will be generated for you, which "+
 		"you can then configure with sqoopctl")
+	addVersionFlags(schemaUpdateCmd, &schemaUpdateOpts.Version)
 	schemaCmd.AddCommand(schemaUpdateCmd)
 }
 
-func updateSchema(name, filename, resolvermap string) error {
+func updateSchema(name, filename, resolvermap string, version versionOpts) error {
 	cli, err := sqoopctl.MakeClient()
 	if err != nil {
 		return err
@@ -64,6 +66,9 @@ func updateSchema(name, filename, resolvermap string) error {
 		Name:         name,
 		InlineSchema: string(inlineSchemaBytes),
 		ResolverMap:  resolvermap,
+		Version:      version.Version,
+		Alias:        version.Alias,
+		Sunset:       version.Sunset,
 		Metadata:     existing.Metadata,
 	}
 	_, err = cli.V1().Schemas().Update(schema)
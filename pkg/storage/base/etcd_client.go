@@ -0,0 +1,261 @@
+package base
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
+)
+
+const (
+	// how long a single request to etcd may take
+	etcdRequestTimeout = 10 * time.Second
+	// how long to wait before restarting a watch after failing to list the items
+	etcdRetryDelay = time.Second
+)
+
+// EtcdStorageClient stores items of a single type as keys under rootPath, holding the serialized item.
+// the resource version of an item is the revision it was last modified at
+type EtcdStorageClient struct {
+	rootPath      string
+	itemType      StorableItemType
+	etcd          *clientv3.Client
+	syncFrequency time.Duration
+}
+
+func NewEtcdStorageClient(rootPath string, itemType StorableItemType, etcd *clientv3.Client, syncFrequency time.Duration) *EtcdStorageClient {
+	return &EtcdStorageClient{
+		rootPath:      rootPath,
+		itemType:      itemType,
+		etcd:          etcd,
+		syncFrequency: syncFrequency,
+	}
+}
+
+func (c *EtcdStorageClient) Create(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "serializing %s", item.GetName())
+	}
+	k := key(c.rootPath, item.GetName())
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	// create the key only if it doesn't exist yet
+	resp, err := c.etcd.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(k), "=", 0)).
+		Then(clientv3.OpPut(k, string(data))).
+		Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing key %s", k)
+	}
+	if !resp.Succeeded {
+		return nil, storage.NewAlreadyExistsErr(
+			errors.Errorf("key found for storageItem %s: %s", item.GetName(), k))
+	}
+	cfgObject, err := c.Get(item.GetName())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting newly created cfg object %s", k)
+	}
+	return cfgObject, nil
+}
+
+func (c *EtcdStorageClient) Update(item *StorableItem) (*StorableItem, error) {
+	data, err := item.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "serializing %s", item.GetName())
+	}
+	k := key(c.rootPath, item.GetName())
+	// the item must exist, and must not have changed since it was read if it carries a resource version
+	cmp := clientv3.Compare(clientv3.CreateRevision(k), ">", 0)
+	if rv := item.GetResourceVersion(); rv != "" {
+		revision, err := strconv.ParseInt(rv, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid resource version %q for storageItem %s", rv, item.GetName())
+		}
+		cmp = clientv3.Compare(clientv3.ModRevision(k), "=", revision)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	resp, err := c.etcd.Txn(ctx).
+		If(cmp).
+		Then(clientv3.OpPut(k, string(data))).
+		Else(clientv3.OpGet(k)).
+		Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "writing key %s", k)
+	}
+	if !resp.Succeeded {
+		if len(resp.Responses) == 0 || len(resp.Responses[0].GetResponseRange().Kvs) == 0 {
+			return nil, errors.Errorf("key not found for storageItem %s: %s", item.GetName(), k)
+		}
+		return nil, errors.Errorf("resource version was invalid for storageItem: %s", item.GetName())
+	}
+	cfgObject, err := c.Get(item.GetName())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting updated cfg object %s", k)
+	}
+	return cfgObject, nil
+}
+
+func (c *EtcdStorageClient) Delete(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	if _, err := c.etcd.Delete(ctx, key(c.rootPath, name)); err != nil {
+		return errors.Wrapf(err, "deleting %s", name)
+	}
+	return nil
+}
+
+func (c *EtcdStorageClient) Get(name string) (*StorableItem, error) {
+	k := key(c.rootPath, name)
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	resp, err := c.etcd.Get(ctx, k)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting key %v", k)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, errors.Errorf("key %s not found for storageItem %s", k, name)
+	}
+	obj, err := c.itemFromKeyValue(resp.Kvs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "converting etcd key to storageItem")
+	}
+	return obj, nil
+}
+
+func (c *EtcdStorageClient) List() ([]*StorableItem, error) {
+	items, _, err := c.list()
+	return items, err
+}
+
+// list returns the items and the revision of the store they were read at
+func (c *EtcdStorageClient) list() ([]*StorableItem, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	resp, err := c.etcd.Get(ctx, c.rootPath+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "listing keys for root %s", c.rootPath)
+	}
+	var storageItems []*StorableItem
+	for _, kv := range resp.Kvs {
+		obj, err := c.itemFromKeyValue(kv)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "converting %s to storageItem", kv.Key)
+		}
+		storageItems = append(storageItems, obj)
+	}
+	return storageItems, resp.Header.Revision, nil
+}
+
+// Watch calls OnUpdate of the handlers with every item whenever an item is created, updated or deleted.
+// the items are also listed every sync frequency, in case the watch misses an event
+func (c *EtcdStorageClient) Watch(handlers ...StorableItemEventHandler) (*storage.Watcher, error) {
+	sync := func() (int64, error) {
+		items, revision, err := c.list()
+		if err != nil {
+			return 0, err
+		}
+		var (
+			resolverMaps []*v1.ResolverMap
+			schemas      []*v1.Schema
+		)
+		for _, item := range items {
+			switch {
+			case item.Schema != nil:
+				schemas = append(schemas, item.Schema)
+			case item.ResolverMap != nil:
+				resolverMaps = append(resolverMaps, item.ResolverMap)
+			}
+		}
+		for _, h := range handlers {
+			if h.SchemaEventHandler != nil {
+				h.SchemaEventHandler.OnUpdate(schemas, nil)
+			}
+			if h.ResolverMapEventHandler != nil {
+				h.ResolverMapEventHandler.OnUpdate(resolverMaps, nil)
+			}
+		}
+		return revision, nil
+	}
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var resync <-chan time.Time
+		if c.syncFrequency > 0 {
+			ticker := time.NewTicker(c.syncFrequency)
+			defer ticker.Stop()
+			resync = ticker.C
+		}
+		var events clientv3.WatchChan
+		for {
+			if events == nil {
+				revision, err := sync()
+				if err != nil {
+					log.Warnf("error syncing with etcd keys: %v", err)
+					select {
+					case <-time.After(etcdRetryDelay):
+					case <-stop:
+						return
+					}
+					continue
+				}
+				// watch from the revision the items were listed at, so no change is missed in between
+				events = c.etcd.Watch(clientv3.WithRequireLeader(ctx), c.rootPath+"/",
+					clientv3.WithPrefix(), clientv3.WithRev(revision+1))
+			}
+			select {
+			case resp, ok := <-events:
+				if !ok || resp.Canceled {
+					// the watch ended, e.g. because the revision was compacted or the leader was lost.
+					// start over with a fresh list
+					log.Warnf("etcd watch for %s ended: %v", c.rootPath, resp.Err())
+					events = nil
+					continue
+				}
+				if len(resp.Events) == 0 {
+					continue
+				}
+				if _, err := sync(); err != nil {
+					log.Warnf("error syncing with etcd keys: %v", err)
+				}
+			case <-resync:
+				if _, err := sync(); err != nil {
+					log.Warnf("error syncing with etcd keys: %v", err)
+				}
+			case err := <-errs:
+				log.Warnf("failed to start watcher to: %v", err)
+				return
+			case <-stop:
+				return
+			}
+		}
+	}), nil
+}
+
+func (c *EtcdStorageClient) itemFromKeyValue(kv *mvccpb.KeyValue) (*StorableItem, error) {
+	item := &StorableItem{}
+	switch c.itemType {
+	case StorableItemTypeSchema:
+		var schema v1.Schema
+		if err := proto.Unmarshal(kv.Value, &schema); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling value as schema")
+		}
+		item.Schema = &schema
+	case StorableItemTypeResolverMap:
+		var resolverMap v1.ResolverMap
+		if err := proto.Unmarshal(kv.Value, &resolverMap); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling value as resolver map")
+		}
+		item.ResolverMap = &resolverMap
+	}
+	item.SetResourceVersion(strconv.FormatInt(kv.ModRevision, 10))
+	return item, nil
+}
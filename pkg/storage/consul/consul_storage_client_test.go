@@ -14,6 +14,7 @@ import (
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
 	"github.com/solo-io/sqoop/pkg/storage"
 	. "github.com/solo-io/sqoop/pkg/storage/consul"
+	"github.com/solo-io/sqoop/pkg/storage/storagetest"
 )
 
 var _ = Describe("ConsulStorageClient", func() {
@@ -375,3 +376,19 @@ var _ = Describe("ConsulStorageClient", func() {
 		})
 	})
 })
+
+var _ = Describe("consul storage", func() {
+	var rootPath string
+	AfterEach(func() {
+		consul, err := api.NewClient(api.DefaultConfig())
+		Expect(err).NotTo(HaveOccurred())
+		consul.KV().DeleteTree(rootPath, nil)
+	})
+	storagetest.Conformance(func() storage.Interface {
+		rootPath = helpers.RandString(4)
+		client, err := NewStorage(api.DefaultConfig(), rootPath, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.V1().Register()).To(Succeed())
+		return client
+	})
+})
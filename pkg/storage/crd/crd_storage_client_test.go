@@ -12,8 +12,10 @@ import (
 	"github.com/solo-io/gloo/pkg/log"
 	. "github.com/solo-io/gloo/test/helpers"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
 	. "github.com/solo-io/sqoop/pkg/storage/crd"
 	crdv1 "github.com/solo-io/sqoop/pkg/storage/crd/solo.io/v1"
+	"github.com/solo-io/sqoop/pkg/storage/storagetest"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiexts "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -222,6 +224,26 @@ var _ = Describe("CrdStorageClient", func() {
 	})
 })
 
+var _ = Describe("crd storage", func() {
+	if os.Getenv("RUN_KUBE_TESTS") != "1" {
+		return
+	}
+	var namespace string
+	AfterEach(func() {
+		TeardownKube(namespace)
+	})
+	storagetest.Conformance(func() storage.Interface {
+		namespace = RandString(8)
+		Must(SetupKubeForTest(namespace))
+		cfg, err := clientcmd.BuildConfigFromFlags("", filepath.Join(os.Getenv("HOME"), ".kube", "config"))
+		Expect(err).NotTo(HaveOccurred())
+		client, err := NewStorage(cfg, namespace, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.V1().Register()).To(Succeed())
+		return client
+	})
+})
+
 func NewTestSchema1() *v1.Schema {
 	return &v1.Schema{
 		Name:         "schema1",
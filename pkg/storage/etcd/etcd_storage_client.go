@@ -0,0 +1,63 @@
+package etcd
+
+import (
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pkg/errors"
+	"github.com/solo-io/sqoop/pkg/storage"
+	"github.com/solo-io/sqoop/pkg/storage/base"
+)
+
+//go:generate go run ${GOPATH}/src/github.com/solo-io/sqoop/pkg/storage/generate/generate_clients.go -f ${GOPATH}/src/github.com/solo-io/sqoop/pkg/storage/etcd/client_template.go.tmpl -o ${GOPATH}/src/github.com/solo-io/sqoop/pkg/storage/etcd/
+type Client struct {
+	etcd *clientv3.Client
+	v1   *v1client
+}
+
+// NewStorage stores schemas and resolver maps in etcd, under rootPath/schemas and rootPath/resolverMaps.
+// watches are notified of changes as they happen, and resync every syncFrequency
+func NewStorage(cfg clientv3.Config, rootPath string, syncFrequency time.Duration) (storage.Interface, error) {
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating etcd client")
+	}
+
+	return &Client{
+		etcd: client,
+		v1: &v1client{
+			schemas: &schemasClient{
+				base: base.NewEtcdStorageClient(rootPath+"/schemas", base.StorableItemTypeSchema, client, syncFrequency),
+			},
+			resolverMaps: &resolverMapsClient{
+				base: base.NewEtcdStorageClient(rootPath+"/resolverMaps", base.StorableItemTypeResolverMap, client, syncFrequency),
+			},
+		},
+	}, nil
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+// Close closes the connections to etcd
+func (c *Client) Close() error {
+	return c.etcd.Close()
+}
+
+type v1client struct {
+	schemas      *schemasClient
+	resolverMaps *resolverMapsClient
+}
+
+func (c *v1client) Register() error {
+	return nil
+}
+
+func (c *v1client) Schemas() storage.Schemas {
+	return c.schemas
+}
+
+func (c *v1client) ResolverMaps() storage.ResolverMaps {
+	return c.resolverMaps
+}
@@ -0,0 +1,119 @@
+package etcd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/proto"
+	"github.com/solo-io/gloo/test/helpers"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
+	. "github.com/solo-io/sqoop/pkg/storage/etcd"
+	"github.com/solo-io/sqoop/pkg/storage/storagetest"
+)
+
+var cfg = clientv3.Config{Endpoints: []string{"localhost:2379"}, DialTimeout: 5 * time.Second}
+
+var _ = Describe("EtcdStorageClient", func() {
+	var (
+		rootPath string
+		etcd     *clientv3.Client
+		client   storage.Interface
+	)
+	BeforeEach(func() {
+		rootPath = helpers.RandString(4)
+		var err error
+		etcd, err = clientv3.New(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		client, err = NewStorage(cfg, rootPath, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		etcd.Delete(context.Background(), rootPath+"/", clientv3.WithPrefix())
+		etcd.Close()
+		client.(*Client).Close()
+	})
+	Describe("Schemas", func() {
+		input := func(name string) *v1.Schema {
+			return &v1.Schema{
+				Name:         name,
+				InlineSchema: "foo",
+				ResolverMap:  "myresolvers",
+			}
+		}
+		It("creates the schema as an etcd key", func() {
+			in := input("myschema")
+			schema, err := client.V1().Schemas().Create(in)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := etcd.Get(context.Background(), rootPath+"/schemas/myschema")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Kvs).To(HaveLen(1))
+			var stored v1.Schema
+			Expect(proto.Unmarshal(resp.Kvs[0].Value, &stored)).To(Succeed())
+			Expect(&stored).To(Equal(in))
+			Expect(schema.Metadata.ResourceVersion).To(Equal(strconv.FormatInt(resp.Kvs[0].ModRevision, 10)))
+			in.Metadata = schema.Metadata
+			Expect(schema).To(Equal(in))
+		})
+		It("updates the schema only if it exists and the resource version is up to date", func() {
+			_, err := client.V1().Schemas().Update(input("myschema"))
+			Expect(err).To(MatchError(ContainSubstring("key not found")))
+			created, err := client.V1().Schemas().Create(input("myschema"))
+			Expect(err).NotTo(HaveOccurred())
+			changed := input("myschema")
+			changed.InlineSchema = "bar"
+			changed.Metadata = created.Metadata
+			updated, err := client.V1().Schemas().Update(changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.InlineSchema).To(Equal("bar"))
+			Expect(updated.Metadata.ResourceVersion).NotTo(Equal(created.Metadata.ResourceVersion))
+			// the resource version of the first update is stale now
+			_, err = client.V1().Schemas().Update(changed)
+			Expect(err).To(MatchError(ContainSubstring("resource version was invalid")))
+			out, err := client.V1().Schemas().Get("myschema")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(updated))
+		})
+	})
+	Describe("ResolverMaps", func() {
+		It("stores resolver maps separately from schemas", func() {
+			resolverMap, err := client.V1().ResolverMaps().Create(&v1.ResolverMap{Name: "myresolvers"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Schemas().Create(&v1.Schema{Name: "myresolvers", InlineSchema: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := etcd.Get(context.Background(), rootPath+"/resolverMaps/myresolvers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Kvs).To(HaveLen(1))
+			list, err := client.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(resolverMap))
+		})
+	})
+})
+
+var _ = Describe("etcd storage", func() {
+	var (
+		rootPath string
+		client   storage.Interface
+	)
+	AfterEach(func() {
+		etcd, err := clientv3.New(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		defer etcd.Close()
+		etcd.Delete(context.Background(), rootPath+"/", clientv3.WithPrefix())
+		client.(*Client).Close()
+	})
+	storagetest.Conformance(func() storage.Interface {
+		rootPath = helpers.RandString(4)
+		var err error
+		client, err = NewStorage(cfg, rootPath, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.V1().Register()).To(Succeed())
+		return client
+	})
+})
@@ -0,0 +1,20 @@
+package etcd_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/pkg/log"
+)
+
+func TestEtcd(t *testing.T) {
+	if os.Getenv("RUN_ETCD_TESTS") != "1" {
+		log.Printf("This test requires etcd listening on localhost:2379 and is disabled by default. To enable, set RUN_ETCD_TESTS=1 in your env.")
+		return
+	}
+	RegisterFailHandler(Fail)
+	log.DefaultOut = GinkgoWriter
+	RunSpecs(t, "Etcd Suite")
+}
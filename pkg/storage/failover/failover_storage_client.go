@@ -0,0 +1,376 @@
+// Package failover combines a primary and a secondary storage backend, e.g. kube and a directory of files
+// exported from it, so Sqoop keeps serving the last config it can read when the primary becomes unavailable
+package failover
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/solo-io/gloo/pkg/log"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
+)
+
+const (
+	primaryBackend   = "primary"
+	secondaryBackend = "secondary"
+)
+
+var (
+	// the backend config is currently read from
+	activeBackend = expvar.NewString("sqoop_storage_active_backend")
+	// the number of times config was switched to the secondary backend
+	failovers = expvar.NewInt("sqoop_storage_failovers")
+)
+
+// Client reads config from the primary backend while it is healthy, and from the secondary otherwise.
+// writes always go to the primary, the secondary is only read. the primary is unhealthy from the moment
+// listing or watching it fails until it can be listed again, which is checked every check interval
+type Client struct {
+	primary, secondary storage.Interface
+	checkInterval      time.Duration
+
+	mu             sync.Mutex
+	primaryHealthy bool
+	// notified whenever the active backend changes
+	subscribers []chan struct{}
+
+	v1 *v1client
+}
+
+// NewStorage returns storage which fails over from primary to secondary
+func NewStorage(primary, secondary storage.Interface, checkInterval time.Duration) *Client {
+	if checkInterval <= 0 {
+		checkInterval = 10 * time.Second
+	}
+	c := &Client{
+		primary:        primary,
+		secondary:      secondary,
+		checkInterval:  checkInterval,
+		primaryHealthy: true,
+	}
+	c.v1 = &v1client{
+		client:       c,
+		schemas:      &schemasClient{client: c},
+		resolverMaps: &resolverMapsClient{client: c},
+	}
+	activeBackend.Set(primaryBackend)
+	return c
+}
+
+func (c *Client) V1() storage.V1 {
+	return c.v1
+}
+
+// PrimaryHealthy returns whether config is read from the primary backend
+func (c *Client) PrimaryHealthy() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.primaryHealthy
+}
+
+// active returns the backend to read from
+func (c *Client) active() storage.Interface {
+	if c.PrimaryHealthy() {
+		return c.primary
+	}
+	return c.secondary
+}
+
+func (c *Client) setPrimaryHealthy(healthy bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.primaryHealthy == healthy {
+		return
+	}
+	c.primaryHealthy = healthy
+	if healthy {
+		log.Printf("primary storage recovered, reading config from the primary")
+		activeBackend.Set(primaryBackend)
+	} else {
+		log.Warnf("primary storage failed, reading config from the secondary: %v", err)
+		activeBackend.Set(secondaryBackend)
+		failovers.Add(1)
+	}
+	for _, subscriber := range c.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *Client) subscribe() chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	subscriber := make(chan struct{}, 1)
+	c.subscribers = append(c.subscribers, subscriber)
+	return subscriber
+}
+
+func (c *Client) unsubscribe(subscriber chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, s := range c.subscribers {
+		if s == subscriber {
+			c.subscribers = append(c.subscribers[:i], c.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Client) checkPrimary() {
+	_, err := c.primary.V1().Schemas().List()
+	c.setPrimaryHealthy(err == nil, err)
+}
+
+// list lists from the primary while it is healthy, failing over to the secondary if listing fails.
+// gets don't fail over, as storage doesn't tell missing items apart from failures
+func (c *Client) list(list func(storage.Interface) error) error {
+	if c.PrimaryHealthy() {
+		err := list(c.primary)
+		if err == nil {
+			return nil
+		}
+		c.setPrimaryHealthy(false, err)
+	}
+	return list(c.secondary)
+}
+
+type v1client struct {
+	client       *Client
+	schemas      *schemasClient
+	resolverMaps *resolverMapsClient
+}
+
+// Register registers both backends. a primary which fails to register is unhealthy from the start,
+// registration only fails if the secondary fails too
+func (c *v1client) Register() error {
+	primaryErr := c.client.primary.V1().Register()
+	if primaryErr != nil && !storage.IsAlreadyExists(primaryErr) {
+		c.client.setPrimaryHealthy(false, primaryErr)
+	}
+	if err := c.client.secondary.V1().Register(); err != nil && !storage.IsAlreadyExists(err) {
+		if primaryErr != nil && !storage.IsAlreadyExists(primaryErr) {
+			return errors.Wrapf(err, "registering secondary storage after the primary failed (%v)", primaryErr)
+		}
+		log.Warnf("registering secondary storage: %v", err)
+	}
+	return nil
+}
+
+func (c *v1client) Schemas() storage.Schemas {
+	return c.schemas
+}
+
+func (c *v1client) ResolverMaps() storage.ResolverMaps {
+	return c.resolverMaps
+}
+
+// watch runs a watch of each backend, passing on the lists of the active backend. when the active backend
+// changes, its latest list is passed on. a watch of the primary which returns is restarted once the primary
+// is healthy again. lists are passed on as interface{} so schemas and resolver maps share the failover logic
+type watch struct {
+	client *Client
+	// start watching a backend, calling onList with the full list on every change
+	start func(backend storage.Interface, onList func(list interface{})) (*storage.Watcher, error)
+	// pass a list of the active backend on to the handlers of the watch
+	deliver func(list interface{})
+
+	mu sync.Mutex
+	// the latest list received from each backend, nil if none has been received
+	lists map[storage.Interface]interface{}
+}
+
+func (w *watch) onList(backend storage.Interface) func(list interface{}) {
+	return func(list interface{}) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.lists[backend] = list
+		if w.client.active() == backend {
+			w.deliver(list)
+		}
+	}
+}
+
+func (w *watch) watcher() (*storage.Watcher, error) {
+	w.lists = make(map[storage.Interface]interface{})
+	secondaryWatcher, err := w.start(w.client.secondary, w.onList(w.client.secondary))
+	if err != nil {
+		return nil, errors.Wrap(err, "watching secondary storage")
+	}
+	primaryWatcher, err := w.start(w.client.primary, w.onList(w.client.primary))
+	if err != nil {
+		// retried when the primary recovers
+		w.client.setPrimaryHealthy(false, err)
+	}
+	return storage.NewWatcher(func(stop <-chan struct{}, errs chan error) {
+		switched := w.client.subscribe()
+		defer w.client.unsubscribe(switched)
+		primaryErrs := make(chan error)
+		secondaryErrs := make(chan error)
+		// the watch of the primary returned, e.g. after failing to list, and must be restarted
+		primaryStopped := make(chan struct{})
+		runPrimary := func(primaryWatcher *storage.Watcher) {
+			primaryWatcher.Run(stop, primaryErrs)
+			select {
+			case primaryStopped <- struct{}{}:
+			case <-stop:
+			}
+		}
+		go secondaryWatcher.Run(stop, secondaryErrs)
+		if primaryWatcher != nil {
+			go runPrimary(primaryWatcher)
+		}
+		check := time.NewTicker(w.client.checkInterval)
+		defer check.Stop()
+		forward := func(err error) {
+			select {
+			case errs <- err:
+			case <-stop:
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				return
+			case err := <-primaryErrs:
+				w.client.setPrimaryHealthy(false, err)
+				forward(errors.Wrap(err, "primary storage"))
+			case err := <-secondaryErrs:
+				forward(errors.Wrap(err, "secondary storage"))
+			case <-primaryStopped:
+				// the list of the stopped watch is not kept up to date anymore
+				primaryWatcher = nil
+				w.mu.Lock()
+				delete(w.lists, w.client.primary)
+				w.mu.Unlock()
+			case <-check.C:
+				w.client.checkPrimary()
+				if primaryWatcher == nil && w.client.PrimaryHealthy() {
+					if primaryWatcher, err = w.start(w.client.primary, w.onList(w.client.primary)); err != nil {
+						w.client.setPrimaryHealthy(false, err)
+						continue
+					}
+					go runPrimary(primaryWatcher)
+				}
+			case <-switched:
+				w.mu.Lock()
+				if list, ok := w.lists[w.client.active()]; ok {
+					w.deliver(list)
+				}
+				w.mu.Unlock()
+			}
+		}
+	}), nil
+}
+
+type schemasClient struct {
+	client *Client
+}
+
+func (c *schemasClient) Create(item *v1.Schema) (*v1.Schema, error) {
+	return c.client.primary.V1().Schemas().Create(item)
+}
+
+func (c *schemasClient) Update(item *v1.Schema) (*v1.Schema, error) {
+	return c.client.primary.V1().Schemas().Update(item)
+}
+
+func (c *schemasClient) Delete(name string) error {
+	return c.client.primary.V1().Schemas().Delete(name)
+}
+
+func (c *schemasClient) Get(name string) (*v1.Schema, error) {
+	return c.client.active().V1().Schemas().Get(name)
+}
+
+func (c *schemasClient) List() ([]*v1.Schema, error) {
+	var list []*v1.Schema
+	err := c.client.list(func(backend storage.Interface) error {
+		var err error
+		list, err = backend.V1().Schemas().List()
+		return err
+	})
+	return list, err
+}
+
+// Watch calls OnUpdate with the full list of the backend config is now read from whenever it changes,
+// without the updated schema
+func (c *schemasClient) Watch(handlers ...storage.SchemaEventHandler) (*storage.Watcher, error) {
+	w := &watch{
+		client: c.client,
+		start: func(backend storage.Interface, onList func(list interface{})) (*storage.Watcher, error) {
+			onChange := func(list []*v1.Schema, _ *v1.Schema) {
+				onList(list)
+			}
+			return backend.V1().Schemas().Watch(&storage.SchemaEventHandlerFuncs{
+				AddFunc:    onChange,
+				UpdateFunc: onChange,
+				DeleteFunc: onChange,
+			})
+		},
+		deliver: func(list interface{}) {
+			for _, h := range handlers {
+				h.OnUpdate(list.([]*v1.Schema), nil)
+			}
+		},
+	}
+	return w.watcher()
+}
+
+type resolverMapsClient struct {
+	client *Client
+}
+
+func (c *resolverMapsClient) Create(item *v1.ResolverMap) (*v1.ResolverMap, error) {
+	return c.client.primary.V1().ResolverMaps().Create(item)
+}
+
+func (c *resolverMapsClient) Update(item *v1.ResolverMap) (*v1.ResolverMap, error) {
+	return c.client.primary.V1().ResolverMaps().Update(item)
+}
+
+func (c *resolverMapsClient) Delete(name string) error {
+	return c.client.primary.V1().ResolverMaps().Delete(name)
+}
+
+func (c *resolverMapsClient) Get(name string) (*v1.ResolverMap, error) {
+	return c.client.active().V1().ResolverMaps().Get(name)
+}
+
+func (c *resolverMapsClient) List() ([]*v1.ResolverMap, error) {
+	var list []*v1.ResolverMap
+	err := c.client.list(func(backend storage.Interface) error {
+		var err error
+		list, err = backend.V1().ResolverMaps().List()
+		return err
+	})
+	return list, err
+}
+
+// Watch calls OnUpdate with the full list of the backend config is now read from whenever it changes,
+// without the updated resolver map
+func (c *resolverMapsClient) Watch(handlers ...storage.ResolverMapEventHandler) (*storage.Watcher, error) {
+	w := &watch{
+		client: c.client,
+		start: func(backend storage.Interface, onList func(list interface{})) (*storage.Watcher, error) {
+			onChange := func(list []*v1.ResolverMap, _ *v1.ResolverMap) {
+				onList(list)
+			}
+			return backend.V1().ResolverMaps().Watch(&storage.ResolverMapEventHandlerFuncs{
+				AddFunc:    onChange,
+				UpdateFunc: onChange,
+				DeleteFunc: onChange,
+			})
+		},
+		deliver: func(list interface{}) {
+			for _, h := range handlers {
+				h.OnUpdate(list.([]*v1.ResolverMap), nil)
+			}
+		},
+	}
+	return w.watcher()
+}
@@ -0,0 +1,138 @@
+package failover_test
+
+import (
+	"expvar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
+	. "github.com/solo-io/sqoop/pkg/storage/failover"
+	"github.com/solo-io/sqoop/pkg/storage/file"
+)
+
+var _ = Describe("FailoverStorageClient", func() {
+	var (
+		primaryDir, secondaryDir string
+		client                   *Client
+	)
+	newFileStorage := func(dir string) storage.Interface {
+		s, err := file.NewStorage(dir, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.V1().Register()).To(Succeed())
+		return s
+	}
+	BeforeEach(func() {
+		var err error
+		primaryDir, err = ioutil.TempDir("", "failoverprimary")
+		Expect(err).NotTo(HaveOccurred())
+		secondaryDir, err = ioutil.TempDir("", "failoversecondary")
+		Expect(err).NotTo(HaveOccurred())
+		primary, secondary := newFileStorage(primaryDir), newFileStorage(secondaryDir)
+		_, err = primary.V1().Schemas().Create(&v1.Schema{Name: "primary", InlineSchema: "type Query { a: String }"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = secondary.V1().Schemas().Create(&v1.Schema{Name: "secondary", InlineSchema: "type Query { a: String }"})
+		Expect(err).NotTo(HaveOccurred())
+		client = NewStorage(primary, secondary, time.Second)
+		Expect(client.V1().Register()).To(Succeed())
+	})
+	AfterEach(func() {
+		os.RemoveAll(primaryDir)
+		os.RemoveAll(secondaryDir)
+	})
+	names := func() []string {
+		schemas, err := client.V1().Schemas().List()
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, schema := range schemas {
+			names = append(names, schema.Name)
+		}
+		return names
+	}
+	It("reads from the primary while it is healthy", func() {
+		Expect(names()).To(Equal([]string{"primary"}))
+		Expect(client.PrimaryHealthy()).To(BeTrue())
+		Expect(expvar.Get("sqoop_storage_active_backend").String()).To(Equal(`"primary"`))
+	})
+	It("fails over to the secondary when the primary can't be read", func() {
+		Expect(os.RemoveAll(filepath.Join(primaryDir, "schemas"))).To(Succeed())
+		Expect(names()).To(Equal([]string{"secondary"}))
+		Expect(client.PrimaryHealthy()).To(BeFalse())
+		Expect(expvar.Get("sqoop_storage_active_backend").String()).To(Equal(`"secondary"`))
+	})
+	Describe("Watch", func() {
+		var (
+			mu      sync.Mutex
+			watched []string
+			stop    chan struct{}
+			w       *storage.Watcher
+		)
+		BeforeEach(func() {
+			watched = nil
+			stop = make(chan struct{})
+			var err error
+			w, err = client.V1().Schemas().Watch(&storage.SchemaEventHandlerFuncs{
+				UpdateFunc: func(list []*v1.Schema, _ *v1.Schema) {
+					mu.Lock()
+					defer mu.Unlock()
+					watched = nil
+					for _, schema := range list {
+						watched = append(watched, schema.Name)
+					}
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		AfterEach(func() {
+			close(stop)
+		})
+		latest := func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return watched
+		}
+		run := func() {
+			go w.Run(stop, make(chan error, 100))
+		}
+		// makes the primary readable again, holding only the given schema
+		recoverPrimary := func(name string) {
+			primary, err := file.NewStorage(primaryDir, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(primary.V1().Register()).To(Succeed())
+			_, err = primary.V1().Schemas().Create(&v1.Schema{Name: name, InlineSchema: "type Query { a: String }"})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("switches to the secondary when the primary fails and back once it recovers", func() {
+			run()
+			Eventually(latest, 5*time.Second).Should(Equal([]string{"primary"}))
+
+			Expect(os.RemoveAll(filepath.Join(primaryDir, "schemas"))).To(Succeed())
+			Eventually(latest, 5*time.Second).Should(Equal([]string{"secondary"}))
+			Expect(client.PrimaryHealthy()).To(BeFalse())
+
+			recoverPrimary("recovered")
+			Eventually(latest, 10*time.Second).Should(Equal([]string{"recovered"}))
+			Expect(client.PrimaryHealthy()).To(BeTrue())
+		})
+		It("restarts a watch of the primary which returned after failing", func() {
+			// the watch of the primary fails to list when it starts, and returns
+			Expect(os.RemoveAll(filepath.Join(primaryDir, "schemas"))).To(Succeed())
+			run()
+			Eventually(latest, 5*time.Second).Should(Equal([]string{"secondary"}))
+
+			recoverPrimary("recovered")
+			Eventually(latest, 10*time.Second).Should(Equal([]string{"recovered"}))
+		})
+	})
+	It("writes to the primary", func() {
+		_, err := client.V1().Schemas().Create(&v1.Schema{Name: "written", InlineSchema: "type Query { a: String }"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names()).To(ConsistOf("primary", "written"))
+	})
+})
@@ -12,7 +12,9 @@ import (
 	"github.com/solo-io/gloo/pkg/log"
 	. "github.com/solo-io/gloo/test/helpers"
 	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
 	. "github.com/solo-io/sqoop/pkg/storage/file"
+	"github.com/solo-io/sqoop/pkg/storage/storagetest"
 )
 
 var _ = Describe("CrdStorageClient", func() {
@@ -154,6 +156,22 @@ var _ = Describe("CrdStorageClient", func() {
 	})
 })
 
+var _ = Describe("file storage", func() {
+	var dir string
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+	storagetest.Conformance(func() storage.Interface {
+		var err error
+		dir, err = ioutil.TempDir("", "filestoragetest")
+		Expect(err).NotTo(HaveOccurred())
+		client, err := NewStorage(dir, 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.V1().Register()).To(Succeed())
+		return client
+	})
+})
+
 func NewTestSchema1() *v1.Schema {
 	return &v1.Schema{
 		Name:         "schema1",
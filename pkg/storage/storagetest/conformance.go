@@ -0,0 +1,213 @@
+// Package storagetest holds the specs every storage backend must pass, so that Sqoop behaves the same
+// whichever backend stores its config
+package storagetest
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gloov1 "github.com/solo-io/gloo/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/api/types/v1"
+	"github.com/solo-io/sqoop/pkg/storage"
+)
+
+// backends which poll their storage may take up to their sync frequency to notice a change
+const watchTimeout = 10 * time.Second
+
+// Conformance registers the shared storage specs. it must be called inside a Describe.
+// newClient returns a registered client with empty storage, and is called before every spec
+func Conformance(newClient func() storage.Interface) {
+	var client storage.Interface
+	BeforeEach(func() {
+		client = newClient()
+	})
+
+	Describe("Schemas", func() {
+		schema := func(name string) *v1.Schema {
+			return &v1.Schema{
+				Name:         name,
+				InlineSchema: "type Query { hero: String }",
+				ResolverMap:  "resolvers",
+				Metadata:     &gloov1.Metadata{Annotations: map[string]string{"foo": "bar"}},
+			}
+		}
+		It("creates schemas and gets them by name", func() {
+			created, err := client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Name).To(Equal("schema1"))
+			Expect(created.InlineSchema).To(Equal(schema("schema1").InlineSchema))
+			Expect(created.Metadata.ResourceVersion).NotTo(BeEmpty())
+
+			got, err := client.V1().Schemas().Get("schema1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(created))
+		})
+		It("rejects creating a schema which already exists", func() {
+			_, err := client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).To(HaveOccurred())
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+		})
+		It("updates schemas with their current resource version", func() {
+			_, err := client.V1().Schemas().Update(schema("missing"))
+			Expect(err).To(HaveOccurred())
+
+			created, err := client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).NotTo(HaveOccurred())
+			changed := schema("schema1")
+			changed.InlineSchema = "type Query { villain: String }"
+			changed.Metadata = created.Metadata
+			updated, err := client.V1().Schemas().Update(changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.InlineSchema).To(Equal(changed.InlineSchema))
+
+			got, err := client.V1().Schemas().Get("schema1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(updated))
+		})
+		It("lists and deletes schemas", func() {
+			list, err := client.V1().Schemas().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+
+			schema1, err := client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).NotTo(HaveOccurred())
+			schema2, err := client.V1().Schemas().Create(schema("schema2"))
+			Expect(err).NotTo(HaveOccurred())
+			list, err = client.V1().Schemas().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(schema1, schema2))
+
+			Expect(client.V1().Schemas().Delete("schema1")).To(Succeed())
+			_, err = client.V1().Schemas().Get("schema1")
+			Expect(err).To(HaveOccurred())
+			list, err = client.V1().Schemas().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(schema2))
+		})
+		It("watches the list of schemas", func() {
+			var (
+				mu     sync.Mutex
+				latest []*v1.Schema
+			)
+			update := func(list []*v1.Schema, _ *v1.Schema) {
+				mu.Lock()
+				defer mu.Unlock()
+				latest = list
+			}
+			names := func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				var names []string
+				for _, schema := range latest {
+					names = append(names, schema.Name)
+				}
+				return names
+			}
+			w, err := client.V1().Schemas().Watch(&storage.SchemaEventHandlerFuncs{
+				AddFunc:    update,
+				UpdateFunc: update,
+				DeleteFunc: update,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			stop := make(chan struct{})
+			defer close(stop)
+			go w.Run(stop, make(chan error, 10))
+
+			_, err = client.V1().Schemas().Create(schema("schema1"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Schemas().Create(schema("schema2"))
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(names, watchTimeout).Should(ConsistOf("schema1", "schema2"))
+
+			Expect(client.V1().Schemas().Delete("schema1")).To(Succeed())
+			Eventually(names, watchTimeout).Should(ConsistOf("schema2"))
+		})
+	})
+
+	Describe("ResolverMaps", func() {
+		resolverMap := func(name string) *v1.ResolverMap {
+			return &v1.ResolverMap{
+				Name:     name,
+				Metadata: &gloov1.Metadata{Annotations: map[string]string{"foo": "bar"}},
+			}
+		}
+		It("creates, updates, lists and deletes resolver maps", func() {
+			created, err := client.V1().ResolverMaps().Create(resolverMap("resolvers1"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().ResolverMaps().Create(resolverMap("resolvers1"))
+			Expect(storage.IsAlreadyExists(err)).To(BeTrue())
+
+			changed := resolverMap("resolvers1")
+			changed.Metadata = created.Metadata
+			changed.Metadata.Annotations = map[string]string{"foo": "baz"}
+			updated, err := client.V1().ResolverMaps().Update(changed)
+			Expect(err).NotTo(HaveOccurred())
+			got, err := client.V1().ResolverMaps().Get("resolvers1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(updated))
+			Expect(got.Metadata.Annotations).To(Equal(map[string]string{"foo": "baz"}))
+
+			list, err := client.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(updated))
+			Expect(client.V1().ResolverMaps().Delete("resolvers1")).To(Succeed())
+			list, err = client.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+		It("stores resolver maps separately from schemas of the same name", func() {
+			_, err := client.V1().ResolverMaps().Create(resolverMap("shared"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.V1().Schemas().Create(&v1.Schema{Name: "shared", InlineSchema: "type Query { hero: String }"})
+			Expect(err).NotTo(HaveOccurred())
+
+			resolverMaps, err := client.V1().ResolverMaps().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolverMaps).To(HaveLen(1))
+			schemas, err := client.V1().Schemas().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schemas).To(HaveLen(1))
+
+			Expect(client.V1().Schemas().Delete("shared")).To(Succeed())
+			_, err = client.V1().ResolverMaps().Get("shared")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("watches the list of resolver maps", func() {
+			var (
+				mu     sync.Mutex
+				latest []*v1.ResolverMap
+			)
+			update := func(list []*v1.ResolverMap, _ *v1.ResolverMap) {
+				mu.Lock()
+				defer mu.Unlock()
+				latest = list
+			}
+			names := func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				var names []string
+				for _, resolverMap := range latest {
+					names = append(names, resolverMap.Name)
+				}
+				return names
+			}
+			w, err := client.V1().ResolverMaps().Watch(&storage.ResolverMapEventHandlerFuncs{
+				AddFunc:    update,
+				UpdateFunc: update,
+				DeleteFunc: update,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			stop := make(chan struct{})
+			defer close(stop)
+			go w.Run(stop, make(chan error, 10))
+
+			_, err = client.V1().ResolverMaps().Create(resolverMap("resolvers1"))
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(names, watchTimeout).Should(ConsistOf("resolvers1"))
+		})
+	})
+}
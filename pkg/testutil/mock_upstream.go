@@ -0,0 +1,154 @@
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockUpstream is an http server which answers requests with canned responses by path, for end to end
+// tests of resolvers without external services. resolvers reach it through its URL, or through its
+// address when it stands in for the proxy of gloo resolvers
+type MockUpstream struct {
+	server    *httptest.Server
+	mu        sync.Mutex
+	responses map[string]*MockResponse
+	// requests answered with the failure of their response so far, by path
+	failures map[string]int
+	requests []RecordedRequest
+}
+
+// MockResponse is the canned response to requests for a path
+type MockResponse struct {
+	// defaults to 200
+	Status  int
+	Headers map[string]string
+	Body    string
+	// how long to wait before responding. requests cancelled while waiting are not answered
+	Latency time.Duration
+	// answer the first FailTimes requests with FailStatus, then with the response, e.g. to test retries
+	FailTimes int
+	// defaults to 503
+	FailStatus int
+	// close the connection without responding
+	Drop bool
+}
+
+// RecordedRequest is a request received by a mock upstream
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// NewMockUpstream starts a mock upstream. requests for paths without a response are answered with 404
+func NewMockUpstream() *MockUpstream {
+	m := &MockUpstream{
+		responses: make(map[string]*MockResponse),
+		failures:  make(map[string]int),
+	}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	return m
+}
+
+// Handle sets the response to requests for path, replacing any previous response
+func (m *MockUpstream) Handle(path string, response MockResponse) *MockUpstream {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[path] = &response
+	delete(m.failures, path)
+	return m
+}
+
+// HandleJSON responds to requests for path with a json body
+func (m *MockUpstream) HandleJSON(path, body string) *MockUpstream {
+	return m.Handle(path, MockResponse{Headers: map[string]string{"Content-Type": "application/json"}, Body: body})
+}
+
+// URL is the base url of the upstream, e.g. http://127.0.0.1:34567
+func (m *MockUpstream) URL() string {
+	return m.server.URL
+}
+
+// Addr is the host and port of the upstream, which gloo resolvers take as the address of the proxy
+func (m *MockUpstream) Addr() string {
+	return strings.TrimPrefix(m.server.URL, "http://")
+}
+
+// Requests returns the requests received for path so far, or every request if path is empty
+func (m *MockUpstream) Requests(path string) []RecordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var requests []RecordedRequest
+	for _, req := range m.requests {
+		if path == "" || req.Path == path {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+func (m *MockUpstream) Close() {
+	m.server.Close()
+}
+
+func (m *MockUpstream) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	m.mu.Lock()
+	m.requests = append(m.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header,
+		Body:   body,
+	})
+	response, ok := m.responses[r.URL.Path]
+	var fail bool
+	if ok && m.failures[r.URL.Path] < response.FailTimes {
+		m.failures[r.URL.Path]++
+		fail = true
+	}
+	m.mu.Unlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("no mock response for %v", r.URL.Path), http.StatusNotFound)
+		return
+	}
+	if response.Latency > 0 {
+		select {
+		case <-time.After(response.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if response.Drop {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+	if fail {
+		status := response.FailStatus
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	io.WriteString(w, response.Body)
+}
@@ -0,0 +1,56 @@
+package testutil_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/solo-io/sqoop/pkg/testutil"
+)
+
+var _ = Describe("MockUpstream", func() {
+	var upstream *MockUpstream
+	BeforeEach(func() {
+		upstream = NewMockUpstream()
+	})
+	AfterEach(func() {
+		upstream.Close()
+	})
+	post := func(path string) (int, string) {
+		res, err := http.Post(upstream.URL()+path, "application/json", bytes.NewBufferString(`{"id":1}`))
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return res.StatusCode, string(body)
+	}
+	It("answers with the canned response of the path and records requests", func() {
+		upstream.HandleJSON("/Query.hero", `{"name":"Luke"}`)
+		status, body := post("/Query.hero")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(Equal(`{"name":"Luke"}`))
+		status, _ = post("/Query.villain")
+		Expect(status).To(Equal(http.StatusNotFound))
+		Expect(upstream.Requests("/Query.hero")).To(HaveLen(1))
+		Expect(string(upstream.Requests("/Query.hero")[0].Body)).To(Equal(`{"id":1}`))
+		Expect(upstream.Requests("")).To(HaveLen(2))
+	})
+	It("fails the first requests before responding", func() {
+		upstream.Handle("/Query.hero", MockResponse{Body: "ok", FailTimes: 2, FailStatus: http.StatusBadGateway})
+		status, _ := post("/Query.hero")
+		Expect(status).To(Equal(http.StatusBadGateway))
+		status, _ = post("/Query.hero")
+		Expect(status).To(Equal(http.StatusBadGateway))
+		status, body := post("/Query.hero")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("ok"))
+	})
+	It("drops connections without responding", func() {
+		upstream.Handle("/Query.hero", MockResponse{Drop: true})
+		_, err := http.Post(upstream.URL()+"/Query.hero", "application/json", nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
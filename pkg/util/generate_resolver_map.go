@@ -6,12 +6,35 @@ import (
 	"github.com/vektah/gqlgen/neelance/schema"
 )
 
+// GenerateResolverMapSkeleton creates an empty resolver for every field of every object type in the schema.
+// Types are visited once each from the schema's type map rather than by following field types,
+// so self-referencing and mutually recursive types do not cause repeated generation.
+// Types and fields are held in maps, which are marshalled with their keys sorted alphabetically,
+// so regenerating a skeleton for the same schema produces identical yaml and json
 func GenerateResolverMapSkeleton(name string, sch *schema.Schema) *v1.ResolverMap {
+	return generateSkeleton(name, sch, true)
+}
+
+// GenerateRootResolverMapSkeleton creates an empty resolver only for the fields of the schema's root operation types.
+// Fields of other types are resolved from the properties of their parent when they have no resolver,
+// so they are left out of the map
+func GenerateRootResolverMapSkeleton(name string, sch *schema.Schema) *v1.ResolverMap {
+	return generateSkeleton(name, sch, false)
+}
+
+func generateSkeleton(name string, sch *schema.Schema, includeDefaults bool) *v1.ResolverMap {
+	roots := make(map[string]bool)
+	for _, t := range sch.EntryPoints {
+		roots[t.TypeName()] = true
+	}
 	types := make(map[string]*v1.TypeResolver)
 	for _, t := range sch.Types {
 		if exec.MetaType(t.TypeName()) {
 			continue
 		}
+		if !includeDefaults && !roots[t.TypeName()] {
+			continue
+		}
 		fields := make(map[string]*v1.Resolver)
 		switch t := t.(type) {
 		case *schema.Object:
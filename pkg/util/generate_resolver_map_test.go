@@ -0,0 +1,70 @@
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"strings"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+	"github.com/solo-io/sqoop/pkg/sqoopctl"
+	. "github.com/solo-io/sqoop/pkg/util"
+)
+
+const cyclicSchema = `
+type Query {
+    comments: [Comment]
+    author(id: ID!): Author
+}
+type Comment {
+    text: String
+    author: Author
+    parent: Comment
+    replies: [Comment]
+}
+type Author {
+    name: String
+    comments: [Comment]
+    favoriteComment: Comment!
+}
+`
+
+var _ = Describe("GenerateResolverMapSkeleton", func() {
+	It("generates a resolver for each field of a cyclic schema exactly once", func() {
+		sch := exec.MustParseSchema(cyclicSchema)
+		resolverMap := GenerateResolverMapSkeleton("cyclic", sch.Schema)
+		Expect(resolverMap.Name).To(Equal("cyclic"))
+		Expect(resolverMap.Types).To(HaveLen(3))
+		Expect(resolverMap.Types["Query"].Fields).To(HaveLen(2))
+		Expect(resolverMap.Types["Comment"].Fields).To(HaveLen(4))
+		Expect(resolverMap.Types["Author"].Fields).To(HaveLen(3))
+		for _, fieldName := range []string{"text", "author", "parent", "replies"} {
+			Expect(resolverMap.Types["Comment"].Fields).To(HaveKey(fieldName))
+			Expect(resolverMap.Types["Comment"].Fields[fieldName].Resolver).To(BeNil())
+		}
+	})
+	It("generates resolvers only for root types when default resolvers are omitted", func() {
+		sch := exec.MustParseSchema(cyclicSchema)
+		resolverMap := GenerateRootResolverMapSkeleton("cyclic", sch.Schema)
+		Expect(resolverMap.Types).To(HaveLen(1))
+		Expect(resolverMap.Types["Query"].Fields).To(HaveLen(2))
+	})
+	It("marshals to identical output on every generation", func() {
+		sch := exec.MustParseSchema(cyclicSchema)
+		for _, format := range []string{"yaml", "json"} {
+			first, err := sqoopctl.Marshal(GenerateResolverMapSkeleton("cyclic", sch.Schema), format)
+			Expect(err).NotTo(HaveOccurred())
+			// map iteration order is randomized, so repeat enough times to catch unstable output
+			for i := 0; i < 20; i++ {
+				out, err := sqoopctl.Marshal(GenerateResolverMapSkeleton("cyclic", sch.Schema), format)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(Equal(first))
+			}
+			yml := string(first)
+			Expect(strings.Index(yml, "Author")).To(BeNumerically("<", strings.Index(yml, "Comment")))
+			Expect(strings.Index(yml, "Comment")).To(BeNumerically("<", strings.Index(yml, "Query")))
+			Expect(strings.Index(yml, "author")).To(BeNumerically("<", strings.Index(yml, "parent")))
+			Expect(strings.Index(yml, "parent")).To(BeNumerically("<", strings.Index(yml, "replies")))
+		}
+	})
+})
@@ -3,8 +3,10 @@ package util
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"text/template"
 
+	"github.com/pkg/errors"
 	"github.com/solo-io/sqoop/pkg/exec"
 )
 
@@ -18,6 +20,14 @@ func ExecTemplate(tmpl *template.Template, params exec.Params) (*bytes.Buffer, e
 	return &buf, err
 }
 
+// ExecTemplateValues executes a template with arguments and a parent object which are plain values,
+// e.g. samples generated from a schema
+func ExecTemplateValues(tmpl *template.Template, args, parent map[string]interface{}) (*bytes.Buffer, error) {
+	buf := bytes.Buffer{}
+	err := tmpl.Execute(&buf, newParams(args, parent, nil, nil, nil, nil))
+	return &buf, err
+}
+
 var templateFuncs = template.FuncMap{
 	"marshal": func(v interface{}) (string, error) {
 		a, err := json.Marshal(v)
@@ -26,11 +36,71 @@ var templateFuncs = template.FuncMap{
 		}
 		return string(a), nil
 	},
+	// the funcs below build structured values in templates, to be encoded with marshal rather than
+	// by writing json by hand, e.g. {{ marshal (dict "name" .Args.name "tags" (list "a" "b")) }}
+	"dict":    dict,
+	"list":    list,
+	"set":     set,
+	"hasKey":  hasKey,
+	"default": defaultValue,
+}
+
+// dict returns a map of alternating keys and values
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict requires pairs of keys and values")
+	}
+	d := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, errors.Errorf("dict keys must be strings, got %v", pairs[i])
+		}
+		d[key] = pairs[i+1]
+	}
+	return d, nil
+}
+
+func list(items ...interface{}) []interface{} {
+	return items
+}
+
+// set sets a key of a map built with dict and returns the map, which is discarded with {{ $_ := set $m "key" value }}.
+// the map is modified, so values set in range loops accumulate
+func set(d map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	d[key] = value
+	return d
+}
+
+func hasKey(d map[string]interface{}, key string) bool {
+	_, ok := d[key]
+	return ok
+}
+
+// defaultValue returns value, or def if value is nil or empty, e.g. {{ .Args.limit | default 10 }}
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	if v := reflect.ValueOf(value); (v.Kind() == reflect.String || v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		return def
+	}
+	return value
 }
 
-type params struct {
-	Args   map[string]interface{}
-	Parent map[string]interface{}
+// templates read .Args, .Parent, .Siblings, .Cookies, .ctx and .claims. the data is a map rather than a struct so the reserved
+// ctx and claims namespaces can be lowercase
+type params map[string]interface{}
+
+func newParams(args, parent, siblings map[string]interface{}, cookies, context map[string]string, claims map[string]interface{}) params {
+	return params{
+		"Args":     args,
+		"Parent":   parent,
+		"Siblings": siblings,
+		"Cookies":  cookies,
+		"ctx":      context,
+		"claims":   claims,
+	}
 }
 
 func templateParams(p exec.Params) params {
@@ -38,8 +108,12 @@ func templateParams(p exec.Params) params {
 	if parentObject, isObject := p.Parent.GoValue().(map[string]interface{}); isObject {
 		parent = parentObject
 	}
-	return params{
-		Args:   p.Args,
-		Parent: parent,
+	var siblings map[string]interface{}
+	if p.Siblings != nil {
+		siblings = make(map[string]interface{}, len(p.Siblings.Keys))
+		for _, item := range p.Siblings.Items() {
+			siblings[item.Key] = item.Value.GoValue()
+		}
 	}
+	return newParams(p.Args, parent, siblings, p.Cookies, p.Context, p.Claims)
 }
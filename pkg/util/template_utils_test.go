@@ -0,0 +1,41 @@
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/sqoop/pkg/exec"
+	. "github.com/solo-io/sqoop/pkg/util"
+)
+
+var _ = Describe("Templates", func() {
+	render := func(tmpl string, args map[string]interface{}) string {
+		t, err := Template(tmpl)
+		Expect(err).NotTo(HaveOccurred())
+		buf, err := ExecTemplate(t, exec.Params{Args: args})
+		Expect(err).NotTo(HaveOccurred())
+		return buf.String()
+	}
+	It("builds structured request bodies from arguments", func() {
+		args := map[string]interface{}{
+			"pet": map[string]interface{}{"name": "fido", "tags": []interface{}{"good", "dog"}},
+		}
+		out := render(`{{ $body := dict "name" .Args.pet.name "limit" (.Args.limit | default 10) }}`+
+			`{{ if hasKey .Args.pet "tags" }}{{ range .Args.pet.tags }}{{ $_ := set $body "lastTag" . }}{{ end }}{{ end }}`+
+			`{{ marshal $body }}`, args)
+		Expect(out).To(MatchJSON(`{"name":"fido","limit":10,"lastTag":"dog"}`))
+	})
+	It("reads well known values of the request from .ctx", func() {
+		t, err := Template(`{"tenant":"{{ .ctx.tenant }}","request":"{{ .ctx.requestId }}","trace":"{{ or .ctx.traceId "none" }}"}`)
+		Expect(err).NotTo(HaveOccurred())
+		buf, err := ExecTemplate(t, exec.Params{Context: map[string]string{"tenant": "acme", "requestId": "abc"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(MatchJSON(`{"tenant":"acme","request":"abc","trace":"none"}`))
+	})
+	It("rejects dicts without a value for every key", func() {
+		t, err := Template(`{{ marshal (dict "name") }}`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ExecTemplate(t, exec.Params{})
+		Expect(err).To(MatchError(ContainSubstring("dict requires pairs of keys and values")))
+	})
+})
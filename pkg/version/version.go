@@ -0,0 +1,9 @@
+package version
+
+// Version is the version of the Sqoop build, set at build time with
+// -ldflags "-X github.com/solo-io/sqoop/pkg/version.Version=<version>"
+var Version = "dev"
+
+// Commit is the git commit Sqoop was built from, set at build time with
+// -ldflags "-X github.com/solo-io/sqoop/pkg/version.Commit=<commit>"
+var Commit = "unknown"
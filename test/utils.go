@@ -6,15 +6,14 @@ import (
 	"github.com/solo-io/sqoop/pkg/resolvers"
 	"github.com/solo-io/sqoop/pkg/util"
 	"github.com/vektah/gqlgen/graphql"
-	"github.com/vektah/gqlgen/neelance/schema"
 )
 
 const resolversName = "starwars-resolvers"
 
-var StarWarsSchema = schema.MustParse(starWarsSchemaString)
+var StarWarsSchema = exec.MustParseSchema(starWarsSchemaString)
 
 func StarWarsResolverMap() *v1.ResolverMap {
-	resolverMap := util.GenerateResolverMapSkeleton(resolversName, StarWarsSchema)
+	resolverMap := util.GenerateResolverMapSkeleton(resolversName, StarWarsSchema.Schema)
 	resolverMap.Types["Query"].Fields["hero"].Resolver = &v1.Resolver_GlooResolver{
 		GlooResolver: &v1.GlooResolver{
 			Function: &v1.GlooResolver_SingleFunction{
@@ -60,7 +59,7 @@ func StarWarsResolverMap() *v1.ResolverMap {
 	}
 	resolverMap.Types["Human"].Fields["appearsIn"].Resolver = &v1.Resolver_TemplateResolver{
 		TemplateResolver: &v1.TemplateResolver{
-			InlineTemplate: `{{ index .Parent "appears_in" }}}`,
+			InlineTemplate: `{{ marshal (index .Parent "appears_in") }}`,
 		},
 	}
 	resolverMap.Types["Droid"].Fields["friends"].Resolver = &v1.Resolver_GlooResolver{
@@ -76,7 +75,7 @@ func StarWarsResolverMap() *v1.ResolverMap {
 	}
 	resolverMap.Types["Droid"].Fields["appearsIn"].Resolver = &v1.Resolver_TemplateResolver{
 		TemplateResolver: &v1.TemplateResolver{
-			InlineTemplate: `{{ index .Parent "appears_in" }}}`,
+			InlineTemplate: `{{ marshal (index .Parent "appears_in") }}`,
 		},
 	}
 	return resolverMap
@@ -92,12 +91,12 @@ func StarWarsV1Schema() *v1.Schema {
 
 func StarWarsExecutableSchema(proxyAddr string) graphql.ExecutableSchema {
 	execResolvers := StarWarsExecutableResolvers(proxyAddr)
-	return exec.NewExecutableSchema(StarWarsSchema, execResolvers)
+	return exec.NewExecutableSchema(StarWarsSchema, execResolvers, exec.Options{})
 }
 
 func StarWarsExecutableResolvers(proxyAddr string) *exec.ExecutableResolverMap {
 	factory := StarWarsResolverFactory(proxyAddr)
-	execResolvers, err := exec.NewExecutableResolvers(StarWarsSchema, factory.CreateResolver)
+	execResolvers, err := exec.NewExecutableResolvers(StarWarsSchema.Schema, factory.CreateResolver)
 	if err != nil {
 		panic(err)
 	}
@@ -105,7 +104,7 @@ func StarWarsExecutableResolvers(proxyAddr string) *exec.ExecutableResolverMap {
 }
 
 func StarWarsResolverFactory(proxyAddr string) *resolvers.ResolverFactory {
-	return resolvers.NewResolverFactory(proxyAddr, StarWarsResolverMap())
+	return resolvers.NewResolverFactory(proxyAddr, StarWarsSchema.Schema, StarWarsResolverMap(), resolvers.Options{})
 }
 
 var starWarsSchemaString = `# The query type, represents all of the entry points into our object graph